@@ -38,10 +38,10 @@ func (ce *CalculationEngine) generateImpactAnalysis(baselineNetIncome decimal.De
 
 // generateLongTermAnalysis generates long-term analysis
 func (ce *CalculationEngine) generateLongTermAnalysis(scenarios []domain.ScenarioSummary) domain.LongTermAnalysis {
-	var bestIncomeScenario, bestLongevityScenario string
-	var bestIncome, bestLongevity decimal.Decimal
+	var bestIncomeScenario, bestLongevityScenario, bestTaxScenario string
+	var bestIncome, bestLongevity, lowestTax decimal.Decimal
 
-	for _, scenario := range scenarios {
+	for i, scenario := range scenarios {
 		if scenario.TotalLifetimeIncome.GreaterThan(bestIncome) {
 			bestIncome = scenario.TotalLifetimeIncome
 			bestIncomeScenario = scenario.Name
@@ -50,11 +50,16 @@ func (ce *CalculationEngine) generateLongTermAnalysis(scenarios []domain.Scenari
 			bestLongevity = decimal.NewFromInt(int64(scenario.TSPLongevity))
 			bestLongevityScenario = scenario.Name
 		}
+		if i == 0 || scenario.TotalLifetimeTaxPV.LessThan(lowestTax) {
+			lowestTax = scenario.TotalLifetimeTaxPV
+			bestTaxScenario = scenario.Name
+		}
 	}
 
 	return domain.LongTermAnalysis{
 		BestScenarioForIncome:    bestIncomeScenario,
 		BestScenarioForLongevity: bestLongevityScenario,
+		BestScenarioForTaxes:     bestTaxScenario,
 		RiskAssessment:           "Consider market volatility and inflation risks",
 		Recommendations:          []string{"Diversify TSP allocations", "Monitor withdrawal rates", "Plan for healthcare costs"},
 	}
@@ -0,0 +1,46 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestScenarioAssumptionOverrides(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(10)
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.05)
+	// The comparison's impact analysis is relative to current pay
+	for key, salary := range map[string]int64{"person_a": 100000, "person_b": 80000} {
+		employee := cfg.PersonalDetails[key]
+		employee.CurrentSalary = decimal.NewFromInt(salary)
+		cfg.PersonalDetails[key] = employee
+	}
+
+	lowReturn := decimal.NewFromFloat(0.04)
+	shorter := 5
+	withOverrides := *scenario
+	withOverrides.Name = "lower returns"
+	withOverrides.AssumptionOverrides = &domain.AssumptionOverrides{TSPReturnPostRetirement: &lowReturn, ProjectionYears: &shorter}
+	cfg.Scenarios = []domain.Scenario{*scenario, withOverrides}
+
+	comparison, err := NewCalculationEngine().RunScenarios(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base, overridden := comparison.Scenarios[0], comparison.Scenarios[1]
+
+	if len(base.Projection) != 10 || len(overridden.Projection) != 5 {
+		t.Fatalf("expected 10 and 5 projection years, got %d and %d", len(base.Projection), len(overridden.Projection))
+	}
+	// Same withdrawals, lower growth: the overridden scenario's TSP trails from the first year
+	for year := 0; year < 5; year++ {
+		if !overridden.Projection[year].TSPBalancePersonA.LessThan(base.Projection[year].TSPBalancePersonA) {
+			t.Fatalf("year %d: expected the 4%% return scenario to hold less TSP, got %s vs %s",
+				year, overridden.Projection[year].TSPBalancePersonA, base.Projection[year].TSPBalancePersonA)
+		}
+	}
+	if !cfg.GlobalAssumptions.TSPReturnPostRetirement.Equal(decimal.NewFromFloat(0.05)) || cfg.GlobalAssumptions.ProjectionYears != 10 {
+		t.Fatalf("overrides must not modify the shared global assumptions")
+	}
+}
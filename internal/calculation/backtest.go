@@ -0,0 +1,186 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// HistoricalBacktestStart is the outcome of running a scenario through the actual market sequence that
+// began in one historical year
+type HistoricalBacktestStart struct {
+	StartYear        int             `json:"start_year"`
+	Success          bool            `json:"success"` // TSP still has a balance at the end of the projection
+	TSPLongevity     int             `json:"tsp_longevity"`
+	FinalTSPBalance  decimal.Decimal `json:"final_tsp_balance"`
+	MinRealNetIncome decimal.Decimal `json:"min_real_net_income"`
+	AverageReturn    decimal.Decimal `json:"average_return"`    // Geometric mean portfolio return over the sequence
+	AverageInflation decimal.Decimal `json:"average_inflation"` // Geometric mean inflation over the sequence
+}
+
+// HistoricalBacktestResult summarizes a scenario run against every complete historical sequence
+type HistoricalBacktestResult struct {
+	ScenarioName    string                    `json:"scenario_name"`
+	ProjectionYears int                       `json:"projection_years"`
+	Starts          []HistoricalBacktestStart `json:"starts"`
+	SuccessRate     decimal.Decimal           `json:"success_rate"` // Fraction of start years that succeeded
+	Worst           HistoricalBacktestStart   `json:"worst"`        // Earliest depletion, then lowest final balance
+}
+
+// RunHistoricalBacktest runs the scenario once for each historical start year with enough data to cover
+// the whole projection, applying that year's and each following year's actual fund returns in order
+// (the Trinity-study approach) instead of sampling years at random. Portfolio returns use the Monte Carlo
+// default TSP allocation. Each year's actual inflation and COLA reach the projection in turn, so pensions,
+// Social Security, withdrawals and real dollars follow the historical path year by year.
+func (ce *CalculationEngine) RunHistoricalBacktest(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*HistoricalBacktestResult, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	if ce.HistoricalData == nil || !ce.HistoricalData.IsLoaded {
+		return nil, ErrHistoricalDataNotLoaded
+	}
+	// The scenario's overrides size the projection; the historical sequence then replaces its rates
+	config, scenario = scenarioAssumptions(config, scenario)
+	minYear, maxYear, err := ce.HistoricalData.GetAvailableYears()
+	if err != nil {
+		return nil, fmt.Errorf("historical backtest: %w", err)
+	}
+	years := config.GlobalAssumptions.ProjectionYears
+	lastStart := maxYear - years + 1
+	if years < 1 || lastStart < minYear {
+		return nil, fmt.Errorf("historical data %d-%d is too short for a %d-year projection", minYear, maxYear, years)
+	}
+
+	result := &HistoricalBacktestResult{ScenarioName: scenario.Name, ProjectionYears: years}
+	successes := 0
+	for startYear := minYear; startYear <= lastStart; startYear++ {
+		start, err := ce.backtestFromYear(ctx, config, scenario, startYear)
+		if err != nil {
+			return nil, fmt.Errorf("historical backtest from %d: %w", startYear, err)
+		}
+		if start.Success {
+			successes++
+		}
+		if len(result.Starts) == 0 || start.TSPLongevity < result.Worst.TSPLongevity ||
+			(start.TSPLongevity == result.Worst.TSPLongevity && start.FinalTSPBalance.LessThan(result.Worst.FinalTSPBalance)) {
+			result.Worst = start
+		}
+		result.Starts = append(result.Starts, start)
+	}
+	result.SuccessRate = decimal.NewFromInt(int64(successes)).Div(decimal.NewFromInt(int64(len(result.Starts))))
+
+	return result, nil
+}
+
+// backtestFromYear runs the scenario with the historical market sequence beginning in startYear
+func (ce *CalculationEngine) backtestFromYear(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario, startYear int) (HistoricalBacktestStart, error) {
+	series, portfolioReturns, err := ce.historicalSequence(config, startYear)
+	if err != nil {
+		return HistoricalBacktestStart{}, err
+	}
+	inflation := make([]decimal.Decimal, len(series.Years))
+	for i, market := range series.Years {
+		inflation[i] = market.InflationRate
+	}
+
+	summary, err := ce.runMarketSequence(ctx, config, scenario, series, portfolioReturns)
+	if err != nil {
+		return HistoricalBacktestStart{}, err
+	}
+
+	start := HistoricalBacktestStart{
+		StartYear:        startYear,
+		Success:          summary.FinalTSPBalance.GreaterThan(decimal.Zero),
+		TSPLongevity:     summary.TSPLongevity,
+		FinalTSPBalance:  summary.FinalTSPBalance,
+		AverageReturn:    geometricMeanRate(portfolioReturns),
+		AverageInflation: geometricMeanRate(inflation),
+	}
+	for i, cf := range summary.Projection {
+		if i == 0 || cf.RealNetIncome.LessThan(start.MinRealNetIncome) {
+			start.MinRealNetIncome = cf.RealNetIncome
+		}
+	}
+	return start, nil
+}
+
+// historicalSequence returns the actual market sequence of the projection's length beginning in startYear,
+// with each year's default-allocation portfolio return
+func (ce *CalculationEngine) historicalSequence(config *domain.Configuration, startYear int) (MarketConditionSeries, []decimal.Decimal, error) {
+	years := config.GlobalAssumptions.ProjectionYears
+	series := MarketConditionSeries{Years: make([]MarketCondition, years)}
+	portfolioReturns := make([]decimal.Decimal, years)
+	for i := range series.Years {
+		market, err := ce.historicalMarketCondition(startYear + i)
+		if err != nil {
+			return MarketConditionSeries{}, nil, err
+		}
+		series.Years[i] = market
+		portfolioReturns[i] = defaultAllocationReturn(market, config)
+	}
+	return series, portfolioReturns, nil
+}
+
+// runMarketSequence runs the scenario with a prescribed year-by-year market sequence: series supplies
+// each year's fund returns, inflation and COLA, and portfolioReturns the matching default-allocation
+// return. config and scenario must already have the scenario's assumption overrides applied.
+func (ce *CalculationEngine) runMarketSequence(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario, series MarketConditionSeries, portfolioReturns []decimal.Decimal) (*domain.ScenarioSummary, error) {
+	inflation := make([]decimal.Decimal, len(series.Years))
+	cola := make([]decimal.Decimal, len(series.Years))
+	for i, market := range series.Years {
+		inflation[i] = market.InflationRate
+		cola[i] = market.COLARate
+	}
+
+	trial := *config
+	trial.GlobalAssumptions.TSPReturnPreRetirement = portfolioReturns[0]
+	trial.GlobalAssumptions.TSPReturnPostRetirement = portfolioReturns[0]
+
+	// A separate engine per run keeps the prescribed returns off the caller's engine
+	runEngine := NewCalculationEngineWithConfig(trial.GlobalAssumptions.FederalRules)
+	runEngine.HistoricalData = ce.HistoricalData
+	runEngine.Logger = ce.Logger
+	runEngine.Debug = ce.Debug
+	runEngine.MonteCarloFundReturns = series.Years[0].TSPReturns
+	runEngine.MonteCarloFundReturnsByYear = series.FundReturns()
+	runEngine.MonteCarloPortfolioReturnsByYear = portfolioReturns
+	runEngine.InflationByYear = inflation
+	runEngine.COLAByYear = cola
+
+	return runEngine.RunScenario(ctx, &trial, scenario)
+}
+
+// historicalMarketCondition returns the actual fund returns, inflation and COLA for one historical year
+func (ce *CalculationEngine) historicalMarketCondition(year int) (MarketCondition, error) {
+	market := MarketCondition{Year: year, TSPReturns: make(map[string]decimal.Decimal)}
+	for _, fund := range []string{"C", "S", "I", "F", "G"} {
+		fundReturn, err := ce.HistoricalData.GetTSPReturn(fund, year)
+		if err != nil {
+			return MarketCondition{}, err
+		}
+		market.TSPReturns[fund] = fundReturn
+	}
+	var err error
+	if market.InflationRate, err = ce.HistoricalData.GetInflationRate(year); err != nil {
+		return MarketCondition{}, err
+	}
+	if market.COLARate, err = ce.HistoricalData.GetCOLARate(year); err != nil {
+		return MarketCondition{}, err
+	}
+	return market, nil
+}
+
+// geometricMeanRate returns the constant annual rate that compounds to the same total as rates
+func geometricMeanRate(rates []decimal.Decimal) decimal.Decimal {
+	if len(rates) == 0 {
+		return decimal.Zero
+	}
+	growth := 1.0
+	for _, rate := range rates {
+		growth *= 1 + rate.InexactFloat64()
+	}
+	return decimal.NewFromFloat(math.Pow(growth, 1/float64(len(rates))) - 1)
+}
@@ -0,0 +1,92 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRunHistoricalBacktest(t *testing.T) {
+	dataPath := t.TempDir()
+	if err := createTestDataFiles(dataPath); err != nil {
+		t.Fatalf("failed to create test data files: %v", err)
+	}
+	hdm := NewHistoricalDataManager(dataPath)
+	if err := hdm.LoadAllData(); err != nil {
+		t.Fatalf("failed to load historical data: %v", err)
+	}
+
+	cfg, scenario := retiredCoupleTestConfig(2)
+	target := decimal.NewFromInt(14000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+
+	ce := NewCalculationEngine()
+	ce.HistoricalData = hdm
+	result, err := ce.RunHistoricalBacktest(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 2020-2023 data covers three complete two-year sequences; only 2021-2022 (a strong year followed
+	// by the 2022 drawdown) exhausts the TSP
+	if len(result.Starts) != 3 || result.Starts[0].StartYear != 2020 || result.Starts[2].StartYear != 2022 {
+		t.Fatalf("expected start years 2020-2022, got %+v", result.Starts)
+	}
+	if result.Starts[1].Success || !result.Starts[0].Success || !result.Starts[2].Success {
+		t.Fatalf("expected only the 2021 start to fail, got %+v", result.Starts)
+	}
+	if result.Worst.StartYear != 2021 {
+		t.Fatalf("expected 2021 to be the worst sequence, got %d", result.Worst.StartYear)
+	}
+	if want := decimal.NewFromInt(2).Div(decimal.NewFromInt(3)); !result.SuccessRate.Equal(want) {
+		t.Fatalf("expected a 2/3 success rate, got %s", result.SuccessRate)
+	}
+	if got, want := result.Starts[0].AverageInflation.InexactFloat64(), 0.02935; got < want-0.0001 || got > want+0.0001 {
+		t.Fatalf("expected the 2020 start to use 2020-2021 mean inflation %.5f, got %.5f", want, got)
+	}
+
+	cfg.GlobalAssumptions.ProjectionYears = 5
+	if _, err := ce.RunHistoricalBacktest(context.Background(), cfg, scenario); err == nil {
+		t.Fatalf("expected an error when no historical sequence covers the projection")
+	}
+}
+
+func TestHistoricalBacktestFollowsYearlyInflation(t *testing.T) {
+	dataPath := t.TempDir()
+	if err := createTestDataFiles(dataPath); err != nil {
+		t.Fatalf("failed to create test data files: %v", err)
+	}
+	hdm := NewHistoricalDataManager(dataPath)
+	if err := hdm.LoadAllData(); err != nil {
+		t.Fatalf("failed to load historical data: %v", err)
+	}
+
+	cfg, scenario := retiredCoupleTestConfig(3)
+	ce := NewCalculationEngine()
+	ce.HistoricalData = hdm
+	series, portfolioReturns, err := ce.historicalSequence(cfg, 2020)
+	if err != nil {
+		t.Fatalf("historical sequence: %v", err)
+	}
+	summary, err := ce.runMarketSequence(context.Background(), cfg, scenario, series, portfolioReturns)
+	if err != nil {
+		t.Fatalf("runMarketSequence: %v", err)
+	}
+
+	// Social Security paid in a year carries the previous sequence year's actual COLA: 1.3% from 2020, then
+	// 5.9% from 2021, rather than their mean
+	ss := func(i int) decimal.Decimal { return summary.Projection[i].SSBenefitPersonA }
+	for i, want := range []decimal.Decimal{decimal.NewFromFloat(1.013), decimal.NewFromFloat(1.059)} {
+		if got := ss(i + 1).Div(ss(i)).Round(6); !got.Equal(want) {
+			t.Fatalf("year %d: expected the sequence's %s COLA, got %s", i+1, want, got)
+		}
+	}
+
+	// Real dollars deflate by each year's own inflation, 1.2% and then 4.7%
+	cf := summary.Projection[2]
+	wantReal := cf.NetIncome.Div(decimal.NewFromFloat(1.012).Mul(decimal.NewFromFloat(1.047)))
+	if !cf.RealNetIncome.Round(2).Equal(wantReal.Round(2)) {
+		t.Fatalf("expected real net income %s from the year-by-year inflation path, got %s", wantReal.Round(2), cf.RealNetIncome.Round(2))
+	}
+}
@@ -4,39 +4,122 @@ import (
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/rpgo/retirement-calculator/pkg/dateutil"
 	"github.com/shopspring/decimal"
 )
 
-// CalculateFERSSupplementYear calculates the FERS Special Retirement Supplement for a given year offset
+// CalculateFERSSupplementYear calculates the FERS Special Retirement Supplement paid in the calendar year
+// yearsSinceRetirement years after the retirement year. The supplement runs from retirement until the
+// retiree turns 62, so the retirement year and the year of the 62nd birthday are prorated by day.
 func CalculateFERSSupplementYear(employee *domain.Employee, retirementDate time.Time, yearsSinceRetirement int, inflationRate decimal.Decimal) decimal.Decimal {
+	return fersSupplementYear(employee, retirementDate, yearsSinceRetirement, constantRate(inflationRate))
+}
+
+// fersSupplementYear is CalculateFERSSupplementYear with an inflation schedule
+func fersSupplementYear(employee *domain.Employee, retirementDate time.Time, yearsSinceRetirement int, inflation rateSchedule) decimal.Decimal {
 	if yearsSinceRetirement < 0 {
 		return decimal.Zero
 	}
 
-	projectionDate := retirementDate.AddDate(yearsSinceRetirement, 0, 0)
-	age := employee.Age(projectionDate)
-	if age >= 62 {
-		return decimal.Zero // SRS stops at age 62
+	calendarYear := retirementDate.Year() + yearsSinceRetirement
+	start := time.Date(calendarYear, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	if retirementDate.After(start) {
+		start = retirementDate
+	}
+	if age62 := employee.BirthDate.AddDate(62, 0, 0); age62.Before(end) {
+		end = age62 // SRS stops at age 62
+	}
+	if !end.After(start) {
+		return decimal.Zero
 	}
 
 	serviceYears := employee.YearsOfService(retirementDate)
-	srs := CalculateFERSSpecialRetirementSupplement(employee.SSBenefit62, serviceYears, age)
+	srs := CalculateFERSSpecialRetirementSupplement(employee.SSBenefit62, serviceYears, employee.Age(start))
 
 	for y := 0; y < yearsSinceRetirement; y++ {
-		srs = srs.Mul(decimal.NewFromFloat(1).Add(inflationRate))
+		srs = srs.Mul(decimal.NewFromFloat(1).Add(inflation.forYear(retirementDate.Year() + y)))
 	}
-	return srs
+
+	days := end.Sub(start).Hours() / 24
+	return srs.Mul(decimal.NewFromFloat(days / float64(dateutil.DaysInYear(calendarYear))))
 }
 
-// CalculateFEHBPremium calculates FEHB premium for a given year
-func CalculateFEHBPremium(employee *domain.Employee, year int, premiumInflation decimal.Decimal, fehbConfig domain.FEHBConfig) decimal.Decimal {
+// CalculateFEHBPremium calculates the enrollee's FEHB premium for a given year under the enrollment type
+// in effect. With a government share configured, the per-pay-period premium is the plan's total premium
+// and the government's share of it is deducted.
+func CalculateFEHBPremium(employee *domain.Employee, enrollment string, year int, premiumInflation decimal.Decimal, fehbConfig domain.FEHBConfig) decimal.Decimal {
 	inflationFactor := decimal.NewFromFloat(1).Add(premiumInflation)
-	adjustedPremium := employee.FEHBPremiumPerPayPeriod.Mul(inflationFactor.Pow(decimal.NewFromInt(int64(year))))
-	return adjustedPremium.Mul(decimal.NewFromInt(int64(fehbConfig.PayPeriodsPerYear)))
+	adjustedPremium := employee.FEHBPremiumForEnrollment(enrollment).Mul(inflationFactor.Pow(decimal.NewFromInt(int64(year))))
+	enrolleeShare := decimal.NewFromInt(1).Sub(fehbConfig.GovernmentShare)
+	return adjustedPremium.Mul(decimal.NewFromInt(int64(fehbPayPeriodsPerYear(fehbConfig)))).Mul(enrolleeShare)
 }
 
-// CalculateRMD wraps RMD calculation with birth year
+// fehbPayPeriodsPerYear returns the configured FEHB pay periods per year, or 26 (bi-weekly) when unset
+func fehbPayPeriodsPerYear(fehbConfig domain.FEHBConfig) int {
+	if fehbConfig.PayPeriodsPerYear > 0 {
+		return fehbConfig.PayPeriodsPerYear
+	}
+	return 26
+}
+
+// ActiveFEHBEnrollment returns the FEHB enrollment type in effect in a calendar year: the latest scheduled
+// change dated in or before that year, else the employee's enrollment. Once only one household member is
+// still covered, self and family or self plus one steps down to self only.
+func ActiveFEHBEnrollment(employee *domain.Employee, changes []domain.FEHBEnrollmentChange, calendarYear, covered int) string {
+	enrollment := employee.FEHBEnrollmentType()
+	var latest time.Time
+	for _, change := range changes {
+		if change.Date.Year() <= calendarYear && !change.Date.Before(latest) {
+			enrollment = change.Enrollment
+			latest = change.Date
+		}
+	}
+	if covered <= 1 {
+		enrollment = domain.FEHBSelfOnly
+	}
+	return enrollment
+}
+
+// defaultMedicarePrimaryPremiumFactor is the reduced-plan premium factor when none is configured
+var defaultMedicarePrimaryPremiumFactor = decimal.NewFromFloat(0.5)
+
+// ApplyMedicareCoordination adjusts the household FEHB premium once covered people become Medicare
+// eligible. The premium is split evenly across the covered people; each Medicare-eligible person's share
+// is kept (keep_both), reduced by the medicare_primary premium factor, or dropped (suspend_fehb).
+func ApplyMedicareCoordination(premium decimal.Decimal, mode string, medicareEligible, covered int, fehbConfig domain.FEHBConfig) decimal.Decimal {
+	if covered <= 0 || medicareEligible <= 0 {
+		return premium
+	}
+	var factor decimal.Decimal
+	switch mode {
+	case domain.MedicareCoordinationMedicarePrimary:
+		factor = fehbConfig.MedicarePrimaryPremiumFactor
+		if factor.IsZero() {
+			factor = defaultMedicarePrimaryPremiumFactor
+		}
+	case domain.MedicareCoordinationSuspendFEHB:
+		factor = decimal.Zero
+	default:
+		return premium
+	}
+	eligibleShare := decimal.NewFromInt(int64(min(medicareEligible, covered))).Div(decimal.NewFromInt(int64(covered)))
+	return premium.Mul(decimal.NewFromInt(1).Sub(eligibleShare.Mul(decimal.NewFromInt(1).Sub(factor))))
+}
+
+// CalculateRMD wraps RMD calculation with birth year. balance must be the traditional (pre-tax)
+// TSP balance only; Roth balances are not subject to RMDs.
 func CalculateRMD(balance decimal.Decimal, birthYear, age int) decimal.Decimal {
 	rmdCalc := NewRMDCalculator(birthYear)
 	return rmdCalc.CalculateRMD(balance, age)
 }
+
+// deferFirstRMD applies the option to take the first RMD by April 1 of the following year. In the first
+// RMD year nothing is required and the RMD is held back; the next year requires its own RMD plus the
+// held-back one. It returns the year's required amount and what is held back.
+func deferFirstRMD(rmd, deferred decimal.Decimal, firstRMDYear bool) (required, held decimal.Decimal) {
+	if firstRMDYear {
+		return decimal.Zero, rmd
+	}
+	return rmd.Add(deferred), decimal.Zero
+}
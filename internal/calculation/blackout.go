@@ -0,0 +1,74 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// MarkBlackoutYears flags the retired years in which guaranteed income has not yet fully ramped up: a
+// living spouse's Social Security or FERS annuity is still zero but starts later in the projection. Each
+// blackout year's GuaranteedIncomeGap is how far its guaranteed income falls short of the first
+// ramped-up year after it, the hole the portfolio has to fill while it is most exposed to sequence risk.
+func MarkBlackoutYears(projection []domain.AnnualCashFlow) {
+	years := make([]guaranteedIncomeYear, len(projection))
+	for i := range projection {
+		years[i] = newGuaranteedIncomeYear(&projection[i])
+	}
+	blackout, gaps := blackoutGaps(years)
+	for i := range projection {
+		projection[i].BlackoutYear = blackout[i]
+		projection[i].GuaranteedIncomeGap = gaps[i]
+	}
+}
+
+// guaranteedIncomeYear is the part of a projection year that blackout detection looks at, small enough
+// to keep for every year of a streamed projection
+type guaranteedIncomeYear struct {
+	retired          bool
+	deceasedA        bool
+	deceasedB        bool
+	ssPaidA, ssPaidB bool
+	pensionPaidA     bool
+	pensionPaidB     bool
+	guaranteedIncome decimal.Decimal
+}
+
+func newGuaranteedIncomeYear(cf *domain.AnnualCashFlow) guaranteedIncomeYear {
+	return guaranteedIncomeYear{
+		retired:          cf.IsRetired,
+		deceasedA:        cf.PersonADeceased,
+		deceasedB:        cf.PersonBDeceased,
+		ssPaidA:          cf.SSBenefitPersonA.IsPositive(),
+		ssPaidB:          cf.SSBenefitPersonB.IsPositive(),
+		pensionPaidA:     cf.PensionPersonA.IsPositive(),
+		pensionPaidB:     cf.PensionPersonB.IsPositive(),
+		guaranteedIncome: cf.GuaranteedIncome,
+	}
+}
+
+// blackoutGaps returns, for each year, whether it is a blackout year and its guaranteed income gap
+func blackoutGaps(years []guaranteedIncomeYear) ([]bool, []decimal.Decimal) {
+	blackout := make([]bool, len(years))
+	gaps := make([]decimal.Decimal, len(years))
+
+	// Walking backwards, track whether each stream is paid in any later year
+	var laterSSA, laterSSB, laterPensionA, laterPensionB bool
+	var ramped decimal.Decimal
+	for i := len(years) - 1; i >= 0; i-- {
+		y := years[i]
+		pending := (!y.deceasedA && ((laterSSA && !y.ssPaidA) || (laterPensionA && !y.pensionPaidA))) ||
+			(!y.deceasedB && ((laterSSB && !y.ssPaidB) || (laterPensionB && !y.pensionPaidB)))
+		blackout[i] = y.retired && pending
+		if blackout[i] {
+			gaps[i] = decimal.Max(ramped.Sub(y.guaranteedIncome), decimal.Zero)
+		} else if y.retired {
+			ramped = y.guaranteedIncome
+		}
+
+		laterSSA = laterSSA || y.ssPaidA
+		laterSSB = laterSSB || y.ssPaidB
+		laterPensionA = laterPensionA || y.pensionPaidA
+		laterPensionB = laterPensionB || y.pensionPaidB
+	}
+	return blackout, gaps
+}
@@ -0,0 +1,64 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBlackoutYearsBeforeDelayedSocialSecurity(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(15)
+	// Born on the 2nd: a January 1st birthday attains each age on December 31st, which would start
+	// Social Security in the December before the 70th birthday year
+	for _, key := range []string{"person_a", "person_b"} {
+		person := cfg.PersonalDetails[key]
+		person.BirthDate = time.Date(1965, 1, 2, 0, 0, 0, 0, time.UTC)
+		cfg.PersonalDetails[key] = person
+	}
+	// Retire at 60 and claim Social Security at 70
+	scenario.PersonA.SSStartAge = 70
+	scenario.PersonB.SSStartAge = 70
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("run scenario: %v", err)
+	}
+	if summary.BlackoutYears != 10 {
+		t.Fatalf("expected 10 blackout years (60-69), got %d", summary.BlackoutYears)
+	}
+	for i, cf := range summary.Projection {
+		if cf.BlackoutYear != (i < 10) {
+			t.Fatalf("year %d: blackout %v, expected %v", cf.Date.Year(), cf.BlackoutYear, i < 10)
+		}
+	}
+
+	// The gap is the Social Security still to come: the first ramped-up year's guaranteed income less
+	// the blackout year's
+	first, ramped := summary.Projection[0], summary.Projection[10]
+	if !first.GuaranteedIncomeGap.Equal(ramped.GuaranteedIncome.Sub(first.GuaranteedIncome)) {
+		t.Fatalf("expected first-year gap %s, got %s", ramped.GuaranteedIncome.Sub(first.GuaranteedIncome), first.GuaranteedIncomeGap)
+	}
+	if !first.GuaranteedIncomeGap.IsPositive() || !summary.MaxBlackoutGap.GreaterThanOrEqual(first.GuaranteedIncomeGap) {
+		t.Fatalf("expected a positive blackout depth, got gap %s max %s", first.GuaranteedIncomeGap, summary.MaxBlackoutGap)
+	}
+	if !ramped.GuaranteedIncomeGap.IsZero() {
+		t.Fatalf("ramped-up years should have no gap, got %s", ramped.GuaranteedIncomeGap)
+	}
+
+	// Claiming at retirement leaves no blackout
+	scenario.PersonA.SSStartAge = 62
+	scenario.PersonB.SSStartAge = 62
+	for key, person := range cfg.PersonalDetails {
+		person.BirthDate = time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC)
+		cfg.PersonalDetails[key] = person
+	}
+	summary, err = NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("run scenario: %v", err)
+	}
+	if summary.BlackoutYears != 0 || !summary.MaxBlackoutGap.Equal(decimal.Zero) {
+		t.Fatalf("expected no blackout years when claiming at 62, got %d (gap %s)", summary.BlackoutYears, summary.MaxBlackoutGap)
+	}
+}
@@ -0,0 +1,75 @@
+package calculation
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// BracketHeadroom is the additional ordinary income that fits before the top of one federal bracket
+type BracketHeadroom struct {
+	Rate     decimal.Decimal `json:"rate"`
+	Top      decimal.Decimal `json:"top"`      // Taxable income at the top of the bracket
+	Headroom decimal.Decimal `json:"headroom"` // Income that can be added before crossing Top
+}
+
+// IRMAAHeadroom is the additional MAGI that fits before an IRMAA tier's surcharge begins
+type IRMAAHeadroom struct {
+	Tier      int             `json:"tier"` // 1 is the first surcharge tier
+	Threshold decimal.Decimal `json:"threshold"`
+	Headroom  decimal.Decimal `json:"headroom"`
+}
+
+// BracketFillReport shows how much more ordinary income, such as a Roth conversion or a larger
+// traditional TSP withdrawal, a year can absorb before reaching each higher bracket and IRMAA tier
+type BracketFillReport struct {
+	FilingStatus      string            `json:"filing_status"`
+	OrdinaryIncome    decimal.Decimal   `json:"ordinary_income"`
+	StandardDeduction decimal.Decimal   `json:"standard_deduction"`
+	TaxableIncome     decimal.Decimal   `json:"taxable_income"`
+	MarginalRate      decimal.Decimal   `json:"marginal_rate"`
+	Brackets          []BracketHeadroom `json:"brackets"`    // The current bracket and every higher one with a ceiling
+	IRMAATiers        []IRMAAHeadroom   `json:"irmaa_tiers"` // Part B tiers not yet reached
+}
+
+// BracketFill reports the headroom left in each federal bracket for a year's ordinary income (before the
+// standard deduction) and in each IRMAA tier for the year's MAGI. Filing status is "mfj" or "single";
+// seniors is the number of filers 65+. The open-ended top bracket has no headroom and is not listed.
+// IRMAA tiers use this year's MAGI, which sets premiums two years later.
+func (ce *CalculationEngine) BracketFill(ordinaryIncome, magi decimal.Decimal, filingStatus string, seniors int) BracketFillReport {
+	standardDed, brackets := ce.TaxCalc.federalDeductionAndBrackets(filingStatus, seniors)
+	taxable := decimal.Max(ordinaryIncome.Sub(standardDed), decimal.Zero)
+	report := BracketFillReport{
+		FilingStatus:      filingStatus,
+		OrdinaryIncome:    ordinaryIncome,
+		StandardDeduction: standardDed,
+		TaxableIncome:     taxable,
+	}
+
+	for i, b := range brackets {
+		if i == len(brackets)-1 {
+			if report.MarginalRate.IsZero() {
+				report.MarginalRate = b.Rate
+			}
+			break
+		}
+		if b.Max.LessThanOrEqual(taxable) {
+			continue
+		}
+		if len(report.Brackets) == 0 {
+			report.MarginalRate = b.Rate
+		}
+		report.Brackets = append(report.Brackets, BracketHeadroom{Rate: b.Rate, Top: b.Max, Headroom: b.Max.Sub(taxable)})
+	}
+
+	mfj := filingStatus != "single"
+	for i, t := range ce.MedicareCalc.IRMAAThresholds {
+		threshold := t.IncomeThresholdSingle
+		if mfj {
+			threshold = t.IncomeThresholdJoint
+		}
+		if magi.GreaterThan(threshold) {
+			continue
+		}
+		report.IRMAATiers = append(report.IRMAATiers, IRMAAHeadroom{Tier: i + 1, Threshold: threshold, Headroom: threshold.Sub(magi)})
+	}
+	return report
+}
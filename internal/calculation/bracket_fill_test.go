@@ -0,0 +1,33 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBracketFillHeadroomTo22PercentMFJ(t *testing.T) {
+	ce := NewCalculationEngine()
+	income := decimal.NewFromInt(150000)
+	report := ce.BracketFill(income, income, "mfj", 0)
+
+	// $150k less the $30k standard deduction is $120k taxable, inside the 22% bracket that tops out at $201,050
+	if !report.TaxableIncome.Equal(decimal.NewFromInt(120000)) {
+		t.Fatalf("expected taxable income 120000, got %s", report.TaxableIncome)
+	}
+	if !report.MarginalRate.Equal(decimal.NewFromFloat(0.22)) {
+		t.Fatalf("expected a 22%% marginal rate, got %s", report.MarginalRate)
+	}
+	if len(report.Brackets) == 0 || !report.Brackets[0].Headroom.Equal(decimal.NewFromInt(81050)) {
+		t.Fatalf("expected 81050 of headroom to the top of the 22%% bracket, got %+v", report.Brackets)
+	}
+	// The 24% bracket's headroom includes the rest of the 22% bracket
+	if !report.Brackets[1].Rate.Equal(decimal.NewFromFloat(0.24)) || !report.Brackets[1].Headroom.Equal(decimal.NewFromInt(263900)) {
+		t.Fatalf("expected 263900 of headroom to the top of the 24%% bracket, got %+v", report.Brackets[1])
+	}
+
+	// The first IRMAA tier starts above $206k MAGI for joint filers
+	if len(report.IRMAATiers) != len(ce.MedicareCalc.IRMAAThresholds) || !report.IRMAATiers[0].Headroom.Equal(decimal.NewFromInt(56000)) {
+		t.Fatalf("expected 56000 of headroom to the first IRMAA tier, got %+v", report.IRMAATiers)
+	}
+}
@@ -1,7 +1,9 @@
 package calculation
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
@@ -13,7 +15,7 @@ func (ce *CalculationEngine) CalculateBreakEvenTSPWithdrawalRate(config *domain.
 	personBEmployee := config.PersonalDetails["person_b"]
 
 	// Find the first year when both are fully retired
-	projectionStartYear := ProjectionBaseYear
+	projectionStartYear := ProjectionStartYear(&config.GlobalAssumptions)
 	personARetirementYear := scenario.PersonA.RetirementDate.Year() - projectionStartYear
 	personBRetirementYear := scenario.PersonB.RetirementDate.Year() - projectionStartYear
 	firstFullRetirementYear := personARetirementYear
@@ -90,7 +92,7 @@ func (ce *CalculationEngine) CalculateBreakEvenAnalysis(config *domain.Configura
 	// Calculate current net income as the target
 	personAEmployee := config.PersonalDetails["person_a"]
 	personBEmployee := config.PersonalDetails["person_b"]
-	targetNetIncome := ce.NetIncomeCalc.Calculate(&personAEmployee, &personBEmployee, ce.Debug)
+	targetNetIncome := ce.NetIncomeCalc.Calculate(&personAEmployee, &personBEmployee, config.GlobalAssumptions.FederalRules.FEHBConfig, ce.TaxCalc.LocalTaxCalculatorFor(config.GlobalAssumptions.CurrentLocation.Municipality), ProjectionStartYear(&config.GlobalAssumptions), ce.Debug)
 
 	results := make([]BreakEvenResult, len(config.Scenarios))
 
@@ -104,7 +106,7 @@ func (ce *CalculationEngine) CalculateBreakEvenAnalysis(config *domain.Configura
 			ScenarioName:            scenario.Name,
 			BreakEvenWithdrawalRate: rate,
 			ProjectedNetIncome:      yearData.NetIncome,
-			ProjectedYear:           yearData.Year + (ProjectionBaseYear - 1),
+			ProjectedYear:           yearData.Date.Year(),
 			TSPWithdrawalAmount:     yearData.TSPWithdrawalPersonA.Add(yearData.TSPWithdrawalPersonB),
 			TotalTSPBalance:         yearData.TotalTSPBalance(),
 			CurrentVsBreakEvenDiff:  yearData.NetIncome.Sub(targetNetIncome),
@@ -290,3 +292,101 @@ func CalculateCumulativeBreakEven(projA, projB []domain.AnnualCashFlow) (*Cumula
 	// No crossover found
 	return nil, nil
 }
+
+// RetirementTimingBreakEven compares retiring on the base scenario's date against working delayYears longer
+type RetirementTimingBreakEven struct {
+	Employee              string                     `json:"employee"`
+	DelayYears            int                        `json:"delay_years"`
+	RetireNowDate         time.Time                  `json:"retire_now_date"`
+	DelayedRetirementDate time.Time                  `json:"delayed_retirement_date"`
+	RetireNowPension      decimal.Decimal            `json:"retire_now_pension"`
+	DelayedPension        decimal.Decimal            `json:"delayed_pension"`
+	BreakEven             *CumulativeBreakEvenResult `json:"break_even,omitempty"` // nil if working longer never catches up
+	BreakEvenAge          int                        `json:"break_even_age,omitempty"`
+}
+
+// CalculateRetirementTimingBreakEven frames the "should I work N more years?" question for one employee
+// ("person_a" or "person_b") of the household in config. It builds a delayed scenario with a later
+// retirement date; the projection then credits the extra service, grows the salary at the employee's
+// salary_growth_rate (held flat when unset) into a later High-3, and grows the TSP through the extra
+// working years. It finds when cumulative net retirement income of working longer overtakes retiring now.
+// Take-home pay from wages is excluded from both projections so the comparison is between retirement
+// incomes only.
+func (ce *CalculationEngine) CalculateRetirementTimingBreakEven(config *domain.Configuration, employee string, baseScenario *domain.Scenario, delayYears int) (*RetirementTimingBreakEven, error) {
+	if config == nil || baseScenario == nil {
+		return nil, ErrMissingInput
+	}
+	if delayYears < 1 {
+		return nil, fmt.Errorf("delay years must be at least 1, got %d", delayYears)
+	}
+	if employee != "person_a" && employee != "person_b" {
+		return nil, fmt.Errorf("%w, got %q", ErrUnknownEmployee, employee)
+	}
+
+	delayed := *baseScenario
+	delayed.Name = fmt.Sprintf("%s (+%d years)", baseScenario.Name, delayYears)
+	retirement := &delayed.PersonA
+	if employee == "person_b" {
+		retirement = &delayed.PersonB
+	}
+	retireNowDate := retirement.RetirementDate
+	retirement.RetirementDate = retireNowDate.AddDate(delayYears, 0, 0)
+	if retirement.AnnuityStartDate != nil && retirement.AnnuityStartDate.Before(retirement.RetirementDate) {
+		retirement.AnnuityStartDate = nil
+	}
+
+	ctx := context.Background()
+	now, err := ce.RunScenario(ctx, config, baseScenario)
+	if err != nil {
+		return nil, fmt.Errorf("retire-now scenario: %w", err)
+	}
+	later, err := ce.RunScenario(ctx, config, &delayed)
+	if err != nil {
+		return nil, fmt.Errorf("delayed scenario: %w", err)
+	}
+
+	original := config.PersonalDetails[employee]
+	pensionAt := func(retirementDate time.Time) decimal.Decimal {
+		emp := original
+		emp.High3Salary = original.ProjectedHigh3Salary(retirementDate, ProjectionStartYear(&config.GlobalAssumptions))
+		return CalculateFERSPension(&emp, retirementDate).ReducedPension
+	}
+	result := &RetirementTimingBreakEven{
+		Employee:              employee,
+		DelayYears:            delayYears,
+		RetireNowDate:         retireNowDate,
+		DelayedRetirementDate: retirement.RetirementDate,
+		RetireNowPension:      pensionAt(retireNowDate),
+		DelayedPension:        pensionAt(retirement.RetirementDate),
+	}
+
+	breakEven, err := CalculateCumulativeBreakEven(retirementIncomeProjection(later.Projection), retirementIncomeProjection(now.Projection))
+	if err != nil {
+		return nil, err
+	}
+	if breakEven != nil {
+		result.BreakEven = breakEven
+		result.BreakEvenAge = breakEven.BreakEvenYear - original.BirthDate.Year()
+	}
+	return result, nil
+}
+
+// retirementIncomeProjection returns a copy of projection with take-home pay from wages removed from
+// NetIncome. Wage take-home is salary less FICA, local earned income tax, payroll TSP/HSA contributions
+// and the wages' pro-rata share of federal and state income tax.
+func retirementIncomeProjection(projection []domain.AnnualCashFlow) []domain.AnnualCashFlow {
+	adjusted := make([]domain.AnnualCashFlow, len(projection))
+	copy(adjusted, projection)
+	for i := range adjusted {
+		cf := &adjusted[i]
+		wages := cf.SalaryPersonA.Add(cf.SalaryPersonB)
+		gross := cf.CalculateTotalIncome()
+		if wages.IsZero() || gross.IsZero() {
+			continue
+		}
+		incomeTaxShare := cf.FederalTax.Add(cf.StateTax).Mul(wages).Div(gross)
+		takeHome := wages.Sub(cf.FICATax).Sub(cf.LocalTax).Sub(cf.TSPContributions).Sub(cf.HSAContributions).Sub(incomeTaxShare)
+		cf.NetIncome = cf.NetIncome.Sub(takeHome)
+	}
+	return adjusted
+}
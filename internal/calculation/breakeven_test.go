@@ -69,3 +69,58 @@ func TestCalculateCumulativeBreakEven_Interpolation(t *testing.T) {
 		t.Fatalf("expected fraction ~0.5, got %s", res.Fraction.String())
 	}
 }
+
+func TestCalculateRetirementTimingBreakEven_WorkThreeMoreYears(t *testing.T) {
+	salaryGrowth := decimal.NewFromFloat(0.02)
+	personA := domain.Employee{
+		Name:             "PersonA",
+		BirthDate:        time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:         time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
+		CurrentSalary:    decimal.NewFromInt(100000),
+		High3Salary:      decimal.NewFromInt(100000),
+		SalaryGrowthRate: &salaryGrowth,
+		SSBenefitFRA:     decimal.NewFromInt(3000),
+	}
+	// A retired spouse's annuity and Social Security put the household in a taxable range
+	personB := domain.Employee{
+		Name:         "PersonB",
+		BirthDate:    time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary:  decimal.NewFromInt(80000),
+		SSBenefitFRA: decimal.NewFromInt(2000),
+	}
+	cfg := &domain.Configuration{
+		PersonalDetails:   map[string]domain.Employee{"person_a": personA, "person_b": personB},
+		GlobalAssumptions: domain.GlobalAssumptions{ProjectionYears: 30, COLAGeneralRate: decimal.NewFromFloat(0.02)},
+	}
+	retire := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := &domain.Scenario{
+		Name:    "retire-now",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+
+	result, err := NewCalculationEngine().CalculateRetirementTimingBreakEven(cfg, "person_a", scenario, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.DelayedPension.GreaterThan(result.RetireNowPension) {
+		t.Fatalf("expected a larger pension from working longer: now=%s delayed=%s", result.RetireNowPension, result.DelayedPension)
+	}
+	// The delayed High-3 averages the 2025-2027 salaries grown 2% a year, not the configured High-3 grown
+	wantHigh3 := decimal.NewFromInt(100000).Mul(decimal.NewFromInt(1).Add(decimal.NewFromFloat(1.02)).Add(decimal.NewFromFloat(1.0404))).Div(decimal.NewFromInt(3))
+	if want := wantHigh3.Mul(decimal.NewFromFloat(0.011)).Mul(decimal.NewFromInt(23)); result.DelayedPension.Sub(want).Abs().GreaterThan(decimal.NewFromInt(5)) {
+		t.Fatalf("expected a delayed pension of about %s from the projected High-3, got %s", want.StringFixed(2), result.DelayedPension)
+	}
+	if result.BreakEven == nil {
+		t.Fatalf("expected working longer to eventually overtake retiring now")
+	}
+	// 20 years at 60 (1.0%) vs. 23 years at 63 (1.1%) with a High-3 from grown salaries catches up in the mid-to-late 70s.
+	// The larger annuity also makes more of Social Security taxable, which pushes the crossover later.
+	if result.BreakEvenAge < 72 || result.BreakEvenAge > 80 {
+		t.Fatalf("expected crossover in the mid-to-late 70s, got age %d (%v)", result.BreakEvenAge, result.BreakEven.CalendarYear)
+	}
+	if !cfg.PersonalDetails["person_a"].High3Salary.Equal(decimal.NewFromInt(100000)) {
+		t.Fatalf("expected caller's High-3 to be unchanged")
+	}
+}
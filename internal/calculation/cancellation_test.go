@@ -0,0 +1,72 @@
+package calculation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
+
+// cancelAfterContext reports cancellation once Err has been checked more than n times
+type cancelAfterContext struct {
+	context.Context
+	remaining int
+}
+
+func (c *cancelAfterContext) Err() error {
+	if c.remaining <= 0 {
+		return context.Canceled
+	}
+	c.remaining--
+	return nil
+}
+
+func TestGenerateAnnualProjectionWithContext_CancelMidRun(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	ctx := &cancelAfterContext{Context: context.Background(), remaining: 5}
+
+	projection, err := NewCalculationEngine().GenerateAnnualProjectionWithContext(ctx, &personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(projection) != 5 {
+		t.Fatalf("expected the 5 completed years to be returned, got %d", len(projection))
+	}
+}
+
+func TestRunScenario_CancelledContext(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewCalculationEngine().RunScenario(ctx, cfg, scenario); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRunFERSMonteCarloWithContext_Timeout(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	cfg.Scenarios = []domain.Scenario{*scenario}
+	engine := NewFERSMonteCarloEngine(cfg, &HistoricalDataManager{IsLoaded: true})
+	mcConfig := engine.config
+	mcConfig.NumSimulations = 50000
+	mcConfig.UseHistorical = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result, err := engine.RunFERSMonteCarloWithContext(ctx, mcConfig)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if result != nil {
+		t.Fatalf("expected no result after cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("cancellation took too long: %s", elapsed)
+	}
+}
@@ -0,0 +1,48 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCashInterestRaisesFederalStateAndSSTaxation(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.High3Salary = decimal.NewFromInt(30000)
+	personA.TSPBalanceTraditional = decimal.Zero
+	personA.SSBenefitFRA = decimal.NewFromInt(1500)
+	personB.High3Salary = decimal.NewFromInt(20000)
+	personB.SSBenefitFRA = decimal.NewFromInt(1000)
+
+	ce := NewCalculationEngine()
+	without := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)[1]
+
+	personA.CashBalance = decimal.NewFromInt(500000)
+	personA.CashYield = decimal.NewFromFloat(0.04)
+	proj := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	with := proj[1]
+
+	// Interest compounds in the account: 500,000 * 1.04 in the first year, 4% of that in the second
+	interest := decimal.NewFromInt(20800)
+	if !with.InterestIncome.Equal(interest) {
+		t.Fatalf("expected interest %s, got %s", interest, with.InterestIncome)
+	}
+	if !with.CashBalance.Equal(decimal.NewFromInt(540800)) {
+		t.Fatalf("expected cash balance 540800, got %s", with.CashBalance)
+	}
+	if !with.FederalTax.GreaterThan(without.FederalTax) {
+		t.Fatalf("expected interest to raise federal tax, got %s vs %s", with.FederalTax, without.FederalTax)
+	}
+	paTax := interest.Mul(ce.TaxCalc.StateTaxCalc.Rate)
+	if !with.StateTax.Sub(without.StateTax).Equal(paTax) {
+		t.Fatalf("expected PA tax on interest of %s, got %s", paTax, with.StateTax.Sub(without.StateTax))
+	}
+	// Taxable income rises by more than the interest because more Social Security becomes taxable
+	if !with.FederalTaxableIncome.Sub(without.FederalTaxableIncome).GreaterThan(interest) {
+		t.Fatalf("expected taxable SS to rise with interest, taxable income %s vs %s", with.FederalTaxableIncome, without.FederalTaxableIncome)
+	}
+	if !with.MAGI.GreaterThan(without.MAGI) {
+		t.Fatalf("expected interest in MAGI, got %s vs %s", with.MAGI, without.MAGI)
+	}
+}
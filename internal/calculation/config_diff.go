@@ -0,0 +1,161 @@
+package calculation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// defaultDiffThreshold is the smallest absolute change DiffConfigurations reports when no threshold is given
+var defaultDiffThreshold = decimal.NewFromInt(1)
+
+// ProjectionDelta is one output metric that differs between two configurations
+type ProjectionDelta struct {
+	Scenario      string           `json:"scenario"`
+	Year          int              `json:"year,omitempty"` // Calendar year; 0 for scenario summary metrics
+	Metric        string           `json:"metric"`
+	Before        decimal.Decimal  `json:"before"`
+	After         decimal.Decimal  `json:"after"`
+	Change        decimal.Decimal  `json:"change"`
+	PercentChange *decimal.Decimal `json:"percent_change,omitempty"` // Change as a fraction of Before; nil when Before is zero
+}
+
+// ProjectionDiff lists what changed in the scenario outputs between two configurations
+type ProjectionDiff struct {
+	Threshold        decimal.Decimal   `json:"threshold"`
+	AddedScenarios   []string          `json:"added_scenarios,omitempty"`
+	RemovedScenarios []string          `json:"removed_scenarios,omitempty"`
+	Deltas           []ProjectionDelta `json:"deltas"`
+}
+
+// IsEmpty reports whether the two configurations produced the same outputs
+func (d *ProjectionDiff) IsEmpty() bool {
+	return len(d.AddedScenarios) == 0 && len(d.RemovedScenarios) == 0 && len(d.Deltas) == 0
+}
+
+// diffSummaryMetrics are the scenario-level metrics compared by DiffConfigurations
+var diffSummaryMetrics = []struct {
+	name  string
+	value func(*domain.ScenarioSummary) decimal.Decimal
+}{
+	{"first_year_net_income", func(s *domain.ScenarioSummary) decimal.Decimal { return s.FirstYearNetIncome }},
+	{"net_present_value", func(s *domain.ScenarioSummary) decimal.Decimal { return s.NetPresentValue }},
+	{"tsp_longevity", func(s *domain.ScenarioSummary) decimal.Decimal { return decimal.NewFromInt(int64(s.TSPLongevity)) }},
+	{"final_tsp_balance", func(s *domain.ScenarioSummary) decimal.Decimal { return s.FinalTSPBalance }},
+	{"min_guaranteed_income", func(s *domain.ScenarioSummary) decimal.Decimal { return s.MinGuaranteedIncome }},
+}
+
+// diffYearMetrics are the per-year metrics compared by DiffConfigurations
+var diffYearMetrics = []struct {
+	name  string
+	value func(*domain.AnnualCashFlow) decimal.Decimal
+}{
+	{"gross_income", func(cf *domain.AnnualCashFlow) decimal.Decimal { return cf.TotalGrossIncome }},
+	{"net_income", func(cf *domain.AnnualCashFlow) decimal.Decimal { return cf.NetIncome }},
+	{"total_taxes", func(cf *domain.AnnualCashFlow) decimal.Decimal {
+		return cf.FederalTax.Add(cf.StateTax).Add(cf.LocalTax).Add(cf.FICATax)
+	}},
+	{"tsp_balance", func(cf *domain.AnnualCashFlow) decimal.Decimal { return cf.TotalTSPBalance() }},
+}
+
+// DiffConfigurations runs every scenario of two configurations and reports the summary and per-year
+// metrics that changed. Scenarios are matched by name and years by calendar year; a year missing from
+// one projection counts as zero. Changes smaller than threshold in absolute terms are suppressed; a zero
+// threshold means $1.
+func (ce *CalculationEngine) DiffConfigurations(before, after *domain.Configuration, threshold decimal.Decimal) (*ProjectionDiff, error) {
+	if before == nil || after == nil {
+		return nil, fmt.Errorf("two configurations are required")
+	}
+	if !threshold.IsPositive() {
+		threshold = defaultDiffThreshold
+	}
+	beforeResults, err := ce.RunScenarios(before)
+	if err != nil {
+		return nil, fmt.Errorf("before configuration: %w", err)
+	}
+	afterResults, err := ce.RunScenarios(after)
+	if err != nil {
+		return nil, fmt.Errorf("after configuration: %w", err)
+	}
+
+	diff := &ProjectionDiff{Threshold: threshold}
+	afterByName := make(map[string]*domain.ScenarioSummary, len(afterResults.Scenarios))
+	for i := range afterResults.Scenarios {
+		afterByName[afterResults.Scenarios[i].Name] = &afterResults.Scenarios[i]
+	}
+	seen := make(map[string]bool, len(beforeResults.Scenarios))
+	for i := range beforeResults.Scenarios {
+		b := &beforeResults.Scenarios[i]
+		seen[b.Name] = true
+		a, ok := afterByName[b.Name]
+		if !ok {
+			diff.RemovedScenarios = append(diff.RemovedScenarios, b.Name)
+			continue
+		}
+		diff.Deltas = append(diff.Deltas, diffScenario(b, a, threshold)...)
+	}
+	for _, a := range afterResults.Scenarios {
+		if !seen[a.Name] {
+			diff.AddedScenarios = append(diff.AddedScenarios, a.Name)
+		}
+	}
+	return diff, nil
+}
+
+// diffScenario compares two runs of the same scenario, summary metrics first and then each year in order
+func diffScenario(before, after *domain.ScenarioSummary, threshold decimal.Decimal) []ProjectionDelta {
+	var deltas []ProjectionDelta
+	add := func(year int, metric string, b, a decimal.Decimal) {
+		change := a.Sub(b)
+		if change.Abs().LessThan(threshold) {
+			return
+		}
+		delta := ProjectionDelta{Scenario: before.Name, Year: year, Metric: metric, Before: b, After: a, Change: change}
+		if !b.IsZero() {
+			pct := change.Div(b.Abs())
+			delta.PercentChange = &pct
+		}
+		deltas = append(deltas, delta)
+	}
+
+	for _, m := range diffSummaryMetrics {
+		add(0, m.name, m.value(before), m.value(after))
+	}
+
+	beforeYears := projectionByYear(before.Projection)
+	afterYears := projectionByYear(after.Projection)
+	for _, year := range mergedYears(before.Projection, after.Projection) {
+		b, a := beforeYears[year], afterYears[year]
+		for _, m := range diffYearMetrics {
+			add(year, m.name, m.value(&b), m.value(&a))
+		}
+	}
+	return deltas
+}
+
+// projectionByYear indexes a projection by calendar year
+func projectionByYear(projection []domain.AnnualCashFlow) map[int]domain.AnnualCashFlow {
+	years := make(map[int]domain.AnnualCashFlow, len(projection))
+	for _, cf := range projection {
+		years[cf.Date.Year()] = cf
+	}
+	return years
+}
+
+// mergedYears returns the calendar years of two projections in order, each once
+func mergedYears(a, b []domain.AnnualCashFlow) []int {
+	var years []int
+	seen := make(map[int]bool, len(a)+len(b))
+	for _, projection := range [][]domain.AnnualCashFlow{a, b} {
+		for _, cf := range projection {
+			if year := cf.Date.Year(); !seen[year] {
+				seen[year] = true
+				years = append(years, year)
+			}
+		}
+	}
+	sort.Ints(years)
+	return years
+}
@@ -0,0 +1,64 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestDiffConfigurations(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	cfg.Scenarios = []domain.Scenario{*scenario}
+	// RunScenarios compares against current net income, so the employees need salaries
+	for key, person := range cfg.PersonalDetails {
+		person.CurrentSalary = person.High3Salary
+		cfg.PersonalDetails[key] = person
+	}
+	ce := NewCalculationEngine()
+
+	same, err := ce.DiffConfigurations(cfg, cfg, decimal.Zero)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !same.IsEmpty() {
+		t.Fatalf("expected an empty diff for identical configurations, got %+v", same.Deltas)
+	}
+
+	variant := *cfg
+	variant.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.06)
+	diff, err := ce.DiffConfigurations(cfg, &variant, decimal.Zero)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff.IsEmpty() {
+		t.Fatalf("expected a higher return to change the outputs")
+	}
+
+	balanceYears := 0
+	for _, d := range diff.Deltas {
+		if d.Change.Abs().LessThan(diff.Threshold) {
+			t.Fatalf("%s %d %s: change %s below the threshold should be suppressed", d.Scenario, d.Year, d.Metric, d.Change)
+		}
+		if !d.After.Sub(d.Before).Equal(d.Change) {
+			t.Fatalf("%s %d: change %s should be after %s less before %s", d.Metric, d.Year, d.Change, d.After, d.Before)
+		}
+		switch d.Metric {
+		case "tsp_balance":
+			balanceYears++
+			if !d.Change.IsPositive() || d.PercentChange == nil || !d.PercentChange.Equal(d.Change.Div(d.Before)) {
+				t.Fatalf("year %d: expected a higher TSP balance with a percentage change, got %+v", d.Year, d)
+			}
+		case "final_tsp_balance":
+			if !d.Change.IsPositive() {
+				t.Fatalf("expected a higher final TSP balance, got %s", d.Change)
+			}
+		case "net_income", "gross_income", "total_taxes", "first_year_net_income":
+			// The 4% rule withdrawal is set by the starting balance, so income does not change
+			t.Fatalf("unexpected %s change in %d: %s", d.Metric, d.Year, d.Change)
+		}
+	}
+	if balanceYears != 5 {
+		t.Fatalf("expected a TSP balance change in each of the 5 years, got %d", balanceYears)
+	}
+}
@@ -1,4 +1,8 @@
 package calculation
 
-// ProjectionBaseYear centralizes the starting calendar year for projections.
-const ProjectionBaseYear = 2025
+import "github.com/rpgo/retirement-calculator/internal/domain"
+
+// ProjectionStartYear returns the calendar year of projection year 0; see domain.ProjectionStartYear
+func ProjectionStartYear(assumptions *domain.GlobalAssumptions) int {
+	return domain.ProjectionStartYear(assumptions)
+}
@@ -0,0 +1,61 @@
+package calculation
+
+import (
+	"fmt"
+	"math"
+)
+
+// tspFundOrder is the canonical fund ordering used for correlation matrices.
+var tspFundOrder = []string{"C", "S", "I", "F", "G"}
+
+// CholeskyDecompose returns the lower-triangular matrix L such that L*Lᵀ equals the
+// given symmetric positive-definite matrix. It returns an error if the matrix is not
+// square, not symmetric, or not positive definite.
+func CholeskyDecompose(m [][]float64) ([][]float64, error) {
+	n := len(m)
+	for i := range m {
+		if len(m[i]) != n {
+			return nil, fmt.Errorf("matrix is not square: row %d has %d columns, want %d", i, len(m[i]), n)
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(m[i][j]-m[j][i]) > 1e-9 {
+				return nil, fmt.Errorf("matrix is not symmetric at (%d,%d)", i, j)
+			}
+		}
+	}
+
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, fmt.Errorf("matrix is not positive definite")
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
+
+// applyCholesky transforms independent standard normal draws z into correlated draws L*z.
+func applyCholesky(l [][]float64, z []float64) []float64 {
+	out := make([]float64, len(z))
+	for i := range l {
+		for k := 0; k <= i && k < len(z); k++ {
+			out[i] += l[i][k] * z[k]
+		}
+	}
+	return out
+}
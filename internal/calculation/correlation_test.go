@@ -0,0 +1,137 @@
+package calculation
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestCholeskyDecompose(t *testing.T) {
+	m := [][]float64{
+		{4, 2, 0.4},
+		{2, 2, 0.5},
+		{0.4, 0.5, 1},
+	}
+	l, err := CholeskyDecompose(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range m {
+		for j := range m {
+			var sum float64
+			for k := range m {
+				sum += l[i][k] * l[j][k]
+			}
+			if math.Abs(sum-m[i][j]) > 1e-9 {
+				t.Fatalf("L*Lt mismatch at (%d,%d): got %v want %v", i, j, sum, m[i][j])
+			}
+		}
+	}
+
+	if _, err := CholeskyDecompose([][]float64{{1, 2}, {2, 1}}); err == nil {
+		t.Fatalf("expected error for non positive-definite matrix")
+	}
+	if _, err := CholeskyDecompose([][]float64{{1, 0.5}, {0.1, 1}}); err == nil {
+		t.Fatalf("expected error for non-symmetric matrix")
+	}
+}
+
+// portfolioReturnVariance draws n years of C/S returns and returns the variance of a 50/50 portfolio.
+func portfolioReturnVariance(engine *FERSMonteCarloEngine, n int) float64 {
+	half := decimal.NewFromFloat(0.5)
+	samples := make([]float64, n)
+	var mean float64
+	for i := 0; i < n; i++ {
+		r := engine.generateStatisticalTSPReturns()
+		samples[i] = r["C"].Mul(half).Add(r["S"].Mul(half)).InexactFloat64()
+		mean += samples[i]
+	}
+	mean /= float64(n)
+	var variance float64
+	for _, s := range samples {
+		variance += (s - mean) * (s - mean)
+	}
+	return variance / float64(n-1)
+}
+
+func TestCorrelatedTSPReturnsIncreasePortfolioVariance(t *testing.T) {
+	independentCfg := &domain.Configuration{}
+	independent := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{BaseConfig: independentCfg}}
+
+	d := decimal.NewFromFloat
+	correlatedCfg := &domain.Configuration{}
+	correlatedCfg.GlobalAssumptions.TSPStatisticalModels.CorrelationMatrix = [][]decimal.Decimal{
+		{d(1), d(0.9), d(0.8), d(0.1), d(0)},
+		{d(0.9), d(1), d(0.75), d(0.05), d(0)},
+		{d(0.8), d(0.75), d(1), d(0.1), d(0)},
+		{d(0.1), d(0.05), d(0.1), d(1), d(0.2)},
+		{d(0), d(0), d(0), d(0.2), d(1)},
+	}
+	chol, err := fundCorrelationCholesky(correlatedCfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	correlated := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{BaseConfig: correlatedCfg}, fundCholesky: chol}
+
+	const n = 5000
+	indVar := portfolioReturnVariance(independent, n)
+	corVar := portfolioReturnVariance(correlated, n)
+
+	// Independent 50/50 C/S variance is ~0.017; with rho=0.9 it is ~0.032.
+	if corVar < indVar*1.5 {
+		t.Fatalf("expected correlated variance to be materially higher: independent=%.5f correlated=%.5f", indVar, corVar)
+	}
+}
+
+func TestRunFERSMonteCarloRejectsInvalidCorrelationMatrix(t *testing.T) {
+	d := decimal.NewFromFloat
+	identity := func() [][]decimal.Decimal {
+		m := make([][]decimal.Decimal, len(tspFundOrder))
+		for i := range m {
+			m[i] = make([]decimal.Decimal, len(tspFundOrder))
+			for j := range m[i] {
+				m[i][j] = decimal.Zero
+			}
+			m[i][i] = d(1)
+		}
+		return m
+	}
+	notPositiveDefinite := identity()
+	notPositiveDefinite[0][1], notPositiveDefinite[1][0] = d(1.5), d(1.5)
+	notSymmetric := identity()
+	notSymmetric[0][1] = d(0.5)
+	// A covariance-like diagonal would still factor, silently rescaling the fund volatilities
+	notUnitDiagonal := identity()
+	notUnitDiagonal[2][2] = d(4)
+
+	for name, matrix := range map[string][][]decimal.Decimal{
+		"wrong size":            {{d(1)}},
+		"not symmetric":         notSymmetric,
+		"not positive definite": notPositiveDefinite,
+		"diagonal not one":      notUnitDiagonal,
+	} {
+		// The matrix is checked before any simulation runs, so no household is needed
+		cfg := &domain.Configuration{GlobalAssumptions: domain.GlobalAssumptions{
+			TSPStatisticalModels: domain.TSPStatisticalModels{CorrelationMatrix: matrix},
+		}}
+		engine := NewFERSMonteCarloEngine(cfg, &HistoricalDataManager{IsLoaded: true})
+		if _, err := engine.RunFERSMonteCarlo(FERSMonteCarloConfig{BaseConfig: cfg, NumSimulations: 2}); err == nil || !strings.Contains(err.Error(), "correlation matrix") {
+			t.Fatalf("%s: expected a correlation matrix error, got %v", name, err)
+		}
+	}
+
+	// A valid matrix is factored once for the run
+	cfg, scenario := retiredCoupleTestConfig(5)
+	cfg.Scenarios = []domain.Scenario{*scenario}
+	cfg.GlobalAssumptions.TSPStatisticalModels.CorrelationMatrix = identity()
+	engine := NewFERSMonteCarloEngine(cfg, &HistoricalDataManager{IsLoaded: true})
+	if _, err := engine.RunFERSMonteCarlo(FERSMonteCarloConfig{BaseConfig: cfg, NumSimulations: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(engine.fundCholesky) != len(tspFundOrder) {
+		t.Fatalf("expected the run's Cholesky factor to be kept, got %v", engine.fundCholesky)
+	}
+}
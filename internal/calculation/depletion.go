@@ -0,0 +1,52 @@
+package calculation
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// DepletionPoint is the share of Monte Carlo simulations whose TSP has run out by a projection year
+type DepletionPoint struct {
+	Year             int             `json:"year"`
+	AgePersonA       int             `json:"age_person_a"`
+	AgePersonB       int             `json:"age_person_b"`
+	DepletedFraction decimal.Decimal `json:"depleted_fraction"`
+}
+
+// DepletionHeatmap returns, for each projection year, the fraction of simulations whose combined TSP
+// balance (first scenario) has been depleted in or before that year. A simulation counts as depleted from
+// its first depleted year onward, so the fractions never decrease. Simulations whose projection ends
+// early (stochastic mortality) keep their final state.
+func (r *FERSMonteCarloResult) DepletionHeatmap() []DepletionPoint {
+	if r == nil || len(r.Simulations) == 0 {
+		return nil
+	}
+
+	// Label years from the longest projection
+	var labels []DepletionPoint
+	var depletedBy []int
+	for _, sim := range r.Simulations {
+		if len(sim.ScenarioResults) == 0 {
+			continue
+		}
+		projection := sim.ScenarioResults[0].Projection
+		for len(labels) < len(projection) {
+			cf := projection[len(labels)]
+			labels = append(labels, DepletionPoint{Year: cf.Date.Year(), AgePersonA: cf.AgePersonA, AgePersonB: cf.AgePersonB})
+			depletedBy = append(depletedBy, 0)
+		}
+		for i, cf := range projection {
+			if cf.IsTSPDepleted() {
+				depletedBy[i]++
+				break
+			}
+		}
+	}
+
+	total := decimal.NewFromInt(int64(len(r.Simulations)))
+	cumulative := 0
+	for i := range labels {
+		cumulative += depletedBy[i]
+		labels[i].DepletedFraction = decimal.NewFromInt(int64(cumulative)).Div(total)
+	}
+	return labels
+}
@@ -1,12 +1,14 @@
 package calculation
 
-import "time"
+import (
+	"time"
 
-// nowFunc returns the current time (override in tests for determinism).
-var nowFunc = time.Now
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
 
-// SetNowFunc overrides the time provider (use only in tests).
-func SetNowFunc(f func() time.Time) { nowFunc = f }
+// SetNowFunc overrides the time provider (use only in tests). It sets the domain clock, which config
+// defaults read too.
+func SetNowFunc(f func() time.Time) { domain.SetNowFunc(f) }
 
 // seedFunc returns a pseudo-random seed (override for deterministic Monte Carlo tests).
 var seedFunc = func() int64 { return time.Now().UnixNano() }
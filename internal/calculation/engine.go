@@ -10,8 +10,9 @@ import (
 )
 
 type NetIncomeCalculator struct {
-	TaxCalc *ComprehensiveTaxCalculator
-	Logger  Logger
+	TaxCalc               *ComprehensiveTaxCalculator
+	TSPContributionLimits domain.TSPContributionLimits // Caps current TSP contributions; zero values use the 2025 limits
+	Logger                Logger
 }
 
 func NewNetIncomeCalculator(taxCalc *ComprehensiveTaxCalculator, logger Logger) *NetIncomeCalculator {
@@ -29,8 +30,20 @@ type CalculationEngine struct {
 	NetIncomeCalc         *NetIncomeCalculator
 	HistoricalData        *HistoricalDataManager
 	MonteCarloFundReturns map[string]decimal.Decimal // Monte Carlo generated fund returns for TSP allocation calculations
-	Debug                 bool                       // Enable debug output for detailed calculations
-	Logger                Logger
+	// MonteCarloFundReturnsByYear holds per-projection-year fund returns (index 0 = the projection start year).
+	// When populated it takes precedence over MonteCarloFundReturns so each year gets its own draw.
+	MonteCarloFundReturnsByYear []map[string]decimal.Decimal
+	// MonteCarloPortfolioReturnsByYear holds per-projection-year weighted portfolio returns used in place
+	// of the fixed pre/post-retirement TSP return for employees without an explicit allocation.
+	MonteCarloPortfolioReturnsByYear []decimal.Decimal
+	// InflationByYear and COLAByYear hold per-projection-year inflation and general COLA rates (index 0 =
+	// the projection start year) used in place of the constant InflationRate and COLAGeneralRate
+	// assumptions, so a market sequence's inflation path reaches pensions, Social Security, withdrawals,
+	// spending needs and real dollars year by year.
+	InflationByYear []decimal.Decimal
+	COLAByYear      []decimal.Decimal
+	Debug           bool // Enable debug output for detailed calculations
+	Logger          Logger
 }
 
 // NewCalculationEngine creates a new calculation engine
@@ -56,6 +69,7 @@ func NewCalculationEngineWithConfig(federalRules domain.FederalRules) *Calculati
 		NetIncomeCalc:       NewNetIncomeCalculator(taxCalc, logger),
 		Logger:              logger,
 	}
+	engine.NetIncomeCalc.TSPContributionLimits = federalRules.TSPContributionLimits
 
 	// Load lifecycle fund data
 	if err := engine.LifecycleFundLoader.LoadAllLifecycleFunds(); err != nil {
@@ -75,120 +89,86 @@ func (ce *CalculationEngine) SetLogger(l Logger) {
 	ce.Logger = l
 }
 
-// RunScenario calculates a complete retirement scenario
-func (ce *CalculationEngine) RunScenario(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*domain.ScenarioSummary, error) {
-	// Local neutral aliases to support incremental rename from human names to person_a/person_b
-	personAEmployee := config.PersonalDetails["person_a"]
-	personBEmployee := config.PersonalDetails["person_b"]
+// scenarioAssumptions returns a copy of the configuration with the scenario's assumption overrides applied
+// and a copy of the scenario with its overrides cleared. Callers that vary assumptions between runs
+// (sensitivity sweeps, Monte Carlo draws, market sequences) resolve the scenario first, so the value they
+// set is the one the projection uses. Without overrides config and scenario are returned unchanged.
+func scenarioAssumptions(config *domain.Configuration, scenario *domain.Scenario) (*domain.Configuration, *domain.Scenario) {
+	if scenario.AssumptionOverrides == nil {
+		return config, scenario
+	}
+	scenarioConfig := *config
+	scenarioConfig.GlobalAssumptions = scenario.AssumptionOverrides.Apply(config.GlobalAssumptions)
+	resolved := *scenario
+	resolved.AssumptionOverrides = nil
+	return &scenarioConfig, &resolved
+}
+
+// scenarioConfiguration applies a scenario's assumption overrides to a copy of the configuration and
+// validates the scenario against the result
+func scenarioConfiguration(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*domain.Configuration, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
+	}
+
+	// Scenario-specific assumptions shadow the global ones on a copy of the configuration
+	config, _ = scenarioAssumptions(config, scenario)
 
-	// Use neutral local variable names
-	personA := personAEmployee
-	personB := personBEmployee
+	personA, personB := config.PersonalDetails["person_a"], config.PersonalDetails["person_b"]
 
 	// Validate retirement dates are after hire dates
 	if scenario.PersonA.RetirementDate.Before(personA.HireDate) {
-		return nil, fmt.Errorf("person_a's retirement date (%s) cannot be before hire date (%s)",
-			scenario.PersonA.RetirementDate.Format("2006-01-02"), personA.HireDate.Format("2006-01-02"))
+		return nil, &RetirementBeforeHireError{Person: "person_a", RetirementDate: scenario.PersonA.RetirementDate, HireDate: personA.HireDate}
 	}
 	if scenario.PersonB.RetirementDate.Before(personB.HireDate) {
-		return nil, fmt.Errorf("person_b's retirement date (%s) cannot be before hire date (%s)",
-			scenario.PersonB.RetirementDate.Format("2006-01-02"), personB.HireDate.Format("2006-01-02"))
+		return nil, &RetirementBeforeHireError{Person: "person_b", RetirementDate: scenario.PersonB.RetirementDate, HireDate: personB.HireDate}
 	}
 
 	// Validate inflation and return rates are reasonable (allow deflation but cap extreme values)
 	if config.GlobalAssumptions.InflationRate.LessThan(decimal.NewFromFloat(-0.10)) || config.GlobalAssumptions.InflationRate.GreaterThan(decimal.NewFromFloat(0.20)) {
-		return nil, fmt.Errorf("inflation rate must be between -10%% and 20%%, got %s%%",
+		return nil, fmt.Errorf("%w, got %s%%", ErrInflationOutOfRange,
 			config.GlobalAssumptions.InflationRate.Mul(decimal.NewFromInt(100)).StringFixed(2))
 	}
 
-	// Generate annual projections
-	projection := ce.GenerateAnnualProjection(&personA, &personB, scenario, &config.GlobalAssumptions, config.GlobalAssumptions.FederalRules)
-
-	// Create scenario summary (guard Year5/Year10 for short projections)
-	first := decimal.Zero
-	if len(projection) > 0 {
-		first = projection[0].NetIncome
-	}
-	year5 := decimal.Zero
-	if len(projection) > 4 {
-		year5 = projection[4].NetIncome
-	}
-	year10 := decimal.Zero
-	if len(projection) > 9 {
-		year10 = projection[9].NetIncome
+	// Validate one-time financial events fall within the projection window
+	if err := validateFinancialEvents(scenario.Events, ProjectionStartYear(&config.GlobalAssumptions), config.GlobalAssumptions.ProjectionYears); err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
 	}
 
-	// Calculate absolute calendar year comparisons for apples-to-apples analysis
-	netIncome2030 := ce.getNetIncomeForYear(projection, 2030)
-	netIncome2035 := ce.getNetIncomeForYear(projection, 2035)
-	netIncome2040 := ce.getNetIncomeForYear(projection, 2040)
-
-	// Calculate pre-retirement baseline projections with COLA growth
-	currentNetIncome := ce.NetIncomeCalc.Calculate(&personA, &personB, ce.Debug)
-	preRetirement2030 := ce.projectPreRetirementNetIncome(currentNetIncome, 2030, config.GlobalAssumptions.COLAGeneralRate)
-	preRetirement2035 := ce.projectPreRetirementNetIncome(currentNetIncome, 2035, config.GlobalAssumptions.COLAGeneralRate)
-	preRetirement2040 := ce.projectPreRetirementNetIncome(currentNetIncome, 2040, config.GlobalAssumptions.COLAGeneralRate)
-
-	summary := &domain.ScenarioSummary{
-		Name:                 scenario.Name,
-		FirstYearNetIncome:   first,
-		Year5NetIncome:       year5,
-		Year10NetIncome:      year10,
-		Projection:           projection,
-		NetIncome2030:        netIncome2030,
-		NetIncome2035:        netIncome2035,
-		NetIncome2040:        netIncome2040,
-		PreRetirementNet2030: preRetirement2030,
-		PreRetirementNet2035: preRetirement2035,
-		PreRetirementNet2040: preRetirement2040,
-	}
+	return config, nil
+}
 
-	// Calculate total lifetime income (present value)
-	var totalPV decimal.Decimal
-	discountRate := decimal.NewFromFloat(0.03) // 3% discount rate
-	for i, year := range projection {
-		discountFactor := decimal.NewFromFloat(1).Add(discountRate).Pow(decimal.NewFromInt(int64(i)))
-		totalPV = totalPV.Add(year.NetIncome.Div(discountFactor))
+// RunScenario calculates a complete retirement scenario. Cancelling ctx stops the projection at the next
+// year boundary and returns the wrapped context error.
+func (ce *CalculationEngine) RunScenario(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*domain.ScenarioSummary, error) {
+	config, err := scenarioConfiguration(ctx, config, scenario)
+	if err != nil {
+		return nil, err
 	}
-	summary.TotalLifetimeIncome = totalPV
 
-	// Determine TSP longevity
-	for i, year := range projection {
-		if year.IsTSPDepleted() {
-			summary.TSPLongevity = i + 1
-			break
-		}
-	}
-	if summary.TSPLongevity == 0 {
-		summary.TSPLongevity = len(projection) // Lasted full projection
-	}
+	// Local neutral aliases to support incremental rename from human names to person_a/person_b
+	personA := config.PersonalDetails["person_a"]
+	personB := config.PersonalDetails["person_b"]
 
-	// Set initial and final TSP balances
-	if len(projection) > 0 {
-		summary.InitialTSPBalance = projection[0].TSPBalancePersonA.Add(projection[0].TSPBalancePersonB)
-		summary.FinalTSPBalance = projection[len(projection)-1].TSPBalancePersonA.Add(projection[len(projection)-1].TSPBalancePersonB)
+	// Generate annual projections
+	projection, err := ce.GenerateAnnualProjectionWithContext(ctx, &personA, &personB, scenario, &config.GlobalAssumptions, config.GlobalAssumptions.FederalRules)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
+	}
 
-		// Calculate success rate for deterministic scenarios based on TSP sustainability
-		summary.SuccessRate = ce.calculateDeterministicSuccessRate(projection, summary.TSPLongevity)
+	accumulator := ce.newScenarioSummaryAccumulator(config, scenario)
+	for i := range projection {
+		accumulator.add(&projection[i])
 	}
+	summary := accumulator.finish()
+	summary.Projection = projection
 
 	return summary, nil
 }
 
-// getNetIncomeForYear finds the net income for a specific calendar year in the projection
-func (ce *CalculationEngine) getNetIncomeForYear(projection []domain.AnnualCashFlow, targetYear int) decimal.Decimal {
-	for _, year := range projection {
-		if year.Date.Year() == targetYear {
-			return year.NetIncome
-		}
-	}
-	return decimal.Zero // Year not found in projection
-}
-
-// projectPreRetirementNetIncome projects current net income to future year with COLA growth
-func (ce *CalculationEngine) projectPreRetirementNetIncome(currentNet decimal.Decimal, targetYear int, colaRate decimal.Decimal) decimal.Decimal {
-	currentYear := 2025 // Base year
-	yearsToProject := targetYear - currentYear
+// projectPreRetirementNetIncome projects current net income from the projection start year to a future year with COLA growth
+func (ce *CalculationEngine) projectPreRetirementNetIncome(currentNet decimal.Decimal, startYear, targetYear int, colaRate decimal.Decimal) decimal.Decimal {
+	yearsToProject := targetYear - startYear
 
 	if yearsToProject <= 0 {
 		return currentNet
@@ -200,21 +180,18 @@ func (ce *CalculationEngine) projectPreRetirementNetIncome(currentNet decimal.De
 	return currentNet.Mul(growthFactor)
 }
 
-// calculateDeterministicSuccessRate calculates success rate based on TSP sustainability and growth
-func (ce *CalculationEngine) calculateDeterministicSuccessRate(projection []domain.AnnualCashFlow, tspLongevity int) decimal.Decimal {
-	if len(projection) == 0 {
+// deterministicSuccessRate calculates success rate based on TSP sustainability and growth over a
+// projection of projectionLength years whose household TSP balance goes from firstTSP to lastTSP. An
+// annuitized TSP that lasts the projection is still paying income, not a declining balance.
+func deterministicSuccessRate(projectionLength int, firstTSP, lastTSP decimal.Decimal, tspLongevity int, annuitized bool) decimal.Decimal {
+	if projectionLength == 0 {
 		return decimal.Zero
 	}
 
-	projectionLength := len(projection)
-
 	// If TSP lasts the full projection period, success rate is 100%
 	if tspLongevity >= projectionLength {
 		// Additional check: TSP should be growing or stable, not just lasting
-		firstTSP := projection[0].TSPBalancePersonA.Add(projection[0].TSPBalancePersonB)
-		lastTSP := projection[projectionLength-1].TSPBalancePersonA.Add(projection[projectionLength-1].TSPBalancePersonB)
-
-		if lastTSP.GreaterThanOrEqual(firstTSP) {
+		if annuitized || lastTSP.GreaterThanOrEqual(firstTSP) {
 			return decimal.NewFromFloat(100.0) // 100% success - TSP lasted and grew
 		} else {
 			return decimal.NewFromFloat(95.0) // 95% success - TSP lasted but declined
@@ -253,7 +230,7 @@ func (ce *CalculationEngine) RunScenarios(config *domain.Configuration) (*domain
 	// Calculate baseline (current net income)
 	personA := config.PersonalDetails["person_a"]
 	personB := config.PersonalDetails["person_b"]
-	baselineNetIncome := ce.NetIncomeCalc.Calculate(&personA, &personB, ce.Debug)
+	baselineNetIncome := ce.NetIncomeCalc.Calculate(&personA, &personB, config.GlobalAssumptions.FederalRules.FEHBConfig, ce.TaxCalc.LocalTaxCalculatorFor(config.GlobalAssumptions.CurrentLocation.Municipality), ProjectionStartYear(&config.GlobalAssumptions), ce.Debug)
 
 	comparison := &domain.ScenarioComparison{
 		BaselineNetIncome: baselineNetIncome,
@@ -268,25 +245,29 @@ func (ce *CalculationEngine) RunScenarios(config *domain.Configuration) (*domain
 	return comparison, nil
 }
 
-func (nic *NetIncomeCalculator) Calculate(personA, personB *domain.Employee, debug bool) decimal.Decimal {
+// Calculate returns the household's current annual net take-home pay in projectionStartYear. The FEHB
+// premium is the enrollee's share under fehbConfig and local tax follows localTaxCalc, as in the projection.
+func (nic *NetIncomeCalculator) Calculate(personA, personB *domain.Employee, fehbConfig domain.FEHBConfig, localTaxCalc *LocalTaxCalculator, projectionStartYear int, debug bool) decimal.Decimal {
 	// Calculate gross income
 	grossIncome := personA.CurrentSalary.Add(personB.CurrentSalary)
 
-	// Calculate FEHB premiums (only Person A pays FEHB, Person B has FSA-HC)
-	fehbPremium := personA.FEHBPremiumPerPayPeriod.Mul(decimal.NewFromInt(26)) // 26 pay periods per year
+	// The household FEHB plan is person_a's; its enrollment type sets who it covers, as in the projection
+	fehbPremium := CalculateFEHBPremium(personA, personA.FEHBEnrollmentType(), 0, decimal.Zero, fehbConfig)
 
-	// Calculate TSP contributions (pre-tax)
-	tspContributions := personA.TotalAnnualTSPContribution().Add(personB.TotalAnnualTSPContribution())
+	// Calculate TSP contributions (pre-tax), capped at the configured elective deferral limits
+	yearEnd := time.Date(projectionStartYear, 12, 31, 0, 0, 0, 0, time.UTC)
+	limits := nic.TSPContributionLimits
+	tspContributions := personA.LimitedTotalAnnualTSPContribution(limits.AnnualLimit(personA.Age(yearEnd), projectionStartYear, decimal.Zero)).
+		Add(personB.LimitedTotalAnnualTSPContribution(limits.AnnualLimit(personB.Age(yearEnd), projectionStartYear, decimal.Zero)))
 
-	// Calculate taxes - use projection start date for age calculation
-	projectionStartYear := ProjectionBaseYear
-	projectionStartDate := time.Date(projectionStartYear, 1, 1, 0, 0, 0, 0, time.UTC)
-	agePersonA := personA.Age(projectionStartDate)
-	agePersonB := personB.Age(projectionStartDate)
+	// Calculate taxes - ages for the tax year are as of January 1 of the following year (see isSeniorForTaxYear)
+	taxYearEnd := time.Date(projectionStartYear+1, 1, 1, 0, 0, 0, 0, time.UTC)
+	agePersonA := personA.Age(taxYearEnd)
+	agePersonB := personB.Age(taxYearEnd)
 
 	// Calculate taxes (excluding FICA for now, will calculate separately)
 	currentTaxableIncome := CalculateCurrentTaxableIncome(personA.CurrentSalary, personB.CurrentSalary)
-	federalTax, stateTax, localTax, _ := nic.TaxCalc.CalculateTotalTaxes(currentTaxableIncome, false, agePersonA, agePersonB, grossIncome)
+	federalTax, stateTax, localTax, _ := nic.TaxCalc.CalculateTotalTaxes(currentTaxableIncome, false, agePersonA, agePersonB, grossIncome, localTaxCalc)
 
 	// Calculate FICA taxes for each individual separately, as SS wage base applies per individual
 	personAFICA := nic.TaxCalc.FICATaxCalc.CalculateFICA(personA.CurrentSalary, personA.CurrentSalary)
@@ -309,7 +290,7 @@ func (nic *NetIncomeCalculator) Calculate(personA, personB *domain.Employee, deb
 		nic.Logger.Debugf("  State Tax:            $%s", stateTax.StringFixed(2))
 		nic.Logger.Debugf("  Local Tax:            $%s", localTax.StringFixed(2))
 		nic.Logger.Debugf("  FICA Tax:             $%s", ficaTax.StringFixed(2))
-		nic.Logger.Debugf("  FEHB Premium (PersonA): $%s", fehbPremium.StringFixed(2))
+		nic.Logger.Debugf("  FEHB Premium:         $%s", fehbPremium.StringFixed(2))
 		nic.Logger.Debugf("  TSP Contributions:    $%s", tspContributions.StringFixed(2))
 		nic.Logger.Debugf("  Total Deductions:     $%s", federalTax.Add(stateTax).Add(localTax).Add(ficaTax).Add(fehbPremium).Add(tspContributions).StringFixed(2))
 		nic.Logger.Debugf("")
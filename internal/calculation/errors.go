@@ -0,0 +1,36 @@
+package calculation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Errors returned for invalid inputs. They are wrapped with context, so match them with errors.Is.
+var (
+	ErrMissingInput            = errors.New("configuration and scenario are required")
+	ErrUnknownEmployee         = errors.New("employee must be person_a or person_b")
+	ErrHistoricalDataNotLoaded = errors.New("historical data not loaded")
+	ErrRetirementBeforeHire    = errors.New("retirement date cannot be before hire date")
+	ErrInflationOutOfRange     = errors.New("inflation rate must be between -10% and 20%")
+	ErrEventOutsideProjection  = errors.New("event date is outside the projection window")
+	ErrInvalidEventAccount     = errors.New(`event account must be "cash" or "tsp"`)
+)
+
+// RetirementBeforeHireError reports a scenario retirement date earlier than the employee's hire date. It
+// matches ErrRetirementBeforeHire.
+type RetirementBeforeHireError struct {
+	Person         string // person_a or person_b
+	RetirementDate time.Time
+	HireDate       time.Time
+}
+
+// Error implements the error interface
+func (e *RetirementBeforeHireError) Error() string {
+	return fmt.Sprintf("%s's retirement date (%s) cannot be before hire date (%s)", e.Person, e.RetirementDate.Format("2006-01-02"), e.HireDate.Format("2006-01-02"))
+}
+
+// Is reports whether target is ErrRetirementBeforeHire
+func (e *RetirementBeforeHireError) Is(target error) bool {
+	return target == ErrRetirementBeforeHire
+}
@@ -0,0 +1,91 @@
+package calculation
+
+import (
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// yearEvents totals a scenario's one-time financial events for a single calendar year.
+// All amounts are non-negative magnitudes.
+type yearEvents struct {
+	CashInflow  decimal.Decimal
+	CashOutflow decimal.Decimal
+	TSPInflow   decimal.Decimal
+	TSPOutflow  decimal.Decimal
+	TaxableCash decimal.Decimal // Taxable cash inflows (e.g. an inherited IRA distribution)
+	TaxableTSP  decimal.Decimal // Taxable TSP distributions used to fund an outflow
+}
+
+// financialEventsForYear sums the events dated in calendarYear
+func financialEventsForYear(events []domain.FinancialEvent, calendarYear int) yearEvents {
+	var totals yearEvents
+	for _, e := range events {
+		if e.Date.Year() != calendarYear || e.Amount.IsZero() {
+			continue
+		}
+		amount := e.Amount.Abs()
+		inflow := e.Amount.IsPositive()
+		switch {
+		case e.IsTSP() && inflow:
+			totals.TSPInflow = totals.TSPInflow.Add(amount)
+		case e.IsTSP():
+			totals.TSPOutflow = totals.TSPOutflow.Add(amount)
+			if e.Taxable {
+				totals.TaxableTSP = totals.TaxableTSP.Add(amount)
+			}
+		case inflow:
+			totals.CashInflow = totals.CashInflow.Add(amount)
+			if e.Taxable {
+				totals.TaxableCash = totals.TaxableCash.Add(amount)
+			}
+		default:
+			totals.CashOutflow = totals.CashOutflow.Add(amount)
+		}
+	}
+	return totals
+}
+
+// applyTSPEvent adds inflow to the traditional balance and takes outflow from traditional first,
+// then Roth. It returns the updated balances and the amount actually distributed.
+func applyTSPEvent(traditional, roth, inflow, outflow decimal.Decimal) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	traditional = traditional.Add(inflow)
+	fromTraditional := decimal.Min(outflow, decimal.Max(traditional, decimal.Zero))
+	fromRoth := decimal.Min(outflow.Sub(fromTraditional), decimal.Max(roth, decimal.Zero))
+	return traditional.Sub(fromTraditional), roth.Sub(fromRoth), fromTraditional.Add(fromRoth)
+}
+
+// scaleFundBalances rescales a per-fund balance map so it sums to total, preserving fund weights
+func scaleFundBalances(funds map[string]decimal.Decimal, total decimal.Decimal) map[string]decimal.Decimal {
+	if funds == nil {
+		return nil
+	}
+	current := decimal.Zero
+	for _, v := range funds {
+		current = current.Add(v)
+	}
+	scaled := make(map[string]decimal.Decimal, len(funds))
+	for fund, v := range funds {
+		if current.IsZero() {
+			scaled[fund] = decimal.Zero
+			continue
+		}
+		scaled[fund] = v.Mul(total).Div(current)
+	}
+	return scaled
+}
+
+// validateFinancialEvents checks that every event has a known account and falls within the projection window
+func validateFinancialEvents(events []domain.FinancialEvent, startYear, projectionYears int) error {
+	lastYear := startYear + projectionYears - 1
+	for i, e := range events {
+		if e.Account != "" && e.Account != domain.EventAccountCash && e.Account != domain.EventAccountTSP {
+			return fmt.Errorf("event %d (%s): %w, got %q", i, e.Name, ErrInvalidEventAccount, e.Account)
+		}
+		if y := e.Date.Year(); y < startYear || y > lastYear {
+			return fmt.Errorf("event %d (%s) on %s: %w %d-%d", i, e.Name, e.Date.Format("2006-01-02"), ErrEventOutsideProjection, startYear, lastYear)
+		}
+	}
+	return nil
+}
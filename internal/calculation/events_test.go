@@ -0,0 +1,78 @@
+package calculation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestFinancialEvent_TaxableInheritanceRaisesTaxAndLaterIRMAA(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	ce := NewCalculationEngine()
+
+	baseline := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	withEvent := *scenario
+	withEvent.Events = []domain.FinancialEvent{
+		{Name: "inherited IRA", Date: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(400000), Taxable: true},
+	}
+	projection := ce.GenerateAnnualProjection(&personA, &personB, &withEvent, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	// 2026 (index 1): the inheritance is income and is taxed
+	if !projection[1].EventIncome.Equal(decimal.NewFromInt(400000)) {
+		t.Fatalf("expected event income of 400000, got %s", projection[1].EventIncome)
+	}
+	if !projection[1].FederalTax.GreaterThan(baseline[1].FederalTax) {
+		t.Fatalf("expected higher federal tax in the event year: %s vs %s", projection[1].FederalTax, baseline[1].FederalTax)
+	}
+	// 2028 (index 3): both turn 65 and IRMAA looks back to 2026 MAGI
+	if !projection[3].MedicarePremium.GreaterThan(baseline[3].MedicarePremium) {
+		t.Fatalf("expected IRMAA surcharge two years after the event: %s vs %s", projection[3].MedicarePremium, baseline[3].MedicarePremium)
+	}
+	if !projection[4].MedicarePremium.Equal(baseline[4].MedicarePremium) {
+		t.Fatalf("expected no IRMAA surcharge three years after the event: %s vs %s", projection[4].MedicarePremium, baseline[4].MedicarePremium)
+	}
+}
+
+func TestFinancialEvent_TSPOutflowReducesBalance(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	ce := NewCalculationEngine()
+
+	baseline := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	withEvent := *scenario
+	withEvent.Events = []domain.FinancialEvent{
+		{Name: "new roof", Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(-30000), Account: domain.EventAccountTSP, Taxable: true},
+	}
+	projection := ce.GenerateAnnualProjection(&personA, &personB, &withEvent, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	drop := baseline[0].TSPBalancePersonA.Sub(projection[0].TSPBalancePersonA)
+	if !drop.Equal(decimal.NewFromInt(30000)) {
+		t.Fatalf("expected TSP balance to drop by 30000, got %s", drop)
+	}
+	if !projection[0].EventExpenses.Equal(decimal.NewFromInt(30000)) {
+		t.Fatalf("expected event expenses of 30000, got %s", projection[0].EventExpenses)
+	}
+	if !projection[0].FederalTaxableIncome.Equal(baseline[0].FederalTaxableIncome.Add(decimal.NewFromInt(30000))) {
+		t.Fatalf("expected the TSP distribution to be taxable: %s vs %s", projection[0].FederalTaxableIncome, baseline[0].FederalTaxableIncome)
+	}
+}
+
+func TestRunScenario_RejectsEventOutsideProjection(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	scenario.Events = []domain.FinancialEvent{
+		{Name: "late windfall", Date: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(10000)},
+	}
+
+	_, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if !errors.Is(err, ErrEventOutsideProjection) || !strings.Contains(err.Error(), "outside the projection window") {
+		t.Fatalf("expected projection window error, got %v", err)
+	}
+}
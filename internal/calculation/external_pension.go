@@ -0,0 +1,50 @@
+package calculation
+
+import (
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// externalPensionsForYear returns an employee's non-federal pension payments for a calendar year. Each
+// pension starts on the birthday the employee reaches its start age, prorated in that year, and grows by
+// its COLA from the following January.
+func externalPensionsForYear(employee *domain.Employee, calendarYear int, generalCOLA rateSchedule) decimal.Decimal {
+	total := decimal.Zero
+	for _, pension := range employee.ExternalPensions {
+		total = total.Add(externalPensionForYear(pension, employee.BirthDate, calendarYear, generalCOLA))
+	}
+	return total
+}
+
+// externalPensionSurvivorShare returns the survivor's share of a deceased employee's non-federal pensions
+// for a calendar year. In the year of death only the share after deathFraction of the year is paid.
+func externalPensionSurvivorShare(employee *domain.Employee, calendarYear int, generalCOLA rateSchedule, deathFraction decimal.Decimal) decimal.Decimal {
+	total := decimal.Zero
+	for _, pension := range employee.ExternalPensions {
+		payment := externalPensionForYear(pension, employee.BirthDate, calendarYear, generalCOLA)
+		total = total.Add(payment.Mul(pension.SurvivorPercentage))
+	}
+	return total.Mul(decimal.NewFromInt(1).Sub(deathFraction))
+}
+
+// externalPensionForYear returns one non-federal pension's payments for a calendar year
+func externalPensionForYear(pension domain.ExternalPension, birthDate time.Time, calendarYear int, generalCOLA rateSchedule) decimal.Decimal {
+	start := birthDate.AddDate(pension.StartAge, 0, 0)
+	if calendarYear < start.Year() {
+		return decimal.Zero
+	}
+	annual := pension.MonthlyAmount.Mul(decimal.NewFromInt(12))
+	if pension.COLA {
+		cola := generalCOLA
+		if pension.COLARate != nil {
+			cola = constantRate(*pension.COLARate)
+		}
+		annual = annual.Mul(cola.growth(start.Year(), calendarYear))
+	}
+	if calendarYear == start.Year() {
+		annual = annual.Mul(decimal.NewFromInt(1).Sub(fractionOfYearBefore(start)))
+	}
+	return annual
+}
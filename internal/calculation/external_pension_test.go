@@ -0,0 +1,72 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestExternalPensionStartsAtAgeWithOwnCOLA(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(6)
+	ce := NewCalculationEngine()
+	baseline, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	colaRate := decimal.NewFromFloat(0.03)
+	personB := cfg.PersonalDetails["person_b"]
+	personB.ExternalPensions = []domain.ExternalPension{
+		{Name: "state plan", StartAge: 65, MonthlyAmount: decimal.NewFromInt(1000), COLA: true, COLARate: &colaRate},
+		{Name: "frozen plan", StartAge: 66, MonthlyAmount: decimal.NewFromInt(500)},
+	}
+	cfg.PersonalDetails["person_b"] = personB
+	summary, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// PersonB turns 65 on January 1, 2028 and 66 a year later
+	expected := map[int]string{2025: "0", 2026: "0", 2027: "0", 2028: "12000", 2029: "18360", 2030: "18730.8"}
+	for i, cf := range summary.Projection {
+		want := decimal.RequireFromString(expected[cf.Date.Year()])
+		if !cf.ExternalPensionPersonB.Equal(want) {
+			t.Fatalf("%d: expected external pension %s, got %s", cf.Date.Year(), want, cf.ExternalPensionPersonB)
+		}
+		if !cf.ExternalPensionPersonA.IsZero() {
+			t.Fatalf("%d: PersonA has no external pension", cf.Date.Year())
+		}
+		base := baseline.Projection[i]
+		if !cf.GuaranteedIncome.Sub(base.GuaranteedIncome).Equal(want) {
+			t.Fatalf("%d: external pension should add to guaranteed income", cf.Date.Year())
+		}
+		if want.IsPositive() && !cf.FederalTax.GreaterThan(base.FederalTax) {
+			t.Fatalf("%d: external pension should be federally taxable", cf.Date.Year())
+		}
+	}
+}
+
+func TestExternalPensionSurvivorShare(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(4)
+	personA := cfg.PersonalDetails["person_a"]
+	personA.ExternalPensions = []domain.ExternalPension{
+		{StartAge: 60, MonthlyAmount: decimal.NewFromInt(1000), SurvivorPercentage: decimal.NewFromFloat(0.5)},
+	}
+	cfg.PersonalDetails["person_a"] = personA
+	deathAge := 63
+	scenario.Mortality = &domain.ScenarioMortality{PersonA: &domain.MortalitySpec{DeathAge: &deathAge}}
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Death at 63 is assumed mid-2026: a quarter of the pension (half of the year at half the amount)
+	expected := []string{"12000", "3000", "6000", "6000"}
+	for i, cf := range summary.Projection {
+		if want := decimal.RequireFromString(expected[i]); !cf.ExternalPensionPersonA.Equal(want) {
+			t.Fatalf("%d: expected %s, got %s", cf.Date.Year(), want, cf.ExternalPensionPersonA)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestFEHBPremiumStepsDownToSelfOnlyAfterSpouseDeath(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(6)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.FEHBEnrollment = domain.FEHBSelfAndFamily
+	personA.FEHBPremiumPerPayPeriod = decimal.NewFromInt(600)
+	personA.FEHBTierPremiums = &domain.FEHBTierPremiums{SelfOnly: decimal.NewFromInt(250), SelfAndFamily: decimal.NewFromInt(600)}
+	cfg.GlobalAssumptions.FederalRules.FEHBConfig.PayPeriodsPerYear = 26
+	deathDate := time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)
+	scenario.Mortality = &domain.ScenarioMortality{PersonB: &domain.MortalitySpec{DeathDate: &deathDate}}
+
+	proj := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	family, selfOnly := decimal.NewFromInt(600*26), decimal.NewFromInt(250*26)
+	for _, cf := range proj {
+		want := family
+		if cf.Date.Year() >= deathDate.Year() {
+			want = selfOnly
+		}
+		if !cf.FEHBPremium.Equal(want) {
+			t.Fatalf("year %d: expected FEHB premium %s, got %s", cf.Date.Year(), want, cf.FEHBPremium)
+		}
+	}
+}
+
+func TestActiveFEHBEnrollmentFollowsScheduledChanges(t *testing.T) {
+	employee := &domain.Employee{FEHBEnrollment: domain.FEHBSelfAndFamily}
+	changes := []domain.FEHBEnrollmentChange{
+		{Date: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Enrollment: domain.FEHBSelfOnly},
+		{Date: time.Date(2027, 7, 1, 0, 0, 0, 0, time.UTC), Enrollment: domain.FEHBSelfPlusOne},
+	}
+	for year, want := range map[int]string{2026: domain.FEHBSelfAndFamily, 2027: domain.FEHBSelfPlusOne, 2031: domain.FEHBSelfOnly} {
+		if got := ActiveFEHBEnrollment(employee, changes, year, 2); got != want {
+			t.Fatalf("%d: expected %s, got %s", year, want, got)
+		}
+	}
+}
+
+func TestFEHBGovernmentShareLeavesRetireePortion(t *testing.T) {
+	employee := &domain.Employee{FEHBPremiumPerPayPeriod: decimal.NewFromInt(1000)}
+	fehbConfig := domain.FEHBConfig{PayPeriodsPerYear: 26}
+	total := CalculateFEHBPremium(employee, domain.FEHBSelfOnly, 0, decimal.Zero, fehbConfig)
+	if !total.Equal(decimal.NewFromInt(26000)) {
+		t.Fatalf("without a government share the configured premium is paid in full, got %s", total)
+	}
+
+	fehbConfig.GovernmentShare = decimal.NewFromFloat(0.72)
+	outOfPocket := CalculateFEHBPremium(employee, domain.FEHBSelfOnly, 0, decimal.Zero, fehbConfig)
+	if !outOfPocket.Equal(decimal.NewFromInt(7280)) {
+		t.Fatalf("expected a 72%% government share to leave 28%% ($7280) out of pocket, got %s", outOfPocket)
+	}
+
+	// The enrollee's share grows with premium inflation like the total premium
+	grown := CalculateFEHBPremium(employee, domain.FEHBSelfOnly, 2, decimal.NewFromFloat(0.05), fehbConfig)
+	if want := outOfPocket.Mul(decimal.NewFromFloat(1.05).Pow(decimal.NewFromInt(2))); !grown.Equal(want) {
+		t.Fatalf("expected %s after two years of premium inflation, got %s", want, grown)
+	}
+}
+
+func TestCurrentNetIncomeUsesProjectionFEHBCost(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	cfg.GlobalAssumptions.FederalRules.FEHBConfig = domain.FEHBConfig{PayPeriodsPerYear: 26, GovernmentShare: decimal.NewFromFloat(0.72)}
+	scenario.PersonA.RetirementDate = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.FEHBPremiumPerPayPeriod = decimal.NewFromInt(1000)
+	cfg.PersonalDetails["person_a"] = personA
+
+	ce := NewCalculationEngine()
+	fehbConfig := cfg.GlobalAssumptions.FederalRules.FEHBConfig
+	withPremium := ce.NetIncomeCalc.Calculate(&personA, &personB, fehbConfig, ce.TaxCalc.LocalTaxCalc, testProjectionStartYear, false)
+	uncovered := personA
+	uncovered.FEHBPremiumPerPayPeriod = decimal.Zero
+	baselineCost := ce.NetIncomeCalc.Calculate(&uncovered, &personB, fehbConfig, ce.TaxCalc.LocalTaxCalc, testProjectionStartYear, false).Sub(withPremium)
+
+	proj := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	if !baselineCost.Equal(decimal.NewFromInt(7280)) {
+		t.Fatalf("expected the current net income to deduct the 28%% enrollee share ($7280), got %s", baselineCost)
+	}
+	if !proj[0].FEHBPremium.Equal(baselineCost) {
+		t.Fatalf("expected the first projection year to charge the same FEHB cost as the baseline %s, got %s", baselineCost, proj[0].FEHBPremium)
+	}
+}
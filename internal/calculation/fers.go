@@ -19,22 +19,34 @@ type FERSInputs struct {
 
 // FERSPensionCalculation represents the complete FERS pension calculation result
 type FERSPensionCalculation struct {
-	High3Salary      decimal.Decimal
-	ServiceYears     decimal.Decimal
-	ServiceMonths    int
-	RetirementAge    int
-	Multiplier       decimal.Decimal
-	AnnualPension    decimal.Decimal
-	SurvivorElection decimal.Decimal // Input election percent (0, 0.25, 0.50 typical)
-	ReducedPension   decimal.Decimal // Retiree's payable pension after survivor reduction
-	SurvivorAnnuity  decimal.Decimal // Amount payable to surviving spouse after death (unreduced base * elected pct)
+	High3Salary       decimal.Decimal
+	ServiceYears      decimal.Decimal
+	ServiceMonths     int
+	RetirementAge     int
+	Multiplier        decimal.Decimal
+	AnnualPension     decimal.Decimal
+	SurvivorElection  decimal.Decimal // Elected survivor percent, normalized to 0, 0.25, or 0.50
+	SurvivorReduction decimal.Decimal // Reduction to the retiree's annuity for the election (0.10 for 50%, 0.05 for 25%)
+	AgeReduction      decimal.Decimal // MRA+10 reduction (5% per year under 62 at commencement)
+	ReducedPension    decimal.Decimal // Retiree's payable pension after age and survivor reductions
+	SurvivorAnnuity   decimal.Decimal // Amount payable to surviving spouse after death (unreduced base * elected pct)
+	Catch62Pension    decimal.Decimal // ReducedPension recomputed without unpaid military time, payable from age 62
 }
 
-// CalculateFERSPension calculates the annual FERS pension
+// CalculateFERSPension calculates the annual FERS pension for an annuity that starts at separation
 func CalculateFERSPension(employee *domain.Employee, retirementDate time.Time) FERSPensionCalculation {
-	// Calculate years of service
-	serviceYears := employee.YearsOfService(retirementDate)
-	retirementAge := employee.Age(retirementDate)
+	return CalculateDeferredFERSPension(employee, retirementDate, retirementDate)
+}
+
+// CalculateDeferredFERSPension calculates the annual FERS pension for an employee who separates on
+// separationDate and whose annuity commences on commencementDate. Service and the multiplier are fixed
+// at separation; the MRA+10 age reduction is measured at commencement, so postponing the annuity
+// reduces or removes it.
+func CalculateDeferredFERSPension(employee *domain.Employee, separationDate, commencementDate time.Time) FERSPensionCalculation {
+	// Calculate years of service. Military time without a deposit is credited until 62 (Catch-62).
+	civilianYears := employee.YearsOfService(separationDate)
+	serviceYears := civilianYears.Add(employee.UnpaidMilitaryYears())
+	retirementAge := employee.Age(separationDate)
 
 	// Determine multiplier based on age and service
 	multiplier := determineMultiplier(retirementAge, serviceYears)
@@ -42,45 +54,78 @@ func CalculateFERSPension(employee *domain.Employee, retirementDate time.Time) F
 	// Calculate base pension (unreduced)
 	annualPension := employee.High3Salary.Mul(serviceYears).Mul(multiplier)
 
-	// Survivor rules (simplified FERS):
-	// If elect 50% survivor annuity -> retiree pension reduced by 10%
-	// If elect 25% survivor annuity -> retiree pension reduced by 5%
-	// Assume input SurvivorBenefitElectionPercent holds desired survivor percent of base (0, 0.25, 0.50).
-	reducedPension := annualPension
-	survivorAnnuity := decimal.Zero
-	election := employee.SurvivorBenefitElectionPercent
-	if election.GreaterThan(decimal.Zero) {
-		// Normalize election to standard values
-		if election.GreaterThan(decimal.NewFromFloat(0.4)) {
-			election = decimal.NewFromFloat(0.5)
-		}
-		if election.GreaterThan(decimal.NewFromFloat(0.20)) && election.LessThan(decimal.NewFromFloat(0.30)) {
-			election = decimal.NewFromFloat(0.25)
-		}
-		if election.Equals(decimal.NewFromFloat(0.5)) {
-			reducedPension = annualPension.Mul(decimal.NewFromFloat(0.90)) // 10% reduction
-			survivorAnnuity = annualPension.Mul(decimal.NewFromFloat(0.50))
-		} else if election.Equals(decimal.NewFromFloat(0.25)) {
-			reducedPension = annualPension.Mul(decimal.NewFromFloat(0.95)) // 5% reduction
-			survivorAnnuity = annualPension.Mul(decimal.NewFromFloat(0.25))
-		} else {
-			// Unsupported value - treat as no survivor
-			election = decimal.Zero
-		}
+	// MRA+10 retirements are reduced 5% for each year the annuitant is under 62 when the annuity starts
+	ageReduction := MRAPlus10Reduction(employee, separationDate, commencementDate)
+
+	// The elected survivor percentage sets both the reduction to the retiree's annuity and the survivor
+	// annuity, which is that percentage of the unreduced annuity (before the age and survivor reductions)
+	election, survivorReduction := survivorElectionTerms(employee.SurvivorBenefitElectionPercent)
+	reducedPension := annualPension.Mul(decimal.NewFromInt(1).Sub(ageReduction)).Mul(decimal.NewFromInt(1).Sub(survivorReduction))
+	survivorAnnuity := annualPension.Mul(election)
+
+	// Social Security-eligible retirees lose the unpaid military years from the annuity at 62. The annuity
+	// is recomputed on civilian service alone, whose multiplier may drop to 1.0% if it is under 20 years.
+	catch62Pension := reducedPension
+	if serviceYears.GreaterThan(civilianYears) && isSocialSecurityEligible(employee) {
+		civilianPension := employee.High3Salary.Mul(civilianYears).Mul(determineMultiplier(retirementAge, civilianYears))
+		catch62Pension = civilianPension.Mul(decimal.NewFromInt(1).Sub(ageReduction)).Mul(decimal.NewFromInt(1).Sub(survivorReduction))
+	}
+
+	// A disability annuity under 62 starts at 60% of High-3 unless the earned annuity is larger
+	if employee.DisabilityRetirement && retirementAge < 62 {
+		reducedPension = decimal.Max(employee.High3Salary.Mul(disabilityFirstYearRate), annualPension).Mul(decimal.NewFromInt(1).Sub(survivorReduction))
+		catch62Pension = reducedPension
 	}
 
 	return FERSPensionCalculation{
-		High3Salary:      employee.High3Salary,
-		ServiceYears:     serviceYears,
-		RetirementAge:    retirementAge,
-		Multiplier:       multiplier,
-		AnnualPension:    annualPension,
-		SurvivorElection: election,
-		ReducedPension:   reducedPension,
-		SurvivorAnnuity:  survivorAnnuity,
+		High3Salary:       employee.High3Salary,
+		ServiceYears:      serviceYears,
+		RetirementAge:     retirementAge,
+		Multiplier:        multiplier,
+		AnnualPension:     annualPension,
+		AgeReduction:      ageReduction,
+		SurvivorElection:  election,
+		SurvivorReduction: survivorReduction,
+		ReducedPension:    reducedPension,
+		SurvivorAnnuity:   survivorAnnuity,
+		Catch62Pension:    catch62Pension,
 	}
 }
 
+// FERS survivor annuity elections: a full (50%) survivor annuity costs the retiree 10% of the annuity and
+// a partial (25%) one costs 5%
+var (
+	fullSurvivorElection     = decimal.NewFromFloat(0.50)
+	fullSurvivorReduction    = decimal.NewFromFloat(0.10)
+	partialSurvivorElection  = decimal.NewFromFloat(0.25)
+	partialSurvivorReduction = decimal.NewFromFloat(0.05)
+)
+
+// FERS disability annuity before 62: 60% of High-3 for the first 12 months, then 40%
+var (
+	disabilityFirstYearRate = decimal.NewFromFloat(0.60)
+	disabilityLaterRate     = decimal.NewFromFloat(0.40)
+)
+
+// survivorElectionTerms returns the survivor election for percent and the reduction it makes to the
+// retiree's annuity. Configuration validation accepts only the FERS elections (domain.IsValidSurvivorElection);
+// any other percent is treated as no election.
+func survivorElectionTerms(percent decimal.Decimal) (election, reduction decimal.Decimal) {
+	switch {
+	case percent.Equal(fullSurvivorElection):
+		return fullSurvivorElection, fullSurvivorReduction
+	case percent.Equal(partialSurvivorElection):
+		return partialSurvivorElection, partialSurvivorReduction
+	default:
+		return decimal.Zero, decimal.Zero
+	}
+}
+
+// isSocialSecurityEligible reports whether the employee has a Social Security benefit estimate
+func isSocialSecurityEligible(employee *domain.Employee) bool {
+	return employee.SSBenefit62.GreaterThan(decimal.Zero) || employee.SSBenefitFRA.GreaterThan(decimal.Zero) || employee.SSBenefit70.GreaterThan(decimal.Zero)
+}
+
 // determineMultiplier determines the FERS pension multiplier based on age and service
 func determineMultiplier(retirementAge int, serviceYears decimal.Decimal) decimal.Decimal {
 	// Enhanced multiplier: 1.1% if age >= 62 with 20+ years of service
@@ -92,27 +137,97 @@ func determineMultiplier(retirementAge int, serviceYears decimal.Decimal) decima
 	return decimal.NewFromFloat(0.010)
 }
 
-// ApplyFERSPensionCOLA applies the FERS COLA rules
-// COLA is not applied until the annuitant reaches age 62
-// Annual COLA Rules:
-// - If CPI change (inflation) is 2% or less, COLA is the actual CPI change
-// - If CPI change is between 2% and 3%, COLA is 2%
-// - If CPI change is greater than 3%, COLA is CPI change minus 1%
-func ApplyFERSPensionCOLA(currentPension decimal.Decimal, inflationRate decimal.Decimal, annuitantAge int) decimal.Decimal {
-	if annuitantAge < 62 {
-		return currentPension // No COLA until age 62
+// FERSCOLAPolicy holds the FERS COLA tiering ("diet COLA") thresholds
+type FERSCOLAPolicy struct {
+	FullCPIThreshold decimal.Decimal // CPI at or below this is passed through in full
+	CapThreshold     decimal.Decimal // CPI above this is reduced by the width of the capped band
+	MinimumAge       int             // Regular retirees receive no COLA before this age
+}
+
+// DefaultFERSCOLAPolicy returns the statutory tiers: full CPI up to 2%, 2% between 2% and 3%,
+// CPI minus 1% above 3%, with COLAs starting at age 62
+func DefaultFERSCOLAPolicy() FERSCOLAPolicy {
+	return FERSCOLAPolicy{
+		FullCPIThreshold: decimal.NewFromFloat(0.02),
+		CapThreshold:     decimal.NewFromFloat(0.03),
+		MinimumAge:       62,
+	}
+}
+
+// NewFERSCOLAPolicy builds the COLA policy from the configured FERS rules, using the statutory
+// defaults for any threshold left unset
+func NewFERSCOLAPolicy(rules domain.FERSRules) FERSCOLAPolicy {
+	policy := DefaultFERSCOLAPolicy()
+	if rules.COLAFullCPIThreshold.GreaterThan(decimal.Zero) {
+		policy.FullCPIThreshold = rules.COLAFullCPIThreshold
+	}
+	if rules.COLACapThreshold.GreaterThan(decimal.Zero) {
+		policy.CapThreshold = rules.COLACapThreshold
+	}
+	if rules.COLAMinimumAge > 0 {
+		policy.MinimumAge = rules.COLAMinimumAge
+	}
+	return policy
+}
+
+// Rate returns the FERS COLA rate for a CPI change:
+// - If CPI change is at or below the full threshold (2%), COLA is the actual CPI change
+// - If CPI change is between the thresholds (2%-3%), COLA is the full threshold (2%)
+// - If CPI change is above the cap threshold (3%), COLA is CPI change minus the band width (1%)
+func (p FERSCOLAPolicy) Rate(inflationRate decimal.Decimal) decimal.Decimal {
+	switch {
+	case inflationRate.LessThanOrEqual(p.FullCPIThreshold):
+		return inflationRate
+	case inflationRate.LessThanOrEqual(p.CapThreshold):
+		return p.FullCPIThreshold
+	default:
+		return inflationRate.Sub(p.CapThreshold.Sub(p.FullCPIThreshold))
+	}
+}
+
+// Apply increases an annuity by one year's COLA. Regular retirees get no COLA before the minimum
+// age; exempt annuities (survivor annuities, special-category and disability retirements) are
+// increased at any age.
+func (p FERSCOLAPolicy) Apply(currentPension, inflationRate decimal.Decimal, annuitantAge int, exempt bool) decimal.Decimal {
+	return p.ApplyProrated(currentPension, inflationRate, annuitantAge, exempt, decimal.NewFromInt(1))
+}
+
+// ApplyProrated is Apply with the COLA scaled by share, for an annuitant's prorated first COLA
+func (p FERSCOLAPolicy) ApplyProrated(currentPension, inflationRate decimal.Decimal, annuitantAge int, exempt bool, share decimal.Decimal) decimal.Decimal {
+	if !exempt && annuitantAge < p.MinimumAge {
+		return currentPension
 	}
+	return currentPension.Mul(decimal.NewFromInt(1).Add(p.Rate(inflationRate).Mul(share)))
+}
+
+// FirstCOLAShare returns the share of the first COLA after an annuity commences. The COLA takes effect in
+// December and the first one is prorated at one twelfth for each month on the annuity rolls before then,
+// so a January annuitant gets 11/12 of it and a December annuitant none.
+func FirstCOLAShare(commencementDate time.Time) decimal.Decimal {
+	return decimal.NewFromInt(int64(12 - commencementDate.Month())).Div(decimal.NewFromInt(12))
+}
 
-	var colaRate decimal.Decimal
-	if inflationRate.LessThanOrEqual(decimal.NewFromFloat(0.02)) {
-		colaRate = inflationRate // Full CPI increase
-	} else if inflationRate.GreaterThan(decimal.NewFromFloat(0.02)) && inflationRate.LessThanOrEqual(decimal.NewFromFloat(0.03)) {
-		colaRate = decimal.NewFromFloat(0.02) // Capped at 2%
-	} else { // inflationRate > 0.03
-		colaRate = inflationRate.Sub(decimal.NewFromFloat(0.01)) // CPI minus 1%
+// colaShare returns the share of the COLA applied in year y counted from commencement
+func colaShare(separationDate, commencementDate time.Time, y int) decimal.Decimal {
+	if y == 1 {
+		return FirstCOLAShare(annuityRollsDate(separationDate, commencementDate))
 	}
+	return decimal.NewFromInt(1)
+}
 
-	return currentPension.Mul(decimal.NewFromFloat(1.0).Add(colaRate))
+// annuityRollsDate returns when an annuity goes on the rolls: an immediate annuity commences on the first
+// of the month after separation, while a deferred or postponed one starts on its commencement date
+func annuityRollsDate(separationDate, commencementDate time.Time) time.Time {
+	if commencementDate.After(separationDate) {
+		return commencementDate
+	}
+	return time.Date(separationDate.Year(), separationDate.Month()+1, 1, 0, 0, 0, 0, separationDate.Location())
+}
+
+// ApplyFERSPensionCOLA applies the statutory FERS COLA rules to a regular retiree's annuity.
+// COLA is not applied until the annuitant reaches age 62; see FERSCOLAPolicy for the tiers.
+func ApplyFERSPensionCOLA(currentPension decimal.Decimal, inflationRate decimal.Decimal, annuitantAge int) decimal.Decimal {
+	return DefaultFERSCOLAPolicy().Apply(currentPension, inflationRate, annuitantAge, false)
 }
 
 // CalculateFERSSpecialRetirementSupplement calculates the FERS Special Retirement Supplement (SRS)
@@ -152,7 +267,7 @@ func ProjectFERSPension(employee *domain.Employee, retirementDate time.Time, pro
 		age := employee.Age(projectionDate)
 
 		// Apply COLA for this year
-		currentPension = ApplyFERSPensionCOLA(currentPension, inflationRate, age)
+		currentPension = DefaultFERSCOLAPolicy().ApplyProrated(currentPension, inflationRate, age, employee.SpecialCategory, colaShare(retirementDate, retirementDate, year))
 		projections[year] = currentPension
 	}
 
@@ -161,76 +276,126 @@ func ProjectFERSPension(employee *domain.Employee, retirementDate time.Time, pro
 
 // CalculatePensionForYear calculates the pension amount for a specific year in the projection
 func CalculatePensionForYear(employee *domain.Employee, retirementDate time.Time, year int, inflationRate decimal.Decimal) decimal.Decimal {
-	// Calculate initial pension
-	initialCalculation := CalculateFERSPension(employee, retirementDate)
-	initialPension := initialCalculation.ReducedPension
+	return CalculateDeferredPensionForYear(employee, retirementDate, retirementDate, year, inflationRate)
+}
+
+// CalculateDeferredPensionForYear calculates the pension amount for a year counted from annuity commencement
+func CalculateDeferredPensionForYear(employee *domain.Employee, separationDate, commencementDate time.Time, year int, inflationRate decimal.Decimal) decimal.Decimal {
+	return deferredPensionForYear(employee, separationDate, commencementDate, year, constantRate(inflationRate), DefaultFERSCOLAPolicy())
+}
 
-	// Year 0 is the base pension without COLA
-	if year == 0 {
-		return initialPension
+// deferredPensionForYear is CalculateDeferredPensionForYear under an explicit COLA policy and inflation
+// schedule. Each COLA follows the inflation of the calendar year before it takes effect.
+func deferredPensionForYear(employee *domain.Employee, separationDate, commencementDate time.Time, year int, inflation rateSchedule, colaPolicy FERSCOLAPolicy) decimal.Decimal {
+	if employee.DisabilityRetirement && employee.Age(separationDate) < 62 {
+		return disabilityPensionForYear(employee, separationDate, commencementDate, year, inflation, colaPolicy)
 	}
 
-	// Apply COLA for each year up to the target year
+	// Calculate initial pension
+	initialCalculation := CalculateDeferredFERSPension(employee, separationDate, commencementDate)
+	initialPension := initialCalculation.ReducedPension
+
+	// Year 0 is the base pension without COLA; apply COLA for each later year up to the target year, with
+	// the first one prorated by the months on the rolls in the commencement year
 	currentPension := initialPension
 	for y := 1; y <= year; y++ {
-		projectionDate := retirementDate.AddDate(y, 0, 0)
+		projectionDate := commencementDate.AddDate(y, 0, 0)
 		age := employee.Age(projectionDate)
-		currentPension = ApplyFERSPensionCOLA(currentPension, inflationRate, age)
+		currentPension = colaPolicy.ApplyProrated(currentPension, inflation.forYear(projectionDate.Year()-1), age, employee.SpecialCategory, colaShare(separationDate, commencementDate, y))
+	}
+
+	// Catch-62: drop unpaid military time once the annuitant reaches 62
+	if !initialCalculation.Catch62Pension.Equal(initialPension) && employee.Age(commencementDate.AddDate(year, 0, 0)) >= 62 {
+		currentPension = currentPension.Mul(initialCalculation.Catch62Pension).Div(initialPension)
 	}
 
 	return currentPension
 }
 
-// ValidateFERSEligibility checks if an employee is eligible for FERS retirement
-func ValidateFERSEligibility(employee *domain.Employee, retirementDate time.Time) (bool, string) {
-	age := employee.Age(retirementDate)
-	serviceYears := employee.YearsOfService(retirementDate)
-	mra := dateutil.MinimumRetirementAge(employee.BirthDate)
-
-	// Check minimum age and service requirements
-	if age < mra {
-		return false, "Employee has not reached Minimum Retirement Age"
+// disabilityPensionForYear returns a disability annuity for a year counted from commencement, as a full
+// year's rate (the caller prorates the commencement year). The 60% rate covers the first 12 months, so the
+// year after commencement blends it with the 40% rate, and the year the annuitant turns 62 blends the 40%
+// rate with the recomputed earned annuity: High-3 increased by the COLAs paid on the disability annuity,
+// times the multiplier and service at 62. The earned annuity at separation is paid instead whenever it
+// is larger.
+func disabilityPensionForYear(employee *domain.Employee, separationDate, commencementDate time.Time, year int, inflation rateSchedule, colaPolicy FERSCOLAPolicy) decimal.Decimal {
+	calc := CalculateDeferredFERSPension(employee, separationDate, commencementDate)
+	survivorFactor := decimal.NewFromInt(1).Sub(calc.SurvivorReduction)
+
+	// Disability annuities receive COLAs at any age, and the recomputation carries them into High-3, so
+	// every stage grows by the same cumulative factor
+	colaFactor := decimal.NewFromInt(1)
+	for y := 1; y <= year; y++ {
+		colaDate := commencementDate.AddDate(y, 0, 0)
+		colaFactor = colaPolicy.ApplyProrated(colaFactor, inflation.forYear(colaDate.Year()-1), employee.Age(colaDate), true, colaShare(separationDate, commencementDate, y))
 	}
-
-	if serviceYears.LessThan(decimal.NewFromInt(5)) {
-		return false, "Employee has less than 5 years of service"
+	rateAmount := func(rate decimal.Decimal) decimal.Decimal {
+		return decimal.Max(employee.High3Salary.Mul(rate), calc.AnnualPension).Mul(survivorFactor).Mul(colaFactor)
 	}
 
-	// Check for immediate annuity eligibility
-	if age >= 62 && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(5)) {
-		return true, "Eligible for immediate annuity at age 62+"
+	calendarYear := commencementDate.Year() + year
+	var disability decimal.Decimal
+	switch year {
+	case 0:
+		disability = rateAmount(disabilityFirstYearRate)
+	case 1:
+		firstYearShare := fractionOfYearBefore(commencementDate)
+		disability = rateAmount(disabilityFirstYearRate).Mul(firstYearShare).Add(rateAmount(disabilityLaterRate).Mul(decimal.NewFromInt(1).Sub(firstYearShare)))
+	default:
+		disability = rateAmount(disabilityLaterRate)
 	}
 
-	if age >= mra && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(10)) {
-		return true, "Eligible for immediate annuity at MRA with 10+ years"
+	age62 := employee.BirthDate.AddDate(62, 0, 0)
+	if calendarYear < age62.Year() {
+		return disability
 	}
-
-	if age >= mra && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(5)) && serviceYears.LessThan(decimal.NewFromInt(10)) {
-		return true, "Eligible for deferred annuity (reduced benefits)"
+	serviceAt62 := employee.YearsOfService(age62)
+	recomputed := employee.High3Salary.Mul(serviceAt62).Mul(determineMultiplier(62, serviceAt62)).Mul(survivorFactor).Mul(colaFactor)
+	if calendarYear > age62.Year() {
+		return recomputed
 	}
+	beforeShare := fractionOfYearBefore(age62)
+	return disability.Mul(beforeShare).Add(recomputed.Mul(decimal.NewFromInt(1).Sub(beforeShare)))
+}
 
-	return false, "Not eligible for immediate annuity"
+// ValidateFERSEligibility checks if an employee is eligible for an immediate FERS annuity on retirementDate,
+// under the same rules configuration validation applies (see domain.Employee.RetirementEligibility)
+func ValidateFERSEligibility(employee *domain.Employee, retirementDate time.Time) (bool, string) {
+	eligibility := employee.RetirementEligibility(retirementDate, retirementDate)
+	return eligibility.Eligible, eligibility.Reason
 }
 
-// CalculatePensionReduction calculates any reduction in pension benefits
+// CalculatePensionReduction calculates any reduction in pension benefits for an annuity that starts at separation
 func CalculatePensionReduction(employee *domain.Employee, retirementDate time.Time) decimal.Decimal {
-	age := employee.Age(retirementDate)
-	serviceYears := employee.YearsOfService(retirementDate)
-	mra := dateutil.MinimumRetirementAge(employee.BirthDate)
+	return MRAPlus10Reduction(employee, retirementDate, retirementDate)
+}
 
-	// No reduction if age 62+ with 5+ years, or MRA+ with 20+ years
-	if (age >= 62 && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(5))) ||
-		(age >= mra && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(20))) {
+// MRAPlus10Reduction returns the FERS age reduction for an MRA+10 annuity: 5% for each full year the
+// annuitant is under 62 at commencement. Every other retirement the employee qualifies for under
+// domain.Employee.RetirementEligibility (62 with 5+ years, 60 with 20+, 30+ years of service, special
+// category or disability) is unreduced.
+func MRAPlus10Reduction(employee *domain.Employee, separationDate, commencementDate time.Time) decimal.Decimal {
+	if !employee.RetirementEligibility(separationDate, commencementDate).Reduced {
 		return decimal.Zero
 	}
+	yearsUnder62 := 62 - employee.Age(commencementDate)
+	return decimal.NewFromInt(int64(yearsUnder62)).Mul(decimal.NewFromFloat(0.05))
+}
 
-	// Reduction applies for MRA+ with 10-20 years of service
-	if age >= mra && serviceYears.GreaterThanOrEqual(decimal.NewFromInt(10)) && serviceYears.LessThan(decimal.NewFromInt(20)) {
-		// 5% reduction for each year under age 62
-		yearsUnder62 := 62 - age
-		reductionRate := decimal.NewFromInt(int64(yearsUnder62)).Mul(decimal.NewFromFloat(0.05))
-		return reductionRate
+// IsSRSEligible reports whether a retiree receives the FERS Special Retirement Supplement. It is only paid
+// with an immediate, unreduced annuity (MRA with 30 years, age 60 with 20 years, or a special-category
+// retirement) that starts before 62, not for MRA+10, deferred or disability retirements.
+func IsSRSEligible(employee *domain.Employee, separationDate, commencementDate time.Time) bool {
+	if employee.DisabilityRetirement || !commencementDate.Equal(separationDate) || employee.Age(separationDate) >= 62 {
+		return false
 	}
+	eligibility := employee.RetirementEligibility(separationDate, commencementDate)
+	return eligibility.Eligible && !eligibility.Reduced
+}
 
-	return decimal.Zero
+// fractionOfYearBefore returns the portion of date's calendar year that elapses before date
+func fractionOfYearBefore(date time.Time) decimal.Decimal {
+	yearStart := time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	days := date.Sub(yearStart).Hours() / 24
+	return decimal.NewFromFloat(days / float64(dateutil.DaysInYear(date.Year())))
 }
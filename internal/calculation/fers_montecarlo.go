@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
@@ -20,12 +22,64 @@ type FERSMonteCarloConfig struct {
 	NumSimulations int
 	UseHistorical  bool
 	Seed           int64
+	MaxConcurrency int // Simulations run at once; zero or negative means runtime.NumCPU()
 
 	// Market variability settings
 	TSPReturnVariability decimal.Decimal // Std dev for TSP returns
 	InflationVariability decimal.Decimal // Std dev for inflation
 	COLAVariability      decimal.Decimal // Std dev for COLA
 	FEHBVariability      decimal.Decimal // Std dev for FEHB increases
+
+	// SuccessCriteria defines what counts as a successful simulation (defaults to TSP not depleted)
+	SuccessCriteria SuccessCriteria
+
+	// Stochastic mortality: when enabled each simulation draws both persons' death years from the
+	// period life table, replacing any deterministic scenario mortality
+	StochasticMortality bool
+	MortalityMultiplier decimal.Decimal // Scales life-table death probabilities (zero means 1.0)
+
+	// Adaptive simulation count: when ConvergenceTolerance is positive, simulations run in batches of
+	// BatchSize and stop once the 95% confidence interval on the success rate is within ±ConvergenceTolerance
+	// (0.005 is ±0.5%), or when NumSimulations have run
+	ConvergenceTolerance decimal.Decimal
+	BatchSize            int // Zero means defaultConvergenceBatchSize
+}
+
+// defaultConvergenceBatchSize is the number of simulations run between convergence checks
+const defaultConvergenceBatchSize = 100
+
+// Monte Carlo success criterion modes
+const (
+	SuccessTSPNotDepleted = "tsp_not_depleted"
+	SuccessIncomeFloor    = "income_floor"
+	SuccessBalanceAtAge   = "balance_at_age"
+)
+
+// SuccessCriteria configures how determineSuccess judges a single simulation
+type SuccessCriteria struct {
+	Mode        string          // One of the Success* modes; empty means SuccessTSPNotDepleted
+	IncomeFloor decimal.Decimal // income_floor: minimum annual net income in today's dollars
+	TargetAge   int             // balance_at_age: PersonA's age at which TSP must still be positive
+}
+
+// Validate checks that the criteria mode is known and its parameters are usable
+func (sc SuccessCriteria) Validate() error {
+	switch sc.Mode {
+	case "", SuccessTSPNotDepleted:
+		return nil
+	case SuccessIncomeFloor:
+		if sc.IncomeFloor.LessThanOrEqual(decimal.Zero) {
+			return fmt.Errorf("income_floor success criterion requires a positive income floor")
+		}
+		return nil
+	case SuccessBalanceAtAge:
+		if sc.TargetAge <= 0 {
+			return fmt.Errorf("balance_at_age success criterion requires a positive target age")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown success criterion mode: %s", sc.Mode)
+	}
 }
 
 // FERSMonteCarloEngine manages FERS Monte Carlo simulations
@@ -33,6 +87,7 @@ type FERSMonteCarloEngine struct {
 	calcEngine     *CalculationEngine
 	historicalData *HistoricalDataManager
 	config         FERSMonteCarloConfig
+	fundCholesky   [][]float64 // Cholesky factor of the run's fund correlation matrix, nil for independent draws
 }
 
 // FERSMonteCarloResult represents the results of a FERS Monte Carlo simulation
@@ -46,18 +101,28 @@ type FERSMonteCarloResult struct {
 	TSPLongevityPercentiles PercentileRanges `json:"tsp_longevity_percentiles"`
 	TSPDepletionRate        decimal.Decimal  `json:"tsp_depletion_rate"`
 	MedianFinalTSPBalance   decimal.Decimal  `json:"median_final_tsp_balance"`
+	DepletionByYear         []DepletionPoint `json:"depletion_by_year"` // Cumulative depletion probability, for heatmaps
 
 	// Risk metrics
 	IncomeVolatility  decimal.Decimal `json:"income_volatility"`
 	WorstCaseScenario decimal.Decimal `json:"worst_case_scenario"`
 	BestCaseScenario  decimal.Decimal `json:"best_case_scenario"`
+	SequenceRisk      SequenceRisk    `json:"sequence_risk"` // Sensitivity of outcomes to the first years' returns
 
 	// Detailed results
 	Simulations      []FERSMonteCarloSimulation `json:"simulations"`
 	MarketConditions []MarketCondition          `json:"market_conditions"`
 
+	// Mortality metrics (populated when StochasticMortality is enabled)
+	MedianProjectionYears int                         `json:"median_projection_years,omitempty"`
+	FirstToDieOutcomes    map[string]MortalityOutcome `json:"first_to_die_outcomes,omitempty"`
+
+	// Convergence: the 95% confidence interval on SuccessRate
+	SuccessRateCIWidth decimal.Decimal `json:"success_rate_ci_width"` // Full width of the interval
+	Converged          bool            `json:"converged,omitempty"`   // An adaptive run stopped within ConvergenceTolerance
+
 	// Configuration
-	NumSimulations  int                        `json:"num_simulations"`
+	NumSimulations  int                        `json:"num_simulations"` // Simulations actually run
 	BaseConfig      *domain.Configuration      `json:"base_config"`
 	AssetAllocation map[string]decimal.Decimal `json:"asset_allocation"`
 }
@@ -65,11 +130,36 @@ type FERSMonteCarloResult struct {
 // FERSMonteCarloSimulation represents a single FERS Monte Carlo simulation
 type FERSMonteCarloSimulation struct {
 	SimulationID     int                       `json:"simulation_id"`
-	MarketConditions MarketCondition           `json:"market_conditions"`
+	MarketConditions MarketCondition           `json:"market_conditions"` // First projection year's conditions
+	MarketSeries     MarketConditionSeries     `json:"market_series"`     // Conditions for every projection year
 	ScenarioResults  []*domain.ScenarioSummary `json:"scenario_results"`
 	Success          bool                      `json:"success"`
 	NetIncomeMetrics NetIncomeMetrics          `json:"net_income_metrics"`
 	TSPMetrics       TSPMetrics                `json:"tsp_metrics"`
+	Mortality        *SimulatedMortality       `json:"mortality,omitempty"` // Drawn death ages when StochasticMortality is enabled
+}
+
+// First-to-die groupings for stochastic mortality outcomes
+const (
+	FirstToDiePersonA  = "person_a"
+	FirstToDiePersonB  = "person_b"
+	FirstToDieSameYear = "same_year"
+	FirstToDieNone     = "none" // Both survive the projection horizon
+)
+
+// SimulatedMortality records the death ages drawn for one simulation
+type SimulatedMortality struct {
+	PersonADeathAge int    `json:"person_a_death_age"`
+	PersonBDeathAge int    `json:"person_b_death_age"`
+	FirstToDie      string `json:"first_to_die"`
+	ProjectionYears int    `json:"projection_years"` // Years projected, ending the year the survivor dies
+}
+
+// MortalityOutcome summarizes simulations sharing the same first-to-die outcome
+type MortalityOutcome struct {
+	Simulations     int             `json:"simulations"`
+	SuccessRate     decimal.Decimal `json:"success_rate"`
+	MedianNetIncome decimal.Decimal `json:"median_net_income"`
 }
 
 // MarketCondition represents market conditions for a simulation
@@ -86,6 +176,15 @@ type MarketConditionSeries struct {
 	Years []MarketCondition `json:"years"`
 }
 
+// FundReturns returns the per-year TSP fund return maps in projection order
+func (mcs MarketConditionSeries) FundReturns() []map[string]decimal.Decimal {
+	returns := make([]map[string]decimal.Decimal, len(mcs.Years))
+	for i, year := range mcs.Years {
+		returns[i] = year.TSPReturns
+	}
+	return returns
+}
+
 // NetIncomeMetrics represents net income metrics for a simulation
 type NetIncomeMetrics struct {
 	FirstYearNetIncome decimal.Decimal `json:"first_year_net_income"`
@@ -108,28 +207,7 @@ type TSPMetrics struct {
 // NewFERSMonteCarloEngine creates a new FERS Monte Carlo engine
 func NewFERSMonteCarloEngine(baseConfig *domain.Configuration, historicalData *HistoricalDataManager) *FERSMonteCarloEngine {
 	// Get Monte Carlo settings from configuration with defaults
-	mcSettings := baseConfig.GlobalAssumptions.MonteCarloSettings
-
-	// Apply defaults if not configured
-	tspVariability := mcSettings.TSPReturnVariability
-	if tspVariability.IsZero() {
-		tspVariability = decimal.NewFromFloat(0.15) // 15% default - typical stock market variability
-	}
-
-	inflationVariability := mcSettings.InflationVariability
-	if inflationVariability.IsZero() {
-		inflationVariability = decimal.NewFromFloat(0.02) // 2% default - based on CPI historical variation
-	}
-
-	colaVariability := mcSettings.COLAVariability
-	if colaVariability.IsZero() {
-		colaVariability = decimal.NewFromFloat(0.02) // 2% default - Social Security COLA variation
-	}
-
-	fehbVariability := mcSettings.FEHBVariability
-	if fehbVariability.IsZero() {
-		fehbVariability = decimal.NewFromFloat(0.05) // 5% default - health insurance premium increases
-	}
+	mcSettings := resolveMonteCarloSettings(baseConfig.GlobalAssumptions.MonteCarloSettings)
 
 	return &FERSMonteCarloEngine{
 		calcEngine:     NewCalculationEngineWithConfig(baseConfig.GlobalAssumptions.FederalRules),
@@ -138,10 +216,10 @@ func NewFERSMonteCarloEngine(baseConfig *domain.Configuration, historicalData *H
 			BaseConfig:           baseConfig,
 			NumSimulations:       1000,
 			UseHistorical:        true,
-			TSPReturnVariability: tspVariability,
-			InflationVariability: inflationVariability,
-			COLAVariability:      colaVariability,
-			FEHBVariability:      fehbVariability,
+			TSPReturnVariability: mcSettings.TSPReturnVariability,
+			InflationVariability: mcSettings.InflationVariability,
+			COLAVariability:      mcSettings.COLAVariability,
+			FEHBVariability:      mcSettings.FEHBVariability,
 		},
 	}
 }
@@ -160,8 +238,22 @@ func (fmc *FERSMonteCarloEngine) SetLogger(l Logger) {
 
 // RunFERSMonteCarlo executes the FERS Monte Carlo simulation
 func (fmce *FERSMonteCarloEngine) RunFERSMonteCarlo(config FERSMonteCarloConfig) (*FERSMonteCarloResult, error) {
+	return fmce.RunFERSMonteCarloWithContext(context.Background(), config)
+}
+
+// RunFERSMonteCarloWithContext executes the FERS Monte Carlo simulation, checking ctx before each
+// simulation and at every projection year. If ctx is cancelled no further simulations are started
+// and the wrapped context error is returned once in-flight simulations stop.
+func (fmce *FERSMonteCarloEngine) RunFERSMonteCarloWithContext(ctx context.Context, config FERSMonteCarloConfig) (*FERSMonteCarloResult, error) {
 	if fmce.historicalData == nil || !fmce.historicalData.IsLoaded {
-		return nil, fmt.Errorf("historical data not loaded")
+		return nil, ErrHistoricalDataNotLoaded
+	}
+	if err := config.SuccessCriteria.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Monte Carlo configuration: %w", err)
+	}
+	fundCholesky, err := fundCorrelationCholesky(config.BaseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Monte Carlo configuration: TSP correlation matrix: %w", err)
 	}
 
 	// Set random seed (Go 1.20+ approach)
@@ -180,21 +272,63 @@ func (fmce *FERSMonteCarloEngine) RunFERSMonteCarlo(config FERSMonteCarloConfig)
 
 	// Update config
 	fmce.config = config
+	fmce.fundCholesky = fundCholesky
 
-	// Run simulations in parallel
+	// Run simulations in parallel, in batches when the count is adaptive
 	simulations := make([]FERSMonteCarloSimulation, config.NumSimulations)
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 10) // Limit concurrency
+	adaptive := config.ConvergenceTolerance.IsPositive()
+	batchSize := config.NumSimulations
+	if adaptive {
+		batchSize = config.BatchSize
+		if batchSize <= 0 {
+			batchSize = defaultConvergenceBatchSize
+		}
+	}
+	run, converged := 0, false
+	for run < config.NumSimulations && !converged {
+		end := run + batchSize
+		if end > config.NumSimulations {
+			end = config.NumSimulations
+		}
+		fmce.runSimulationBatch(ctx, simulations, run, end)
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("monte carlo simulation cancelled: %w", err)
+		}
+		run = end
+		converged = adaptive && successRateMargin(countSuccesses(simulations[:run]), run).LessThanOrEqual(config.ConvergenceTolerance)
+	}
+
+	// Calculate aggregate results
+	result := fmce.calculateAggregateResults(simulations[:run])
+	result.SuccessRateCIWidth = successRateMargin(countSuccesses(simulations[:run]), run).Mul(decimal.NewFromInt(2))
+	result.Converged = converged
+
+	return result, nil
+}
 
-	for i := 0; i < config.NumSimulations; i++ {
+// runSimulationBatch runs simulations start through end-1 in parallel, storing each at its index. Once ctx
+// is cancelled no further simulations are started; the caller checks ctx afterwards.
+func (fmce *FERSMonteCarloEngine) runSimulationBatch(ctx context.Context, simulations []FERSMonteCarloSimulation, start, end int) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, concurrencyLimit(fmce.config.MaxConcurrency)) // Limit concurrency
+
+launch:
+	for i := start; i < end; i++ {
+		select {
+		case <-ctx.Done():
+			break launch
+		case semaphore <- struct{}{}: // Acquire semaphore
+		}
 		wg.Add(1)
 		go func(simIndex int) {
 			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire semaphore
 			defer func() { <-semaphore }() // Release semaphore
 
-			simulation, err := fmce.runSingleFERSSimulation(simIndex)
+			simulation, err := fmce.runSingleFERSSimulation(ctx, simIndex)
 			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
 				// Log error but continue with other simulations
 				if fmce.calcEngine != nil && fmce.calcEngine.Logger != nil {
 					fmce.calcEngine.Logger.Errorf("Simulation %d failed: %v", simIndex, err)
@@ -206,39 +340,104 @@ func (fmce *FERSMonteCarloEngine) RunFERSMonteCarlo(config FERSMonteCarloConfig)
 	}
 
 	wg.Wait()
+}
 
-	// Calculate aggregate results
-	result := fmce.calculateAggregateResults(simulations)
+// countSuccesses returns how many simulations succeeded
+func countSuccesses(simulations []FERSMonteCarloSimulation) int {
+	count := 0
+	for _, sim := range simulations {
+		if sim.Success {
+			count++
+		}
+	}
+	return count
+}
 
-	return result, nil
+// successRateMargin returns the half-width of the 95% Wilson score interval for a success rate of
+// successes out of n. Unlike the normal approximation it stays positive when every simulation succeeds or
+// fails, so an adaptive run cannot stop on a lucky first batch.
+func successRateMargin(successes, n int) decimal.Decimal {
+	if n == 0 {
+		return decimal.NewFromInt(1)
+	}
+	const z = 1.959964
+	p := float64(successes) / float64(n)
+	nf := float64(n)
+	margin := z * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf)) / (1 + z*z/nf)
+	return decimal.NewFromFloat(margin)
 }
 
 // runSingleFERSSimulation runs a single FERS Monte Carlo simulation
-func (fmce *FERSMonteCarloEngine) runSingleFERSSimulation(simIndex int) (*FERSMonteCarloSimulation, error) {
-	// Generate market conditions with enhanced variability
-	marketConditions := fmce.generateEnhancedMarketConditions()
-
+func (fmce *FERSMonteCarloEngine) runSingleFERSSimulation(ctx context.Context, simIndex int) (*FERSMonteCarloSimulation, error) {
 	// Create a proper deep copy of the configuration to ensure each simulation is independent
-	modifiedConfig := fmce.deepCopyConfiguration(fmce.config.BaseConfig)
-	modifiedConfig.GlobalAssumptions = fmce.applyMarketConditionsToAssumptions(marketConditions)
-
-	// Apply TSP market conditions to the configuration
-	fmce.applyMarketConditionsToTSPCalculations(marketConditions, &modifiedConfig)
+	baseConfig := fmce.deepCopyConfiguration(fmce.config.BaseConfig)
+
+	// Apply each scenario's assumption overrides before the draws, so the simulated inflation, COLA,
+	// returns and lifespans replace the overridden values instead of being replaced by them
+	scenarioConfigs := make([]domain.Configuration, len(baseConfig.Scenarios))
+	years := baseConfig.GlobalAssumptions.ProjectionYears
+	for i := range baseConfig.Scenarios {
+		config, scenario := scenarioAssumptions(&baseConfig, &baseConfig.Scenarios[i])
+		scenarioConfigs[i] = *config
+		scenarioConfigs[i].Scenarios = []domain.Scenario{*scenario}
+		years = max(years, config.GlobalAssumptions.ProjectionYears)
+	}
 
-	// Create a separate calculation engine instance for this simulation to avoid race conditions
-	// when running parallel simulations with different Monte Carlo fund returns
-	simEngine := NewCalculationEngineWithConfig(modifiedConfig.GlobalAssumptions.FederalRules)
-	simEngine.HistoricalData = fmce.calcEngine.HistoricalData // Share historical data
-	simEngine.Logger = fmce.calcEngine.Logger                 // Share logger
-	simEngine.Debug = fmce.calcEngine.Debug                   // Share debug setting
+	// Generate an independent market condition for every projection year so that
+	// sequence-of-returns risk is captured. Each year's inflation and COLA come from
+	// that year's draw.
+	marketSeries := fmce.generateMarketConditionSeries(years)
+	marketConditions := marketSeries.Years[0]
+	inflation := make([]decimal.Decimal, len(marketSeries.Years))
+	cola := make([]decimal.Decimal, len(marketSeries.Years))
+	for i, market := range marketSeries.Years {
+		inflation[i] = market.InflationRate
+		cola[i] = market.COLARate
+	}
 
-	// Set Monte Carlo fund returns on this simulation's engine
-	simEngine.MonteCarloFundReturns = marketConditions.TSPReturns
+	var deathAgeA, deathAgeB int
+	if fmce.config.StochasticMortality {
+		deathAgeA, deathAgeB = fmce.drawStochasticMortality(&baseConfig)
+	}
 
-	// Run full FERS calculation for each scenario using the simulation-specific engine
+	// Run full FERS calculation for each scenario using a simulation-specific engine
 	var scenarioResults []*domain.ScenarioSummary
-	for _, scenario := range modifiedConfig.Scenarios {
-		summary, err := simEngine.RunScenario(context.Background(), &modifiedConfig, &scenario)
+	var mortality *SimulatedMortality
+	for i := range scenarioConfigs {
+		modifiedConfig := &scenarioConfigs[i]
+		modifiedConfig.GlobalAssumptions = applyMarketConditionsToAssumptions(modifiedConfig.GlobalAssumptions, marketConditions)
+
+		// Apply TSP market conditions to the configuration
+		fmce.applyMarketConditionsToTSPCalculations(marketConditions, modifiedConfig)
+
+		if fmce.config.StochasticMortality {
+			scenarioMortality := applyStochasticMortality(modifiedConfig, deathAgeA, deathAgeB)
+			if mortality == nil {
+				mortality = scenarioMortality
+			}
+		}
+
+		portfolioReturns := make([]decimal.Decimal, len(marketSeries.Years))
+		for year, market := range marketSeries.Years {
+			portfolioReturns[year] = defaultAllocationReturn(market, modifiedConfig)
+		}
+
+		// Create a separate calculation engine instance for this simulation to avoid race conditions
+		// when running parallel simulations with different Monte Carlo fund returns
+		simEngine := NewCalculationEngineWithConfig(modifiedConfig.GlobalAssumptions.FederalRules)
+		simEngine.HistoricalData = fmce.calcEngine.HistoricalData // Share historical data
+		simEngine.Logger = fmce.calcEngine.Logger                 // Share logger
+		simEngine.Debug = fmce.calcEngine.Debug                   // Share debug setting
+
+		// Set Monte Carlo fund returns on this simulation's engine
+		simEngine.MonteCarloFundReturns = marketConditions.TSPReturns
+		simEngine.MonteCarloFundReturnsByYear = marketSeries.FundReturns()
+		simEngine.MonteCarloPortfolioReturnsByYear = portfolioReturns
+		simEngine.InflationByYear = inflation
+		simEngine.COLAByYear = cola
+
+		scenario := &modifiedConfig.Scenarios[0]
+		summary, err := simEngine.RunScenario(ctx, modifiedConfig, scenario)
 		if err != nil {
 			return nil, fmt.Errorf("failed to run scenario %s: %w", scenario.Name, err)
 		}
@@ -257,13 +456,99 @@ func (fmce *FERSMonteCarloEngine) runSingleFERSSimulation(simIndex int) (*FERSMo
 	return &FERSMonteCarloSimulation{
 		SimulationID:     simIndex,
 		MarketConditions: marketConditions,
+		MarketSeries:     marketSeries,
 		ScenarioResults:  scenarioResults,
 		Success:          success,
 		NetIncomeMetrics: netIncomeMetrics,
 		TSPMetrics:       tspMetrics,
+		Mortality:        mortality,
 	}, nil
 }
 
+// drawStochasticMortality draws a death age for each person from the period life table for their sex
+func (fmce *FERSMonteCarloEngine) drawStochasticMortality(config *domain.Configuration) (deathAgeA, deathAgeB int) {
+	multiplier := 1.0
+	if fmce.config.MortalityMultiplier.GreaterThan(decimal.Zero) {
+		multiplier = fmce.config.MortalityMultiplier.InexactFloat64()
+	}
+	personA, personB := config.PersonalDetails["person_a"], config.PersonalDetails["person_b"]
+	startYear := ProjectionStartYear(&config.GlobalAssumptions)
+	deathAgeA = drawDeathAge(startYear-personA.BirthDate.Year(), personA.Sex, multiplier, rand.Float64)
+	deathAgeB = drawDeathAge(startYear-personB.BirthDate.Year(), personB.Sex, multiplier, rand.Float64)
+	return deathAgeA, deathAgeB
+}
+
+// applyStochasticMortality applies drawn death ages to every scenario as a deterministic death, so the
+// existing survivor pension, SS and filing-status logic takes over. The projection is shortened to end
+// in the year the survivor dies.
+func applyStochasticMortality(config *domain.Configuration, deathAgeA, deathAgeB int) *SimulatedMortality {
+	personA, personB := config.PersonalDetails["person_a"], config.PersonalDetails["person_b"]
+	startYear := ProjectionStartYear(&config.GlobalAssumptions)
+	currentAgeA := startYear - personA.BirthDate.Year()
+	currentAgeB := startYear - personB.BirthDate.Year()
+
+	years := config.GlobalAssumptions.ProjectionYears
+	idxA, idxB := deathAgeA-currentAgeA, deathAgeB-currentAgeB
+	if last := max(idxA, idxB) + 1; last < years {
+		years = last
+	}
+	config.GlobalAssumptions.ProjectionYears = years
+
+	for i := range config.Scenarios {
+		scenario := &config.Scenarios[i]
+		mortality := &domain.ScenarioMortality{
+			PersonA: &domain.MortalitySpec{DeathAge: &deathAgeA},
+			PersonB: &domain.MortalitySpec{DeathAge: &deathAgeB},
+		}
+		if scenario.Mortality != nil {
+			mortality.Assumptions = scenario.Mortality.Assumptions
+		}
+		scenario.Mortality = mortality
+
+		// Drop events after the household ends so they don't fall outside the projection window
+		var events []domain.FinancialEvent
+		for _, event := range scenario.Events {
+			if event.Date.Year()-startYear < years {
+				events = append(events, event)
+			}
+		}
+		scenario.Events = events
+	}
+
+	return &SimulatedMortality{
+		PersonADeathAge: deathAgeA,
+		PersonBDeathAge: deathAgeB,
+		FirstToDie:      firstToDie(idxA, idxB, config.GlobalAssumptions.ProjectionYears),
+		ProjectionYears: years,
+	}
+}
+
+// firstToDie classifies which person dies first given their 0-based death year indexes
+func firstToDie(idxA, idxB, projectionYears int) string {
+	switch {
+	case idxA >= projectionYears && idxB >= projectionYears:
+		return FirstToDieNone
+	case idxA < idxB:
+		return FirstToDiePersonA
+	case idxB < idxA:
+		return FirstToDiePersonB
+	default:
+		return FirstToDieSameYear
+	}
+}
+
+// generateMarketConditionSeries draws an independent market condition for each projection year
+func (fmce *FERSMonteCarloEngine) generateMarketConditionSeries(years int) MarketConditionSeries {
+	if years < 1 {
+		years = 1
+	}
+	series := MarketConditionSeries{Years: make([]MarketCondition, years)}
+	for i := range series.Years {
+		series.Years[i] = fmce.generateEnhancedMarketConditions()
+	}
+	return series
+}
+
 // generateEnhancedMarketConditions generates market conditions with proper Monte Carlo variability
 func (fmce *FERSMonteCarloEngine) generateEnhancedMarketConditions() MarketCondition {
 	if fmce.config.UseHistorical {
@@ -417,15 +702,11 @@ func (fmce *FERSMonteCarloEngine) generateHistoricalMarketConditions() MarketCon
 // generateStatisticalMarketConditions generates market conditions using statistical distributions
 func (fmce *FERSMonteCarloEngine) generateStatisticalMarketConditions() MarketCondition {
 	marketData := MarketCondition{
-		Year:       rand.Intn(30) + 2025, // Random year between 2025-2055
-		TSPReturns: make(map[string]decimal.Decimal),
+		Year: rand.Intn(30) + 2025, // Random year between 2025-2055
 	}
 
-	// Generate TSP fund returns
-	funds := []string{"C", "S", "I", "F", "G"}
-	for _, fund := range funds {
-		marketData.TSPReturns[fund] = fmce.generateStatisticalTSPReturn(fund)
-	}
+	// Generate TSP fund returns (correlated when a correlation matrix is configured)
+	marketData.TSPReturns = fmce.generateStatisticalTSPReturns()
 
 	marketData.InflationRate = fmce.generateStatisticalInflation()
 	marketData.COLARate = fmce.generateStatisticalCOLA()
@@ -434,12 +715,11 @@ func (fmce *FERSMonteCarloEngine) generateStatisticalMarketConditions() MarketCo
 	return marketData
 }
 
-// generateStatisticalTSPReturn generates statistical TSP return for a fund
-func (fmce *FERSMonteCarloEngine) generateStatisticalTSPReturn(fund string) decimal.Decimal {
+// fundStatistics returns the mean and standard deviation used to model a fund's annual return
+func (fmce *FERSMonteCarloEngine) fundStatistics(fund string) (mean, stdDev decimal.Decimal) {
 	// Get statistical models from configuration
 	models := fmce.config.BaseConfig.GlobalAssumptions.TSPStatisticalModels
 
-	var mean, stdDev decimal.Decimal
 	var foundInConfig bool
 
 	// Get parameters from configuration based on fund
@@ -476,30 +756,18 @@ func (fmce *FERSMonteCarloEngine) generateStatisticalTSPReturn(fund string) deci
 		}
 	}
 
-	// Use historical defaults if not configured (preserving current values with documentation)
+	// Use historical defaults if not configured
 	if !foundInConfig {
-		switch fund {
-		case "C":
-			mean = decimal.NewFromFloat(0.1125)   // 11.25% historical mean (TSP.gov 1988-2024)
-			stdDev = decimal.NewFromFloat(0.1744) // 17.44% historical std dev
-		case "S":
-			mean = decimal.NewFromFloat(0.1117)   // 11.17% historical mean (TSP.gov 1988-2024)
-			stdDev = decimal.NewFromFloat(0.1933) // 19.33% historical std dev
-		case "I":
-			mean = decimal.NewFromFloat(0.0634)   // 6.34% historical mean (TSP.gov 1988-2024)
-			stdDev = decimal.NewFromFloat(0.1863) // 18.63% historical std dev
-		case "F":
-			mean = decimal.NewFromFloat(0.0532)   // 5.32% historical mean (TSP.gov 1988-2024)
-			stdDev = decimal.NewFromFloat(0.0565) // 5.65% historical std dev
-		case "G":
-			mean = decimal.NewFromFloat(0.0493)   // 4.93% historical mean (TSP.gov 1988-2024)
-			stdDev = decimal.NewFromFloat(0.0165) // 1.65% historical std dev (very stable)
-		default:
-			mean = decimal.NewFromFloat(0.08)   // 8% default mean for unknown funds
-			stdDev = decimal.NewFromFloat(0.15) // 15% default std dev
-		}
+		mean, stdDev = defaultFundStatistics(fund)
 	}
 
+	return mean, stdDev
+}
+
+// generateStatisticalTSPReturn generates statistical TSP return for a fund
+func (fmce *FERSMonteCarloEngine) generateStatisticalTSPReturn(fund string) decimal.Decimal {
+	mean, stdDev := fmce.fundStatistics(fund)
+
 	// Generate normal distribution using Box-Muller transform
 	u1 := rand.Float64()
 	u2 := rand.Float64()
@@ -510,6 +778,63 @@ func (fmce *FERSMonteCarloEngine) generateStatisticalTSPReturn(fund string) deci
 	return mean.Add(zDecimal.Mul(stdDev))
 }
 
+// generateStatisticalTSPReturns generates one year's returns for all five TSP funds.
+// When a correlation matrix is configured the draws are correlated via its Cholesky
+// factor, computed once per run; otherwise each fund is drawn independently.
+func (fmce *FERSMonteCarloEngine) generateStatisticalTSPReturns() map[string]decimal.Decimal {
+	returns := make(map[string]decimal.Decimal, len(tspFundOrder))
+
+	chol := fmce.fundCholesky
+	if chol == nil {
+		for _, fund := range tspFundOrder {
+			returns[fund] = fmce.generateStatisticalTSPReturn(fund)
+		}
+		return returns
+	}
+
+	z := make([]float64, len(tspFundOrder))
+	for i := range z {
+		z[i] = fmce.boxMullerTransform(rand.Float64(), rand.Float64())
+	}
+	correlated := applyCholesky(chol, z)
+
+	for i, fund := range tspFundOrder {
+		mean, stdDev := fmce.fundStatistics(fund)
+		returns[fund] = mean.Add(decimal.NewFromFloat(correlated[i]).Mul(stdDev))
+	}
+	return returns
+}
+
+// fundCorrelationCholesky returns the lower-triangular Cholesky factor of the configured
+// fund correlation matrix, or nil when no matrix is configured. It returns an error when
+// the matrix is not 5x5, symmetric and positive definite.
+func fundCorrelationCholesky(config *domain.Configuration) ([][]float64, error) {
+	if config == nil {
+		return nil, nil
+	}
+	matrix := config.GlobalAssumptions.TSPStatisticalModels.CorrelationMatrix
+	if len(matrix) == 0 {
+		return nil, nil
+	}
+	if len(matrix) != len(tspFundOrder) {
+		return nil, fmt.Errorf("correlation matrix must be %dx%d, got %d rows", len(tspFundOrder), len(tspFundOrder), len(matrix))
+	}
+	m := make([][]float64, len(matrix))
+	for i, row := range matrix {
+		if len(row) != len(tspFundOrder) {
+			return nil, fmt.Errorf("correlation matrix row %d has %d columns, want %d", i, len(row), len(tspFundOrder))
+		}
+		if !row[i].Equal(decimal.NewFromInt(1)) {
+			return nil, fmt.Errorf("correlation matrix diagonal entry %d is %s, want 1", i, row[i])
+		}
+		m[i] = make([]float64, len(row))
+		for j, v := range row {
+			m[i][j] = v.InexactFloat64()
+		}
+	}
+	return CholeskyDecompose(m)
+}
+
 // generateStatisticalInflation generates statistical inflation rate
 func (fmce *FERSMonteCarloEngine) generateStatisticalInflation() decimal.Decimal {
 	mean := decimal.NewFromFloat(0.0259)   // 2.59% historical mean
@@ -573,12 +898,8 @@ func (fmce *FERSMonteCarloEngine) boxMullerTransform(u1, u2 float64) float64 {
 	return z0
 }
 
-// applyMarketConditionsToAssumptions applies market conditions to global assumptions
-func (fmce *FERSMonteCarloEngine) applyMarketConditionsToAssumptions(market MarketCondition) domain.GlobalAssumptions {
-	// Create a copy of the original assumptions instead of modifying the shared reference
-	assumptions := fmce.config.BaseConfig.GlobalAssumptions
-
-	// Apply market conditions to the copy
+// applyMarketConditionsToAssumptions returns a copy of assumptions with the market's inflation and COLA
+func applyMarketConditionsToAssumptions(assumptions domain.GlobalAssumptions, market MarketCondition) domain.GlobalAssumptions {
 	assumptions.InflationRate = market.InflationRate
 	assumptions.COLAGeneralRate = market.COLARate
 
@@ -587,30 +908,54 @@ func (fmce *FERSMonteCarloEngine) applyMarketConditionsToAssumptions(market Mark
 
 // applyMarketConditionsToTSPCalculations applies market conditions to TSP calculations
 func (fmce *FERSMonteCarloEngine) applyMarketConditionsToTSPCalculations(market MarketCondition, config *domain.Configuration) {
-	// Get default TSP allocation from configuration
-	defaultAllocation := config.GlobalAssumptions.MonteCarloSettings.DefaultTSPAllocation
+	weightedReturn := defaultAllocationReturn(market, config)
 
-	// Create asset allocation map from configuration (with fallback defaults)
-	assetAllocation := map[string]decimal.Decimal{
-		"C": defaultAllocation.CFund,
-		"S": defaultAllocation.SFund,
-		"I": defaultAllocation.IFund,
-		"F": defaultAllocation.FFund,
-		"G": defaultAllocation.GFund,
-	}
+	// Apply the weighted return to both pre and post retirement TSP return rates
+	config.GlobalAssumptions.TSPReturnPreRetirement = weightedReturn
+	config.GlobalAssumptions.TSPReturnPostRetirement = weightedReturn
+}
 
-	// Apply fallback defaults if allocation is zero or not configured
-	if defaultAllocation.CFund.IsZero() && defaultAllocation.SFund.IsZero() &&
-		defaultAllocation.IFund.IsZero() && defaultAllocation.FFund.IsZero() &&
-		defaultAllocation.GFund.IsZero() {
-		// Use conservative balanced allocation as ultimate fallback
-		assetAllocation = map[string]decimal.Decimal{
-			"C": decimal.NewFromFloat(0.60), // 60% Large Cap Stock Index
-			"S": decimal.NewFromFloat(0.20), // 20% Small Cap Stock Index
-			"I": decimal.NewFromFloat(0.10), // 10% International Stock Index
-			"F": decimal.NewFromFloat(0.10), // 10% Fixed Income Index
-			"G": decimal.NewFromFloat(0.00), // 0% Government Securities
+// weightedTSPReturn computes the household's TSP portfolio return for a market condition: each person's
+// own allocation (their lifecycle fund at the projection start, or their fixed allocation) weighted by
+// their TSP balance. A person with neither holds the Monte Carlo default allocation, which also applies
+// outright when no one has a balance.
+func (fmce *FERSMonteCarloEngine) weightedTSPReturn(market MarketCondition, config *domain.Configuration) decimal.Decimal {
+	start := time.Date(ProjectionStartYear(&config.GlobalAssumptions), 1, 1, 0, 0, 0, 0, time.UTC)
+	var totalBalance, weightedReturn decimal.Decimal
+	for _, employee := range config.PersonalDetails {
+		balance := employee.TotalTSPBalance()
+		if !balance.IsPositive() {
+			continue
+		}
+		portfolioReturn := defaultAllocationReturn(market, config)
+		if employee.TSPLifecycleFund != nil && fmce.calcEngine != nil && fmce.calcEngine.LifecycleFundLoader != nil {
+			portfolioReturn = allocationReturn(market, fmce.calcEngine.getTSPAllocationForEmployee(&employee, start))
+		} else if employee.TSPAllocation != nil {
+			portfolioReturn = allocationReturn(market, *employee.TSPAllocation)
 		}
+		totalBalance = totalBalance.Add(balance)
+		weightedReturn = weightedReturn.Add(portfolioReturn.Mul(balance))
+	}
+	if totalBalance.IsZero() {
+		return defaultAllocationReturn(market, config)
+	}
+	return weightedReturn.Div(totalBalance)
+}
+
+// defaultAllocationReturn weights a market condition's fund returns by the configured Monte Carlo default
+// TSP allocation, falling back to a balanced 60/20/10/10 C/S/I/F mix when none is configured
+func defaultAllocationReturn(market MarketCondition, config *domain.Configuration) decimal.Decimal {
+	return allocationReturn(market, resolveMonteCarloSettings(config.GlobalAssumptions.MonteCarloSettings).DefaultTSPAllocation)
+}
+
+// allocationReturn weights a market condition's fund returns by a TSP allocation
+func allocationReturn(market MarketCondition, allocation domain.TSPAllocation) decimal.Decimal {
+	assetAllocation := map[string]decimal.Decimal{
+		"C": allocation.CFund,
+		"S": allocation.SFund,
+		"I": allocation.IFund,
+		"F": allocation.FFund,
+		"G": allocation.GFund,
 	}
 
 	var weightedReturn decimal.Decimal
@@ -619,10 +964,7 @@ func (fmce *FERSMonteCarloEngine) applyMarketConditionsToTSPCalculations(market
 			weightedReturn = weightedReturn.Add(returnRate.Mul(allocation))
 		}
 	}
-
-	// Apply the weighted return to both pre and post retirement TSP return rates
-	config.GlobalAssumptions.TSPReturnPreRetirement = weightedReturn
-	config.GlobalAssumptions.TSPReturnPostRetirement = weightedReturn
+	return weightedReturn
 }
 
 // calculateNetIncomeMetrics calculates net income metrics for a simulation
@@ -645,6 +987,7 @@ func (fmce *FERSMonteCarloEngine) calculateNetIncomeMetrics(scenarioResults []*d
 		totalNetIncome = decimal.Zero
 
 		// Apply reasonable bounds to prevent extreme outliers while preserving natural distribution
+		maxReasonableIncome := resolveMonteCarloSettings(fmce.config.BaseConfig.GlobalAssumptions.MonteCarloSettings).MaxReasonableIncome
 		for _, year := range summary.Projection {
 			netIncome := year.NetIncome
 
@@ -655,11 +998,6 @@ func (fmce *FERSMonteCarloEngine) calculateNetIncomeMetrics(scenarioResults []*d
 
 			// Cap extremely unrealistic values using configured limit
 			// This preserves the natural distribution while preventing calculation errors
-			maxReasonableIncome := fmce.config.BaseConfig.GlobalAssumptions.MonteCarloSettings.MaxReasonableIncome
-			if maxReasonableIncome.IsZero() {
-				maxReasonableIncome = decimal.NewFromInt(5000000) // $5M default cap
-			}
-
 			if netIncome.GreaterThan(maxReasonableIncome) {
 				// Cap extreme values that might indicate calculation errors
 				netIncome = maxReasonableIncome
@@ -712,16 +1050,25 @@ func (fmce *FERSMonteCarloEngine) calculateTSPMetrics(scenarioResults []*domain.
 	}
 }
 
-// determineSuccess determines if a simulation is successful
+// determineSuccess determines if a simulation is successful under the configured success criteria.
+// Every scenario in the simulation must meet the criterion.
 func (fmce *FERSMonteCarloEngine) determineSuccess(scenarioResults []*domain.ScenarioSummary) bool {
 	if len(scenarioResults) == 0 {
 		return false
 	}
 
-	// Simplified success criteria: check if TSP lasts the full projection
-	// Could be enhanced with more sophisticated criteria
+	criteria := fmce.config.SuccessCriteria
 	for _, summary := range scenarioResults {
-		if summary.TSPLongevity < len(summary.Projection) {
+		var ok bool
+		switch criteria.Mode {
+		case SuccessIncomeFloor:
+			ok = meetsIncomeFloor(summary.Projection, criteria.IncomeFloor)
+		case SuccessBalanceAtAge:
+			ok = hasBalanceAtAge(summary.Projection, criteria.TargetAge)
+		default:
+			ok = summary.TSPLongevity >= summary.PlanHorizonYears
+		}
+		if !ok {
 			return false
 		}
 	}
@@ -729,6 +1076,32 @@ func (fmce *FERSMonteCarloEngine) determineSuccess(scenarioResults []*domain.Sce
 	return true
 }
 
+// meetsIncomeFloor reports whether real (today's dollar) net income stays at or above floor every year
+func meetsIncomeFloor(projection []domain.AnnualCashFlow, floor decimal.Decimal) bool {
+	for _, year := range projection {
+		if year.RealNetIncome.LessThan(floor) {
+			return false
+		}
+	}
+	return len(projection) > 0
+}
+
+// hasBalanceAtAge reports whether the combined TSP balance is positive in the year PersonA reaches
+// targetAge. If the projection ends first, the final year's balance is used.
+func hasBalanceAtAge(projection []domain.AnnualCashFlow, targetAge int) bool {
+	if len(projection) == 0 {
+		return false
+	}
+	year := projection[len(projection)-1]
+	for _, cf := range projection {
+		if cf.AgePersonA >= targetAge {
+			year = cf
+			break
+		}
+	}
+	return !year.IsTSPDepleted()
+}
+
 // calculateAggregateResults calculates aggregate results across all simulations
 func (fmce *FERSMonteCarloEngine) calculateAggregateResults(simulations []FERSMonteCarloSimulation) *FERSMonteCarloResult {
 	// Count successful simulations
@@ -784,7 +1157,7 @@ func (fmce *FERSMonteCarloEngine) calculateAggregateResults(simulations []FERSMo
 	worstCase := fmce.findMin(netIncomes)
 	bestCase := fmce.findMax(netIncomes)
 
-	return &FERSMonteCarloResult{
+	result := &FERSMonteCarloResult{
 		SuccessRate:             successRate,
 		MedianNetIncome:         medianNetIncome,
 		NetIncomePercentiles:    netIncomePercentiles,
@@ -798,34 +1171,61 @@ func (fmce *FERSMonteCarloEngine) calculateAggregateResults(simulations []FERSMo
 		NumSimulations:          len(simulations),
 		BaseConfig:              fmce.config.BaseConfig,
 	}
+	result.DepletionByYear = result.DepletionHeatmap()
+	result.SequenceRisk = fmce.calculateSequenceRisk(simulations, fmce.config.BaseConfig)
+	if fmce.config.StochasticMortality {
+		result.MedianProjectionYears, result.FirstToDieOutcomes = fmce.calculateMortalityOutcomes(simulations)
+	}
+	return result
 }
 
-// Helper functions for statistical calculations
-func (fmce *FERSMonteCarloEngine) calculatePercentileRanges(values []decimal.Decimal) PercentileRanges {
-	if len(values) == 0 {
-		return PercentileRanges{}
+// calculateMortalityOutcomes returns the median projection length and success/income metrics grouped
+// by which person died first
+func (fmce *FERSMonteCarloEngine) calculateMortalityOutcomes(simulations []FERSMonteCarloSimulation) (int, map[string]MortalityOutcome) {
+	var lengths []decimal.Decimal
+	type group struct {
+		successes int
+		incomes   []decimal.Decimal
+	}
+	groups := make(map[string]*group)
+	for _, sim := range simulations {
+		if sim.Mortality == nil {
+			continue
+		}
+		lengths = append(lengths, decimal.NewFromInt(int64(sim.Mortality.ProjectionYears)))
+		g, ok := groups[sim.Mortality.FirstToDie]
+		if !ok {
+			g = &group{}
+			groups[sim.Mortality.FirstToDie] = g
+		}
+		if sim.Success {
+			g.successes++
+		}
+		g.incomes = append(g.incomes, sim.NetIncomeMetrics.AverageNetIncome)
 	}
 
-	// Sort values
-	fmce.sortDecimalSlice(values)
-
-	n := len(values)
-	return PercentileRanges{
-		P10: values[n/10],
-		P25: values[n/4],
-		P50: values[n/2],
-		P75: values[3*n/4],
-		P90: values[9*n/10],
+	outcomes := make(map[string]MortalityOutcome, len(groups))
+	for key, g := range groups {
+		n := len(g.incomes)
+		outcomes[key] = MortalityOutcome{
+			Simulations:     n,
+			SuccessRate:     decimal.NewFromInt(int64(g.successes)).Div(decimal.NewFromInt(int64(n))),
+			MedianNetIncome: fmce.calculateMedian(g.incomes),
+		}
 	}
+	if len(lengths) == 0 {
+		return 0, outcomes
+	}
+	return int(fmce.calculateMedian(lengths).IntPart()), outcomes
 }
 
-func (fmce *FERSMonteCarloEngine) calculateMedian(values []decimal.Decimal) decimal.Decimal {
-	if len(values) == 0 {
-		return decimal.Zero
-	}
+// Helper functions for statistical calculations
+func (fmce *FERSMonteCarloEngine) calculatePercentileRanges(values []decimal.Decimal) PercentileRanges {
+	return percentileRanges(values, MonteCarloPercentileMethod)
+}
 
-	fmce.sortDecimalSlice(values)
-	return values[len(values)/2]
+func (fmce *FERSMonteCarloEngine) calculateMedian(values []decimal.Decimal) decimal.Decimal {
+	return Percentile(values, 0.5, MonteCarloPercentileMethod)
 }
 
 func (fmce *FERSMonteCarloEngine) calculateStandardDeviation(values []decimal.Decimal) decimal.Decimal {
@@ -882,33 +1282,55 @@ func (fmce *FERSMonteCarloEngine) findMax(values []decimal.Decimal) decimal.Deci
 	return max
 }
 
-func (fmce *FERSMonteCarloEngine) sortDecimalSlice(values []decimal.Decimal) {
-	// Simple bubble sort for small arrays
-	for i := 0; i < len(values)-1; i++ {
-		for j := 0; j < len(values)-i-1; j++ {
-			if values[j].GreaterThan(values[j+1]) {
-				values[j], values[j+1] = values[j+1], values[j]
-			}
-		}
-	}
-}
-
-// deepCopyConfiguration creates a deep copy of the configuration to ensure each simulation is independent
+// deepCopyConfiguration returns a copy of config that shares no pointers, slices or maps with it, so
+// simulations never share mutable state. Every field is copied, including ones added later.
 func (fmce *FERSMonteCarloEngine) deepCopyConfiguration(config *domain.Configuration) domain.Configuration {
-	// Deep copy the configuration
-	newConfig := domain.Configuration{
-		PersonalDetails:   make(map[string]domain.Employee),
-		GlobalAssumptions: config.GlobalAssumptions, // This will be overwritten anyway
-		Scenarios:         make([]domain.Scenario, len(config.Scenarios)),
-	}
+	return deepCopy(reflect.ValueOf(*config)).Interface().(domain.Configuration)
+}
 
-	// Deep copy personal details
-	for key, employee := range config.PersonalDetails {
-		newConfig.PersonalDetails[key] = employee // decimal.Decimal is a value type, so this is safe
+// deepCopy returns a copy of v with every pointer, slice and map reachable through exported fields
+// duplicated. Unexported fields are copied by value; the types that have them here (decimal.Decimal,
+// time.Time) are immutable.
+func deepCopy(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			out.Set(reflect.New(v.Type().Elem()))
+			out.Elem().Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				out.Index(i).Set(deepCopy(v.Index(i)))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				out.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Struct:
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				out.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		out.Set(v)
 	}
-
-	// Deep copy scenarios
-	copy(newConfig.Scenarios, config.Scenarios)
-
-	return newConfig
+	return out
 }
@@ -1,7 +1,11 @@
 package calculation
 
 import (
+	"context"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
@@ -350,3 +354,300 @@ func createTestHistoricalDataManager(t *testing.T) *HistoricalDataManager {
 	t.Fatalf("Failed to load test historical data from any path: %v", err)
 	return nil
 }
+
+func TestDeepCopyConfigurationIsolatesMortality(t *testing.T) {
+	deathAge := 85
+	deathDate := time.Date(2040, 6, 1, 0, 0, 0, 0, time.UTC)
+	rate := decimal.NewFromFloat(0.04)
+	original := &domain.Configuration{
+		Scenarios: []domain.Scenario{{
+			Name:    "mortality",
+			PersonA: domain.RetirementScenario{TSPWithdrawalRate: &rate},
+			Mortality: &domain.ScenarioMortality{
+				PersonA:     &domain.MortalitySpec{DeathAge: &deathAge},
+				PersonB:     &domain.MortalitySpec{DeathDate: &deathDate},
+				Assumptions: &domain.MortalityAssumptions{TSPSpousalTransfer: "merge", SurvivorSpendingFactor: decimal.NewFromFloat(0.7)},
+			},
+		}},
+	}
+	engine := &FERSMonteCarloEngine{}
+
+	copied := engine.deepCopyConfiguration(original)
+	m := copied.Scenarios[0].Mortality
+	*m.PersonA.DeathAge = 70
+	*m.PersonB.DeathDate = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.Assumptions.TSPSpousalTransfer = "separate"
+	m.PersonA = nil
+	*copied.Scenarios[0].PersonA.TSPWithdrawalRate = decimal.NewFromFloat(0.10)
+
+	orig := original.Scenarios[0].Mortality
+	if orig.PersonA == nil || *orig.PersonA.DeathAge != 85 {
+		t.Fatalf("original PersonA mortality was modified")
+	}
+	if !orig.PersonB.DeathDate.Equal(deathDate) {
+		t.Fatalf("original PersonB death date was modified: %v", orig.PersonB.DeathDate)
+	}
+	if orig.Assumptions.TSPSpousalTransfer != "merge" {
+		t.Fatalf("original mortality assumptions were modified")
+	}
+	if !original.Scenarios[0].PersonA.TSPWithdrawalRate.Equal(decimal.NewFromFloat(0.04)) {
+		t.Fatalf("original withdrawal rate was modified")
+	}
+}
+
+func TestDeepCopyConfigurationSharesNoReferences(t *testing.T) {
+	// Populate every pointer, slice and map reachable from a Configuration so a field added later is
+	// covered without changing this test
+	original := &domain.Configuration{}
+	populateReferences(reflect.ValueOf(original).Elem(), 0)
+
+	copied := (&FERSMonteCarloEngine{}).deepCopyConfiguration(original)
+	assertNoSharedReferences(t, "Configuration", reflect.ValueOf(*original), reflect.ValueOf(copied))
+}
+
+// populateReferences allocates every nil pointer, slice and map under v's exported fields, to a limited
+// depth for recursive types
+func populateReferences(v reflect.Value, depth int) {
+	if depth > 6 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		populateReferences(v.Elem(), depth+1)
+	case reflect.Slice:
+		if v.IsNil() {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		}
+		for i := 0; i < v.Len(); i++ {
+			populateReferences(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		populateReferences(elem, depth+1)
+		v.SetMapIndex(reflect.New(v.Type().Key()).Elem(), elem)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			populateReferences(v.Index(i), depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				populateReferences(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// assertNoSharedReferences fails when a pointer, slice or map under original's exported fields is the
+// same one under copied
+func assertNoSharedReferences(t *testing.T, path string, original, copied reflect.Value) {
+	t.Helper()
+	switch original.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if original.IsNil() {
+			return
+		}
+		if copied.IsNil() {
+			t.Fatalf("%s was not copied", path)
+		}
+		if original.Pointer() == copied.Pointer() {
+			t.Fatalf("%s is shared between the original and the copy", path)
+		}
+	}
+	switch original.Kind() {
+	case reflect.Pointer:
+		assertNoSharedReferences(t, path, original.Elem(), copied.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < original.Len(); i++ {
+			assertNoSharedReferences(t, fmt.Sprintf("%s[%d]", path, i), original.Index(i), copied.Index(i))
+		}
+	case reflect.Map:
+		iter := original.MapRange()
+		for iter.Next() {
+			assertNoSharedReferences(t, fmt.Sprintf("%s[%v]", path, iter.Key()), iter.Value(), copied.MapIndex(iter.Key()))
+		}
+	case reflect.Struct:
+		for i := 0; i < original.NumField(); i++ {
+			if field := original.Type().Field(i); field.IsExported() {
+				assertNoSharedReferences(t, path+"."+field.Name, original.Field(i), copied.Field(i))
+			}
+		}
+	}
+}
+
+func TestDetermineSuccessCriteria(t *testing.T) {
+	// Two simulations: both keep the TSP funded, but the second dips to $40k real net income and
+	// runs low on TSP by age 70.
+	simulation := func(lowIncome decimal.Decimal, balanceAt70 decimal.Decimal) []*domain.ScenarioSummary {
+		projection := make([]domain.AnnualCashFlow, 10)
+		for i := range projection {
+			projection[i] = domain.AnnualCashFlow{AgePersonA: 62 + i, RealNetIncome: decimal.NewFromInt(60000), TSPBalancePersonA: decimal.NewFromInt(100000)}
+		}
+		projection[5].RealNetIncome = lowIncome
+		projection[8].TSPBalancePersonA = balanceAt70
+		return []*domain.ScenarioSummary{{TSPLongevity: len(projection), Projection: projection}}
+	}
+	sims := [][]*domain.ScenarioSummary{
+		simulation(decimal.NewFromInt(60000), decimal.NewFromInt(100000)),
+		simulation(decimal.NewFromInt(40000), decimal.Zero),
+	}
+	successRate := func(criteria SuccessCriteria) int {
+		engine := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{SuccessCriteria: criteria}}
+		count := 0
+		for _, s := range sims {
+			if engine.determineSuccess(s) {
+				count++
+			}
+		}
+		return count
+	}
+
+	if got := successRate(SuccessCriteria{}); got != 2 {
+		t.Fatalf("expected both simulations to pass the depletion criterion, got %d", got)
+	}
+	if got := successRate(SuccessCriteria{Mode: SuccessIncomeFloor, IncomeFloor: decimal.NewFromInt(50000)}); got != 1 {
+		t.Fatalf("expected a tight income floor to fail one simulation, got %d successes", got)
+	}
+	if got := successRate(SuccessCriteria{Mode: SuccessBalanceAtAge, TargetAge: 70}); got != 1 {
+		t.Fatalf("expected balance_at_age 70 to fail one simulation, got %d successes", got)
+	}
+	if err := (SuccessCriteria{Mode: "bogus"}).Validate(); err == nil {
+		t.Fatalf("expected unknown mode to be rejected")
+	}
+}
+
+func TestStochasticMortalityShortensProjections(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	cfg.Scenarios = []domain.Scenario{*scenario}
+	medianYears := func(multiplier decimal.Decimal) int {
+		engine := &FERSMonteCarloEngine{
+			calcEngine: NewCalculationEngine(),
+			config:     FERSMonteCarloConfig{BaseConfig: cfg, StochasticMortality: true, MortalityMultiplier: multiplier},
+		}
+		var simulations []FERSMonteCarloSimulation
+		for i := 0; i < 40; i++ {
+			sim, err := engine.runSingleFERSSimulation(context.Background(), i)
+			if err != nil {
+				t.Fatalf("simulation failed: %v", err)
+			}
+			if sim.Mortality == nil || len(sim.ScenarioResults[0].Projection) != sim.Mortality.ProjectionYears {
+				t.Fatalf("expected the projection to end in the survivor's death year, got %+v", sim.Mortality)
+			}
+			simulations = append(simulations, *sim)
+		}
+		result := engine.calculateAggregateResults(simulations)
+		total := 0
+		for _, outcome := range result.FirstToDieOutcomes {
+			total += outcome.Simulations
+		}
+		if total != len(simulations) {
+			t.Fatalf("expected first-to-die outcomes to cover all %d simulations, got %d", len(simulations), total)
+		}
+		return result.MedianProjectionYears
+	}
+
+	table := medianYears(decimal.NewFromInt(1))
+	high := medianYears(decimal.NewFromInt(25))
+	if high >= table {
+		t.Fatalf("expected high mortality to shorten the median projection: table=%d high=%d", table, high)
+	}
+}
+
+func TestMortalityRate(t *testing.T) {
+	if MortalityRate(65) <= MortalityRate(60) || MortalityRate(90) <= MortalityRate(85) {
+		t.Fatalf("expected mortality to increase with age")
+	}
+	if MortalityRate(maxLifeTableAge) != 1 {
+		t.Fatalf("expected certain death at %d", maxLifeTableAge)
+	}
+}
+
+func TestDepletionHeatmapIsCumulative(t *testing.T) {
+	simulation := func(balances ...int64) FERSMonteCarloSimulation {
+		projection := make([]domain.AnnualCashFlow, len(balances))
+		for i, balance := range balances {
+			projection[i] = domain.AnnualCashFlow{
+				Date:              time.Date(2025+i, 1, 1, 0, 0, 0, 0, time.UTC),
+				AgePersonA:        62 + i,
+				AgePersonB:        60 + i,
+				TSPBalancePersonA: decimal.NewFromInt(balance),
+			}
+		}
+		return FERSMonteCarloSimulation{ScenarioResults: []*domain.ScenarioSummary{{Projection: projection}}}
+	}
+	result := &FERSMonteCarloResult{Simulations: []FERSMonteCarloSimulation{
+		simulation(100, 50, 0, 0),
+		simulation(100, 0, 10, 20), // A later event inflow does not undo the depletion
+		simulation(100, 90, 80, 70),
+		simulation(100, 80), // Projection cut short by mortality
+	}}
+
+	points := result.DepletionHeatmap()
+	expected := []float64{0, 0.25, 0.5, 0.5}
+	if len(points) != len(expected) {
+		t.Fatalf("expected %d years, got %d", len(expected), len(points))
+	}
+	for i, p := range points {
+		if !p.DepletedFraction.Equal(decimal.NewFromFloat(expected[i])) {
+			t.Fatalf("year %d: expected depleted fraction %v, got %s", p.Year, expected[i], p.DepletedFraction)
+		}
+	}
+	if points[3].Year != 2028 || points[3].AgePersonA != 65 || points[3].AgePersonB != 63 {
+		t.Fatalf("expected year and ages from the projection, got %+v", points[3])
+	}
+
+	// Aggregation publishes the series on the result for JSON export
+	aggregate := (&FERSMonteCarloEngine{}).calculateAggregateResults(result.Simulations)
+	if len(aggregate.DepletionByYear) != len(points) || !aggregate.DepletionByYear[3].DepletedFraction.Equal(points[3].DepletedFraction) {
+		t.Fatalf("expected the aggregate result to carry the depletion series, got %+v", aggregate.DepletionByYear)
+	}
+}
+
+func TestAdaptiveSimulationCountConverges(t *testing.T) {
+	config, scenario := retiredCoupleTestConfig(15)
+	config.Scenarios = []domain.Scenario{*scenario}
+	engine := NewFERSMonteCarloEngine(config, &HistoricalDataManager{IsLoaded: true})
+	run := func(tolerance float64) *FERSMonteCarloResult {
+		result, err := engine.RunFERSMonteCarlo(FERSMonteCarloConfig{
+			BaseConfig:           config,
+			NumSimulations:       400,
+			ConvergenceTolerance: decimal.NewFromFloat(tolerance),
+			BatchSize:            25,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Simulations) != result.NumSimulations || result.NumSimulations%25 != 0 {
+			t.Fatalf("expected whole batches to be reported, got %d simulations", result.NumSimulations)
+		}
+		return result
+	}
+
+	loose := run(0.15)
+	tight := run(0.02)
+	if !loose.Converged || loose.SuccessRateCIWidth.GreaterThan(decimal.NewFromFloat(0.3)) {
+		t.Fatalf("expected the loose run to converge within ±15%%, got width %s", loose.SuccessRateCIWidth)
+	}
+	if tight.NumSimulations <= loose.NumSimulations {
+		t.Fatalf("expected a tight tolerance to run more simulations: tight=%d loose=%d", tight.NumSimulations, loose.NumSimulations)
+	}
+	if !tight.SuccessRateCIWidth.LessThan(loose.SuccessRateCIWidth) {
+		t.Fatalf("expected more simulations to narrow the interval: tight=%s loose=%s", tight.SuccessRateCIWidth, loose.SuccessRateCIWidth)
+	}
+}
+
+func TestSuccessRateMargin(t *testing.T) {
+	// 500 of 1000: 1.96*sqrt(.25/1000) ≈ 0.031
+	if m := successRateMargin(500, 1000).InexactFloat64(); m < 0.030 || m > 0.032 {
+		t.Fatalf("expected a margin near 0.031, got %f", m)
+	}
+	if !successRateMargin(100, 100).IsPositive() {
+		t.Fatalf("expected a positive margin when every simulation succeeds")
+	}
+}
@@ -61,6 +61,13 @@ func TestApplyFERSPensionCOLA(t *testing.T) {
 			annuitantAge:    60,
 			expectedPension: decimal.NewFromInt(30000),
 		},
+		{
+			name:            "Full COLA at age 62 with 1.5% inflation",
+			currentPension:  decimal.NewFromInt(30000),
+			inflationRate:   decimal.NewFromFloat(0.015),
+			annuitantAge:    62,
+			expectedPension: decimal.NewFromInt(30450), // 30000 * 1.015
+		},
 		{
 			name:            "Full COLA at age 62 with 2% inflation",
 			currentPension:  decimal.NewFromInt(30000),
@@ -93,6 +100,83 @@ func TestApplyFERSPensionCOLA(t *testing.T) {
 	}
 }
 
+func TestFERSCOLAPolicy(t *testing.T) {
+	policy := DefaultFERSCOLAPolicy()
+	for _, tt := range []struct {
+		cpi      float64
+		fersCOLA float64
+	}{
+		{0.015, 0.015}, // full CPI
+		{0.025, 0.02},  // diet COLA: capped at 2%
+		{0.04, 0.03},   // diet COLA: CPI minus 1%
+	} {
+		assert.True(t, policy.Rate(decimal.NewFromFloat(tt.cpi)).Equal(decimal.NewFromFloat(tt.fersCOLA)),
+			"CPI %.3f: FERS COLA %s", tt.cpi, policy.Rate(decimal.NewFromFloat(tt.cpi)))
+	}
+
+	// Exempt annuities (survivor, special category, disability) are increased before 62
+	pension := decimal.NewFromInt(30000)
+	assert.True(t, policy.Apply(pension, decimal.NewFromFloat(0.04), 55, false).Equal(pension))
+	assert.True(t, policy.Apply(pension, decimal.NewFromFloat(0.04), 55, true).Equal(decimal.NewFromInt(30900)))
+
+	// Configured thresholds override the statutory tiers; unset values keep the defaults
+	custom := NewFERSCOLAPolicy(domain.FERSRules{
+		COLAFullCPIThreshold: decimal.NewFromFloat(0.03),
+		COLACapThreshold:     decimal.NewFromFloat(0.05),
+	})
+	assert.Equal(t, 62, custom.MinimumAge)
+	assert.True(t, custom.Rate(decimal.NewFromFloat(0.04)).Equal(decimal.NewFromFloat(0.03)))
+	assert.True(t, custom.Rate(decimal.NewFromFloat(0.06)).Equal(decimal.NewFromFloat(0.04)))
+}
+
+func TestSpecialCategoryPensionCOLABefore62(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate:   time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary: decimal.NewFromInt(100000),
+	}
+	retirement := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	inflation := decimal.NewFromFloat(0.025)
+
+	regular := CalculatePensionForYear(employee, retirement, 1, inflation)
+	employee.SpecialCategory = true
+	special := CalculatePensionForYear(employee, retirement, 1, inflation)
+
+	// Separating on January 1 puts the annuity on the rolls February 1, for 10/12 of the first COLA
+	firstCOLA := decimal.NewFromInt(1).Add(decimal.NewFromFloat(0.02).Mul(decimal.NewFromInt(10).Div(decimal.NewFromInt(12))))
+	assert.True(t, special.Equal(regular.Mul(firstCOLA)),
+		"special category at 51 should get the 2%% diet COLA: regular %s special %s", regular, special)
+}
+
+func TestFirstCOLAProratedByCommencementMonth(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate:   time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:    time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary: decimal.NewFromInt(100000),
+	}
+	inflation := decimal.NewFromFloat(0.02)
+
+	// The first COLA's dollar increase for an immediate annuity, which commences the month after separation
+	firstCOLA := func(retirement time.Time, months int64) decimal.Decimal {
+		base := CalculatePensionForYear(employee, retirement, 0, inflation)
+		increase := CalculatePensionForYear(employee, retirement, 1, inflation).Sub(base)
+		expected := base.Mul(decimal.NewFromFloat(0.02).Mul(decimal.NewFromInt(months).Div(decimal.NewFromInt(12))))
+		assert.True(t, increase.Equal(expected), "%s retiree first COLA %s, want %s", retirement.Month(), increase, expected)
+		return increase
+	}
+	december31 := firstCOLA(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 11)
+	october31 := firstCOLA(time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), 1)
+	firstCOLA(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 10)
+
+	assert.True(t, december31.GreaterThan(october31))
+	assert.True(t, FirstCOLAShare(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)).IsZero(), "a December annuitant gets no first COLA")
+
+	// Later COLAs are paid in full
+	retirement := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	second := CalculatePensionForYear(employee, retirement, 2, inflation)
+	assert.True(t, second.Equal(CalculatePensionForYear(employee, retirement, 1, inflation).Mul(decimal.NewFromFloat(1.02))))
+}
+
 func TestCalculateFERSSpecialRetirementSupplement(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -148,7 +232,7 @@ func TestValidateFERSEligibility(t *testing.T) {
 			hireDate:       time.Date(1985, 3, 20, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
 			expectedValid:  true,
-			expectedReason: "Eligible for immediate annuity at age 62+",
+			expectedReason: "age 62 with 5+ years of service",
 		},
 		{
 			name:           "Not eligible - too young",
@@ -156,7 +240,7 @@ func TestValidateFERSEligibility(t *testing.T) {
 			hireDate:       time.Date(1985, 3, 20, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
 			expectedValid:  false,
-			expectedReason: "Employee has not reached Minimum Retirement Age",
+			expectedReason: "not eligible for a FERS annuity",
 		},
 		{
 			name:           "Not eligible - insufficient service",
@@ -164,7 +248,7 @@ func TestValidateFERSEligibility(t *testing.T) {
 			hireDate:       time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
 			expectedValid:  false,
-			expectedReason: "Employee has less than 5 years of service",
+			expectedReason: "not eligible for a FERS annuity at age 62 with 2.8 years",
 		},
 	}
 
@@ -333,7 +417,7 @@ func TestFERSEligibilityScenarios(t *testing.T) {
 			hireDate:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
 			expectedValid:  true,
-			expectedReason: "Eligible for immediate annuity at age 62+",
+			expectedReason: "age 62 with 5+ years of service",
 		},
 		{
 			name:           "Immediate: Age 60 with 20 years",
@@ -341,7 +425,7 @@ func TestFERSEligibilityScenarios(t *testing.T) {
 			hireDate:       time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 			expectedValid:  true,
-			expectedReason: "Eligible for immediate annuity at MRA with 10+ years",
+			expectedReason: "age 60 with 20+ years of service",
 		},
 		{
 			name:           "Immediate: MRA with 30 years",
@@ -349,7 +433,7 @@ func TestFERSEligibilityScenarios(t *testing.T) {
 			hireDate:       time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), // Age 58 - above MRA
 			expectedValid:  true,
-			expectedReason: "Eligible for immediate annuity at MRA with 10+ years",
+			expectedReason: "MRA with 30+ years of service",
 		},
 		{
 			name:           "Not eligible: Under MRA",
@@ -357,7 +441,7 @@ func TestFERSEligibilityScenarios(t *testing.T) {
 			hireDate:       time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
 			retirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), // Age 55
 			expectedValid:  false,
-			expectedReason: "Employee has not reached Minimum Retirement Age",
+			expectedReason: "not eligible for a FERS annuity",
 		},
 	}
 
@@ -374,3 +458,232 @@ func TestFERSEligibilityScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestMRAPlus10Reduction(t *testing.T) {
+	employee := &domain.Employee{
+		High3Salary: decimal.NewFromInt(100000),
+		BirthDate:   time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:    time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	separation := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC) // MRA 57 with 15 years of service
+
+	immediate := CalculateFERSPension(employee, separation)
+	assert.True(t, immediate.AgeReduction.Equal(decimal.NewFromFloat(0.25)), "expected 5%% per year under 62, got %s", immediate.AgeReduction)
+	assert.True(t, immediate.ReducedPension.Equal(immediate.AnnualPension.Mul(decimal.NewFromFloat(0.75))),
+		"expected 25%% reduction of %s, got %s", immediate.AnnualPension, immediate.ReducedPension)
+	assert.False(t, IsSRSEligible(employee, separation, separation), "MRA+10 retirees do not receive the SRS")
+
+	// Postponing the annuity to 60 shrinks the reduction; at 62 it disappears
+	at60 := CalculateDeferredFERSPension(employee, separation, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, at60.AgeReduction.Equal(decimal.NewFromFloat(0.10)), "got %s", at60.AgeReduction)
+	at62 := CalculateDeferredFERSPension(employee, separation, time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, at62.AgeReduction.IsZero())
+	assert.True(t, at62.ReducedPension.Equal(immediate.AnnualPension))
+}
+
+func TestSurvivorElectionReductionAndAnnuity(t *testing.T) {
+	employee := &domain.Employee{
+		High3Salary: decimal.NewFromInt(100000),
+		BirthDate:   time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:    time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	retirement := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		election          float64
+		expectedReduction float64
+	}{
+		{0, 0},
+		{0.5, 0.10},
+		{0.25, 0.05},
+	}
+	for _, tt := range tests {
+		employee.SurvivorBenefitElectionPercent = decimal.NewFromFloat(tt.election)
+		calc := CalculateFERSPension(employee, retirement)
+		reduction := decimal.NewFromFloat(tt.expectedReduction)
+		assert.True(t, calc.SurvivorReduction.Equal(reduction), "%v election: reduction %s", tt.election, calc.SurvivorReduction)
+		assert.True(t, calc.ReducedPension.Equal(calc.AnnualPension.Mul(decimal.NewFromInt(1).Sub(reduction))),
+			"%v election: retiree annuity %s of %s", tt.election, calc.ReducedPension, calc.AnnualPension)
+		assert.True(t, calc.SurvivorAnnuity.Equal(calc.AnnualPension.Mul(decimal.NewFromFloat(tt.election))),
+			"%v election: survivor annuity %s of %s", tt.election, calc.SurvivorAnnuity, calc.AnnualPension)
+	}
+
+	// The survivor annuity stays a share of the unreduced annuity when an MRA+10 age reduction applies
+	mraPlus10 := &domain.Employee{
+		High3Salary:                    decimal.NewFromInt(100000),
+		BirthDate:                      time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:                       time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+		SurvivorBenefitElectionPercent: decimal.NewFromFloat(0.5),
+	}
+	calc := CalculateFERSPension(mraPlus10, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, calc.SurvivorAnnuity.Equal(calc.AnnualPension.Mul(decimal.NewFromFloat(0.5))))
+	assert.True(t, calc.ReducedPension.Equal(calc.AnnualPension.Mul(decimal.NewFromFloat(0.75)).Mul(decimal.NewFromFloat(0.90))))
+
+	// Percentages that are not a FERS election are rejected by validation and never mapped to one
+	mraPlus10.SurvivorBenefitElectionPercent = decimal.NewFromFloat(0.3)
+	assert.True(t, CalculateFERSPension(mraPlus10, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)).SurvivorElection.IsZero())
+}
+
+func TestProjectionDeferredAnnuityStartsLater(t *testing.T) {
+	personA := domain.Employee{
+		Name:        "PersonA",
+		High3Salary: decimal.NewFromInt(100000),
+		BirthDate:   time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:    time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	start := time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := domain.Scenario{
+		Name:    "deferred",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), AnnuityStartDate: &start, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 9}
+
+	unreduced := CalculateDeferredFERSPension(&personA, scenario.PersonA.RetirementDate, start)
+	assert.True(t, unreduced.AgeReduction.IsZero())
+
+	projection := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, &scenario, &assumptions, domain.FederalRules{})
+	for _, cf := range projection {
+		if cf.Date.Year() < 2032 {
+			assert.True(t, cf.PensionPersonA.IsZero(), "expected no pension before the deferred start in %d, got %s", cf.Date.Year(), cf.PensionPersonA)
+			assert.True(t, cf.FERSSupplementPersonA.IsZero(), "deferred retirees do not receive the SRS")
+		} else {
+			assert.True(t, cf.PensionPersonA.Equal(unreduced.ReducedPension), "expected unreduced pension in %d, got %s", cf.Date.Year(), cf.PensionPersonA)
+		}
+	}
+}
+
+func TestMilitaryBuybackRaisesPension(t *testing.T) {
+	base := domain.Employee{
+		High3Salary:          decimal.NewFromInt(100000),
+		BirthDate:            time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:             time.Date(2007, 1, 1, 0, 0, 0, 0, time.UTC),
+		MilitaryServiceYears: decimal.NewFromInt(4),
+		SSBenefitFRA:         decimal.NewFromInt(2500),
+	}
+	retirementDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // age 62 with 18 civilian years
+
+	withoutDeposit := base
+	paid := base
+	paid.MilitaryDepositPaid = true
+
+	civilianOnly := CalculateFERSPension(&domain.Employee{High3Salary: base.High3Salary, BirthDate: base.BirthDate, HireDate: base.HireDate}, retirementDate)
+	bought := CalculateFERSPension(&paid, retirementDate)
+
+	// 18 years at 1.0% vs 22 years at the enhanced 1.1% multiplier
+	assert.True(t, civilianOnly.Multiplier.Equal(decimal.NewFromFloat(0.010)))
+	assert.True(t, bought.Multiplier.Equal(decimal.NewFromFloat(0.011)))
+	assert.True(t, bought.ServiceYears.Sub(civilianOnly.ServiceYears).Equal(decimal.NewFromInt(4)))
+	assert.True(t, bought.ReducedPension.Equal(bought.ServiceYears.Mul(decimal.NewFromInt(100000)).Mul(decimal.NewFromFloat(0.011))))
+
+	// Without the deposit the military years are dropped at 62 for an SS-eligible retiree
+	unpaid := CalculateFERSPension(&withoutDeposit, retirementDate)
+	assert.True(t, unpaid.Catch62Pension.LessThan(unpaid.ReducedPension))
+	// The 18 civilian years alone fall short of the 20 the 1.1% multiplier needs
+	assert.InDelta(t, 18000, unpaid.Catch62Pension.InexactFloat64(), 5, "expected about 18 years at 1.0%%")
+	assert.True(t, CalculatePensionForYear(&withoutDeposit, retirementDate, 0, decimal.Zero).Equal(unpaid.Catch62Pension))
+	assert.True(t, CalculatePensionForYear(&paid, retirementDate, 0, decimal.Zero).Equal(bought.ReducedPension))
+}
+
+func TestFERSSupplementStopsAt62(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate:   time.Date(1965, 7, 1, 0, 0, 0, 0, time.UTC), // turns 62 on 2027-07-01
+		HireDate:    time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+		SSBenefit62: decimal.NewFromInt(2000),
+	}
+	retirement := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // age 59 with 30 years
+	assert.True(t, IsSRSEligible(employee, retirement, retirement))
+
+	full := CalculateFERSSupplementYear(employee, retirement, 1, decimal.Zero)
+	assert.InDelta(t, 18000, full.InexactFloat64(), 5, "2026 should pay a full year (2000*12*30/40)")
+
+	// 2027: paid Jan 1 - Jun 30 only (181 of 365 days)
+	half := CalculateFERSSupplementYear(employee, retirement, 2, decimal.Zero)
+	assert.True(t, half.Div(full).Round(6).Equal(decimal.NewFromFloat(181.0/365.0).Round(6)), "expected a half-year supplement, got %s", half)
+	assert.True(t, CalculateFERSSupplementYear(employee, retirement, 3, decimal.Zero).IsZero(), "no SRS after turning 62")
+
+	// Mid-year retirement is prorated from the retirement date
+	midYear := time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC)
+	partial := CalculateFERSSupplementYear(employee, midYear, 0, decimal.Zero)
+	fullYear := CalculateFERSSupplementYear(employee, midYear, 1, decimal.Zero)
+	assert.True(t, partial.Div(fullYear).Round(6).Equal(decimal.NewFromFloat(183.0/365.0).Round(6)), "expected retirement-year proration, got %s", partial)
+}
+
+func TestFERSSupplementRequiresImmediateRetirement(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), // MRA 57
+		HireDate:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	beforeMRA := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, IsSRSEligible(employee, beforeMRA, beforeMRA), "separating at 55 is not an immediate retirement")
+
+	atMRA := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC) // 60 with 30 years
+	assert.True(t, IsSRSEligible(employee, atMRA, atMRA))
+	assert.False(t, IsSRSEligible(employee, atMRA, atMRA.AddDate(1, 0, 0)), "postponed annuities do not receive the SRS")
+}
+
+func TestSpecialCategoryRetirementIsUnreducedWithSupplement(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate:       time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:        time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary:     decimal.NewFromInt(100000),
+		SSBenefit62:     decimal.NewFromInt(2000),
+		SpecialCategory: true,
+	}
+	retirement := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // LEO at 50 with 20 years
+
+	eligible, reason := ValidateFERSEligibility(employee, retirement)
+	assert.True(t, eligible, reason)
+	assert.True(t, MRAPlus10Reduction(employee, retirement, retirement).IsZero(), "special-category retirements are not age-reduced")
+	assert.True(t, IsSRSEligible(employee, retirement, retirement), "special-category retirees receive the SRS")
+
+	// The same service without special-category coverage qualifies for nothing, so no reduction applies either
+	employee.SpecialCategory = false
+	eligible, _ = ValidateFERSEligibility(employee, retirement)
+	assert.False(t, eligible)
+	assert.False(t, IsSRSEligible(employee, retirement, retirement))
+}
+
+func TestDisabilityRetirementTransitions(t *testing.T) {
+	employee := &domain.Employee{
+		BirthDate:            time.Date(1970, 7, 1, 0, 0, 0, 0, time.UTC), // turns 62 on 2032-07-01
+		HireDate:             time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary:          decimal.NewFromInt(100000),
+		SSBenefit62:          decimal.NewFromInt(2000),
+		DisabilityRetirement: true,
+	}
+	retirement := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC) // age 55 with 20.5 years (earned annuity $20,500)
+	assert.False(t, IsSRSEligible(employee, retirement, retirement), "disability retirees do not receive the SRS")
+	eligible, _ := ValidateFERSEligibility(employee, retirement)
+	assert.True(t, eligible, "disability retirement has no age requirement")
+
+	// 60% for the first 12 months: all of 2025's payments and the first half of 2026
+	assert.True(t, CalculatePensionForYear(employee, retirement, 0, decimal.Zero).Equal(decimal.NewFromInt(60000)))
+	firstYearShare := decimal.NewFromInt(181).Div(decimal.NewFromInt(365))
+	blended := decimal.NewFromInt(60000).Mul(firstYearShare).Add(decimal.NewFromInt(40000).Mul(decimal.NewFromInt(1).Sub(firstYearShare)))
+	assert.True(t, CalculatePensionForYear(employee, retirement, 1, decimal.Zero).Equal(blended))
+
+	// 40% until 62
+	for year := 2; year <= 6; year++ {
+		assert.True(t, CalculatePensionForYear(employee, retirement, year, decimal.Zero).Equal(decimal.NewFromInt(40000)), "year %d", year)
+	}
+
+	// Recomputed at 62 with about 27.5 years (including the time on disability) at the 1.1% multiplier
+	recomputed := CalculatePensionForYear(employee, retirement, 8, decimal.Zero)
+	assert.InDelta(t, 30250, recomputed.InexactFloat64(), 5)
+	turning62 := CalculatePensionForYear(employee, retirement, 7, decimal.Zero)
+	before62 := decimal.NewFromInt(182).Div(decimal.NewFromInt(366))
+	assert.True(t, turning62.Equal(decimal.NewFromInt(40000).Mul(before62).Add(recomputed.Mul(decimal.NewFromInt(1).Sub(before62)))))
+
+	// COLAs are paid before 62 from the second year, the first prorated at 4/12 for an annuity on the rolls
+	// from August, and carried into the recomputed annuity
+	inflation := decimal.NewFromFloat(0.025)
+	firstCOLA := decimal.NewFromInt(1).Add(decimal.NewFromFloat(0.02).Mul(decimal.NewFromInt(4).Div(decimal.NewFromInt(12))))
+	assert.True(t, CalculatePensionForYear(employee, retirement, 2, inflation).Equal(decimal.NewFromInt(40000).Mul(firstCOLA).Mul(decimal.NewFromFloat(1.02))))
+	assert.True(t, CalculatePensionForYear(employee, retirement, 8, inflation).Equal(recomputed.Mul(firstCOLA).Mul(decimal.NewFromFloat(1.02).Pow(decimal.NewFromInt(7)))))
+}
@@ -0,0 +1,43 @@
+package calculation
+
+import (
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// retiredCoupleTestConfig is the shared fixture for projection tests: two federal employees born in 1963
+// and hired in 1990 who both retire at the start of 2025 and claim Social Security at 62. Only PersonA has
+// a TSP balance ($300,000 traditional), drawn with the 4% rule. Tests that project this household adjust
+// the returned copies to the case they cover; tests of other households, or that need no household at all,
+// build their own inputs rather than reshaping or extending this one.
+func retiredCoupleTestConfig(projectionYears int) (*domain.Configuration, *domain.Scenario) {
+	cfg := &domain.Configuration{
+		PersonalDetails: map[string]domain.Employee{
+			"person_a": {
+				Name:                  "PersonA",
+				BirthDate:             time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+				HireDate:              time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				High3Salary:           decimal.NewFromInt(100000),
+				SSBenefitFRA:          decimal.NewFromInt(3000),
+				TSPBalanceTraditional: decimal.NewFromInt(300000),
+			},
+			"person_b": {
+				Name:         "PersonB",
+				BirthDate:    time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+				HireDate:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+				High3Salary:  decimal.NewFromInt(80000),
+				SSBenefitFRA: decimal.NewFromInt(2000),
+			},
+		},
+		GlobalAssumptions: domain.GlobalAssumptions{ProjectionYears: projectionYears, COLAGeneralRate: decimal.NewFromFloat(0.02)},
+	}
+	retire := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := &domain.Scenario{
+		Name:    "retired-couple",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	return cfg, scenario
+}
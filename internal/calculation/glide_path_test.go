@@ -0,0 +1,65 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// tspShortfallYear returns the first projected year person_a's TSP can no longer pay the full annual
+// withdrawal, or 0 if it lasts
+func tspShortfallYear(t *testing.T, cfg *domain.Configuration, scenario *domain.Scenario, annualWithdrawal decimal.Decimal) int {
+	t.Helper()
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cf := range summary.Projection {
+		if cf.TSPWithdrawalPersonA.LessThan(annualWithdrawal) {
+			return cf.Date.Year()
+		}
+	}
+	return 0
+}
+
+func TestPostRetirementReturnAtAge_StepsDownWithGlidePath(t *testing.T) {
+	ga := domain.GlobalAssumptions{
+		TSPReturnPostRetirement: decimal.NewFromFloat(0.06),
+		TSPReturnGlidePath: map[int]decimal.Decimal{
+			70: decimal.NewFromFloat(0.045),
+			80: decimal.NewFromFloat(0.03),
+		},
+	}
+	for age, want := range map[int]float64{62: 0.06, 69: 0.06, 70: 0.045, 79: 0.045, 80: 0.03, 95: 0.03} {
+		if got := ga.PostRetirementReturnAtAge(age); !got.Equal(decimal.NewFromFloat(want)) {
+			t.Fatalf("age %d: expected return %v, got %s", age, want, got)
+		}
+	}
+}
+
+func TestProjection_GlidePathShortensTSPLongevity(t *testing.T) {
+	run := func(glidePath map[int]decimal.Decimal) int {
+		cfg, scenario := retiredCoupleTestConfig(30)
+		cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.02)
+		cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.06)
+		cfg.GlobalAssumptions.TSPReturnGlidePath = glidePath
+		target := decimal.NewFromInt(2500)
+		scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+		scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+		return tspShortfallYear(t, cfg, scenario, target.Mul(decimal.NewFromInt(12)))
+	}
+
+	flat := run(nil)
+	glide := run(map[int]decimal.Decimal{
+		65: decimal.NewFromFloat(0.04),
+		70: decimal.NewFromFloat(0.02),
+	})
+	if flat == 0 || glide == 0 {
+		t.Fatalf("expected the TSP to run short in both projections: flat %d, glide path %d", flat, glide)
+	}
+	if glide >= flat {
+		t.Fatalf("expected lower glide path returns to exhaust the TSP earlier: flat %d, glide path %d", flat, glide)
+	}
+}
@@ -0,0 +1,130 @@
+package calculation
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/config"
+	"github.com/shopspring/decimal"
+)
+
+// goldenProjectionCases pairs committed example configurations with their expected projections. Run with
+// UPDATE_GOLDEN=1 to rewrite the golden files after an intentional change to the numbers.
+var goldenProjectionCases = []struct {
+	name       string
+	configPath string
+}{
+	{"couple", filepath.Join("..", "..", "example_config.yaml")},
+	{"single_person", filepath.Join("testdata", "golden", "single_person.yaml")},
+}
+
+// TestGoldenProjections runs every scenario of each example configuration end to end and compares the
+// full year-by-year projections against committed golden files
+func TestGoldenProjections(t *testing.T) {
+	defer SetNowFunc(testClock)
+	SetNowFunc(func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) })
+	SetSeedFunc(func() int64 { return 12345 })
+	defer SetSeedFunc(func() int64 { return time.Now().UnixNano() })
+
+	update := os.Getenv("UPDATE_GOLDEN") == "1"
+	for _, tc := range goldenProjectionCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := config.NewInputParser().LoadFromFile(tc.configPath)
+			if err != nil {
+				t.Fatalf("load config: %v", err)
+			}
+			results, err := NewCalculationEngine().RunScenarios(cfg)
+			if err != nil {
+				t.Fatalf("run scenarios: %v", err)
+			}
+
+			data, err := goldenProjectionJSON(results.Scenarios)
+			if err != nil {
+				t.Fatalf("serialize projections: %v", err)
+			}
+
+			goldenPath := filepath.Join("testdata", "golden", tc.name+".golden.json")
+			if update {
+				if err := os.WriteFile(goldenPath, data, 0644); err != nil {
+					t.Fatalf("write golden: %v", err)
+				}
+			}
+			golden, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("read golden (run with UPDATE_GOLDEN=1 to create it): %v", err)
+			}
+			if !bytes.Equal(golden, data) {
+				t.Fatalf("projection drift in %s; run UPDATE_GOLDEN=1 to accept\n%s", goldenPath, firstDifference(golden, data))
+			}
+		})
+	}
+}
+
+// goldenProjectionJSON serializes each scenario's projection with every decimal rounded to cents, so the
+// golden files only change when a result changes by at least a cent
+func goldenProjectionJSON(scenarios interface{}) ([]byte, error) {
+	raw, err := json.Marshal(scenarios)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(roundGoldenValues(tree), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// roundGoldenValues rounds decimal strings (how decimal.Decimal marshals) and fractional numbers to two
+// places, leaving integers such as ages and years as they are
+func roundGoldenValues(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = roundGoldenValues(item)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = roundGoldenValues(item)
+		}
+	case string:
+		if d, err := decimal.NewFromString(val); err == nil {
+			return d.StringFixed(2)
+		}
+	case json.Number:
+		if _, err := val.Int64(); err != nil {
+			if d, err := decimal.NewFromString(val.String()); err == nil {
+				return json.Number(d.StringFixed(2))
+			}
+		}
+	}
+	return v
+}
+
+// firstDifference describes the first line where the golden and current output diverge
+func firstDifference(want, have []byte) string {
+	wantLines, haveLines := bytes.Split(want, []byte("\n")), bytes.Split(have, []byte("\n"))
+	for i := 0; i < len(wantLines) || i < len(haveLines); i++ {
+		var w, h []byte
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(haveLines) {
+			h = haveLines[i]
+		}
+		if !bytes.Equal(w, h) {
+			return "line " + strconv.Itoa(i+1) + ":\n--- want ---\n" + string(w) + "\n--- have ---\n" + string(h)
+		}
+	}
+	return "outputs differ"
+}
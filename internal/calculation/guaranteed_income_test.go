@@ -0,0 +1,42 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestGuaranteedAndAtRiskIncomeSplit(t *testing.T) {
+	cf := domain.AnnualCashFlow{
+		PensionPersonA:       decimal.NewFromInt(40000),
+		SSBenefitPersonB:     decimal.NewFromInt(20000),
+		TSPWithdrawalPersonA: decimal.NewFromInt(15000),
+		SalaryPersonB:        decimal.NewFromInt(5000),
+	}
+	if got := cf.CalculateGuaranteedIncome(); !got.Equal(decimal.NewFromInt(60000)) {
+		t.Fatalf("expected pension and Social Security to be guaranteed (60000), got %s", got)
+	}
+	if got := cf.CalculateAtRiskIncome(); !got.Equal(decimal.NewFromInt(15000)) {
+		t.Fatalf("expected the TSP withdrawal to be at risk (15000), got %s", got)
+	}
+
+	cfg, scenario := retiredCoupleTestConfig(6)
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("run scenario: %v", err)
+	}
+	minimum := decimal.Zero
+	for i, year := range summary.Projection {
+		if !year.GuaranteedIncome.Add(year.AtRiskIncome).Equal(year.TotalGrossIncome) {
+			t.Fatalf("year %d: guaranteed %s plus at-risk %s should equal gross %s for retirees without wages", i, year.GuaranteedIncome, year.AtRiskIncome, year.TotalGrossIncome)
+		}
+		if i == 0 || year.GuaranteedIncome.LessThan(minimum) {
+			minimum = year.GuaranteedIncome
+		}
+	}
+	if !summary.MinGuaranteedIncome.Equal(minimum) || !minimum.IsPositive() {
+		t.Fatalf("expected minimum guaranteed income %s, got %s", minimum, summary.MinGuaranteedIncome)
+	}
+}
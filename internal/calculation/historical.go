@@ -307,7 +307,7 @@ func (hdm *HistoricalDataManager) calculateStatistics(values []decimal.Decimal,
 // GetTSPReturn returns the historical return for a specific TSP fund and year
 func (hdm *HistoricalDataManager) GetTSPReturn(fundName string, year int) (decimal.Decimal, error) {
 	if !hdm.IsLoaded {
-		return decimal.Zero, fmt.Errorf("historical data not loaded")
+		return decimal.Zero, ErrHistoricalDataNotLoaded
 	}
 
 	var dataset *HistoricalDataSet
@@ -372,7 +372,7 @@ func (hdm *HistoricalDataManager) GetCOLARate(year int) (decimal.Decimal, error)
 // GetRandomHistoricalYear returns a random year from the available historical data
 func (hdm *HistoricalDataManager) GetRandomHistoricalYear() (int, error) {
 	if !hdm.IsLoaded || hdm.TSPFunds.CFund == nil {
-		return 0, fmt.Errorf("historical data not loaded")
+		return 0, ErrHistoricalDataNotLoaded
 	}
 
 	// Use C Fund data as reference for available years
@@ -389,7 +389,7 @@ func (hdm *HistoricalDataManager) GetRandomHistoricalYear() (int, error) {
 // GetAvailableYears returns the range of available years for historical data
 func (hdm *HistoricalDataManager) GetAvailableYears() (int, int, error) {
 	if !hdm.IsLoaded || hdm.TSPFunds.CFund == nil {
-		return 0, 0, fmt.Errorf("historical data not loaded")
+		return 0, 0, ErrHistoricalDataNotLoaded
 	}
 
 	return hdm.TSPFunds.CFund.MinYear, hdm.TSPFunds.CFund.MaxYear, nil
@@ -398,7 +398,7 @@ func (hdm *HistoricalDataManager) GetAvailableYears() (int, int, error) {
 // ValidateDataQuality performs quality checks on the loaded data
 func (hdm *HistoricalDataManager) ValidateDataQuality() ([]string, error) {
 	if !hdm.IsLoaded {
-		return nil, fmt.Errorf("historical data not loaded")
+		return nil, ErrHistoricalDataNotLoaded
 	}
 
 	var issues []string
@@ -0,0 +1,71 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// 2025 HSA contribution limits for family HDHP coverage
+var (
+	hsaFamilyLimit2025  = decimal.NewFromInt(8550)
+	hsaCatchUpAmount    = decimal.NewFromInt(1000)
+	hsaCatchUpStartAge  = 55
+	hsaMedicareStopsAge = 65
+)
+
+// HSAContributionLimit returns the annual HSA contribution limit for an account holder of the given age.
+// Contributions are not allowed once Medicare starts at 65.
+func HSAContributionLimit(age int) decimal.Decimal {
+	if age >= hsaMedicareStopsAge {
+		return decimal.Zero
+	}
+	return hsaFamilyLimit2025.Add(hsaCatchUp(age))
+}
+
+// hsaCatchUp returns the catch-up an account holder of the given age may add to their own HSA
+func hsaCatchUp(age int) decimal.Decimal {
+	if age >= hsaCatchUpStartAge && age < hsaMedicareStopsAge {
+		return hsaCatchUpAmount
+	}
+	return decimal.Zero
+}
+
+// CalculateHSAContribution caps the requested annual contribution at the limit and prorates it for the
+// portion of the year worked. Payroll contributions stop at retirement.
+func CalculateHSAContribution(requested decimal.Decimal, age int, workFraction decimal.Decimal) decimal.Decimal {
+	return decimal.Min(requested, HSAContributionLimit(age)).Mul(workFraction)
+}
+
+// CalculateHouseholdHSAContributions caps both spouses' contributions as CalculateHSAContribution does,
+// then holds them to one family limit between them: spouses on family HDHP coverage share the family
+// limit, split in proportion to what each contributes beyond their own catch-up. The catch-up belongs to
+// each account holder 55 or older and goes only to their own HSA, so two eligible spouses add two.
+func CalculateHouseholdHSAContributions(requestedA, requestedB decimal.Decimal, ageA, ageB int, workFractionA, workFractionB decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	split := func(requested decimal.Decimal, age int, workFraction decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+		total := decimal.Max(CalculateHSAContribution(requested, age, workFraction), decimal.Zero)
+		catchUp := decimal.Min(total, hsaCatchUp(age).Mul(workFraction))
+		return total.Sub(catchUp), catchUp
+	}
+	baseA, catchUpA := split(requestedA, ageA, workFractionA)
+	baseB, catchUpB := split(requestedB, ageB, workFractionB)
+	if combined := baseA.Add(baseB); combined.GreaterThan(hsaFamilyLimit2025) {
+		baseA = hsaFamilyLimit2025.Mul(baseA).Div(combined)
+		baseB = hsaFamilyLimit2025.Sub(baseA)
+	}
+	return baseA.Add(catchUpA), baseB.Add(catchUpB)
+}
+
+// hsaReturnOrDefault returns the configured HSA return, including an explicit 0%, falling back to the
+// post-retirement TSP return when unset
+func hsaReturnOrDefault(assumptions *domain.GlobalAssumptions) decimal.Decimal {
+	if assumptions.HSAReturn == nil {
+		return assumptions.TSPReturnPostRetirement
+	}
+	return *assumptions.HSAReturn
+}
+
+// drawHSA takes up to amount from balance, returning the remaining balance and the amount drawn
+func drawHSA(balance, amount decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	drawn := decimal.Min(decimal.Max(balance, decimal.Zero), decimal.Max(amount, decimal.Zero))
+	return balance.Sub(drawn), drawn
+}
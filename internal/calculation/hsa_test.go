@@ -0,0 +1,94 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateHSAContribution_Limits(t *testing.T) {
+	tests := []struct {
+		name         string
+		requested    decimal.Decimal
+		age          int
+		workFraction decimal.Decimal
+		expected     decimal.Decimal
+	}{
+		{"under limit", decimal.NewFromInt(5000), 45, decimal.NewFromInt(1), decimal.NewFromInt(5000)},
+		{"capped at family limit", decimal.NewFromInt(20000), 45, decimal.NewFromInt(1), decimal.NewFromInt(8550)},
+		{"catch-up at 55", decimal.NewFromInt(20000), 55, decimal.NewFromInt(1), decimal.NewFromInt(9550)},
+		{"prorated retirement year", decimal.NewFromInt(8000), 60, decimal.NewFromFloat(0.5), decimal.NewFromInt(4000)},
+		{"none once on Medicare", decimal.NewFromInt(8000), 65, decimal.NewFromInt(1), decimal.Zero},
+	}
+	for _, tt := range tests {
+		if got := CalculateHSAContribution(tt.requested, tt.age, tt.workFraction); !got.Equal(tt.expected) {
+			t.Fatalf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestCalculateHouseholdHSAContributions_SharedFamilyLimit(t *testing.T) {
+	one := decimal.NewFromInt(1)
+	tests := []struct {
+		name                   string
+		requestedA, requestedB decimal.Decimal
+		ageA, ageB             int
+		expectedA, expectedB   decimal.Decimal
+	}{
+		{"under the family limit", decimal.NewFromInt(3000), decimal.NewFromInt(4000), 45, 45, decimal.NewFromInt(3000), decimal.NewFromInt(4000)},
+		{"family limit shared", decimal.NewFromInt(20000), decimal.NewFromInt(20000), 45, 45, decimal.NewFromInt(4275), decimal.NewFromInt(4275)},
+		{"one catch-up", decimal.NewFromInt(20000), decimal.NewFromInt(20000), 56, 50, decimal.NewFromInt(5275), decimal.NewFromInt(4275)},
+		{"a catch-up for each spouse 55 or older", decimal.NewFromInt(20000), decimal.NewFromInt(20000), 56, 58, decimal.NewFromInt(5275), decimal.NewFromInt(5275)},
+	}
+	for _, tt := range tests {
+		a, b := CalculateHouseholdHSAContributions(tt.requestedA, tt.requestedB, tt.ageA, tt.ageB, one, one)
+		if !a.Equal(tt.expectedA) || !b.Equal(tt.expectedB) {
+			t.Fatalf("%s: expected %s/%s, got %s/%s", tt.name, tt.expectedA, tt.expectedB, a, b)
+		}
+	}
+}
+
+func TestHSAReturnHonorsExplicitZero(t *testing.T) {
+	assumptions := domain.GlobalAssumptions{TSPReturnPostRetirement: decimal.NewFromFloat(0.045)}
+	if got := hsaReturnOrDefault(&assumptions); !got.Equal(assumptions.TSPReturnPostRetirement) {
+		t.Fatalf("expected an unset HSA return to follow the post-retirement TSP return, got %s", got)
+	}
+	zero := decimal.Zero
+	assumptions.HSAReturn = &zero
+	if got := hsaReturnOrDefault(&assumptions); !got.IsZero() {
+		t.Fatalf("expected an explicit 0%% HSA return to be kept, got %s", got)
+	}
+}
+
+func TestHSAPaysPremiumsAndReducesTSPWithdrawals(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.FEHBPremiumPerPayPeriod = decimal.NewFromInt(300)
+	cfg.GlobalAssumptions.FederalRules.FEHBConfig.PayPeriodsPerYear = 26
+	target := decimal.NewFromInt(2000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+	ce := NewCalculationEngine()
+
+	run := func(hsaBalance decimal.Decimal) []decimal.Decimal {
+		a, b := personA, personB
+		a.HSABalance = hsaBalance
+		projection := ce.GenerateAnnualProjection(&a, &b, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+		cf := projection[0]
+		return []decimal.Decimal{cf.TSPWithdrawalPersonA, cf.HSAWithdrawal, cf.FEHBPremium, cf.FederalTaxableIncome}
+	}
+
+	without := run(decimal.Zero)
+	with := run(decimal.NewFromInt(50000))
+
+	if !with[1].Equal(with[2]) {
+		t.Fatalf("expected the HSA to pay the full FEHB premium %s, drew %s", with[2], with[1])
+	}
+	if !without[0].Sub(with[0]).Equal(with[1]) {
+		t.Fatalf("expected TSP withdrawal to drop by the HSA draw: without=%s with=%s draw=%s", without[0], with[0], with[1])
+	}
+	if !with[3].LessThan(without[3]) {
+		t.Fatalf("expected lower federal taxable income when the HSA pays premiums: with=%s without=%s", with[3], without[3])
+	}
+}
@@ -168,6 +168,11 @@ func TestErrorConditions(t *testing.T) {
 		assert.Error(t, err, "Should error on invalid retirement date")
 		assert.Nil(t, result, "Should not return result on error")
 		assert.Contains(t, err.Error(), "cannot be before hire date", "Error should mention hire date")
+		assert.ErrorIs(t, err, ErrRetirementBeforeHire)
+		var hireErr *RetirementBeforeHireError
+		if assert.ErrorAs(t, err, &hireErr) {
+			assert.Equal(t, "person_a", hireErr.Person)
+		}
 	})
 
 	t.Run("Invalid inflation rate", func(t *testing.T) {
@@ -188,6 +193,7 @@ func TestErrorConditions(t *testing.T) {
 		assert.Error(t, err, "Should error on unrealistic inflation rate")
 		assert.Nil(t, result, "Should not return result on error")
 		assert.Contains(t, err.Error(), "inflation rate must be between -10% and 20%", "Error should mention inflation rate bounds")
+		assert.ErrorIs(t, err, ErrInflationOutOfRange)
 	})
 
 	t.Run("Historical deflation rate", func(t *testing.T) {
@@ -239,6 +245,9 @@ func TestRealWorldDataValidation(t *testing.T) {
 	currentNetIncome := engine.NetIncomeCalc.Calculate(
 		&personA,
 		&personB,
+		config.GlobalAssumptions.FederalRules.FEHBConfig,
+		engine.TaxCalc.LocalTaxCalculatorFor(config.GlobalAssumptions.CurrentLocation.Municipality),
+		ProjectionStartYear(&config.GlobalAssumptions),
 		engine.Debug,
 	)
 
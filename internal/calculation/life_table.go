@@ -0,0 +1,51 @@
+package calculation
+
+import (
+	"math"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/rpgo/retirement-calculator/pkg/lifetable"
+)
+
+// maxLifeTableAge is the age by which death is certain
+const maxLifeTableAge = lifetable.MaxAge
+
+// MortalityRate returns the one-year probability of death at the given age from the unisex life table
+func MortalityRate(age int) float64 {
+	return lifetable.MortalityRate(age, "")
+}
+
+// drawDeathAge samples the age at death for someone currently aged currentAge from the life table for their
+// sex. multiplier scales every q(x) (1 = table mortality) and uniform supplies draws in [0, 1).
+func drawDeathAge(currentAge int, sex string, multiplier float64, uniform func() float64) int {
+	for age := currentAge; age < maxLifeTableAge; age++ {
+		if uniform() < math.Min(1, lifetable.MortalityRate(age, sex)*multiplier) {
+			return age
+		}
+	}
+	return maxLifeTableAge
+}
+
+// LifeExpectancySummary is each person's and the couple's expected remaining years of life at the start
+// of the projection, from the life table for each person's sex
+type LifeExpectancySummary struct {
+	AgePersonA int     `json:"age_person_a"`
+	AgePersonB int     `json:"age_person_b"`
+	PersonA    float64 `json:"person_a"`
+	PersonB    float64 `json:"person_b"`
+	Joint      float64 `json:"joint"` // Expected years until the second death
+}
+
+// LifeExpectancies returns the household's single and joint life expectancies at the projection start
+func LifeExpectancies(config *domain.Configuration) LifeExpectancySummary {
+	personA, personB := config.PersonalDetails["person_a"], config.PersonalDetails["person_b"]
+	startYear := ProjectionStartYear(&config.GlobalAssumptions)
+	ageA, ageB := startYear-personA.BirthDate.Year(), startYear-personB.BirthDate.Year()
+	return LifeExpectancySummary{
+		AgePersonA: ageA,
+		AgePersonB: ageB,
+		PersonA:    lifetable.LifeExpectancy(ageA, personA.Sex),
+		PersonB:    lifetable.LifeExpectancy(ageB, personB.Sex),
+		Joint:      lifetable.JointLifeExpectancy(ageA, personA.Sex, ageB, personB.Sex),
+	}
+}
@@ -0,0 +1,18 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// LifetimeTaxes sums a projection's nominal federal income tax, state income tax, and all income tax
+// including local. Scenario summaries also report the total's present value, which is what comparisons
+// between scenarios that pay tax at different times should use.
+func LifetimeTaxes(projection []domain.AnnualCashFlow) (federal, state, total decimal.Decimal) {
+	for _, cf := range projection {
+		federal = federal.Add(cf.FederalTax)
+		state = state.Add(cf.StateTax)
+		total = total.Add(cf.IncomeTax())
+	}
+	return federal, state, total
+}
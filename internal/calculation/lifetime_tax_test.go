@@ -0,0 +1,74 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// rothConversionTestSummary runs a household drawing a large traditional TSP with tax_smart withdrawals
+// and delaying Social Security to 70, optionally converting to Roth in the years before SS starts
+func rothConversionTestSummary(t *testing.T, name string, conversions map[int]decimal.Decimal) *domain.ScenarioSummary {
+	t.Helper()
+	cfg, scenario := retiredCoupleTestConfig(30)
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.05)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.025)
+	personA := cfg.PersonalDetails["person_a"]
+	personA.TSPBalanceTraditional = decimal.NewFromInt(800000)
+	cfg.PersonalDetails["person_a"] = personA
+	scenario.Name = name
+	scenario.PersonA.SSStartAge, scenario.PersonB.SSStartAge = 70, 70
+	scenario.PersonA.TSPWithdrawalStrategy = "tax_smart"
+	scenario.PersonA.RothConversions = conversions
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return summary
+}
+
+func TestLifetimeTax_RothConversionLowersLifetimeTax(t *testing.T) {
+	baseline := rothConversionTestSummary(t, "no-conversion", nil)
+	conversions := map[int]decimal.Decimal{}
+	for year := 2025; year <= 2029; year++ {
+		conversions[year] = decimal.NewFromInt(60000)
+	}
+	converted := rothConversionTestSummary(t, "roth-conversion", conversions)
+
+	for _, s := range []*domain.ScenarioSummary{baseline, converted} {
+		federal, state, total := LifetimeTaxes(s.Projection)
+		if !s.TotalLifetimeFederalTax.Equal(federal) || !s.TotalLifetimeStateTax.Equal(state) || !s.TotalLifetimeTax.Equal(total) {
+			t.Fatalf("%s: summary lifetime taxes do not match the projection", s.Name)
+		}
+		if !s.TotalLifetimeTax.GreaterThanOrEqual(s.TotalLifetimeFederalTax) {
+			t.Fatalf("%s: total lifetime tax %s below federal %s", s.Name, s.TotalLifetimeTax, s.TotalLifetimeFederalTax)
+		}
+		// The present value discounts each year's tax at the default 3%
+		pv := decimal.Zero
+		for i, cf := range s.Projection {
+			pv = pv.Add(cf.IncomeTax().Mul(DiscountFactor(defaultDiscountRate, i)))
+		}
+		if !s.TotalLifetimeTaxPV.Equal(pv) || !pv.LessThan(total) {
+			t.Fatalf("%s: expected lifetime tax present value %s below the nominal %s, got %s", s.Name, pv.StringFixed(2), total.StringFixed(2), s.TotalLifetimeTaxPV.StringFixed(2))
+		}
+	}
+
+	first := converted.Projection[0]
+	if !first.RothConversion.Equal(decimal.NewFromInt(60000)) || !first.TSPBalanceRoth.IsPositive() {
+		t.Fatalf("expected a 60000 conversion into Roth in the first year, got %s (Roth balance %s)", first.RothConversion, first.TSPBalanceRoth)
+	}
+	if !first.FederalTax.GreaterThan(baseline.Projection[0].FederalTax) {
+		t.Fatalf("expected the conversion to be taxed in the year it is made")
+	}
+	if !converted.TotalLifetimeTax.LessThan(baseline.TotalLifetimeTax) {
+		t.Fatalf("expected Roth conversions to lower lifetime tax: conversion %s, baseline %s", converted.TotalLifetimeTax.StringFixed(0), baseline.TotalLifetimeTax.StringFixed(0))
+	}
+
+	analysis := NewCalculationEngine().generateLongTermAnalysis([]domain.ScenarioSummary{*baseline, *converted})
+	if analysis.BestScenarioForTaxes != "roth-conversion" {
+		t.Fatalf("expected the Roth conversion scenario to have the lowest lifetime tax, got %q", analysis.BestScenarioForTaxes)
+	}
+}
@@ -0,0 +1,22 @@
+package calculation
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// testProjectionStartYear is the year the test fixtures were written for
+const testProjectionStartYear = 2025
+
+// TestMain pins the clock so projections that leave projection_start_year unset start in
+// testProjectionStartYear whatever the real date is
+func TestMain(m *testing.M) {
+	SetNowFunc(testClock)
+	os.Exit(m.Run())
+}
+
+// testClock returns the start of testProjectionStartYear
+func testClock() time.Time {
+	return time.Date(testProjectionStartYear, 1, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,83 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestGenerateMarketConditionSeries(t *testing.T) {
+	cfg := &domain.Configuration{}
+	engine := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{BaseConfig: cfg, UseHistorical: false}}
+
+	series := engine.generateMarketConditionSeries(10)
+	if len(series.Years) != 10 {
+		t.Fatalf("expected 10 years, got %d", len(series.Years))
+	}
+	distinct := false
+	for i := 1; i < len(series.Years); i++ {
+		if !series.Years[i].TSPReturns["C"].Equal(series.Years[0].TSPReturns["C"]) {
+			distinct = true
+		}
+	}
+	if !distinct {
+		t.Fatalf("expected independent draws per year, got identical C fund returns")
+	}
+	if got := len(series.FundReturns()); got != 10 {
+		t.Fatalf("expected 10 fund return maps, got %d", got)
+	}
+
+	if got := len(engine.generateMarketConditionSeries(0).Years); got != 1 {
+		t.Fatalf("expected at least one year for empty horizon, got %d", got)
+	}
+}
+
+// Test that the order of annual returns matters once per-year returns are supplied to the engine
+func TestPerYearReturnsCaptureSequenceRisk(t *testing.T) {
+	personA := domain.Employee{
+		Name:                  "PersonA",
+		BirthDate:             time.Date(1963, 6, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:              time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		TSPBalanceTraditional: decimal.NewFromInt(1000000),
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1963, 6, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := domain.Scenario{
+		Name:    "sequence",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: start, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: start, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 10, TSPReturnPostRetirement: decimal.NewFromFloat(0.05)}
+
+	poor := decimal.NewFromFloat(-0.15)
+	good := decimal.NewFromFloat(0.15)
+	frontLoaded := make([]decimal.Decimal, 10)
+	backLoaded := make([]decimal.Decimal, 10)
+	for i := 0; i < 10; i++ {
+		if i < 5 {
+			frontLoaded[i], backLoaded[i] = poor, good
+		} else {
+			frontLoaded[i], backLoaded[i] = good, poor
+		}
+	}
+
+	run := func(returns []decimal.Decimal) decimal.Decimal {
+		ce := NewCalculationEngine()
+		ce.MonteCarloPortfolioReturnsByYear = returns
+		a, b := personA, personB
+		proj := ce.GenerateAnnualProjection(&a, &b, &scenario, &assumptions, assumptions.FederalRules)
+		return proj[len(proj)-1].TSPBalancePersonA
+	}
+
+	front := run(frontLoaded)
+	back := run(backLoaded)
+	if !front.LessThan(back.Mul(decimal.NewFromFloat(0.9))) {
+		t.Fatalf("expected front-loaded losses to end materially lower: front=%s back=%s", front.StringFixed(2), back.StringFixed(2))
+	}
+}
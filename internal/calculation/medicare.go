@@ -7,10 +7,12 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// MedicareCalculator handles Medicare Part B premium calculations including IRMAA
+// MedicareCalculator handles Medicare Part B and Part D premium calculations including IRMAA
 type MedicareCalculator struct {
-	BasePremium2025 decimal.Decimal
-	IRMAAThresholds []IRMAAThreshold
+	BasePremium2025      decimal.Decimal
+	IRMAAThresholds      []IRMAAThreshold
+	PartDBasePremium2025 decimal.Decimal
+	PartDIRMAAThresholds []IRMAAThreshold
 }
 
 // IRMAAThreshold represents an IRMAA income threshold and corresponding surcharge
@@ -52,25 +54,32 @@ func NewMedicareCalculator() *MedicareCalculator {
 				MonthlySurcharge:      decimal.NewFromFloat(489.10),
 			},
 		},
+		// Part D is optional: as with a MedicareConfig that leaves it unset, neither a base premium nor
+		// IRMAA-D is included unless configured
 	}
 }
 
 // NewMedicareCalculatorWithConfig creates a new Medicare calculator with configurable values
 func NewMedicareCalculatorWithConfig(config domain.MedicareConfig) *MedicareCalculator {
-	// Convert domain.MedicareIRMAAThreshold to calculation.IRMAAThreshold
+	return &MedicareCalculator{
+		BasePremium2025:      config.BasePremium2025,
+		IRMAAThresholds:      convertIRMAAThresholds(config.IRMAAThresholds),
+		PartDBasePremium2025: config.PartDBasePremium2025,
+		PartDIRMAAThresholds: convertIRMAAThresholds(config.PartDIRMAAThresholds),
+	}
+}
+
+// convertIRMAAThresholds converts domain.MedicareIRMAAThreshold to calculation.IRMAAThreshold
+func convertIRMAAThresholds(configThresholds []domain.MedicareIRMAAThreshold) []IRMAAThreshold {
 	var thresholds []IRMAAThreshold
-	for _, threshold := range config.IRMAAThresholds {
+	for _, threshold := range configThresholds {
 		thresholds = append(thresholds, IRMAAThreshold{
 			IncomeThresholdSingle: threshold.IncomeThresholdSingle,
 			IncomeThresholdJoint:  threshold.IncomeThresholdJoint,
 			MonthlySurcharge:      threshold.MonthlySurcharge,
 		})
 	}
-
-	return &MedicareCalculator{
-		BasePremium2025: config.BasePremium2025,
-		IRMAAThresholds: thresholds,
-	}
+	return thresholds
 }
 
 // CalculatePartBPremium calculates Medicare Part B premium including IRMAA surcharge
@@ -103,7 +112,7 @@ func (mc *MedicareCalculator) CalculateAnnualPartBCost(estimatedMAGI decimal.Dec
 	basePremium := mc.BasePremium2025
 
 	// Calculate IRMAA surcharge based on MAGI
-	irmaaSurcharge := mc.calculateIRMAASurcharge(estimatedMAGI, isMarriedFilingJointly)
+	irmaaSurcharge := calculateIRMAASurcharge(mc.IRMAAThresholds, estimatedMAGI, isMarriedFilingJointly)
 
 	// Apply IRMAA surcharge
 	totalMonthlyPremium := basePremium.Add(irmaaSurcharge)
@@ -114,12 +123,19 @@ func (mc *MedicareCalculator) CalculateAnnualPartBCost(estimatedMAGI decimal.Dec
 	return annualCost
 }
 
+// CalculateAnnualPartDCost calculates annual Medicare Part D cost: the base premium plus IRMAA-D, which is
+// based on the same lookback MAGI as Part B
+func (mc *MedicareCalculator) CalculateAnnualPartDCost(estimatedMAGI decimal.Decimal, isMarriedFilingJointly bool) decimal.Decimal {
+	irmaaSurcharge := calculateIRMAASurcharge(mc.PartDIRMAAThresholds, estimatedMAGI, isMarriedFilingJointly)
+	return mc.PartDBasePremium2025.Add(irmaaSurcharge).Mul(decimal.NewFromInt(12))
+}
+
 // calculateIRMAASurcharge calculates IRMAA surcharge based on MAGI
-func (mc *MedicareCalculator) calculateIRMAASurcharge(estimatedMAGI decimal.Decimal, isMarriedFilingJointly bool) decimal.Decimal {
+func calculateIRMAASurcharge(thresholds []IRMAAThreshold, estimatedMAGI decimal.Decimal, isMarriedFilingJointly bool) decimal.Decimal {
 	var totalSurcharge decimal.Decimal
 
 	// Apply IRMAA thresholds cumulatively based on filing status
-	for _, threshold := range mc.IRMAAThresholds {
+	for _, threshold := range thresholds {
 		var incomeThreshold decimal.Decimal
 		if isMarriedFilingJointly {
 			incomeThreshold = threshold.IncomeThresholdJoint
@@ -171,34 +187,44 @@ func IsMedicareEligible(birthDate, atDate time.Time) bool {
 	return age >= 65
 }
 
-// calculateMedicarePremium calculates Medicare Part B premiums with IRMAA considerations
-// based on current year income (simplified - real IRMAA uses 2-year-old MAGI)
-func (ce *CalculationEngine) calculateMedicarePremium(personA, personB *domain.Employee, projectionDate time.Time,
-	pensionPersonA, pensionPersonB, tspWithdrawalPersonA, tspWithdrawalPersonB, ssPersonA, ssPersonB decimal.Decimal) decimal.Decimal {
-	var totalPremium decimal.Decimal
-
-	// Estimate MAGI for IRMAA calculation (simplified)
-	// In reality, IRMAA uses MAGI from 2 years prior
-	totalPensionIncome := pensionPersonA.Add(pensionPersonB)
-	totalTSPWithdrawals := tspWithdrawalPersonA.Add(tspWithdrawalPersonB)
+// isIRMAALifeChangingEventYear reports whether year (0-based) is one of either spouse's first two retirement
+// years, when a work-stoppage life-changing event lets IRMAA use the current year's MAGI
+func isIRMAALifeChangingEventYear(year, personARetirementYear, personBRetirementYear int) bool {
+	for _, retirementYear := range []int{personARetirementYear, personBRetirementYear} {
+		if year >= retirementYear && year <= retirementYear+1 {
+			return true
+		}
+	}
+	return false
+}
 
+// estimateHouseholdMAGI estimates a year's combined MAGI from wages, retirement income and other taxable income
+func (ce *CalculationEngine) estimateHouseholdMAGI(wages, pensionIncome, tspWithdrawals, ssBenefits, otherIncome decimal.Decimal) decimal.Decimal {
 	// Calculate taxable portion of Social Security (simplified)
-	totalSSBenefits := ssPersonA.Add(ssPersonB)
-	otherIncome := totalPensionIncome.Add(totalTSPWithdrawals)
-	taxableSSBenefits := ce.TaxCalc.CalculateSocialSecurityTaxation(totalSSBenefits, otherIncome)
+	nonSSIncome := wages.Add(pensionIncome).Add(tspWithdrawals).Add(otherIncome)
+	taxableSSBenefits := ce.TaxCalc.CalculateSocialSecurityTaxation(ssBenefits, nonSSIncome)
+
+	return EstimateMAGI(pensionIncome, tspWithdrawals, taxableSSBenefits, wages.Add(otherIncome))
+}
 
-	// Estimate combined MAGI
-	estimatedMAGI := EstimateMAGI(totalPensionIncome, totalTSPWithdrawals, taxableSSBenefits, decimal.Zero)
+// calculateMedicarePremium calculates Medicare Part B and Part D premiums with IRMAA considerations.
+// The caller supplies the MAGI used for IRMAA, which should be the household MAGI from two years prior.
+// The Part B hold-harmless rule is not applied: it only limits a year's increase in the standard premium
+// to the Social Security COLA, and the base premium here stays at its 2025 level, so it never binds.
+func (ce *CalculationEngine) calculateMedicarePremium(personA, personB *domain.Employee, projectionDate time.Time, irmaaMAGI decimal.Decimal) decimal.Decimal {
+	var totalPremium decimal.Decimal
 
 	// Check if PersonA is Medicare eligible
 	if IsMedicareEligible(personA.BirthDate, projectionDate) {
-		personAPremium := ce.MedicareCalc.CalculateAnnualPartBCost(estimatedMAGI, true) // Married filing jointly
+		personAPremium := ce.MedicareCalc.CalculateAnnualPartBCost(irmaaMAGI, true) // Married filing jointly
+		personAPremium = personAPremium.Add(ce.MedicareCalc.CalculateAnnualPartDCost(irmaaMAGI, true))
 		totalPremium = totalPremium.Add(personAPremium)
 	}
 
 	// Check if PersonB is Medicare eligible
 	if IsMedicareEligible(personB.BirthDate, projectionDate) {
-		personBPremium := ce.MedicareCalc.CalculateAnnualPartBCost(estimatedMAGI, true) // Married filing jointly
+		personBPremium := ce.MedicareCalc.CalculateAnnualPartBCost(irmaaMAGI, true) // Married filing jointly
+		personBPremium = personBPremium.Add(ce.MedicareCalc.CalculateAnnualPartDCost(irmaaMAGI, true))
 		totalPremium = totalPremium.Add(personBPremium)
 	}
 
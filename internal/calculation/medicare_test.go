@@ -2,7 +2,9 @@ package calculation
 
 import (
 	"testing"
+	"time"
 
+	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
 )
 
@@ -203,3 +205,130 @@ func TestMedicareRealWorldScenario(t *testing.T) {
 			monthlyPremiumPerPerson.Sub(decimal.NewFromFloat(185.00)).StringFixed(2))
 	}
 }
+
+func TestMedicareCoordinationChangesPremiumsAt65(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.FEHBPremiumPerPayPeriod = decimal.NewFromInt(300)
+	cfg.GlobalAssumptions.FederalRules.FEHBConfig.PayPeriodsPerYear = 26
+	ce := NewCalculationEngine()
+
+	// Both were born in 1963, so 2027 (index 2) is the last year before Medicare and 2028 the first with it
+	premiums := func(mode string) (before, after domain.AnnualCashFlow) {
+		s := *scenario
+		s.MedicareCoordination = mode
+		projection := ce.GenerateAnnualProjection(&personA, &personB, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+		return projection[2], projection[3]
+	}
+	keepBefore, keep := premiums(domain.MedicareCoordinationKeepBoth)
+	primaryBefore, primary := premiums(domain.MedicareCoordinationMedicarePrimary)
+	suspendBefore, suspend := premiums(domain.MedicareCoordinationSuspendFEHB)
+
+	for _, before := range []domain.AnnualCashFlow{primaryBefore, suspendBefore} {
+		if !before.FEHBPremium.Equal(keepBefore.FEHBPremium) {
+			t.Fatalf("expected identical FEHB premiums before 65, got %s and %s", before.FEHBPremium, keepBefore.FEHBPremium)
+		}
+	}
+	if !suspend.FEHBPremium.IsZero() || !suspend.MedicarePremium.GreaterThan(decimal.Zero) {
+		t.Fatalf("expected suspended FEHB with Part B continuing, got FEHB %s Medicare %s", suspend.FEHBPremium, suspend.MedicarePremium)
+	}
+	if !primary.FEHBPremium.Equal(keep.FEHBPremium.Mul(decimal.NewFromFloat(0.5))) {
+		t.Fatalf("expected medicare_primary to halve the FEHB premium, got %s of %s", primary.FEHBPremium, keep.FEHBPremium)
+	}
+	total := func(cf domain.AnnualCashFlow) decimal.Decimal { return cf.FEHBPremium.Add(cf.MedicarePremium) }
+	if !(total(keep).GreaterThan(total(primary)) && total(primary).GreaterThan(total(suspend))) {
+		t.Fatalf("expected combined health premiums keep_both > medicare_primary > suspend_fehb, got %s, %s, %s", total(keep), total(primary), total(suspend))
+	}
+}
+
+func TestApplyMedicareCoordination(t *testing.T) {
+	premium := decimal.NewFromInt(10000)
+	cfg := domain.FEHBConfig{MedicarePrimaryPremiumFactor: decimal.NewFromFloat(0.4)}
+	tests := []struct {
+		mode              string
+		eligible, covered int
+		expected          decimal.Decimal
+	}{
+		{domain.MedicareCoordinationKeepBoth, 2, 2, decimal.NewFromInt(10000)},
+		{domain.MedicareCoordinationSuspendFEHB, 1, 2, decimal.NewFromInt(5000)},
+		{domain.MedicareCoordinationSuspendFEHB, 2, 2, decimal.Zero},
+		{domain.MedicareCoordinationMedicarePrimary, 2, 2, decimal.NewFromInt(4000)},
+		{domain.MedicareCoordinationMedicarePrimary, 0, 2, decimal.NewFromInt(10000)},
+	}
+	for _, tt := range tests {
+		if got := ApplyMedicareCoordination(premium, tt.mode, tt.eligible, tt.covered, cfg); !got.Equal(tt.expected) {
+			t.Fatalf("%s with %d/%d eligible: expected %s, got %s", tt.mode, tt.eligible, tt.covered, tt.expected, got)
+		}
+	}
+}
+
+func TestIRMAALifeChangingEventUsesCurrentMAGI(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(4)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	// Both are already on Medicare and retire from high salaries at the start of the projection
+	for _, p := range []*domain.Employee{&personA, &personB} {
+		p.BirthDate = p.BirthDate.AddDate(-5, 0, 0)
+		p.CurrentSalary = decimal.NewFromInt(250000)
+	}
+	ce := NewCalculationEngine()
+
+	premiums := func(lifeChangingEvent bool) []domain.AnnualCashFlow {
+		s := *scenario
+		s.IRMAALifeChangingEvent = lifeChangingEvent
+		a, b := personA, personB
+		return ce.GenerateAnnualProjection(&a, &b, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	}
+	lookback := premiums(false)
+	lce := premiums(true)
+
+	// The first two retirement years would otherwise be priced on the $500k pre-retirement salaries
+	for year := 0; year < 2; year++ {
+		want := ce.calculateMedicarePremium(&personA, &personB, lce[year].Date, lce[year].MAGI)
+		if !lce[year].MedicarePremium.Equal(want) {
+			t.Fatalf("year %d: expected IRMAA on current MAGI %s (%s), got %s", year, lce[year].MAGI, want, lce[year].MedicarePremium)
+		}
+		if !lce[year].MedicarePremium.LessThan(lookback[year].MedicarePremium) {
+			t.Fatalf("year %d: expected the life-changing event to lower premiums, got %s vs %s", year, lce[year].MedicarePremium, lookback[year].MedicarePremium)
+		}
+	}
+	// From the third year the lookback already reaches retirement income
+	if !lce[2].MedicarePremium.Equal(lookback[2].MedicarePremium) {
+		t.Fatalf("expected identical premiums once the lookback covers retirement, got %s vs %s", lce[2].MedicarePremium, lookback[2].MedicarePremium)
+	}
+}
+
+func TestPartDIRMAAAddsToMedicarePremium(t *testing.T) {
+	ce := NewCalculationEngine()
+	// 2025 Part D base premium and IRMAA-D tiers, each surcharge the step up from the tier below
+	ce.MedicareCalc.PartDBasePremium2025 = decimal.NewFromFloat(36.78)
+	for i, step := range []float64{13.70, 21.60, 21.70, 21.60, 7.20} {
+		tier := ce.MedicareCalc.IRMAAThresholds[i]
+		tier.MonthlySurcharge = decimal.NewFromFloat(step)
+		ce.MedicareCalc.PartDIRMAAThresholds = append(ce.MedicareCalc.PartDIRMAAThresholds, tier)
+	}
+	birth := time.Date(1955, 1, 1, 0, 0, 0, 0, time.UTC)
+	personA := &domain.Employee{BirthDate: birth}
+	personB := &domain.Employee{BirthDate: birth}
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// Top tier for a couple: Part B 185 + every surcharge, Part D 36.78 + 85.80, per person per month
+	topMAGI := decimal.NewFromInt(800000)
+	partB := ce.MedicareCalc.CalculateAnnualPartBCost(topMAGI, true)
+	partD := ce.MedicareCalc.CalculateAnnualPartDCost(topMAGI, true)
+	if want := decimal.NewFromFloat(36.78 + 85.80).Mul(decimal.NewFromInt(12)); !partD.Equal(want) {
+		t.Fatalf("expected top-tier Part D cost %s, got %s", want, partD)
+	}
+	if !partB.GreaterThan(decimal.NewFromInt(185 * 12)) {
+		t.Fatalf("expected a Part B surcharge at MAGI %s, got %s", topMAGI, partB)
+	}
+	premium := ce.calculateMedicarePremium(personA, personB, date, topMAGI)
+	if want := partB.Add(partD).Mul(decimal.NewFromInt(2)); !premium.Equal(want) {
+		t.Fatalf("expected household premium %s, got %s", want, premium)
+	}
+
+	// Below the first tier only the base premiums apply
+	basePremium := ce.calculateMedicarePremium(personA, personB, date, decimal.NewFromInt(150000))
+	if want := decimal.NewFromFloat(185 + 36.78).Mul(decimal.NewFromInt(24)); !basePremium.Equal(want) {
+		t.Fatalf("expected base household premium %s, got %s", want, basePremium)
+	}
+}
@@ -1,9 +1,11 @@
 package calculation
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime"
 	"sync"
 
 	"github.com/shopspring/decimal"
@@ -28,6 +30,15 @@ type MonteCarloConfig struct {
 	WithdrawalStrategy string
 	InitialBalance     decimal.Decimal
 	AnnualWithdrawal   decimal.Decimal
+	MaxConcurrency     int // Simulations run at once; zero or negative means runtime.NumCPU()
+}
+
+// concurrencyLimit returns the number of simulations to run at once, defaulting to one per CPU
+func concurrencyLimit(maxConcurrency int) int {
+	if maxConcurrency <= 0 {
+		return runtime.NumCPU()
+	}
+	return maxConcurrency
 }
 
 // MonteCarloResult represents the results of a Monte Carlo simulation
@@ -90,8 +101,14 @@ func NewMonteCarloSimulator(historicalData *HistoricalDataManager, config MonteC
 
 // RunSimulation executes the Monte Carlo simulation
 func (mcs *MonteCarloSimulator) RunSimulation(config MonteCarloConfig) (*MonteCarloResult, error) {
+	return mcs.RunSimulationWithContext(context.Background(), config)
+}
+
+// RunSimulationWithContext executes the Monte Carlo simulation, stopping before the next simulation
+// once ctx is cancelled and returning the wrapped context error
+func (mcs *MonteCarloSimulator) RunSimulationWithContext(ctx context.Context, config MonteCarloConfig) (*MonteCarloResult, error) {
 	if mcs.HistoricalData == nil || !mcs.HistoricalData.IsLoaded {
-		return nil, fmt.Errorf("historical data not loaded")
+		return nil, ErrHistoricalDataNotLoaded
 	}
 
 	// Set random seed (using modern Go approach)
@@ -101,13 +118,18 @@ func (mcs *MonteCarloSimulator) RunSimulation(config MonteCarloConfig) (*MonteCa
 	// Run simulations in parallel
 	results := make([]SimulationOutcome, mcs.NumSimulations)
 	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, 10) // Limit concurrent simulations
+	semaphore := make(chan struct{}, concurrencyLimit(config.MaxConcurrency)) // Limit concurrent simulations
 
+launch:
 	for i := 0; i < mcs.NumSimulations; i++ {
+		select {
+		case <-ctx.Done():
+			break launch
+		case semaphore <- struct{}{}: // Acquire semaphore
+		}
 		wg.Add(1)
 		go func(simIndex int) {
 			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire semaphore
 			defer func() { <-semaphore }() // Release semaphore
 
 			outcome := mcs.runSingleSimulation(config)
@@ -116,6 +138,9 @@ func (mcs *MonteCarloSimulator) RunSimulation(config MonteCarloConfig) (*MonteCa
 	}
 
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("monte carlo simulation cancelled: %w", err)
+	}
 
 	// Calculate aggregate statistics
 	successRate := mcs.calculateSuccessRate(results)
@@ -433,12 +458,7 @@ func (mcs *MonteCarloSimulator) calculateMedianEndingBalance(simulations []Simul
 		balances[i] = sim.EndingBalance
 	}
 
-	// Sort balances
-	mcs.sortBalances(balances)
-
-	// Return the middle value
-	middleIndex := len(balances) / 2
-	return balances[middleIndex]
+	return Percentile(balances, 0.5, MonteCarloPercentileMethod)
 }
 
 // calculatePercentileRanges calculates percentile ranges for ending balances
@@ -449,30 +469,5 @@ func (mcs *MonteCarloSimulator) calculatePercentileRanges(simulations []Simulati
 		balances[i] = sim.EndingBalance
 	}
 
-	// Sort balances
-	mcs.sortBalances(balances)
-
-	// Calculate percentiles
-	n := len(balances)
-
-	return PercentileRanges{
-		P10: balances[n/10],
-		P25: balances[n/4],
-		P50: balances[n/2],
-		P75: balances[3*n/4],
-		P90: balances[9*n/10],
-	}
-}
-
-// sortBalances sorts balances in ascending order
-func (mcs *MonteCarloSimulator) sortBalances(balances []decimal.Decimal) {
-	// Simple bubble sort for small arrays
-	// In production, use a more efficient sorting algorithm
-	for i := 0; i < len(balances)-1; i++ {
-		for j := 0; j < len(balances)-i-1; j++ {
-			if balances[j].GreaterThan(balances[j+1]) {
-				balances[j], balances[j+1] = balances[j+1], balances[j]
-			}
-		}
-	}
+	return percentileRanges(balances, MonteCarloPercentileMethod)
 }
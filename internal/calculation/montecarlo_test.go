@@ -2,6 +2,7 @@ package calculation
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -416,3 +417,53 @@ func TestMonteCarloErrorHandling(t *testing.T) {
 		t.Error("Expected error when historical data is not loaded")
 	}
 }
+
+func TestConcurrencyLimit(t *testing.T) {
+	if got := concurrencyLimit(4); got != 4 {
+		t.Fatalf("expected explicit limit 4, got %d", got)
+	}
+	for _, n := range []int{0, -3} {
+		if got := concurrencyLimit(n); got != runtime.NumCPU() {
+			t.Fatalf("expected %d to default to NumCPU (%d), got %d", n, runtime.NumCPU(), got)
+		}
+	}
+}
+
+// BenchmarkMonteCarloConcurrency runs the same simulation set at increasing concurrency limits; on a
+// multi-core machine ns/op should fall as the limit rises toward the CPU count.
+func BenchmarkMonteCarloConcurrency(b *testing.B) {
+	testDataPath := b.TempDir()
+	if err := createTestDataFiles(testDataPath); err != nil {
+		b.Fatalf("Failed to create test data files: %v", err)
+	}
+	hdm := NewHistoricalDataManager(testDataPath)
+	if err := hdm.LoadAllData(); err != nil {
+		b.Fatalf("Failed to load historical data: %v", err)
+	}
+
+	limits := []int{1, 2, 4}
+	if runtime.NumCPU() > 4 {
+		limits = append(limits, runtime.NumCPU())
+	}
+	for _, limit := range limits {
+		b.Run(fmt.Sprintf("concurrency=%d", limit), func(b *testing.B) {
+			config := MonteCarloConfig{
+				NumSimulations:     500,
+				ProjectionYears:    30,
+				Seed:               12345,
+				AssetAllocation:    map[string]decimal.Decimal{"C": decimal.NewFromFloat(0.6), "F": decimal.NewFromFloat(0.4)},
+				WithdrawalStrategy: "fixed_amount",
+				InitialBalance:     decimal.NewFromInt(1000000),
+				AnnualWithdrawal:   decimal.NewFromInt(40000),
+				MaxConcurrency:     limit,
+			}
+			simulator := NewMonteCarloSimulator(hdm, config)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := simulator.RunSimulation(config); err != nil {
+					b.Fatalf("simulation failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -4,15 +4,15 @@ import (
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/rpgo/retirement-calculator/pkg/dateutil"
 	"github.com/shopspring/decimal"
 )
 
-// deriveDeathYearIndexes returns 0-based projection year indexes for each death if within projection horizon.
-func deriveDeathYearIndexes(scenario *domain.Scenario, personA, personB *domain.Employee, projectionYears int) (personAIdx *int, personBIdx *int) {
+// deriveDeathYearIndexes returns 0-based projection year indexes (year 0 = baseYear) for each death if within projection horizon.
+func deriveDeathYearIndexes(scenario *domain.Scenario, personA, personB *domain.Employee, baseYear, projectionYears int) (personAIdx *int, personBIdx *int) {
 	if scenario == nil || scenario.Mortality == nil {
 		return nil, nil
 	}
-	baseYear := ProjectionBaseYear
 	if scenario.Mortality.PersonA != nil {
 		if scenario.Mortality.PersonA.DeathDate != nil {
 			y := scenario.Mortality.PersonA.DeathDate.Year() - baseYear
@@ -55,7 +55,7 @@ func deathFractionInYear(deathIdx *int, year int, deathDate *time.Time) (decimal
 	}
 	yearStart := time.Date(deathDate.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
 	daysBefore := deathDate.Sub(yearStart).Hours() / 24.0
-	daysInYear := 365.0 // ignore leap for simplicity
+	daysInYear := float64(dateutil.DaysInYear(deathDate.Year()))
 	frac := daysBefore / daysInYear
 	if frac < 0 {
 		frac = 0
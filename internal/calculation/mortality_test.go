@@ -31,7 +31,7 @@ func TestMortalityBasic(t *testing.T) {
 	}
 
 	// Year index for 2030 relative to base year 2025
-	deathYearIdx := deathDate.Year() - ProjectionBaseYear
+	deathYearIdx := deathDate.Year() - testProjectionStartYear
 	if deathYearIdx < 0 || deathYearIdx >= len(projection) {
 		t.Fatalf("death year index out of range: %d", deathYearIdx)
 	}
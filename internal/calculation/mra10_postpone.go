@@ -0,0 +1,162 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// fehbLapsedFraction returns the share of a calendar year in which a separated employee whose annuity
+// starts after separation has no FEHB. A postponed MRA+10 annuity suspends coverage from the retirement
+// date until the annuity starts, when it is reinstated; any other deferred annuity ends FEHB for good at
+// separation.
+func fehbLapsedFraction(employee *domain.Employee, retirement domain.RetirementScenario, calendarYear int) decimal.Decimal {
+	if retirement.AnnuityStartDate == nil {
+		return decimal.Zero
+	}
+	yearStart := time.Date(calendarYear, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextYear := yearStart.AddDate(1, 0, 0)
+	from, to := retirement.RetirementDate, nextYear
+	if fehbReinstatedAtCommencement(employee, retirement.RetirementDate) && retirement.AnnuityStartDate.Before(nextYear) {
+		to = *retirement.AnnuityStartDate
+	}
+	if from.Before(yearStart) {
+		from = yearStart
+	}
+	if !to.After(from) {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(to.Sub(from).Hours() / nextYear.Sub(yearStart).Hours())
+}
+
+// fehbReinstatedAtCommencement reports whether FEHB suspended at separation returns when a postponed
+// annuity starts: only for an MRA+10 retirement by an employee enrolled for the five years before separation
+func fehbReinstatedAtCommencement(employee *domain.Employee, separation time.Time) bool {
+	if !employee.RetirementEligibility(separation, separation).Reduced {
+		return false
+	}
+	return employee.FEHBEnrolledSince == nil || !employee.FEHBEnrolledSince.After(separation.AddDate(-5, 0, 0))
+}
+
+// MRA10Option is one annuity commencement choice for an MRA+10 retiree
+type MRA10Option struct {
+	CommencementDate  time.Time       `json:"commencement_date"`
+	CommencementAge   int             `json:"commencement_age"`
+	AgeReduction      decimal.Decimal `json:"age_reduction"`   // 5% for each year under 62 at commencement
+	InitialPension    decimal.Decimal `json:"initial_pension"` // Annual annuity at commencement
+	FEHBSuspended     bool            `json:"fehb_suspended"`  // FEHB coverage lapses from separation until commencement
+	LifetimeNetIncome decimal.Decimal `json:"lifetime_net_income"`
+}
+
+// MRA10PostponementAnalysis compares taking a reduced MRA+10 annuity at separation with postponing it to
+// the first date it is unreduced. BreakEven is when cumulative net income of postponing overtakes the
+// immediate annuity; nil when it never does within the projection.
+type MRA10PostponementAnalysis struct {
+	Employee       string                     `json:"employee"`
+	SeparationDate time.Time                  `json:"separation_date"`
+	Immediate      MRA10Option                `json:"immediate"`
+	Postponed      MRA10Option                `json:"postponed"`
+	BreakEven      *CumulativeBreakEvenResult `json:"break_even,omitempty"`
+	BreakEvenAge   int                        `json:"break_even_age,omitempty"`
+}
+
+// CompareMRA10Postponement runs a scenario twice for one MRA+10 retiree ("person_a" or "person_b"): with
+// the annuity starting at separation and the age reduction applied, and postponed to the first of the
+// month after the employee turns 62 (60 with 20 years of service), when no reduction applies. The
+// household's FEHB is carried by person_a, so postponing person_a's annuity suspends it until the annuity
+// starts, or ends it without five years of coverage before separation; gapCoverageCost is the annual premium, in today's dollars and grown at the FEHB premium
+// inflation rate, of replacement coverage bought during that gap.
+func (ce *CalculationEngine) CompareMRA10Postponement(config *domain.Configuration, employee string, scenario *domain.Scenario, gapCoverageCost decimal.Decimal) (*MRA10PostponementAnalysis, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	if employee != "person_a" && employee != "person_b" {
+		return nil, fmt.Errorf("%w, got %q", ErrUnknownEmployee, employee)
+	}
+	emp, ok := config.PersonalDetails[employee]
+	if !ok {
+		return nil, fmt.Errorf("%s employee details are required", employee)
+	}
+
+	immediate, postponed := *scenario, *scenario
+	immediateRetirement, postponedRetirement := &immediate.PersonA, &postponed.PersonA
+	if employee == "person_b" {
+		immediateRetirement, postponedRetirement = &immediate.PersonB, &postponed.PersonB
+	}
+	separation := immediateRetirement.RetirementDate
+	if MRAPlus10Reduction(&emp, separation, separation).IsZero() {
+		return nil, fmt.Errorf("%s retirement on %s is not a reduced MRA+10 retirement", employee, separation.Format("2006-01-02"))
+	}
+
+	unreducedAge := 62
+	if emp.YearsOfService(separation).GreaterThanOrEqual(decimal.NewFromInt(20)) {
+		unreducedAge = 60
+	}
+	birthday := emp.BirthDate.AddDate(unreducedAge, 0, 0)
+	commencement := time.Date(birthday.Year(), birthday.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	immediateRetirement.AnnuityStartDate = nil
+	postponedRetirement.AnnuityStartDate = &commencement
+
+	ctx := context.Background()
+	immediateSummary, err := ce.RunScenario(ctx, config, &immediate)
+	if err != nil {
+		return nil, fmt.Errorf("immediate annuity scenario: %w", err)
+	}
+	postponedSummary, err := ce.RunScenario(ctx, config, &postponed)
+	if err != nil {
+		return nil, fmt.Errorf("postponed annuity scenario: %w", err)
+	}
+
+	postponedProjection := postponedSummary.Projection
+	fehbSuspended := employee == "person_a"
+	if fehbSuspended && gapCoverageCost.IsPositive() {
+		postponedProjection = make([]domain.AnnualCashFlow, len(postponedSummary.Projection))
+		copy(postponedProjection, postponedSummary.Projection)
+		growth := decimal.NewFromInt(1).Add(config.GlobalAssumptions.FEHBPremiumInflation)
+		for i := range postponedProjection {
+			cf := &postponedProjection[i]
+			cost := gapCoverageCost.Mul(growth.Pow(decimal.NewFromInt(int64(i)))).Mul(fehbLapsedFraction(&emp, *postponedRetirement, cf.Date.Year()))
+			cf.NetIncome = cf.NetIncome.Sub(cost)
+		}
+	}
+
+	option := func(commencement time.Time, projection []domain.AnnualCashFlow, suspended bool) MRA10Option {
+		calc := CalculateDeferredFERSPension(&emp, separation, commencement)
+		lifetime := decimal.Zero
+		for _, cf := range projection {
+			lifetime = lifetime.Add(cf.NetIncome)
+		}
+		return MRA10Option{
+			CommencementDate:  commencement,
+			CommencementAge:   emp.Age(commencement),
+			AgeReduction:      calc.AgeReduction,
+			InitialPension:    calc.ReducedPension,
+			FEHBSuspended:     suspended,
+			LifetimeNetIncome: lifetime,
+		}
+	}
+	result := &MRA10PostponementAnalysis{
+		Employee:       employee,
+		SeparationDate: separation,
+		Immediate:      option(separation, immediateSummary.Projection, false),
+		Postponed:      option(commencement, postponedProjection, fehbSuspended),
+	}
+
+	// The two projections match until separation, so the comparison starts in the separation year
+	immediateProjection := immediateSummary.Projection
+	if start := separation.Year() - ProjectionStartYear(&config.GlobalAssumptions); start > 0 && start < len(immediateProjection) && start < len(postponedProjection) {
+		immediateProjection, postponedProjection = immediateProjection[start:], postponedProjection[start:]
+	}
+	breakEven, err := CalculateCumulativeBreakEven(postponedProjection, immediateProjection)
+	if err != nil {
+		return nil, err
+	}
+	if breakEven != nil {
+		result.BreakEven = breakEven
+		result.BreakEvenAge = breakEven.BreakEvenYear - emp.BirthDate.Year()
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestCompareMRA10Postponement_CrossoverAge(t *testing.T) {
+	// Separating at 57 with 17 years is an MRA+10 retirement reduced 25%; postponing to 62 removes the
+	// reduction but gives up five years of annuity and FEHB
+	personA := domain.Employee{
+		Name:                    "PersonA",
+		BirthDate:               time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:                time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+		High3Salary:             decimal.NewFromInt(100000),
+		SSBenefitFRA:            decimal.NewFromInt(3000),
+		FEHBPremiumPerPayPeriod: decimal.NewFromInt(300),
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	cfg := &domain.Configuration{
+		PersonalDetails: map[string]domain.Employee{"person_a": personA, "person_b": personB},
+		GlobalAssumptions: domain.GlobalAssumptions{
+			ProjectionYears: 40,
+			COLAGeneralRate: decimal.NewFromFloat(0.02),
+			FederalRules:    domain.FederalRules{FEHBConfig: domain.FEHBConfig{PayPeriodsPerYear: 26}},
+		},
+	}
+	scenario := &domain.Scenario{
+		Name:    "mra10",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+
+	// Replacement coverage in the gap costs what FEHB would have, so the choice turns on the annuity alone
+	ce := NewCalculationEngine()
+	result, err := ce.CompareMRA10Postponement(cfg, "person_a", scenario, decimal.NewFromInt(300*26))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Immediate.AgeReduction.Equal(decimal.NewFromFloat(0.25)) || !result.Postponed.AgeReduction.IsZero() {
+		t.Fatalf("expected a 25%% reduction only on the immediate annuity, got %s and %s", result.Immediate.AgeReduction, result.Postponed.AgeReduction)
+	}
+	if want := time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC); !result.Postponed.CommencementDate.Equal(want) {
+		t.Fatalf("expected the postponed annuity to start %s, got %s", want.Format("2006-01-02"), result.Postponed.CommencementDate.Format("2006-01-02"))
+	}
+	if !result.Postponed.FEHBSuspended {
+		t.Fatalf("postponing person_a's annuity should suspend the household FEHB")
+	}
+
+	// Five years of forgone annuity at 75% take about fifteen years of the 25% increase to recover
+	if result.BreakEven == nil {
+		t.Fatalf("expected postponing to overtake the immediate annuity within the projection")
+	}
+	if result.BreakEvenAge < 72 || result.BreakEvenAge > 82 {
+		t.Fatalf("expected a crossover in the late 70s, got age %d", result.BreakEvenAge)
+	}
+	if !result.Postponed.LifetimeNetIncome.GreaterThan(result.Immediate.LifetimeNetIncome) {
+		t.Fatalf("through age 94 postponing should pay more: %s vs %s", result.Postponed.LifetimeNetIncome, result.Immediate.LifetimeNetIncome)
+	}
+
+	// Costlier replacement coverage during the gap pushes the crossover later
+	covered, err := ce.CompareMRA10Postponement(cfg, "person_a", scenario, decimal.NewFromInt(10000))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if covered.BreakEven == nil || covered.BreakEven.CalendarYear <= result.BreakEven.CalendarYear {
+		t.Fatalf("expected costlier gap coverage to push the crossover later, got %+v", covered.BreakEven)
+	}
+	if !covered.Postponed.LifetimeNetIncome.LessThan(result.Postponed.LifetimeNetIncome) {
+		t.Fatalf("costlier gap coverage should reduce the postponed lifetime income")
+	}
+
+	// The household FEHB premium stops from separation until the postponed annuity starts
+	start := result.Postponed.CommencementDate
+	scenario.PersonA.AnnuityStartDate = &start
+	summary, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cf := range summary.Projection[:10] {
+		year := cf.Date.Year()
+		if suspended := year >= 2027 && year <= 2031; suspended != cf.FEHBPremium.IsZero() {
+			t.Fatalf("%d: unexpected FEHB premium %s", year, cf.FEHBPremium)
+		}
+	}
+	scenario.PersonA.AnnuityStartDate = nil
+
+	// A retirement that is not MRA+10 has nothing to postpone
+	personA.HireDate = time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg.PersonalDetails["person_a"] = personA
+	if _, err := ce.CompareMRA10Postponement(cfg, "person_a", scenario, decimal.Zero); err == nil {
+		t.Fatalf("expected an error for an unreduced retirement")
+	}
+}
+
+func TestDeferredAnnuityFEHBSuspendedOrLost(t *testing.T) {
+	separation := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	commencement := time.Date(2032, 2, 1, 0, 0, 0, 0, time.UTC)
+	retirement := domain.RetirementScenario{RetirementDate: separation, AnnuityStartDate: &commencement}
+	mra10 := &domain.Employee{
+		BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	lapsed := func(employee *domain.Employee, year int) decimal.Decimal {
+		return fehbLapsedFraction(employee, retirement, year)
+	}
+
+	// A postponed MRA+10 annuity with five years of coverage suspends FEHB and restores it at commencement
+	if !lapsed(mra10, 2030).Equal(decimal.NewFromInt(1)) || !lapsed(mra10, 2033).IsZero() {
+		t.Fatalf("expected FEHB suspended in 2030 and reinstated by 2033, got %s and %s", lapsed(mra10, 2030), lapsed(mra10, 2033))
+	}
+	if got := lapsed(mra10, 2032); !got.IsPositive() || !got.LessThan(decimal.NewFromFloat(0.1)) {
+		t.Fatalf("expected FEHB suspended only for January 2032, got %s", got)
+	}
+
+	// Without five years of coverage before separation it is lost for good
+	recent := *mra10
+	enrolled := separation.AddDate(-3, 0, 0)
+	recent.FEHBEnrolledSince = &enrolled
+	if !lapsed(&recent, 2033).Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("expected FEHB lost after commencement without five years of coverage, got %s", lapsed(&recent, 2033))
+	}
+
+	// A deferred annuity (separating before the MRA) cannot carry FEHB either
+	young := *mra10
+	young.BirthDate = time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !lapsed(&young, 2040).Equal(decimal.NewFromInt(1)) {
+		t.Fatalf("expected FEHB lost for a deferred annuity, got %s", lapsed(&young, 2040))
+	}
+
+	// An immediate annuity keeps FEHB
+	immediate := retirement
+	immediate.AnnuityStartDate = nil
+	if !fehbLapsedFraction(mra10, immediate, 2030).IsZero() {
+		t.Fatalf("expected FEHB kept with an immediate annuity")
+	}
+}
@@ -0,0 +1,29 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// postRetirementWagesForYear returns part-time wages earned after separation in the given calendar year.
+// A flat annual amount is prorated for the retirement year; schedule entries are taken as full-year figures.
+func postRetirementWagesForYear(rs domain.RetirementScenario, retired bool, calendarYear, age int, workFraction decimal.Decimal) decimal.Decimal {
+	if !retired || rs.PostRetirementWages == nil {
+		return decimal.Zero
+	}
+	wages, scheduled := rs.PostRetirementWages.ForYear(calendarYear, age)
+	if !scheduled {
+		wages = wages.Mul(decimal.NewFromInt(1).Sub(workFraction))
+	}
+	return wages
+}
+
+// ApplySRSEarningsTest reduces the FERS supplement by $1 for every $2 of earnings above the Social
+// Security annual exempt amount in rules, never below zero
+func ApplySRSEarningsTest(supplement, earnings decimal.Decimal, rules domain.SocialSecurityRules) decimal.Decimal {
+	excess := earnings.Sub(earningsTestExemptAmount(rules))
+	if excess.LessThanOrEqual(decimal.Zero) {
+		return supplement
+	}
+	return decimal.Max(supplement.Sub(excess.Div(decimal.NewFromInt(2))), decimal.Zero)
+}
@@ -0,0 +1,114 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestApplySRSEarningsTest(t *testing.T) {
+	supplement := decimal.NewFromInt(20000)
+	rules := domain.SocialSecurityRules{}
+	if got := ApplySRSEarningsTest(supplement, decimal.NewFromInt(20000), rules); !got.Equal(supplement) {
+		t.Fatalf("expected no reduction under the exempt amount, got %s", got)
+	}
+	// $40,000 of earnings is $16,600 over the exempt amount: $8,300 reduction
+	if got := ApplySRSEarningsTest(supplement, decimal.NewFromInt(40000), rules); !got.Equal(decimal.NewFromInt(11700)) {
+		t.Fatalf("expected 11700, got %s", got)
+	}
+	if got := ApplySRSEarningsTest(supplement, decimal.NewFromInt(100000), rules); !got.IsZero() {
+		t.Fatalf("expected the supplement to be fully offset, got %s", got)
+	}
+	// A configured $30,000 exempt amount leaves $10,000 over it: $5,000 reduction
+	rules.EarningsTestExemptAmount = decimal.NewFromInt(30000)
+	if got := ApplySRSEarningsTest(supplement, decimal.NewFromInt(40000), rules); !got.Equal(decimal.NewFromInt(15000)) {
+		t.Fatalf("expected the configured exempt amount to apply, got %s", got)
+	}
+}
+
+func TestPostRetirementWagesReduceSRSAndIncurFICA(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(4)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.BirthDate = time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC) // 60 with 35 years: SRS eligible
+	personA.SSBenefit62 = decimal.NewFromInt(2100)
+	scenario.PersonA.SSStartAge = 67
+	cfg.GlobalAssumptions.CurrentLocation.Municipality = UpperMakefieldTownship
+	ce := NewCalculationEngine()
+
+	run := func(wages *domain.PostRetirementWages) []domain.AnnualCashFlow {
+		s := *scenario
+		s.PersonA.PostRetirementWages = wages
+		a, b := personA, personB
+		return ce.GenerateAnnualProjection(&a, &b, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	}
+
+	without := run(nil)
+	with := run(&domain.PostRetirementWages{Schedule: map[int]decimal.Decimal{2026: decimal.NewFromInt(40000)}})
+
+	year := 1 // 2026
+	if !with[year].PostRetirementWagesPersonA.Equal(decimal.NewFromInt(40000)) {
+		t.Fatalf("expected $40,000 of part-time wages in 2026, got %s", with[year].PostRetirementWagesPersonA)
+	}
+	reduction := without[year].FERSSupplementPersonA.Sub(with[year].FERSSupplementPersonA)
+	if !reduction.Equal(decimal.NewFromInt(8300)) {
+		t.Fatalf("expected the SRS earnings test to cut $8,300, got %s (without=%s with=%s)", reduction, without[year].FERSSupplementPersonA, with[year].FERSSupplementPersonA)
+	}
+	if !without[year].FICATax.IsZero() || !with[year].FICATax.GreaterThan(decimal.Zero) {
+		t.Fatalf("expected FICA only in the year with wages: without=%s with=%s", without[year].FICATax, with[year].FICATax)
+	}
+	if !with[year].LocalTax.GreaterThan(decimal.Zero) {
+		t.Fatalf("expected local earned income tax on part-time wages")
+	}
+	if !with[2].FERSSupplementPersonA.Equal(without[2].FERSSupplementPersonA) || !with[2].FICATax.IsZero() {
+		t.Fatalf("expected no effect in years without scheduled wages")
+	}
+}
+
+func TestApplySSEarningsTest(t *testing.T) {
+	birth := time.Date(1963, 7, 1, 0, 0, 0, 0, time.UTC) // FRA 67 reached in 2030
+	benefit := decimal.NewFromInt(25000)
+	rules := domain.SocialSecurityRules{}
+
+	if got := ApplySSEarningsTest(benefit, decimal.NewFromInt(40000), birth, 2026, rules); !got.Equal(decimal.NewFromInt(16700)) {
+		t.Fatalf("expected $1 per $2 over $23,400 before the FRA year, got %s", got)
+	}
+	// FRA year: six months of $150k wages = $75k, $12,840 over $62,160 withholds $4,280
+	if got := ApplySSEarningsTest(benefit, decimal.NewFromInt(150000), birth, 2030, rules); !got.Equal(decimal.NewFromInt(20720)) {
+		t.Fatalf("expected $1 per $3 over $62,160 in the FRA year, got %s", got)
+	}
+	if got := ApplySSEarningsTest(benefit, decimal.NewFromInt(150000), birth, 2031, rules); !got.Equal(benefit) {
+		t.Fatalf("expected no earnings test after FRA, got %s", got)
+	}
+	rules.EarningsTestExemptAmount = decimal.NewFromInt(30000)
+	if got := ApplySSEarningsTest(benefit, decimal.NewFromInt(40000), birth, 2026, rules); !got.Equal(decimal.NewFromInt(20000)) {
+		t.Fatalf("expected the configured exempt amount to apply, got %s", got)
+	}
+}
+
+func TestPartTimeWagesReduceEarlySocialSecurity(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(8)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	ce := NewCalculationEngine()
+
+	run := func(wages *domain.PostRetirementWages) []domain.AnnualCashFlow {
+		s := *scenario
+		s.PersonA.PostRetirementWages = wages
+		a, b := personA, personB
+		return ce.GenerateAnnualProjection(&a, &b, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	}
+
+	without := run(nil)
+	with := run(&domain.PostRetirementWages{AnnualAmount: decimal.NewFromInt(40000)})
+
+	year := 1 // 2026, age 63 after claiming at 62
+	reduction := without[year].SSBenefitPersonA.Sub(with[year].SSBenefitPersonA)
+	if !reduction.Equal(decimal.NewFromInt(8300)) {
+		t.Fatalf("expected the earnings test to withhold $8,300, got %s (without=%s with=%s)", reduction, without[year].SSBenefitPersonA, with[year].SSBenefitPersonA)
+	}
+	// 1963 birth: FRA 67 in 2030, after which wages no longer reduce benefits
+	if !with[6].SSBenefitPersonA.Equal(without[6].SSBenefitPersonA) {
+		t.Fatalf("expected no withholding after FRA: without=%s with=%s", without[6].SSBenefitPersonA, with[6].SSBenefitPersonA)
+	}
+}
@@ -0,0 +1,68 @@
+package calculation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Percentile methods
+const (
+	// PercentileNearestRank returns the smallest observed value with at least p of the values at or below
+	// it (the value at rank ceil(p*n)); it never interpolates
+	PercentileNearestRank = "nearest_rank"
+	// PercentileLinear interpolates linearly between the closest ranks, at position p*(n-1)
+	PercentileLinear = "linear"
+)
+
+// MonteCarloPercentileMethod is the method used for every Monte Carlo statistic: the P10-P90 ranges,
+// medians, and the per-year percentile bands in the HTML report, so the summary cards and the
+// tables and charts agree
+const MonteCarloPercentileMethod = PercentileLinear
+
+// Percentile returns the p-th percentile (p from 0 to 1) of values using method, one of
+// PercentileNearestRank or PercentileLinear; any other method is treated as linear. values is not
+// modified. An empty slice returns zero.
+func Percentile(values []decimal.Decimal, p float64, method string) decimal.Decimal {
+	n := len(values)
+	if n == 0 {
+		return decimal.Zero
+	}
+	sorted := make([]decimal.Decimal, n)
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	// Positions are worked in decimal so that, e.g., 0.9 of 9 is exactly 8.1
+	pd := decimal.NewFromFloat(math.Min(math.Max(p, 0), 1))
+
+	if method == PercentileNearestRank {
+		rank := int(pd.Mul(decimal.NewFromInt(int64(n))).Ceil().IntPart())
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	position := pd.Mul(decimal.NewFromInt(int64(n - 1)))
+	lower := int(position.Floor().IntPart())
+	if lower >= n-1 {
+		return sorted[n-1]
+	}
+	weight := position.Sub(decimal.NewFromInt(int64(lower)))
+	return sorted[lower].Add(sorted[lower+1].Sub(sorted[lower]).Mul(weight))
+}
+
+// percentileRanges returns the P10-P90 ranges of values using method
+func percentileRanges(values []decimal.Decimal, method string) PercentileRanges {
+	if len(values) == 0 {
+		return PercentileRanges{}
+	}
+	return PercentileRanges{
+		P10: Percentile(values, 0.10, method),
+		P25: Percentile(values, 0.25, method),
+		P50: Percentile(values, 0.50, method),
+		P75: Percentile(values, 0.75, method),
+		P90: Percentile(values, 0.90, method),
+	}
+}
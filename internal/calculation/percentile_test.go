@@ -0,0 +1,65 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestPercentileMethods(t *testing.T) {
+	// 10 values, 1..10, out of order
+	values := make([]decimal.Decimal, 0, 10)
+	for _, v := range []int64{7, 3, 10, 1, 5, 9, 2, 8, 4, 6} {
+		values = append(values, decimal.NewFromInt(v))
+	}
+
+	tests := []struct {
+		p      float64
+		method string
+		want   string
+	}{
+		// P90 of 10 values: the old integer indexing (values[9*n/10]) returned the maximum, 10
+		{0.90, PercentileNearestRank, "9"},
+		{0.90, PercentileLinear, "9.1"},
+		{0.50, PercentileNearestRank, "5"},
+		{0.50, PercentileLinear, "5.5"},
+		{0.10, PercentileNearestRank, "1"},
+		{0.10, PercentileLinear, "1.9"},
+		{0.25, PercentileLinear, "3.25"},
+		{0, PercentileNearestRank, "1"},
+		{1, PercentileNearestRank, "10"},
+		{0, PercentileLinear, "1"},
+		{1, PercentileLinear, "10"},
+	}
+	for _, tt := range tests {
+		got := Percentile(values, tt.p, tt.method)
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Fatalf("Percentile(p=%v, %s) = %s, want %s", tt.p, tt.method, got, tt.want)
+		}
+	}
+
+	if !values[0].Equal(decimal.NewFromInt(7)) {
+		t.Fatalf("Percentile reordered its input")
+	}
+	if !Percentile(nil, 0.5, PercentileLinear).IsZero() {
+		t.Fatalf("empty input should give zero")
+	}
+	if got := Percentile(values[:1], 0.9, PercentileLinear); !got.Equal(decimal.NewFromInt(7)) {
+		t.Fatalf("single value percentile = %s, want 7", got)
+	}
+}
+
+func TestMonteCarloPercentileRangesMatchPercentile(t *testing.T) {
+	values := make([]decimal.Decimal, 10)
+	for i := range values {
+		values[i] = decimal.NewFromInt(int64(i + 1))
+	}
+	fmce := &FERSMonteCarloEngine{}
+	ranges := fmce.calculatePercentileRanges(values)
+	if !ranges.P90.Equal(Percentile(values, 0.9, MonteCarloPercentileMethod)) {
+		t.Fatalf("P90 = %s, want %s", ranges.P90, Percentile(values, 0.9, MonteCarloPercentileMethod))
+	}
+	if !fmce.calculateMedian(values).Equal(ranges.P50) {
+		t.Fatalf("median %s differs from P50 %s", fmce.calculateMedian(values), ranges.P50)
+	}
+}
@@ -0,0 +1,68 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// runPlanToAge runs a 40-year projection in which PersonA's TSP, drawn at a flat $930 a month with no
+// returns, runs out at 88
+func runPlanToAge(t *testing.T, planToAge int) *domain.ScenarioSummary {
+	t.Helper()
+	cfg, scenario := retiredCoupleTestConfig(40)
+	target := decimal.NewFromInt(930)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+	personA := cfg.PersonalDetails["person_a"]
+	personA.PlanToAge = planToAge
+	cfg.PersonalDetails["person_a"] = personA
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := summary.Projection[summary.TSPLongevity-1].AgePersonA; got != 88 {
+		t.Fatalf("expected the TSP to deplete at 88, got %d", got)
+	}
+	return summary
+}
+
+func TestPlanToAgeJudgesSuccessAgainstAge(t *testing.T) {
+	full := runPlanToAge(t, 0)
+	if full.PlanHorizonYears != 40 {
+		t.Fatalf("expected the full 40-year horizon without plan_to_age, got %d", full.PlanHorizonYears)
+	}
+	if !full.SuccessRate.Equal(decimal.NewFromFloat(67.5)) {
+		t.Fatalf("expected 27 of 40 years (67.5%%) over the full horizon, got %s", full.SuccessRate)
+	}
+
+	to90 := runPlanToAge(t, 90)
+	if to90.PlanHorizonYears != 29 {
+		t.Fatalf("expected a 29-year horizon through age 90, got %d", to90.PlanHorizonYears)
+	}
+	if to90.TSPLongevity != full.TSPLongevity {
+		t.Fatalf("plan_to_age should not change when the TSP runs out")
+	}
+	if !to90.SuccessRate.GreaterThan(full.SuccessRate) {
+		t.Fatalf("expected success judged to 90 (%s) to beat the full horizon (%s)", to90.SuccessRate, full.SuccessRate)
+	}
+	if to90.SuccessRate.GreaterThanOrEqual(decimal.NewFromInt(95)) {
+		t.Fatalf("depleting at 88 should not count as lasting to 90, got %s", to90.SuccessRate)
+	}
+
+	to85 := runPlanToAge(t, 85)
+	if !to85.SuccessRate.Equal(decimal.NewFromInt(95)) {
+		t.Fatalf("expected the TSP to last (while declining) through 85, got %s", to85.SuccessRate)
+	}
+
+	engine := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{}}
+	if engine.determineSuccess([]*domain.ScenarioSummary{to90}) {
+		t.Fatalf("depleting at 88 should fail a plan to 90")
+	}
+	if !engine.determineSuccess([]*domain.ScenarioSummary{to85}) {
+		t.Fatalf("depleting at 88 should pass a plan to 85")
+	}
+}
@@ -0,0 +1,61 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// defaultDiscountRate is used for net present value when GlobalAssumptions.DiscountRate is unset
+var defaultDiscountRate = decimal.NewFromFloat(0.03)
+
+// discountRateOrDefault returns the configured discount rate, including an explicit 0%, falling back to
+// 3% when unset
+func discountRateOrDefault(assumptions *domain.GlobalAssumptions) decimal.Decimal {
+	if assumptions == nil || assumptions.DiscountRate == nil {
+		return defaultDiscountRate
+	}
+	return *assumptions.DiscountRate
+}
+
+// DiscountFactor returns 1/(1+rate)^years, the value today of one dollar received after years
+func DiscountFactor(rate decimal.Decimal, years int) decimal.Decimal {
+	return decimal.NewFromInt(1).Div(decimal.NewFromInt(1).Add(rate).Pow(decimal.NewFromInt(int64(years))))
+}
+
+// NetPresentValue discounts each year's NetIncome to the projection start. The first year is not
+// discounted, matching how the projection treats it as the current year.
+func NetPresentValue(projection []domain.AnnualCashFlow, discountRate decimal.Decimal) decimal.Decimal {
+	total := decimal.Zero
+	for i, year := range projection {
+		total = total.Add(year.NetIncome.Mul(DiscountFactor(discountRate, i)))
+	}
+	return total
+}
+
+// ApplyRealDollars fills RealNetIncome for each year by deflating NetIncome back to projection-start dollars
+func ApplyRealDollars(projection []domain.AnnualCashFlow, inflationRate decimal.Decimal) {
+	applyRealDollars(projection, constantRate(inflationRate))
+}
+
+// applyRealDollars is ApplyRealDollars with an inflation schedule
+func applyRealDollars(projection []domain.AnnualCashFlow, inflation rateSchedule) {
+	for i := range projection {
+		if len(inflation.byYear) == 0 {
+			projection[i].RealNetIncome = projection[i].NetIncome.Mul(DiscountFactor(inflation.rate, i))
+			continue
+		}
+		startYear := projection[0].Date.Year()
+		projection[i].RealNetIncome = projection[i].NetIncome.Div(inflation.growth(startYear, startYear+i))
+	}
+}
+
+// RealDollarProjection returns a copy of projection with NetIncome replaced by RealNetIncome so that
+// existing comparisons (e.g. CalculateCumulativeBreakEven) can be run in today's dollars
+func RealDollarProjection(projection []domain.AnnualCashFlow) []domain.AnnualCashFlow {
+	adjusted := make([]domain.AnnualCashFlow, len(projection))
+	copy(adjusted, projection)
+	for i := range adjusted {
+		adjusted[i].NetIncome = adjusted[i].RealNetIncome
+	}
+	return adjusted
+}
@@ -0,0 +1,96 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+func flatProjection(years int, net decimal.Decimal) []domain.AnnualCashFlow {
+	projection := make([]domain.AnnualCashFlow, years)
+	for i := range projection {
+		projection[i] = domain.AnnualCashFlow{
+			Year:      i + 1,
+			Date:      time.Date(testProjectionStartYear+i, 1, 1, 0, 0, 0, 0, time.UTC),
+			NetIncome: net,
+		}
+	}
+	return projection
+}
+
+// Test that NPV of a flat nominal stream equals the closed-form annuity-due value P * (1 - v^n) / (1 - v)
+func TestNetPresentValue_FlatStreamMatchesAnnuity(t *testing.T) {
+	payment := decimal.NewFromInt(50000)
+	rate := decimal.NewFromFloat(0.04)
+	years := 30
+
+	npv := NetPresentValue(flatProjection(years, payment), rate)
+
+	v := DiscountFactor(rate, 1)
+	one := decimal.NewFromInt(1)
+	expected := payment.Mul(one.Sub(v.Pow(decimal.NewFromInt(int64(years))))).Div(one.Sub(v))
+	if npv.Sub(expected).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("expected NPV %s, got %s", expected.StringFixed(2), npv.StringFixed(2))
+	}
+
+	if got := NetPresentValue(flatProjection(years, payment), decimal.Zero); !got.Equal(payment.Mul(decimal.NewFromInt(int64(years)))) {
+		t.Fatalf("zero discount rate should equal the nominal sum, got %s", got)
+	}
+}
+
+func TestApplyRealDollars(t *testing.T) {
+	projection := flatProjection(3, decimal.NewFromInt(102000))
+	ApplyRealDollars(projection, decimal.NewFromFloat(0.02))
+
+	if !projection[0].RealNetIncome.Equal(decimal.NewFromInt(102000)) {
+		t.Fatalf("first year should be unchanged in real dollars, got %s", projection[0].RealNetIncome)
+	}
+	if projection[1].RealNetIncome.Sub(decimal.NewFromInt(100000)).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("expected year 2 real income of 100000, got %s", projection[1].RealNetIncome)
+	}
+
+	adjusted := RealDollarProjection(projection)
+	if !adjusted[2].NetIncome.Equal(projection[2].RealNetIncome) || !projection[2].NetIncome.Equal(decimal.NewFromInt(102000)) {
+		t.Fatalf("RealDollarProjection should copy real values without modifying the input")
+	}
+}
+
+func TestDiscountRateHonorsExplicitZero(t *testing.T) {
+	rate := func(doc string) decimal.Decimal {
+		t.Helper()
+		var assumptions domain.GlobalAssumptions
+		if err := yaml.Unmarshal([]byte(doc), &assumptions); err != nil {
+			t.Fatalf("unmarshal %q: %v", doc, err)
+		}
+		return discountRateOrDefault(&assumptions)
+	}
+	if got := rate("projection_years: 10"); !got.Equal(defaultDiscountRate) {
+		t.Fatalf("expected the 3%% default when unset, got %s", got)
+	}
+	if got := rate("discount_rate: 0"); !got.IsZero() {
+		t.Fatalf("expected an explicit 0%% discount rate to be kept, got %s", got)
+	}
+	if got := rate("discount_rate: 0.05"); !got.Equal(decimal.NewFromFloat(0.05)) {
+		t.Fatalf("expected 5%%, got %s", got)
+	}
+
+	// At 0% the net present value is the nominal sum
+	cfg, scenario := retiredCoupleTestConfig(5)
+	zero := decimal.Zero
+	cfg.GlobalAssumptions.DiscountRate = &zero
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nominal := decimal.Zero
+	for _, cf := range summary.Projection {
+		nominal = nominal.Add(cf.NetIncome)
+	}
+	if !summary.NetPresentValue.Equal(nominal) {
+		t.Fatalf("expected NPV at 0%% to equal nominal income %s, got %s", nominal, summary.NetPresentValue)
+	}
+}
@@ -1,6 +1,8 @@
 package calculation
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
@@ -10,11 +12,47 @@ import (
 
 // GenerateAnnualProjection generates annual cash flow projections for a scenario
 func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.Employee, scenario *domain.Scenario, assumptions *domain.GlobalAssumptions, federalRules domain.FederalRules) []domain.AnnualCashFlow {
-	projection := make([]domain.AnnualCashFlow, assumptions.ProjectionYears)
+	projection, _ := ce.GenerateAnnualProjectionWithContext(context.Background(), personA, personB, scenario, assumptions, federalRules)
+	return projection
+}
+
+// GenerateAnnualProjectionWithContext is GenerateAnnualProjection with cancellation checked at each
+// projection year. On cancellation it returns the years completed so far and the wrapped context error.
+func (ce *CalculationEngine) GenerateAnnualProjectionWithContext(ctx context.Context, personA, personB *domain.Employee, scenario *domain.Scenario, assumptions *domain.GlobalAssumptions, federalRules domain.FederalRules) ([]domain.AnnualCashFlow, error) {
+	projection := make([]domain.AnnualCashFlow, 0, assumptions.ProjectionYears)
+	err := ce.streamAnnualProjection(ctx, personA, personB, scenario, assumptions, federalRules, func(cashFlow *domain.AnnualCashFlow) {
+		projection = append(projection, *cashFlow)
+	})
+	if err != nil {
+		return projection, err
+	}
+
+	applyRealDollars(projection, ce.inflationSchedule(assumptions))
+	MarkBlackoutYears(projection)
+
+	return projection, nil
+}
+
+// streamAnnualProjection computes the projection one year at a time and passes each year to emit without
+// keeping the years it has emitted. Passes that need the whole projection, real dollars and blackout years,
+// are left to the caller. emit must not retain the pointer.
+func (ce *CalculationEngine) streamAnnualProjection(ctx context.Context, personA, personB *domain.Employee, scenario *domain.Scenario, assumptions *domain.GlobalAssumptions, federalRules domain.FederalRules, emit func(*domain.AnnualCashFlow)) error {
+	// IRMAA looks back two years: MAGI of the last two years, indexed by year parity
+	var recentMAGI [2]decimal.Decimal
+
+	// Salaries grow until retirement and High-3 follows them. The projection works on copies so the
+	// caller's employees keep their configured salaries.
+	projectionStartYear := ProjectionStartYear(assumptions)
+	employeeA, employeeB := *personA, *personB
+	employeeA.High3Salary = personA.ProjectedHigh3Salary(scenario.PersonA.RetirementDate, projectionStartYear)
+	employeeB.High3Salary = personB.ProjectedHigh3Salary(scenario.PersonB.RetirementDate, projectionStartYear)
+	configuredA, configuredB := personA, personB
+	personA, personB = &employeeA, &employeeB
+
+	// Local income tax follows the household's municipality
+	localTaxCalc := ce.TaxCalc.LocalTaxCalculatorFor(assumptions.CurrentLocation.Municipality)
 
 	// Determine retirement year (0-based index)
-	// Projection starts at ProjectionBaseYear (first year of projection)
-	projectionStartYear := ProjectionBaseYear
 	retirementYear := scenario.PersonA.RetirementDate.Year() - projectionStartYear
 	if retirementYear < 0 {
 		retirementYear = 0
@@ -25,14 +63,30 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 	currentTSPRothPersonA := personA.TSPBalanceRoth
 	currentTSPTraditionalPersonB := personB.TSPBalanceTraditional
 	currentTSPRothPersonB := personB.TSPBalanceRoth
+	// Per-fund balances for employees with an allocation, carried between years so the mix drifts until rebalanced
+	var tspTraditionalFundsPersonA, tspRothFundsPersonA, tspTraditionalFundsPersonB, tspRothFundsPersonB TSPFundBalances
+	var deferredRMDPersonA, deferredRMDPersonB decimal.Decimal // First RMDs deferred to April 1 of the next year
+
+	// Initialize HSA balances
+	currentHSAPersonA := personA.HSABalance
+	currentHSAPersonB := personB.HSABalance
+	hsaReturn := hsaReturnOrDefault(assumptions)
+
+	// Brokerage accounts, drawn before the TSP for need-based spending
+	taxableAccountPersonA := newTaxableAccount(personA, assumptions.TSPReturnPostRetirement)
+	taxableAccountPersonB := newTaxableAccount(personB, assumptions.TSPReturnPostRetirement)
+	cashPersonA, cashPersonB := personA.CashBalance, personB.CashBalance
+
+	colaPolicy := NewFERSCOLAPolicy(federalRules.FERSRules)
+	inflation, cola := ce.inflationSchedule(assumptions), ce.colaSchedule(assumptions)
 
 	// Create TSP withdrawal strategies
 	// For Scenario 2, we need to account for extra growth before withdrawals start
-	personAStrategy := ce.createTSPStrategy(&scenario.PersonA, currentTSPTraditionalPersonA.Add(currentTSPRothPersonA), assumptions.InflationRate)
-	personBStrategy := ce.createTSPStrategy(&scenario.PersonB, currentTSPTraditionalPersonB.Add(currentTSPRothPersonB), assumptions.InflationRate)
+	personAStrategy := ce.createTSPStrategy(personA, &scenario.PersonA, currentTSPTraditionalPersonA.Add(currentTSPRothPersonA), inflation)
+	personBStrategy := ce.createTSPStrategy(personB, &scenario.PersonB, currentTSPTraditionalPersonB.Add(currentTSPRothPersonB), inflation)
 
 	// Mortality derived dates using helper
-	personADeathYearIndex, personBDeathYearIndex := deriveDeathYearIndexes(scenario, personA, personB, assumptions.ProjectionYears)
+	personADeathYearIndex, personBDeathYearIndex := deriveDeathYearIndexes(scenario, personA, personB, projectionStartYear, assumptions.ProjectionYears)
 
 	survivorSpendingFactor := decimal.NewFromFloat(1.0)
 	if scenario.Mortality != nil && scenario.Mortality.Assumptions != nil && !scenario.Mortality.Assumptions.SurvivorSpendingFactor.IsZero() {
@@ -42,14 +96,27 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 	personADeceased := false
 	personBDeceased := false
 
+	// TSP life annuities, bought at retirement under the "annuity" withdrawal strategy
+	var annuityPersonA, annuityPersonB *tspAnnuityContract
+
 	for year := 0; year < assumptions.ProjectionYears; year++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("projection cancelled at year %d: %w", projectionStartYear+year, err)
+		}
 		projectionDate := time.Date(projectionStartYear, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(year, 0, 0)
 		agePersonA := personA.Age(projectionDate)
 		agePersonB := personB.Age(projectionDate)
+		personA.CurrentSalary = configuredA.SalaryInYear(projectionDate.Year(), projectionStartYear)
+		personB.CurrentSalary = configuredB.SalaryInYear(projectionDate.Year(), projectionStartYear)
+
+		// Employee TSP contributions are capped at the elective deferral limit, with catch-up amounts by the
+		// age reached during the year
+		yearEnd := time.Date(projectionDate.Year(), 12, 31, 23, 59, 59, 0, time.UTC)
+		tspLimitPersonA := federalRules.TSPContributionLimits.AnnualLimit(personA.Age(yearEnd), projectionDate.Year(), assumptions.InflationRate)
+		tspLimitPersonB := federalRules.TSPContributionLimits.AnnualLimit(personB.Age(yearEnd), projectionDate.Year(), assumptions.InflationRate)
 
 		// Calculate partial year retirement for each person
-		// Projection starts at ProjectionBaseYear, so year 0 = ProjectionBaseYear, etc.
-		projectionStartYear := ProjectionBaseYear
+		// Year 0 is projectionStartYear, so retirement years are indexes from it
 		personARetirementYear := scenario.PersonA.RetirementDate.Year() - projectionStartYear
 		personBRetirementYear := scenario.PersonB.RetirementDate.Year() - projectionStartYear
 
@@ -65,7 +132,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			personARetirementDate := scenario.PersonA.RetirementDate
 			yearStart := time.Date(projectionDate.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
 			daysWorked := personARetirementDate.Sub(yearStart).Hours() / 24
-			daysInYear := 365.0
+			daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
 			personAWorkFraction = decimal.NewFromFloat(daysWorked / daysInYear)
 		} else if isPersonARetired {
 			personAWorkFraction = decimal.Zero
@@ -78,7 +145,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			personBRetirementDate := scenario.PersonB.RetirementDate
 			yearStart := time.Date(projectionDate.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
 			daysWorked := personBRetirementDate.Sub(yearStart).Hours() / 24
-			daysInYear := 365.0
+			daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
 			personBWorkFraction = decimal.NewFromFloat(daysWorked / daysInYear)
 		} else if isPersonBRetired {
 			personBWorkFraction = decimal.Zero
@@ -86,6 +153,25 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			personBWorkFraction = decimal.NewFromInt(1)
 		}
 
+		// Breaks in service before the retirement year pause salary and contributions
+		if year < personARetirementYear {
+			personAWorkFraction = decimal.Max(decimal.Zero, personAWorkFraction.Sub(personA.ServiceBreakFraction(projectionDate.Year())))
+		}
+		if year < personBRetirementYear {
+			personBWorkFraction = decimal.Max(decimal.Zero, personBWorkFraction.Sub(personB.ServiceBreakFraction(projectionDate.Year())))
+		}
+
+		// A QDRO transfers the former spouse's share of the TSP at the start of its year. It takes effect
+		// before retirement, so the withdrawal strategy is set up again on the reduced balance.
+		if year == qdroTransferYear(personA.QDRO, projectionStartYear) {
+			currentTSPTraditionalPersonA, currentTSPRothPersonA = applyQDROTransfer(personA.QDRO, currentTSPTraditionalPersonA, currentTSPRothPersonA)
+			personAStrategy = ce.createTSPStrategy(personA, &scenario.PersonA, currentTSPTraditionalPersonA.Add(currentTSPRothPersonA), inflation)
+		}
+		if year == qdroTransferYear(personB.QDRO, projectionStartYear) {
+			currentTSPTraditionalPersonB, currentTSPRothPersonB = applyQDROTransfer(personB.QDRO, currentTSPTraditionalPersonB, currentTSPRothPersonB)
+			personBStrategy = ce.createTSPStrategy(personB, &scenario.PersonB, currentTSPTraditionalPersonB.Add(currentTSPRothPersonB), inflation)
+		}
+
 		// Apply death events at start-of-year (Phase 1: incomes stop this year)
 		if personADeathYearIndex != nil && year >= *personADeathYearIndex {
 			personADeceased = true
@@ -111,19 +197,29 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			}
 		}
 
+		// A surviving spouse inherits the brokerage account with a stepped-up basis
+		if personADeceased && !personBDeceased {
+			taxableAccountPersonB.Inherit(taxableAccountPersonA)
+		}
+		if personBDeceased && !personADeceased {
+			taxableAccountPersonA.Inherit(taxableAccountPersonB)
+		}
+
 		// Calculate FERS pensions (only for retired portion of year, and not after death)
 		var pensionPersonA, pensionPersonB decimal.Decimal
 		var survivorPensionPersonA, survivorPensionPersonB decimal.Decimal
-		if isPersonARetired && !personADeceased {
-			pensionPersonA = CalculatePensionForYear(personA, scenario.PersonA.RetirementDate, year-personARetirementYear, assumptions.InflationRate)
-			// Adjust for partial year if retiring this year
-			if year == personARetirementYear {
-				pensionPersonA = pensionPersonA.Mul(decimal.NewFromInt(1).Sub(personAWorkFraction))
+		annuityStartPersonA := scenario.PersonA.AnnuityCommencementDate()
+		annuityStartYearPersonA := annuityStartPersonA.Year() - projectionStartYear
+		if isPersonARetired && !personADeceased && year >= annuityStartYearPersonA {
+			pensionPersonA = deferredPensionForYear(personA, scenario.PersonA.RetirementDate, annuityStartPersonA, year-annuityStartYearPersonA, inflation, colaPolicy)
+			// Adjust for partial year if the annuity starts this year
+			if year == annuityStartYearPersonA {
+				pensionPersonA = pensionPersonA.Mul(decimal.NewFromInt(1).Sub(fractionOfYearBefore(annuityStartPersonA)))
 			}
 
 			// Debug output for pension calculation
 			if ce.Debug && year == personARetirementYear {
-				ce.Logger.Debugf("DEBUG: PersonA pension calculation for year %d", ProjectionBaseYear+year)
+				ce.Logger.Debugf("DEBUG: PersonA pension calculation for year %d", projectionDate.Year())
 				ce.Logger.Debugf("  Retirement date: %s", scenario.PersonA.RetirementDate.Format("2006-01-02"))
 				ce.Logger.Debugf("  Age at retirement: %d", personA.Age(scenario.PersonA.RetirementDate))
 				ce.Logger.Debugf("  Years of service: %s", personA.YearsOfService(scenario.PersonA.RetirementDate).StringFixed(2))
@@ -139,12 +235,41 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				ce.Logger.Debugf("  Current-year cash received (partial): $%s", pensionPersonA.StringFixed(2))
 			}
 		}
-		if isPersonBRetired && !personBDeceased {
-			pensionPersonB = CalculatePensionForYear(personB, scenario.PersonB.RetirementDate, year-personBRetirementYear, assumptions.InflationRate)
-			// Adjust for partial year if retiring this year
-			if year == personBRetirementYear {
-				pensionPersonB = pensionPersonB.Mul(decimal.NewFromInt(1).Sub(personBWorkFraction))
+		annuityStartPersonB := scenario.PersonB.AnnuityCommencementDate()
+		annuityStartYearPersonB := annuityStartPersonB.Year() - projectionStartYear
+		if isPersonBRetired && !personBDeceased && year >= annuityStartYearPersonB {
+			pensionPersonB = deferredPensionForYear(personB, scenario.PersonB.RetirementDate, annuityStartPersonB, year-annuityStartYearPersonB, inflation, colaPolicy)
+			// Adjust for partial year if the annuity starts this year
+			if year == annuityStartYearPersonB {
+				pensionPersonB = pensionPersonB.Mul(decimal.NewFromInt(1).Sub(fractionOfYearBefore(annuityStartPersonB)))
+			}
+		}
+
+		// A QDRO pays the former spouse's share of each annuity payment from its effective date
+		pensionPersonA = pensionPersonA.Mul(qdroPensionFactor(personA.QDRO, projectionDate.Year()))
+		pensionPersonB = pensionPersonB.Mul(qdroPensionFactor(personB.QDRO, projectionDate.Year()))
+
+		// Non-federal pensions stop at death; their survivor share continues to the spouse
+		var externalPensionPersonA, externalPensionPersonB decimal.Decimal
+		if !personADeceased {
+			externalPensionPersonA = externalPensionsForYear(personA, projectionDate.Year(), cola)
+		} else if !personBDeceased {
+			var deathDate *time.Time
+			if scenario.Mortality.PersonA != nil {
+				deathDate = scenario.Mortality.PersonA.DeathDate
+			}
+			frac, _ := deathFractionInYear(personADeathYearIndex, year, deathDate)
+			externalPensionPersonA = externalPensionSurvivorShare(personA, projectionDate.Year(), cola, frac)
+		}
+		if !personBDeceased {
+			externalPensionPersonB = externalPensionsForYear(personB, projectionDate.Year(), cola)
+		} else if !personADeceased {
+			var deathDate *time.Time
+			if scenario.Mortality.PersonB != nil {
+				deathDate = scenario.Mortality.PersonB.DeathDate
 			}
+			frac, _ := deathFractionInYear(personBDeathYearIndex, year, deathDate)
+			externalPensionPersonB = externalPensionSurvivorShare(personB, projectionDate.Year(), cola, frac)
 		}
 
 		// Survivor pension logic with pro-rating in death year
@@ -159,7 +284,10 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				for cy := 1; cy <= yearsSinceRet; cy++ {
 					projDate := scenario.PersonA.RetirementDate.AddDate(cy, 0, 0)
 					ageAt := personA.Age(projDate)
-					currentSurvivor = ApplyFERSPensionCOLA(currentSurvivor, assumptions.InflationRate, ageAt)
+					// The retiree's own annuity follows the age-62 rule; once it converts to a
+					// survivor annuity, COLAs apply regardless of age
+					exempt := personA.SpecialCategory || (personADeathYearIndex != nil && personARetirementYear+cy >= *personADeathYearIndex)
+					currentSurvivor = colaPolicy.Apply(currentSurvivor, inflation.forYear(projDate.Year()-1), ageAt, exempt)
 				}
 				if personADeathYearIndex != nil && year >= *personADeathYearIndex {
 					// Pro-rate in death year: survivor receives only portion AFTER death
@@ -186,7 +314,8 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				for cy := 1; cy <= yearsSinceRet; cy++ {
 					projDate := scenario.PersonB.RetirementDate.AddDate(cy, 0, 0)
 					ageAt := personB.Age(projDate)
-					currentSurvivor = ApplyFERSPensionCOLA(currentSurvivor, assumptions.InflationRate, ageAt)
+					exempt := personB.SpecialCategory || (personBDeathYearIndex != nil && personBRetirementYear+cy >= *personBDeathYearIndex)
+					currentSurvivor = colaPolicy.Apply(currentSurvivor, inflation.forYear(projDate.Year()-1), ageAt, exempt)
 				}
 				if personBDeathYearIndex != nil && year >= *personBDeathYearIndex {
 					var deathDate *time.Time
@@ -206,55 +335,38 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 		// Calculate Social Security benefits
 		ssPersonA := decimal.Zero
 		if !personADeceased {
-			ssPersonA = CalculateSSBenefitForYear(personA, scenario.PersonA.SSStartAge, year, assumptions.COLAGeneralRate)
+			ssPersonA = ssBenefitForYear(personA, scenario.PersonA.SSStartAge, projectionDate.Year(), cola)
 		}
 		ssPersonB := decimal.Zero
 		if !personBDeceased {
-			ssPersonB = CalculateSSBenefitForYear(personB, scenario.PersonB.SSStartAge, year, assumptions.COLAGeneralRate)
+			ssPersonB = ssBenefitForYear(personB, scenario.PersonB.SSStartAge, projectionDate.Year(), cola)
 		}
 
-		// Prorate Social Security if the person reaches their SS start age during this calendar year
-		yearEnd := time.Date(projectionDate.Year(), 12, 31, 23, 59, 59, 0, time.UTC)
-		// PersonA
-		agePersonAStart := agePersonA
-		agePersonAEnd := personA.Age(yearEnd)
-		if agePersonAStart < scenario.PersonA.SSStartAge && agePersonAEnd >= scenario.PersonA.SSStartAge {
-			// birthday occurs this year; prorate SS for months/days after birthday
-			birthdayThisYear := time.Date(projectionDate.Year(), personA.BirthDate.Month(), personA.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
-			// If the person also retires earlier this same year (before their birthday),
-			// defer prorating to the retirement-based logic. Otherwise use the birthday-based prorate.
-			if !(year == personARetirementYear && scenario.PersonA.RetirementDate.Before(birthdayThisYear)) {
-				daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
-				daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
-				frac := daysAfter / daysInYear
-				if frac < 0 {
-					frac = 0
-				}
-				ssPersonA = ssPersonA.Mul(decimal.NewFromFloat(frac))
+		// Spousal SS: while both are alive each person receives the greater of their own benefit
+		// and the spousal benefit based on the other's PIA
+		if !personADeceased && !personBDeceased {
+			spousalA := spousalSSBenefitForYear(personA, personB, scenario.PersonA.SSStartAge, scenario.PersonB.SSStartAge, projectionDate.Year(), cola)
+			if spousalA.GreaterThan(ssPersonA) {
+				ssPersonA = spousalA
 			}
-		}
-		// PersonB
-		agePersonBStart := agePersonB
-		agePersonBEnd := personB.Age(yearEnd)
-		if agePersonBStart < scenario.PersonB.SSStartAge && agePersonBEnd >= scenario.PersonB.SSStartAge {
-			birthdayThisYear := time.Date(projectionDate.Year(), personB.BirthDate.Month(), personB.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
-			// If the person also retires earlier this same year (before their birthday),
-			// defer prorating to the retirement-based logic. Otherwise use the birthday-based prorate.
-			if !(year == personBRetirementYear && scenario.PersonB.RetirementDate.Before(birthdayThisYear)) {
-				daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
-				daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
-				frac := daysAfter / daysInYear
-				if frac < 0 {
-					frac = 0
-				}
-				ssPersonB = ssPersonB.Mul(decimal.NewFromFloat(frac))
+			spousalB := spousalSSBenefitForYear(personB, personA, scenario.PersonB.SSStartAge, scenario.PersonA.SSStartAge, projectionDate.Year(), cola)
+			if spousalB.GreaterThan(ssPersonB) {
+				ssPersonB = spousalB
 			}
 		}
+
+		// In the entitlement year Social Security is paid only from the entitlement month
+		if months := SSBenefitMonthsInYear(personA.BirthDate, scenario.PersonA.SSStartAge, projectionDate.Year()); months < 12 {
+			ssPersonA = ssPersonA.Mul(decimal.NewFromInt(int64(months))).Div(decimal.NewFromInt(12))
+		}
+		if months := SSBenefitMonthsInYear(personB.BirthDate, scenario.PersonB.SSStartAge, projectionDate.Year()); months < 12 {
+			ssPersonB = ssPersonB.Mul(decimal.NewFromInt(int64(months))).Div(decimal.NewFromInt(12))
+		}
 		// Survivor SS refined: compute survivor benefit factoring early-claim reduction
 		if personADeceased && !personBDeceased {
 			fra := dateutil.FullRetirementAge(personB.BirthDate)
 			// Use deceased's current-year benefit (pre-death). If zero (due to modeling order), recalc directly.
-			deceasedBenefit := CalculateSSBenefitForYear(personA, scenario.PersonA.SSStartAge, year, assumptions.COLAGeneralRate)
+			deceasedBenefit := ssBenefitForYear(personA, scenario.PersonA.SSStartAge, projectionDate.Year(), cola)
 			candidate := CalculateSurvivorSSBenefit(deceasedBenefit, agePersonB, fra)
 			if candidate.GreaterThan(ssPersonB) {
 				ssPersonB = candidate
@@ -262,7 +374,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 		}
 		if personBDeceased && !personADeceased {
 			fra := dateutil.FullRetirementAge(personA.BirthDate)
-			deceasedBenefit := CalculateSSBenefitForYear(personB, scenario.PersonB.SSStartAge, year, assumptions.COLAGeneralRate)
+			deceasedBenefit := ssBenefitForYear(personB, scenario.PersonB.SSStartAge, projectionDate.Year(), cola)
 			candidate := CalculateSurvivorSSBenefit(deceasedBenefit, agePersonA, fra)
 			if candidate.GreaterThan(ssPersonA) {
 				ssPersonA = candidate
@@ -280,10 +392,8 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				if scenario.PersonA.RetirementDate.Before(birthdayThisYear) {
 					ssPersonA = ssPersonA.Mul(decimal.NewFromInt(1).Sub(personAWorkFraction))
 				}
-			} else {
-				// Will start SS later when turns 62
-				ssPersonA = decimal.Zero
 			}
+			// Otherwise Social Security starts after retirement, from the entitlement month
 		}
 		if year == personBRetirementYear && personBRetirementYear >= 0 {
 			// PersonB can start SS immediately upon retirement
@@ -301,71 +411,160 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				if retirementDate.Before(birthdayThisYear) {
 					ssPersonB = ssMonthlyBenefit.Mul(decimal.NewFromInt(int64(monthsOfBenefits)))
 				}
-			} else {
-				ssPersonB = decimal.Zero
 			}
 		}
 
+		// Part-time wages after separation count as earned income for taxes and the earnings tests
+		postRetirementWagesPersonA := postRetirementWagesForYear(scenario.PersonA, isPersonARetired && !personADeceased, projectionDate.Year(), agePersonA, personAWorkFraction)
+		postRetirementWagesPersonB := postRetirementWagesForYear(scenario.PersonB, isPersonBRetired && !personBDeceased, projectionDate.Year(), agePersonB, personBWorkFraction)
+
+		// Social Security claimed before FRA is withheld under the earnings test on all wages for the year
+		ssPersonA = ApplySSEarningsTest(ssPersonA, personA.CurrentSalary.Mul(personAWorkFraction).Add(postRetirementWagesPersonA), personA.BirthDate, projectionDate.Year(), federalRules.SocialSecurityRules)
+		ssPersonB = ApplySSEarningsTest(ssPersonB, personB.CurrentSalary.Mul(personBWorkFraction).Add(postRetirementWagesPersonB), personB.BirthDate, projectionDate.Year(), federalRules.SocialSecurityRules)
+
 		// Calculate FERS Special Retirement Supplement (only if retired)
 		var srsPersonA, srsPersonB decimal.Decimal
-		if isPersonARetired && !personADeceased {
-			srsPersonA = CalculateFERSSupplementYear(personA, scenario.PersonA.RetirementDate, year-personARetirementYear, assumptions.InflationRate)
-			// Adjust for partial year if retiring this year
-			if year == personARetirementYear {
-				srsPersonA = srsPersonA.Mul(decimal.NewFromInt(1).Sub(personAWorkFraction))
-			}
+		if isPersonARetired && !personADeceased && IsSRSEligible(personA, scenario.PersonA.RetirementDate, annuityStartPersonA) {
+			// Prorated for the retirement year and the year the retiree turns 62
+			srsPersonA = fersSupplementYear(personA, scenario.PersonA.RetirementDate, year-personARetirementYear, inflation)
+			srsPersonA = ApplySRSEarningsTest(srsPersonA, postRetirementWagesPersonA, federalRules.SocialSecurityRules)
 		}
-		if isPersonBRetired && !personBDeceased {
-			srsPersonB = CalculateFERSSupplementYear(personB, scenario.PersonB.RetirementDate, year-personBRetirementYear, assumptions.InflationRate)
-			// Adjust for partial year if retiring this year
-			if year == personBRetirementYear {
-				srsPersonB = srsPersonB.Mul(decimal.NewFromInt(1).Sub(personBWorkFraction))
+		if isPersonBRetired && !personBDeceased && IsSRSEligible(personB, scenario.PersonB.RetirementDate, annuityStartPersonB) {
+			// Prorated for the retirement year and the year the retiree turns 62
+			srsPersonB = fersSupplementYear(personB, scenario.PersonB.RetirementDate, year-personBRetirementYear, inflation)
+			srsPersonB = ApplySRSEarningsTest(srsPersonB, postRetirementWagesPersonB, federalRules.SocialSecurityRules)
+		}
+
+		// Calculate FEHB and Medicare premiums. IRMAA is based on MAGI from two years prior; years before the
+		// projection are assumed to have had the current salaries as MAGI (see the life-changing event below).
+		coordination := federalRules.FEHBConfig.MedicareCoordination
+		if scenario.MedicareCoordination != "" {
+			coordination = scenario.MedicareCoordination
+		}
+		var covered, medicareEligible int
+		for _, p := range []struct {
+			employee *domain.Employee
+			deceased bool
+		}{{personA, personADeceased}, {personB, personBDeceased}} {
+			if p.deceased {
+				continue
 			}
+			covered++
+			if IsMedicareEligible(p.employee.BirthDate, projectionDate) {
+				medicareEligible++
+			}
+		}
+		fehbEnrollment := ActiveFEHBEnrollment(personA, scenario.FEHBEnrollmentChanges, projectionDate.Year(), covered)
+		fehbPremium := CalculateFEHBPremium(personA, fehbEnrollment, year, assumptions.FEHBPremiumInflation, federalRules.FEHBConfig)
+		fehbPremium = ApplyMedicareCoordination(fehbPremium, coordination, medicareEligible, covered, federalRules.FEHBConfig)
+		// The household plan is person_a's, suspended while person_a's postponed annuity has not started and
+		// lost when a deferred annuity cannot carry it
+		fehbPremium = fehbPremium.Mul(decimal.NewFromInt(1).Sub(fehbLapsedFraction(personA, scenario.PersonA, projectionDate.Year())))
+		irmaaMAGI := personA.CurrentSalary.Add(personB.CurrentSalary)
+		if year >= 2 {
+			irmaaMAGI = recentMAGI[year%2]
+		}
+		medicarePremium := ce.calculateMedicarePremium(personA, personB, projectionDate, irmaaMAGI)
+
+		// HSAs grow, take payroll contributions while working, and pay premiums tax-free once retired
+		hsaContributionPersonA, hsaContributionPersonB := CalculateHouseholdHSAContributions(
+			personA.HSAContribution, personB.HSAContribution, agePersonA, agePersonB, personAWorkFraction, personBWorkFraction,
+		)
+		hsaGrowth := decimal.NewFromInt(1).Add(hsaReturn)
+		currentHSAPersonA = currentHSAPersonA.Mul(hsaGrowth).Add(hsaContributionPersonA)
+		currentHSAPersonB = currentHSAPersonB.Mul(hsaGrowth).Add(hsaContributionPersonB)
+		premiumsDue := fehbPremium.Add(medicarePremium)
+		var hsaWithdrawalPersonA, hsaWithdrawalPersonB decimal.Decimal
+		if isPersonARetired {
+			currentHSAPersonA, hsaWithdrawalPersonA = drawHSA(currentHSAPersonA, premiumsDue)
+		}
+		if isPersonBRetired {
+			currentHSAPersonB, hsaWithdrawalPersonB = drawHSA(currentHSAPersonB, premiumsDue.Sub(hsaWithdrawalPersonA))
+		}
+
+		// A TSP life annuity consumes the whole balance in the retirement year and its payments replace withdrawals
+		if scenario.PersonA.TSPWithdrawalStrategy == TSPAnnuityStrategy && annuityPersonA == nil && isPersonARetired && !personADeceased {
+			annuityPersonA = purchaseTSPAnnuity(currentTSPTraditionalPersonA, currentTSPRothPersonA, year, agePersonA, agePersonB, scenario.PersonA.TSPAnnuity, assumptions.InflationRate)
+			currentTSPTraditionalPersonA, currentTSPRothPersonA = decimal.Zero, decimal.Zero
+		}
+		if scenario.PersonB.TSPWithdrawalStrategy == TSPAnnuityStrategy && annuityPersonB == nil && isPersonBRetired && !personBDeceased {
+			annuityPersonB = purchaseTSPAnnuity(currentTSPTraditionalPersonB, currentTSPRothPersonB, year, agePersonB, agePersonA, scenario.PersonB.TSPAnnuity, assumptions.InflationRate)
+			currentTSPTraditionalPersonB, currentTSPRothPersonB = decimal.Zero, decimal.Zero
+		}
+		tspAnnuityPersonA, taxableAnnuityPersonA := annuityPersonA.paymentForYear(year, !personADeceased, !personBDeceased)
+		tspAnnuityPersonB, taxableAnnuityPersonB := annuityPersonB.paymentForYear(year, !personBDeceased, !personADeceased)
+		if year == personARetirementYear {
+			tspAnnuityPersonA = tspAnnuityPersonA.Mul(decimal.NewFromInt(1).Sub(personAWorkFraction))
+			taxableAnnuityPersonA = taxableAnnuityPersonA.Mul(decimal.NewFromInt(1).Sub(personAWorkFraction))
+		}
+		if year == personBRetirementYear {
+			tspAnnuityPersonB = tspAnnuityPersonB.Mul(decimal.NewFromInt(1).Sub(personBWorkFraction))
+			taxableAnnuityPersonB = taxableAnnuityPersonB.Mul(decimal.NewFromInt(1).Sub(personBWorkFraction))
 		}
 
 		// Calculate TSP withdrawals and update balances
 		var tspWithdrawalPersonA, tspWithdrawalPersonB decimal.Decimal
 
-		// Calculate RMD amounts (full and prorated) for this year for each person
+		// Calculate RMD amounts (full and prorated) for this year for each person. Balances have not yet
+		// grown this year, so RMDs divide the prior year-end traditional balance.
 		rmdPersonA := decimal.Zero
 		rmdPersonB := decimal.Zero
 		// PersonA RMD
 		rmdAgePersonA := dateutil.GetRMDAge(personA.BirthDate.Year())
-		agePersonAEnd = personA.Age(yearEnd)
+		agePersonAEnd := personA.Age(yearEnd)
 		if agePersonA < rmdAgePersonA && agePersonAEnd >= rmdAgePersonA {
-			// First RMD year: prorate based on birthday
-			birthdayThisYear := time.Date(projectionDate.Year(), personA.BirthDate.Month(), personA.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
-			daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
-			daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
-			frac := daysAfter / daysInYear
-			if frac < 0 {
-				frac = 0
+			// First RMD year: prorate based on birthday, unless the full first RMD is deferred to April 1 of the
+			// next year
+			rmdPersonA = CalculateRMD(currentTSPTraditionalPersonA, personA.BirthDate.Year(), rmdAgePersonA)
+			if !scenario.PersonA.DeferFirstRMD {
+				birthdayThisYear := time.Date(projectionDate.Year(), personA.BirthDate.Month(), personA.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
+				daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
+				daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
+				frac := daysAfter / daysInYear
+				if frac < 0 {
+					frac = 0
+				}
+				rmdPersonA = rmdPersonA.Mul(decimal.NewFromFloat(frac))
 			}
-			fullRMD := CalculateRMD(currentTSPTraditionalPersonA, personA.BirthDate.Year(), rmdAgePersonA)
-			rmdPersonA = fullRMD.Mul(decimal.NewFromFloat(frac))
 		} else if agePersonA >= rmdAgePersonA {
 			// Regular RMD year (apply full amount)
 			rmdPersonA = CalculateRMD(currentTSPTraditionalPersonA, personA.BirthDate.Year(), agePersonA)
 		}
 		// PersonB RMD
 		rmdAgePersonB := dateutil.GetRMDAge(personB.BirthDate.Year())
-		agePersonBEnd = personB.Age(yearEnd)
+		agePersonBEnd := personB.Age(yearEnd)
 		if agePersonB < rmdAgePersonB && agePersonBEnd >= rmdAgePersonB {
-			birthdayThisYear := time.Date(projectionDate.Year(), personB.BirthDate.Month(), personB.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
-			daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
-			daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
-			frac := daysAfter / daysInYear
-			if frac < 0 {
-				frac = 0
+			// First RMD year: prorate based on birthday, unless the full first RMD is deferred to April 1 of the
+			// next year
+			rmdPersonB = CalculateRMD(currentTSPTraditionalPersonB, personB.BirthDate.Year(), rmdAgePersonB)
+			if !scenario.PersonB.DeferFirstRMD {
+				birthdayThisYear := time.Date(projectionDate.Year(), personB.BirthDate.Month(), personB.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
+				daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
+				daysInYear := float64(dateutil.DaysInYear(projectionDate.Year()))
+				frac := daysAfter / daysInYear
+				if frac < 0 {
+					frac = 0
+				}
+				rmdPersonB = rmdPersonB.Mul(decimal.NewFromFloat(frac))
 			}
-			fullRMD := CalculateRMD(currentTSPTraditionalPersonB, personB.BirthDate.Year(), rmdAgePersonB)
-			rmdPersonB = fullRMD.Mul(decimal.NewFromFloat(frac))
 		} else if agePersonB >= rmdAgePersonB {
 			rmdPersonB = CalculateRMD(currentTSPTraditionalPersonB, personB.BirthDate.Year(), agePersonB)
 		}
-		if isPersonARetired && !personADeceased {
+		// The 4% rule floors withdrawals at the full-year RMD; a deferred first RMD is instead taken the
+		// following year on top of that year's own RMD, so deferral floors at the deferred amounts
+		rmdFloorPersonA := CalculateRMD(currentTSPTraditionalPersonA, personA.BirthDate.Year(), agePersonA)
+		rmdFloorPersonB := CalculateRMD(currentTSPTraditionalPersonB, personB.BirthDate.Year(), agePersonB)
+		if scenario.PersonA.DeferFirstRMD && !personADeceased {
+			rmdPersonA, deferredRMDPersonA = deferFirstRMD(rmdPersonA, deferredRMDPersonA, projectionDate.Year() == personA.BirthDate.Year()+rmdAgePersonA)
+			rmdFloorPersonA = rmdPersonA
+		}
+		if scenario.PersonB.DeferFirstRMD && !personBDeceased {
+			rmdPersonB, deferredRMDPersonB = deferFirstRMD(rmdPersonB, deferredRMDPersonB, projectionDate.Year() == personB.BirthDate.Year()+rmdAgePersonB)
+			rmdFloorPersonB = rmdPersonB
+		}
+		if isPersonARetired && !personADeceased && annuityPersonA == nil {
 			// For 4% rule: Always withdraw 4% of initial balance (adjusted for inflation)
-			if scenario.PersonA.TSPWithdrawalStrategy == "4_percent_rule" {
+			if isInitialRateStrategy(scenario.PersonA.TSPWithdrawalStrategy) {
 				// Use the 4% rule strategy to calculate withdrawals
 				tspWithdrawalPersonA = personAStrategy.CalculateWithdrawal(
 					currentTSPTraditionalPersonA.Add(currentTSPRothPersonA),
@@ -373,7 +572,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 					decimal.Zero, // Not used for 4% rule
 					agePersonA,
 					dateutil.IsRMDYear(personA.BirthDate, projectionDate),
-					CalculateRMD(currentTSPTraditionalPersonA, personA.BirthDate.Year(), agePersonA),
+					rmdFloorPersonA,
 				)
 				// Adjust for partial year if retiring this year
 				if year == personARetirementYear {
@@ -381,7 +580,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				}
 			} else {
 				// For need_based: Use the target monthly amount
-				targetIncome := pensionPersonA.Add(pensionPersonB).Add(ssPersonA).Add(ssPersonB).Add(srsPersonA).Add(srsPersonB)
+				targetIncome := pensionPersonA.Add(pensionPersonB).Add(ssPersonA).Add(ssPersonB).Add(srsPersonA).Add(srsPersonB).Add(tspAnnuityPersonA).Add(tspAnnuityPersonB)
 
 				// Calculate withdrawals
 				tspWithdrawalPersonA = personAStrategy.CalculateWithdrawal(
@@ -399,15 +598,15 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			}
 		}
 
-		if isPersonBRetired && !personBDeceased {
-			if scenario.PersonB.TSPWithdrawalStrategy == "4_percent_rule" {
+		if isPersonBRetired && !personBDeceased && annuityPersonB == nil {
+			if isInitialRateStrategy(scenario.PersonB.TSPWithdrawalStrategy) {
 				tspWithdrawalPersonB = personBStrategy.CalculateWithdrawal(
 					currentTSPTraditionalPersonB.Add(currentTSPRothPersonB),
 					year-personBRetirementYear+1,
 					decimal.Zero, // Not used for 4% rule
 					agePersonB,
 					dateutil.IsRMDYear(personB.BirthDate, projectionDate),
-					CalculateRMD(currentTSPTraditionalPersonB, personB.BirthDate.Year(), agePersonB),
+					rmdFloorPersonB,
 				)
 				// Adjust for partial year if retiring this year
 				if year == personBRetirementYear {
@@ -415,7 +614,7 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 				}
 			} else {
 				// For need_based: Use the target monthly amount
-				targetIncome := pensionPersonA.Add(pensionPersonB).Add(ssPersonA).Add(ssPersonB).Add(srsPersonA).Add(srsPersonB)
+				targetIncome := pensionPersonA.Add(pensionPersonB).Add(ssPersonA).Add(ssPersonB).Add(srsPersonA).Add(srsPersonB).Add(tspAnnuityPersonA).Add(tspAnnuityPersonB)
 
 				// Calculate withdrawals
 				tspWithdrawalPersonB = personBStrategy.CalculateWithdrawal(
@@ -433,46 +632,167 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			}
 		}
 
-		// Update TSP balances
+		// Premiums paid from the HSA no longer need to be funded by need-based TSP withdrawals (RMDs still apply)
+		if scenario.PersonA.TSPWithdrawalStrategy == "need_based" && hsaWithdrawalPersonA.GreaterThan(decimal.Zero) {
+			tspWithdrawalPersonA = decimal.Max(tspWithdrawalPersonA.Sub(hsaWithdrawalPersonA), decimal.Min(rmdPersonA, tspWithdrawalPersonA))
+		}
+		if scenario.PersonB.TSPWithdrawalStrategy == "need_based" && hsaWithdrawalPersonB.GreaterThan(decimal.Zero) {
+			tspWithdrawalPersonB = decimal.Max(tspWithdrawalPersonB.Sub(hsaWithdrawalPersonB), decimal.Min(rmdPersonB, tspWithdrawalPersonB))
+		}
+
+		// Need-based spending above the RMD comes from the brokerage account first. Sales realize gains and
+		// dividends are taxed every year; both are taxed at long-term capital gains rates.
+		var taxableDrawPersonA, taxableDrawPersonB, realizedGainPersonA, realizedGainPersonB decimal.Decimal
+		if scenario.PersonA.TSPWithdrawalStrategy == "need_based" && isPersonARetired && !personADeceased {
+			taxableDrawPersonA, realizedGainPersonA = taxableAccountPersonA.Withdraw(tspWithdrawalPersonA.Sub(rmdPersonA))
+			tspWithdrawalPersonA = tspWithdrawalPersonA.Sub(taxableDrawPersonA)
+		}
+		if scenario.PersonB.TSPWithdrawalStrategy == "need_based" && isPersonBRetired && !personBDeceased {
+			taxableDrawPersonB, realizedGainPersonB = taxableAccountPersonB.Withdraw(tspWithdrawalPersonB.Sub(rmdPersonB))
+			tspWithdrawalPersonB = tspWithdrawalPersonB.Sub(taxableDrawPersonB)
+		}
+		dividends := taxableAccountPersonA.Grow(ce.portfolioReturnForYear(year, taxableAccountPersonA.Return)).
+			Add(taxableAccountPersonB.Grow(ce.portfolioReturnForYear(year, taxableAccountPersonB.Return)))
+		capitalGains := realizedGainPersonA.Add(realizedGainPersonB).Add(dividends)
+
+		// Cash reserves earn taxable interest, which stays in the account
+		interestIncome := cashPersonA.Mul(personA.CashYield).Add(cashPersonB.Mul(personB.CashYield))
+		cashPersonA = cashPersonA.Add(cashPersonA.Mul(personA.CashYield))
+		cashPersonB = cashPersonB.Add(cashPersonB.Mul(personB.CashYield))
+
+		events := financialEventsForYear(scenario.Events, projectionDate.Year())
+
+		// Need-based targets set as net income are grossed up for the federal, state, and local tax the
+		// traditional withdrawal adds to the rest of the year's income
+		needBasedPersonA, isNeedBasedPersonA := personAStrategy.(*NeedBasedWithdrawal)
+		needBasedPersonB, isNeedBasedPersonB := personBStrategy.(*NeedBasedWithdrawal)
+		if (isNeedBasedPersonA && needBasedPersonA.Net) || (isNeedBasedPersonB && needBasedPersonB.Net) {
+			workingA := personA.CurrentSalary.Mul(personAWorkFraction).Sub(hsaContributionPersonA).Add(postRetirementWagesPersonA)
+			workingB := personB.CurrentSalary.Mul(personBWorkFraction).Sub(hsaContributionPersonB).Add(postRetirementWagesPersonB)
+			incomeTax := func(withdrawalA, withdrawalB decimal.Decimal) decimal.Decimal {
+				federal, state, local, _, _, _, _, _ := ce.calculateTaxes(
+					personA, personB, scenario, localTaxCalc, projectionStartYear, year, isPersonARetired && isPersonBRetired,
+					pensionPersonA.Add(externalPensionPersonA), pensionPersonB.Add(externalPensionPersonB), survivorPensionPersonA, survivorPensionPersonB,
+					decimal.Min(withdrawalA, currentTSPTraditionalPersonA).Add(taxableAnnuityPersonA),
+					decimal.Min(withdrawalB, currentTSPTraditionalPersonB).Add(taxableAnnuityPersonB),
+					ssPersonA, ssPersonB, workingA, workingB, events.TaxableCash.Add(events.TaxableTSP), capitalGains, interestIncome,
+				)
+				return federal.Add(state).Add(local)
+			}
+			if isNeedBasedPersonA && isPersonARetired && !personADeceased {
+				baseTax := incomeTax(decimal.Zero, tspWithdrawalPersonB)
+				tspWithdrawalPersonA = needBasedPersonA.GrossUpWithdrawal(tspWithdrawalPersonA, currentTSPTraditionalPersonA.Add(currentTSPRothPersonA), year-personARetirementYear+1, agePersonA, func(gross decimal.Decimal) decimal.Decimal {
+					return incomeTax(gross, tspWithdrawalPersonB).Sub(baseTax)
+				})
+			}
+			if isNeedBasedPersonB && isPersonBRetired && !personBDeceased {
+				baseTax := incomeTax(tspWithdrawalPersonA, decimal.Zero)
+				tspWithdrawalPersonB = needBasedPersonB.GrossUpWithdrawal(tspWithdrawalPersonB, currentTSPTraditionalPersonB.Add(currentTSPRothPersonB), year-personBRetirementYear+1, agePersonB, func(gross decimal.Decimal) decimal.Decimal {
+					return incomeTax(tspWithdrawalPersonA, gross).Sub(baseTax)
+				})
+			}
+		}
+
+		// Tax-aware strategies choose the traditional/Roth mix from the bracket headroom left by the rest of
+		// the year's income. Other strategies are split by the balance update below, and only the traditional
+		// portion is taxed.
+		fromTraditionalPersonA, fromRothPersonA := tspWithdrawalPersonA, decimal.Zero
+		fromTraditionalPersonB, fromRothPersonB := tspWithdrawalPersonB, decimal.Zero
+		taxAwarePersonA, isTaxAwarePersonA := personAStrategy.(TaxAwareWithdrawalStrategy)
+		taxAwarePersonB, isTaxAwarePersonB := personBStrategy.(TaxAwareWithdrawalStrategy)
+		withdrawalTaxContext := func() WithdrawalTaxContext {
+			nonSSIncome := personA.CurrentSalary.Mul(personAWorkFraction).Add(personB.CurrentSalary.Mul(personBWorkFraction)).
+				Add(postRetirementWagesPersonA).Add(postRetirementWagesPersonB).
+				Add(pensionPersonA).Add(pensionPersonB).Add(survivorPensionPersonA).Add(survivorPensionPersonB).
+				Add(externalPensionPersonA).Add(externalPensionPersonB).
+				Add(taxableAnnuityPersonA).Add(taxableAnnuityPersonB).
+				Add(events.TaxableCash).Add(events.TaxableTSP).Add(capitalGains).Add(interestIncome)
+			filingStatus, seniors, _, _ := filingStatusForYear(scenario, personA, personB, projectionStartYear, year)
+			standardDeduction, brackets := ce.TaxCalc.federalDeductionAndBrackets(filingStatus, seniors)
+			ss := ssPersonA.Add(ssPersonB)
+			provisional := ce.TaxCalc.SSTaxCalc.CalculateProvisionalIncome(nonSSIncome, decimal.Zero, ss)
+			taxableSS := ce.TaxCalc.SSTaxCalc.CalculateTaxableSocialSecurity(ss, provisional)
+			if filingStatus == "single" {
+				taxableSS = ce.TaxCalc.SSTaxCalc.CalculateTaxableSocialSecuritySingle(ss, provisional)
+			}
+			return WithdrawalTaxContext{
+				OtherTaxableIncome: nonSSIncome.Add(taxableSS),
+				StandardDeduction:  standardDeduction,
+				Brackets:           brackets,
+			}
+		}
+
+		// RMD smoothing raises traditional withdrawals before RMDs begin so less is left for RMDs to force out.
+		// Like the RMD, the smoothed amount must come from the traditional balance.
+		traditionalMinimumPersonA, traditionalMinimumPersonB := rmdPersonA, rmdPersonB
+		if smoothing := scenario.PersonA.RMDSmoothing; smoothing != nil && isPersonARetired && !personADeceased && annuityPersonA == nil && !isTaxAwarePersonA && agePersonA < rmdAgePersonA {
+			taxContext := withdrawalTaxContext()
+			taxContext.OtherTaxableIncome = taxContext.OtherTaxableIncome.Add(tspWithdrawalPersonB)
+			floor := rmdSmoothingFloor(smoothing, taxContext)
+			traditionalMinimumPersonA = decimal.Max(traditionalMinimumPersonA, decimal.Min(floor, currentTSPTraditionalPersonA))
+			tspWithdrawalPersonA = decimal.Max(tspWithdrawalPersonA, traditionalMinimumPersonA)
+		}
+		if smoothing := scenario.PersonB.RMDSmoothing; smoothing != nil && isPersonBRetired && !personBDeceased && annuityPersonB == nil && !isTaxAwarePersonB && agePersonB < rmdAgePersonB {
+			taxContext := withdrawalTaxContext()
+			taxContext.OtherTaxableIncome = taxContext.OtherTaxableIncome.Add(tspWithdrawalPersonA)
+			floor := rmdSmoothingFloor(smoothing, taxContext)
+			traditionalMinimumPersonB = decimal.Max(traditionalMinimumPersonB, decimal.Min(floor, currentTSPTraditionalPersonB))
+			tspWithdrawalPersonB = decimal.Max(tspWithdrawalPersonB, traditionalMinimumPersonB)
+		}
+
+		if isTaxAwarePersonA || isTaxAwarePersonB {
+			taxContext := withdrawalTaxContext()
+			if isTaxAwarePersonA && tspWithdrawalPersonA.GreaterThan(decimal.Zero) {
+				fromTraditionalPersonA, fromRothPersonA = taxAwarePersonA.SourceWithdrawal(tspWithdrawalPersonA, currentTSPTraditionalPersonA, currentTSPRothPersonA, rmdPersonA, taxContext)
+				taxContext.OtherTaxableIncome = taxContext.OtherTaxableIncome.Add(fromTraditionalPersonA)
+			}
+			if isTaxAwarePersonB && tspWithdrawalPersonB.GreaterThan(decimal.Zero) {
+				fromTraditionalPersonB, fromRothPersonB = taxAwarePersonB.SourceWithdrawal(tspWithdrawalPersonB, currentTSPTraditionalPersonB, currentTSPRothPersonB, rmdPersonB, taxContext)
+			}
+		}
+
+		// Update TSP balances. Employees with an allocation are tracked per fund: every fund grows at its own
+		// return and the account is rebalanced to the target allocation on the employee's schedule.
+		var tspFundsPersonA, tspFundsPersonB map[string]decimal.Decimal
 		if isPersonARetired {
 			// Post-retirement TSP growth with withdrawals
 			// Use lifecycle fund allocation if available, otherwise use default return rate
 			if personA.TSPLifecycleFund != nil || personA.TSPAllocation != nil {
-				// Apply withdrawal first
-				if tspWithdrawalPersonA.GreaterThan(currentTSPTraditionalPersonA) {
-					// Take from Roth if traditional is insufficient
-					remainingWithdrawal := tspWithdrawalPersonA.Sub(currentTSPTraditionalPersonA)
-					currentTSPTraditionalPersonA = decimal.Zero
-					if remainingWithdrawal.GreaterThan(currentTSPRothPersonA) {
-						currentTSPRothPersonA = decimal.Zero
-					} else {
-						currentTSPRothPersonA = currentTSPRothPersonA.Sub(remainingWithdrawal)
-					}
-				} else {
-					currentTSPTraditionalPersonA = currentTSPTraditionalPersonA.Sub(tspWithdrawalPersonA)
+				// Apply withdrawal first, from traditional and then Roth unless a tax-aware strategy sourced it
+				if !isTaxAwarePersonA {
+					fromTraditionalPersonA, fromRothPersonA = sourceTraditionalFirst(tspWithdrawalPersonA, currentTSPTraditionalPersonA, currentTSPRothPersonA)
 				}
+				currentTSPTraditionalPersonA, currentTSPRothPersonA = withdrawSourced(currentTSPTraditionalPersonA, currentTSPRothPersonA, fromTraditionalPersonA, fromRothPersonA)
 
-				// Apply growth using lifecycle fund allocation
-				allocation := ce.getTSPAllocationForEmployee(personA, projectionDate)
-				weightedReturn := ce.calculateTSPReturnWithAllocation(allocation, projectionDate.Year())
-
-				currentTSPTraditionalPersonA = currentTSPTraditionalPersonA.Mul(decimal.NewFromFloat(1).Add(weightedReturn))
-				currentTSPRothPersonA = currentTSPRothPersonA.Mul(decimal.NewFromFloat(1).Add(weightedReturn))
+				// Carry each fund forward at its own return, rebalancing on the employee's schedule
+				tspTraditionalFundsPersonA = ce.growTSPFunds(personA, tspTraditionalFundsPersonA, currentTSPTraditionalPersonA, decimal.Zero, projectionDate, year)
+				tspRothFundsPersonA = ce.growTSPFunds(personA, tspRothFundsPersonA, currentTSPRothPersonA, decimal.Zero, projectionDate, year)
+				currentTSPTraditionalPersonA = tspTraditionalFundsPersonA.Total()
+				currentTSPRothPersonA = tspRothFundsPersonA.Total()
+				tspFundsPersonA = tspTraditionalFundsPersonA.Add(tspRothFundsPersonA).AsMap()
 			} else {
-				currentTSPTraditionalPersonA, currentTSPRothPersonA = ce.updateTSPBalances(
-					currentTSPTraditionalPersonA, currentTSPRothPersonA, tspWithdrawalPersonA,
-					assumptions.TSPReturnPostRetirement,
-				)
+				// Grow, then withdraw the RMD from traditional and the rest from Roth first unless a tax-aware
+				// strategy sourced it
+				returnRate := decimal.NewFromInt(1).Add(ce.portfolioReturnForYear(year, assumptions.PostRetirementReturnAtAge(agePersonA)))
+				grownTraditional, grownRoth := currentTSPTraditionalPersonA.Mul(returnRate), currentTSPRothPersonA.Mul(returnRate)
+				if !isTaxAwarePersonA {
+					fromTraditionalPersonA, fromRothPersonA = sourceRMDThenRoth(tspWithdrawalPersonA, grownTraditional, grownRoth, traditionalMinimumPersonA)
+				}
+				currentTSPTraditionalPersonA, currentTSPRothPersonA = withdrawSourced(grownTraditional, grownRoth, fromTraditionalPersonA, fromRothPersonA)
 			}
 		} else {
-			// Pre-retirement TSP growth with contributions
+			// Pre-retirement TSP growth with contributions, the Roth share of the employee's going to Roth
 			// Use lifecycle fund allocation if available, otherwise use default return rate
+			traditionalContributionPersonA, rothContributionPersonA := personA.LimitedTSPContributionSplit(tspLimitPersonA)
 			if personA.TSPLifecycleFund != nil || personA.TSPAllocation != nil {
-				currentTSPTraditionalPersonA = ce.growTSPBalanceWithAllocation(personA, currentTSPTraditionalPersonA, personA.TotalAnnualTSPContribution(), projectionDate)
-				currentTSPRothPersonA = ce.growTSPBalanceWithAllocation(personA, currentTSPRothPersonA, decimal.Zero, projectionDate)
+				tspTraditionalFundsPersonA = ce.growTSPFunds(personA, tspTraditionalFundsPersonA, currentTSPTraditionalPersonA, traditionalContributionPersonA, projectionDate, year)
+				tspRothFundsPersonA = ce.growTSPFunds(personA, tspRothFundsPersonA, currentTSPRothPersonA, rothContributionPersonA, projectionDate, year)
+				currentTSPTraditionalPersonA = tspTraditionalFundsPersonA.Total()
+				currentTSPRothPersonA = tspRothFundsPersonA.Total()
+				tspFundsPersonA = tspTraditionalFundsPersonA.Add(tspRothFundsPersonA).AsMap()
 			} else {
-				currentTSPTraditionalPersonA = ce.growTSPBalance(currentTSPTraditionalPersonA, personA.TotalAnnualTSPContribution(), assumptions.TSPReturnPreRetirement)
-				currentTSPRothPersonA = ce.growTSPBalance(currentTSPRothPersonA, decimal.Zero, assumptions.TSPReturnPreRetirement)
+				currentTSPTraditionalPersonA = ce.growTSPBalance(currentTSPTraditionalPersonA, traditionalContributionPersonA, ce.portfolioReturnForYear(year, assumptions.TSPReturnPreRetirement))
+				currentTSPRothPersonA = ce.growTSPBalance(currentTSPRothPersonA, rothContributionPersonA, ce.portfolioReturnForYear(year, assumptions.TSPReturnPreRetirement))
 			}
 		}
 
@@ -480,119 +800,183 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 			// Post-retirement TSP growth with withdrawals
 			// Use lifecycle fund allocation if available, otherwise use default return rate
 			if personB.TSPLifecycleFund != nil || personB.TSPAllocation != nil {
-				// Apply withdrawal first
-				if tspWithdrawalPersonB.GreaterThan(currentTSPTraditionalPersonB) {
-					// Take from Roth if traditional is insufficient
-					remainingWithdrawal := tspWithdrawalPersonB.Sub(currentTSPTraditionalPersonB)
-					currentTSPTraditionalPersonB = decimal.Zero
-					if remainingWithdrawal.GreaterThan(currentTSPRothPersonB) {
-						currentTSPRothPersonB = decimal.Zero
-					} else {
-						currentTSPRothPersonB = currentTSPRothPersonB.Sub(remainingWithdrawal)
-					}
-				} else {
-					currentTSPTraditionalPersonB = currentTSPTraditionalPersonB.Sub(tspWithdrawalPersonB)
+				// Apply withdrawal first, from traditional and then Roth unless a tax-aware strategy sourced it
+				if !isTaxAwarePersonB {
+					fromTraditionalPersonB, fromRothPersonB = sourceTraditionalFirst(tspWithdrawalPersonB, currentTSPTraditionalPersonB, currentTSPRothPersonB)
 				}
+				currentTSPTraditionalPersonB, currentTSPRothPersonB = withdrawSourced(currentTSPTraditionalPersonB, currentTSPRothPersonB, fromTraditionalPersonB, fromRothPersonB)
 
-				// Apply growth using lifecycle fund allocation
-				allocation := ce.getTSPAllocationForEmployee(personB, projectionDate)
-				weightedReturn := ce.calculateTSPReturnWithAllocation(allocation, projectionDate.Year())
-
-				currentTSPTraditionalPersonB = currentTSPTraditionalPersonB.Mul(decimal.NewFromFloat(1).Add(weightedReturn))
-				currentTSPRothPersonB = currentTSPRothPersonB.Mul(decimal.NewFromFloat(1).Add(weightedReturn))
+				// Carry each fund forward at its own return, rebalancing on the employee's schedule
+				tspTraditionalFundsPersonB = ce.growTSPFunds(personB, tspTraditionalFundsPersonB, currentTSPTraditionalPersonB, decimal.Zero, projectionDate, year)
+				tspRothFundsPersonB = ce.growTSPFunds(personB, tspRothFundsPersonB, currentTSPRothPersonB, decimal.Zero, projectionDate, year)
+				currentTSPTraditionalPersonB = tspTraditionalFundsPersonB.Total()
+				currentTSPRothPersonB = tspRothFundsPersonB.Total()
+				tspFundsPersonB = tspTraditionalFundsPersonB.Add(tspRothFundsPersonB).AsMap()
 			} else {
-				currentTSPTraditionalPersonB, currentTSPRothPersonB = ce.updateTSPBalances(
-					currentTSPTraditionalPersonB, currentTSPRothPersonB, tspWithdrawalPersonB,
-					assumptions.TSPReturnPostRetirement,
-				)
+				// Grow, then withdraw the RMD from traditional and the rest from Roth first unless a tax-aware
+				// strategy sourced it
+				returnRate := decimal.NewFromInt(1).Add(ce.portfolioReturnForYear(year, assumptions.PostRetirementReturnAtAge(agePersonB)))
+				grownTraditional, grownRoth := currentTSPTraditionalPersonB.Mul(returnRate), currentTSPRothPersonB.Mul(returnRate)
+				if !isTaxAwarePersonB {
+					fromTraditionalPersonB, fromRothPersonB = sourceRMDThenRoth(tspWithdrawalPersonB, grownTraditional, grownRoth, traditionalMinimumPersonB)
+				}
+				currentTSPTraditionalPersonB, currentTSPRothPersonB = withdrawSourced(grownTraditional, grownRoth, fromTraditionalPersonB, fromRothPersonB)
 			}
 		} else {
-			// Pre-retirement TSP growth with contributions
+			// Pre-retirement TSP growth with contributions, the Roth share of the employee's going to Roth
 			// Use lifecycle fund allocation if available, otherwise use default return rate
+			traditionalContributionPersonB, rothContributionPersonB := personB.LimitedTSPContributionSplit(tspLimitPersonB)
 			if personB.TSPLifecycleFund != nil || personB.TSPAllocation != nil {
-				currentTSPTraditionalPersonB = ce.growTSPBalanceWithAllocation(personB, currentTSPTraditionalPersonB, personB.TotalAnnualTSPContribution(), projectionDate)
-				currentTSPRothPersonB = ce.growTSPBalanceWithAllocation(personB, currentTSPRothPersonB, decimal.Zero, projectionDate)
+				tspTraditionalFundsPersonB = ce.growTSPFunds(personB, tspTraditionalFundsPersonB, currentTSPTraditionalPersonB, traditionalContributionPersonB, projectionDate, year)
+				tspRothFundsPersonB = ce.growTSPFunds(personB, tspRothFundsPersonB, currentTSPRothPersonB, rothContributionPersonB, projectionDate, year)
+				currentTSPTraditionalPersonB = tspTraditionalFundsPersonB.Total()
+				currentTSPRothPersonB = tspRothFundsPersonB.Total()
+				tspFundsPersonB = tspTraditionalFundsPersonB.Add(tspRothFundsPersonB).AsMap()
 			} else {
-				currentTSPTraditionalPersonB = ce.growTSPBalance(currentTSPTraditionalPersonB, personB.TotalAnnualTSPContribution(), assumptions.TSPReturnPreRetirement)
-				currentTSPRothPersonB = ce.growTSPBalance(currentTSPRothPersonB, decimal.Zero, assumptions.TSPReturnPreRetirement)
+				currentTSPTraditionalPersonB = ce.growTSPBalance(currentTSPTraditionalPersonB, traditionalContributionPersonB, ce.portfolioReturnForYear(year, assumptions.TSPReturnPreRetirement))
+				currentTSPRothPersonB = ce.growTSPBalance(currentTSPRothPersonB, rothContributionPersonB, ce.portfolioReturnForYear(year, assumptions.TSPReturnPreRetirement))
 			}
 		}
 
+		// Apply one-time financial events. TSP events use PersonA's account unless PersonA has died.
+		var eventTSPDistribution decimal.Decimal
+		if !events.TSPInflow.IsZero() || !events.TSPOutflow.IsZero() {
+			if personADeceased && !personBDeceased {
+				currentTSPTraditionalPersonB, currentTSPRothPersonB, eventTSPDistribution = applyTSPEvent(currentTSPTraditionalPersonB, currentTSPRothPersonB, events.TSPInflow, events.TSPOutflow)
+				tspFundsPersonB = scaleFundBalances(tspFundsPersonB, currentTSPTraditionalPersonB.Add(currentTSPRothPersonB))
+			} else {
+				currentTSPTraditionalPersonA, currentTSPRothPersonA, eventTSPDistribution = applyTSPEvent(currentTSPTraditionalPersonA, currentTSPRothPersonA, events.TSPInflow, events.TSPOutflow)
+				tspFundsPersonA = scaleFundBalances(tspFundsPersonA, currentTSPTraditionalPersonA.Add(currentTSPRothPersonA))
+			}
+		}
+		eventTaxableIncome := events.TaxableCash.Add(decimal.Min(events.TaxableTSP, eventTSPDistribution))
+
+		// Scheduled Roth conversions move traditional balances to Roth; the amount is taxed as ordinary income
+		var rothConversionPersonA, rothConversionPersonB decimal.Decimal
+		if amount, ok := scenario.PersonA.RothConversions[projectionDate.Year()]; ok && !personADeceased {
+			currentTSPTraditionalPersonA, currentTSPRothPersonA, rothConversionPersonA = applyRothConversion(currentTSPTraditionalPersonA, currentTSPRothPersonA, amount)
+		}
+		if amount, ok := scenario.PersonB.RothConversions[projectionDate.Year()]; ok && !personBDeceased {
+			currentTSPTraditionalPersonB, currentTSPRothPersonB, rothConversionPersonB = applyRothConversion(currentTSPTraditionalPersonB, currentTSPRothPersonB, amount)
+		}
+
 		// Debug TSP balances for Scenario 2 to show extra growth
 		if ce.Debug && year == 1 && scenario.PersonA.RetirementDate.Year() == 2027 {
-			ce.Logger.Debugf("TSP Growth in Scenario 2 (year %d)", ProjectionBaseYear+year)
+			ce.Logger.Debugf("TSP Growth in Scenario 2 (year %d)", projectionDate.Year())
 			ce.Logger.Debugf("  PersonA's TSP balance: %s", currentTSPTraditionalPersonA.Add(currentTSPRothPersonA).StringFixed(2))
 			ce.Logger.Debugf("  PersonB's TSP balance: %s", currentTSPTraditionalPersonB.Add(currentTSPRothPersonB).StringFixed(2))
 			ce.Logger.Debugf("  Combined TSP balance: %s", currentTSPTraditionalPersonA.Add(currentTSPRothPersonA).Add(currentTSPTraditionalPersonB).Add(currentTSPRothPersonB).StringFixed(2))
 			ce.Logger.Debugf("")
 		}
 
-		// Calculate FEHB premiums
-		fehbPremium := CalculateFEHBPremium(personA, year, assumptions.FEHBPremiumInflation, federalRules.FEHBConfig)
-
-		// Calculate Medicare premiums (if applicable)
-		medicarePremium := ce.calculateMedicarePremium(personA, personB, projectionDate,
-			pensionPersonA, pensionPersonB, tspWithdrawalPersonA, tspWithdrawalPersonB, ssPersonA, ssPersonB)
+		// Qualified charitable distributions count toward the RMD but are excluded from taxable income
+		qcdLimit := QCDAnnualLimit(projectionDate.Year(), assumptions.InflationRate)
+		qcdPersonA := CalculateQCD(scenario.PersonA.QCDAnnualAmount, rmdPersonA, tspWithdrawalPersonA, qcdLimit)
+		qcdPersonB := CalculateQCD(scenario.PersonB.QCDAnnualAmount, rmdPersonB, tspWithdrawalPersonB, qcdLimit)
+		taxableTSPWithdrawalPersonA := fromTraditionalPersonA.Sub(qcdPersonA).Add(taxableAnnuityPersonA).Add(rothConversionPersonA)
+		taxableTSPWithdrawalPersonB := fromTraditionalPersonB.Sub(qcdPersonB).Add(taxableAnnuityPersonB).Add(rothConversionPersonB)
 
 		// Calculate taxes - handle transition years properly
-		// Pass the actual working income and retirement income separately
-		workingIncomePersonA := personA.CurrentSalary.Mul(personAWorkFraction)
-		workingIncomePersonB := personB.CurrentSalary.Mul(personBWorkFraction)
-
-		federalTax, stateTax, localTax, ficaTax, taxableTotal, stdDedUsed, filingStatusUsed, seniors65 := ce.calculateTaxes(
-			personA, personB, scenario, year, isPersonARetired && isPersonBRetired,
-			pensionPersonA, pensionPersonB, survivorPensionPersonA, survivorPensionPersonB,
-			tspWithdrawalPersonA, tspWithdrawalPersonB,
+		// Pass the actual working income and retirement income separately. Payroll HSA contributions
+		// are excluded from federal taxable wages and FICA.
+		workingIncomePersonA := personA.CurrentSalary.Mul(personAWorkFraction).Sub(hsaContributionPersonA).Add(postRetirementWagesPersonA)
+		workingIncomePersonB := personB.CurrentSalary.Mul(personBWorkFraction).Sub(hsaContributionPersonB).Add(postRetirementWagesPersonB)
+
+		// Record this year's MAGI for IRMAA two years from now
+		magi := ce.estimateHouseholdMAGI(workingIncomePersonA.Add(workingIncomePersonB),
+			pensionPersonA.Add(pensionPersonB).Add(survivorPensionPersonA).Add(survivorPensionPersonB).Add(externalPensionPersonA).Add(externalPensionPersonB),
+			taxableTSPWithdrawalPersonA.Add(taxableTSPWithdrawalPersonB), ssPersonA.Add(ssPersonB), eventTaxableIncome.Add(capitalGains).Add(interestIncome))
+
+		// A retirement life-changing event replaces the lookback MAGI with this year's when that lowers IRMAA.
+		// Premiums already paid from the HSAs are returned to them, last draw first.
+		if scenario.IRMAALifeChangingEvent && magi.LessThan(irmaaMAGI) && isIRMAALifeChangingEventYear(year, personARetirementYear, personBRetirementYear) {
+			lcePremium := ce.calculateMedicarePremium(personA, personB, projectionDate, magi)
+			refund := medicarePremium.Sub(lcePremium)
+			medicarePremium = lcePremium
+			returnedB := decimal.Min(refund, hsaWithdrawalPersonB)
+			hsaWithdrawalPersonB, currentHSAPersonB = hsaWithdrawalPersonB.Sub(returnedB), currentHSAPersonB.Add(returnedB)
+			returnedA := decimal.Min(refund.Sub(returnedB), hsaWithdrawalPersonA)
+			hsaWithdrawalPersonA, currentHSAPersonA = hsaWithdrawalPersonA.Sub(returnedA), currentHSAPersonA.Add(returnedA)
+		}
+
+		federalTax, stateTax, localTax, ficaTax, taxableTotal, deductionUsed, filingStatusUsed, seniors65 := ce.calculateTaxes(
+			personA, personB, scenario, localTaxCalc, projectionStartYear, year, isPersonARetired && isPersonBRetired,
+			pensionPersonA.Add(externalPensionPersonA), pensionPersonB.Add(externalPensionPersonB), survivorPensionPersonA, survivorPensionPersonB,
+			taxableTSPWithdrawalPersonA, taxableTSPWithdrawalPersonB,
 			ssPersonA, ssPersonB,
 			workingIncomePersonA, workingIncomePersonB,
+			eventTaxableIncome, capitalGains, interestIncome,
 		)
 
 		// Calculate TSP contributions (only for working portion of year)
 		var tspContributions decimal.Decimal
 		if (!isPersonARetired || !isPersonBRetired) && !(personADeceased || personBDeceased) {
-			personAContributions := personA.TotalAnnualTSPContribution().Mul(personAWorkFraction)
-			personBContributions := personB.TotalAnnualTSPContribution().Mul(personBWorkFraction)
+			personAContributions := personA.LimitedTotalAnnualTSPContribution(tspLimitPersonA).Mul(personAWorkFraction)
+			personBContributions := personB.LimitedTotalAnnualTSPContribution(tspLimitPersonB).Mul(personBWorkFraction)
 			tspContributions = personAContributions.Add(personBContributions)
 		}
 
 		// Create annual cash flow
 		cashFlow := domain.AnnualCashFlow{
-			Year:                     year + 1,
-			Date:                     projectionDate,
-			AgePersonA:               agePersonA,
-			AgePersonB:               agePersonB,
-			SalaryPersonA:            personA.CurrentSalary.Mul(personAWorkFraction),
-			SalaryPersonB:            personB.CurrentSalary.Mul(personBWorkFraction),
-			PensionPersonA:           pensionPersonA,
-			PensionPersonB:           pensionPersonB,
-			TSPWithdrawalPersonA:     tspWithdrawalPersonA,
-			TSPWithdrawalPersonB:     tspWithdrawalPersonB,
-			SSBenefitPersonA:         ssPersonA,
-			SSBenefitPersonB:         ssPersonB,
-			FERSSupplementPersonA:    srsPersonA,
-			FERSSupplementPersonB:    srsPersonB,
-			FederalTax:               federalTax,
-			FederalTaxableIncome:     taxableTotal,
-			FederalStandardDeduction: stdDedUsed,
-			FederalFilingStatus:      filingStatusUsed,
-			FederalSeniors65Plus:     seniors65,
-			StateTax:                 stateTax,
-			LocalTax:                 localTax,
-			FICATax:                  ficaTax,
-			TSPContributions:         tspContributions,
-			FEHBPremium:              fehbPremium,
-			MedicarePremium:          medicarePremium,
-			TSPBalancePersonA:        currentTSPTraditionalPersonA.Add(currentTSPRothPersonA),
-			TSPBalancePersonB:        currentTSPTraditionalPersonB.Add(currentTSPRothPersonB),
-			TSPBalanceTraditional:    currentTSPTraditionalPersonA.Add(currentTSPTraditionalPersonB),
-			TSPBalanceRoth:           currentTSPRothPersonA.Add(currentTSPRothPersonB),
-			IsRetired:                isPersonARetired && isPersonBRetired, // Both retired
-			IsMedicareEligible:       dateutil.IsMedicareEligible(personA.BirthDate, projectionDate) || dateutil.IsMedicareEligible(personB.BirthDate, projectionDate),
-			IsRMDYear:                dateutil.IsRMDYear(personA.BirthDate, projectionDate) || dateutil.IsRMDYear(personB.BirthDate, projectionDate),
-			RMDAmount:                rmdPersonA.Add(rmdPersonB),
-			PersonADeceased:          personADeceased,
-			PersonBDeceased:          personBDeceased,
-			FilingStatusSingle:       false,
+			Year:                       year + 1,
+			Date:                       projectionDate,
+			AgePersonA:                 agePersonA,
+			AgePersonB:                 agePersonB,
+			SalaryPersonA:              personA.CurrentSalary.Mul(personAWorkFraction),
+			SalaryPersonB:              personB.CurrentSalary.Mul(personBWorkFraction),
+			PostRetirementWagesPersonA: postRetirementWagesPersonA,
+			PostRetirementWagesPersonB: postRetirementWagesPersonB,
+			PensionPersonA:             pensionPersonA,
+			PensionPersonB:             pensionPersonB,
+			ExternalPensionPersonA:     externalPensionPersonA,
+			ExternalPensionPersonB:     externalPensionPersonB,
+			TSPWithdrawalPersonA:       tspWithdrawalPersonA,
+			TSPWithdrawalPersonB:       tspWithdrawalPersonB,
+			TSPAnnuityPersonA:          tspAnnuityPersonA,
+			TSPAnnuityPersonB:          tspAnnuityPersonB,
+			TSPAnnuitized:              tspAnnuityPersonA.Add(tspAnnuityPersonB).IsPositive(),
+			SSBenefitPersonA:           ssPersonA,
+			SSBenefitPersonB:           ssPersonB,
+			FERSSupplementPersonA:      srsPersonA,
+			FERSSupplementPersonB:      srsPersonB,
+			FederalTax:                 federalTax,
+			FederalTaxableIncome:       taxableTotal,
+			FederalStandardDeduction:   deductionUsed,
+			FederalFilingStatus:        filingStatusUsed,
+			FederalSeniors65Plus:       seniors65,
+			StateTax:                   stateTax,
+			LocalTax:                   localTax,
+			FICATax:                    ficaTax,
+			TSPContributions:           tspContributions,
+			HSAContributions:           hsaContributionPersonA.Add(hsaContributionPersonB),
+			HSAWithdrawal:              hsaWithdrawalPersonA.Add(hsaWithdrawalPersonB),
+			HSABalance:                 currentHSAPersonA.Add(currentHSAPersonB),
+			TaxableAccountWithdrawal:   taxableDrawPersonA.Add(taxableDrawPersonB),
+			TaxableAccountBalance:      taxableAccountPersonA.Balance.Add(taxableAccountPersonB.Balance),
+			CashBalance:                cashPersonA.Add(cashPersonB),
+			CapitalGains:               capitalGains,
+			InterestIncome:             interestIncome,
+			FEHBPremium:                fehbPremium,
+			MedicarePremium:            medicarePremium,
+			TSPBalancePersonA:          currentTSPTraditionalPersonA.Add(currentTSPRothPersonA),
+			TSPBalancePersonB:          currentTSPTraditionalPersonB.Add(currentTSPRothPersonB),
+			TSPBalanceTraditional:      currentTSPTraditionalPersonA.Add(currentTSPTraditionalPersonB),
+			TSPBalanceRoth:             currentTSPRothPersonA.Add(currentTSPRothPersonB),
+			TSPFundBalancesPersonA:     tspFundsPersonA,
+			TSPFundBalancesPersonB:     tspFundsPersonB,
+			IsRetired:                  isPersonARetired && isPersonBRetired, // Both retired
+			IsMedicareEligible:         dateutil.IsMedicareEligible(personA.BirthDate, projectionDate) || dateutil.IsMedicareEligible(personB.BirthDate, projectionDate),
+			IsRMDYear:                  dateutil.IsRMDYear(personA.BirthDate, projectionDate) || dateutil.IsRMDYear(personB.BirthDate, projectionDate),
+			RMDAmount:                  rmdPersonA.Add(rmdPersonB),
+			QCDPersonA:                 qcdPersonA,
+			QCDPersonB:                 qcdPersonB,
+			EventIncome:                events.CashInflow.Add(eventTSPDistribution),
+			EventExpenses:              events.CashOutflow.Add(eventTSPDistribution),
+			RothConversion:             rothConversionPersonA.Add(rothConversionPersonB),
+			MAGI:                       magi,
+			PersonADeceased:            personADeceased,
+			PersonBDeceased:            personBDeceased,
+			FilingStatusSingle:         false,
 		}
 
 		// Determine filing status for display (mirror simplified logic in taxes.go)
@@ -626,10 +1010,36 @@ func (ce *CalculationEngine) GenerateAnnualProjection(personA, personB *domain.E
 
 		// Calculate total gross income and net income
 		cashFlow.TotalGrossIncome = cashFlow.CalculateTotalIncome()
+		cashFlow.GuaranteedIncome = cashFlow.CalculateGuaranteedIncome()
+		cashFlow.AtRiskIncome = cashFlow.CalculateAtRiskIncome()
 		cashFlow.CalculateNetIncome()
+		cashFlow.EffectiveTaxRate, cashFlow.EffectiveTotalTaxRate = cashFlow.CalculateEffectiveTaxRates()
+		cashFlow.MarginalBracket = ce.TaxCalc.yearMarginalRate(cashFlow)
+		if cashFlow.IsRetired {
+			probedFederalTax, _, _, _, probedTaxableIncome, _, _, _ := ce.calculateTaxes(
+				personA, personB, scenario, localTaxCalc, projectionStartYear, year, true,
+				pensionPersonA.Add(externalPensionPersonA), pensionPersonB.Add(externalPensionPersonB), survivorPensionPersonA, survivorPensionPersonB,
+				taxableTSPWithdrawalPersonA.Add(taxTorpedoProbe), taxableTSPWithdrawalPersonB,
+				ssPersonA, ssPersonB,
+				workingIncomePersonA, workingIncomePersonB,
+				eventTaxableIncome, capitalGains, interestIncome,
+			)
+			markTaxTorpedo(&cashFlow, probedFederalTax, probedTaxableIncome)
+		}
 
-		projection[year] = cashFlow
+		// Compare net income with the inflation-adjusted spending need, reduced after a death
+		if scenario.SpendingNeed != nil {
+			need := scenario.SpendingNeed.ForYear(projectionDate.Year()).Mul(inflation.growth(projectionStartYear, projectionDate.Year()))
+			if personADeceased || personBDeceased {
+				need = need.Mul(survivorSpendingFactor)
+			}
+			cashFlow.SpendingNeed = need
+			cashFlow.SpendingSurplus = cashFlow.NetIncome.Sub(need)
+		}
+
+		recentMAGI[year%2] = cashFlow.MAGI
+		emit(&cashFlow)
 	}
 
-	return projection
+	return nil
 }
@@ -0,0 +1,86 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestProjectionStartYearShiftsAgesAndRetirementIndexing(t *testing.T) {
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 8, ProjectionStartYear: 2030}
+	personA := domain.Employee{
+		Name:          "PersonA",
+		BirthDate:     time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:      time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		CurrentSalary: decimal.NewFromInt(90000),
+		High3Salary:   decimal.NewFromInt(90000),
+	}
+	personB := personA
+	personB.Name = "PersonB"
+	retire := time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := &domain.Scenario{
+		Name:    "start-2030",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+
+	ce := NewCalculationEngine()
+	proj := ce.GenerateAnnualProjection(&personA, &personB, scenario, &assumptions, assumptions.FederalRules)
+
+	for i, cf := range proj {
+		if got := cf.Date.Year(); got != 2030+i {
+			t.Fatalf("year %d: expected calendar year %d, got %d", i, 2030+i, got)
+		}
+		if cf.AgePersonA != 60+i {
+			t.Fatalf("year %d: expected age %d, got %d", i, 60+i, cf.AgePersonA)
+		}
+	}
+	// Retirement on 2032-01-01 is projection index 2
+	if proj[1].IsRetired || !proj[1].PensionPersonA.IsZero() {
+		t.Fatalf("expected no retirement before 2032, got retired=%v pension=%s", proj[1].IsRetired, proj[1].PensionPersonA)
+	}
+	if !proj[2].IsRetired || !proj[2].PensionPersonA.IsPositive() {
+		t.Fatalf("expected pension from 2032, got retired=%v pension=%s", proj[2].IsRetired, proj[2].PensionPersonA)
+	}
+	// Taxes see the same ages: both turn 65 on January 1, 2035, which the IRS counts as 65 for 2034 (index 4)
+	if proj[3].FederalSeniors65Plus != 0 || proj[4].FederalSeniors65Plus != 2 {
+		t.Fatalf("expected seniors 0 in 2033 and 2 in 2034, got %d and %d", proj[3].FederalSeniors65Plus, proj[4].FederalSeniors65Plus)
+	}
+}
+
+func TestProjectionStartYearSetsComparisonYears(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	cfg.GlobalAssumptions.ProjectionStartYear = 2030
+	cfg.GlobalAssumptions.COLAGeneralRate = decimal.NewFromFloat(0.02)
+	retire := time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario.PersonA.RetirementDate = retire
+	scenario.PersonB.RetirementDate = retire
+
+	ce := NewCalculationEngine()
+	full, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	streamed, err := ce.RunScenarioSummaryOnly(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	baseline := ce.NetIncomeCalc.Calculate(&personA, &personB, cfg.GlobalAssumptions.FederalRules.FEHBConfig, ce.TaxCalc.LocalTaxCalculatorFor(cfg.GlobalAssumptions.CurrentLocation.Municipality), 2030, false)
+	wantPreRetirement := baseline.Mul(decimal.NewFromFloat(1.02).Pow(decimal.NewFromInt(5)))
+	for _, summary := range []*domain.ScenarioSummary{full, streamed} {
+		if got := summary.ComparisonYears; len(got) != 3 || got[0] != 2035 || got[1] != 2040 || got[2] != 2045 {
+			t.Fatalf("expected comparison years 2035/2040/2045, got %v", got)
+		}
+		if want := full.Projection[5].NetIncome; !summary.NetIncome2030.Equal(want) {
+			t.Fatalf("expected first comparison net income from 2035 (%s), got %s", want, summary.NetIncome2030)
+		}
+		if !summary.PreRetirementNet2030.Equal(wantPreRetirement) {
+			t.Fatalf("expected pre-retirement baseline grown five years from 2030 (%s), got %s", wantPreRetirement, summary.PreRetirementNet2030)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package calculation
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// qcdLimitBaseYear is the year of the published QCD limit below
+	qcdLimitBaseYear = 2024
+	// qcdLimitRoundingIncrement is the increment the indexed QCD limit is rounded down to
+	qcdLimitRoundingIncrement = 1000
+)
+
+// qcdLimitBase is the per-person annual QCD limit for qcdLimitBaseYear (SECURE 2.0 indexes it after 2023)
+var qcdLimitBase = decimal.NewFromInt(105000)
+
+// QCDAnnualLimit returns the per-person QCD limit for a calendar year, indexing the 2024 limit by
+// inflationRate and rounding down to the nearest $1,000 as the IRS does
+func QCDAnnualLimit(year int, inflationRate decimal.Decimal) decimal.Decimal {
+	if year <= qcdLimitBaseYear {
+		return qcdLimitBase
+	}
+	factor := decimal.NewFromInt(1).Add(inflationRate).Pow(decimal.NewFromInt(int64(year - qcdLimitBaseYear)))
+	increment := decimal.NewFromInt(qcdLimitRoundingIncrement)
+	return qcdLimitBase.Mul(factor).Div(increment).Floor().Mul(increment)
+}
+
+// CalculateQCD returns the portion of a year's TSP withdrawal paid directly to charity. The requested
+// amount is capped at the indexed annual limit, at the RMD it is satisfying, and at the withdrawal itself.
+func CalculateQCD(requested, rmd, withdrawal, limit decimal.Decimal) decimal.Decimal {
+	if requested.LessThanOrEqual(decimal.Zero) || rmd.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+	return decimal.Max(decimal.Zero, decimal.Min(requested, limit, rmd, withdrawal))
+}
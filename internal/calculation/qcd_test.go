@@ -0,0 +1,87 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestQCDAnnualLimit(t *testing.T) {
+	if got := QCDAnnualLimit(2024, decimal.NewFromFloat(0.03)); !got.Equal(decimal.NewFromInt(105000)) {
+		t.Fatalf("expected 2024 limit of 105000, got %s", got)
+	}
+	// 105000 * 1.03^2 = 111394.5, rounded down to the nearest 1000
+	if got := QCDAnnualLimit(2026, decimal.NewFromFloat(0.03)); !got.Equal(decimal.NewFromInt(111000)) {
+		t.Fatalf("expected indexed 2026 limit of 111000, got %s", got)
+	}
+}
+
+func TestCalculateQCD_Caps(t *testing.T) {
+	limit := decimal.NewFromInt(105000)
+	tests := []struct {
+		name                       string
+		requested, rmd, withdrawal decimal.Decimal
+		expected                   decimal.Decimal
+	}{
+		{"under all caps", decimal.NewFromInt(10000), decimal.NewFromInt(40000), decimal.NewFromInt(50000), decimal.NewFromInt(10000)},
+		{"capped at RMD", decimal.NewFromInt(60000), decimal.NewFromInt(40000), decimal.NewFromInt(50000), decimal.NewFromInt(40000)},
+		{"capped at limit", decimal.NewFromInt(200000), decimal.NewFromInt(150000), decimal.NewFromInt(150000), limit},
+		{"no RMD", decimal.NewFromInt(10000), decimal.Zero, decimal.NewFromInt(50000), decimal.Zero},
+	}
+	for _, tt := range tests {
+		if got := CalculateQCD(tt.requested, tt.rmd, tt.withdrawal, limit); !got.Equal(tt.expected) {
+			t.Fatalf("%s: expected %s, got %s", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestProjectionQCDReducesFederalTax(t *testing.T) {
+	personA := domain.Employee{
+		Name:                  "PersonA",
+		BirthDate:             time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:              time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC),
+		TSPBalanceTraditional: decimal.NewFromInt(1000000),
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1952, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	retire := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := domain.Scenario{
+		Name:    "qcd",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 70, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 70, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 2}
+	federalRules := domain.FederalRules{
+		FederalTaxConfig: domain.FederalTaxConfig{
+			StandardDeductionMFJ: decimal.NewFromInt(30000),
+			TaxBrackets2025: []domain.TaxBracket{
+				{Min: decimal.Zero, Max: decimal.NewFromInt(1000000), Rate: decimal.NewFromFloat(0.2)},
+			},
+		},
+	}
+
+	run := func(qcd decimal.Decimal) domain.AnnualCashFlow {
+		scenario := base
+		scenario.PersonA.QCDAnnualAmount = qcd
+		a, b := personA, personB
+		return NewCalculationEngine().GenerateAnnualProjection(&a, &b, &scenario, &assumptions, federalRules)[0]
+	}
+
+	without := run(decimal.Zero)
+	with := run(decimal.NewFromInt(20000))
+
+	if !with.QCDPersonA.Equal(decimal.NewFromInt(20000)) {
+		t.Fatalf("expected QCD of 20000, got %s (RMD %s)", with.QCDPersonA, with.RMDAmount)
+	}
+	if !with.TSPWithdrawalPersonA.Equal(without.TSPWithdrawalPersonA) {
+		t.Fatalf("QCD should not change the TSP withdrawal: %s vs %s", with.TSPWithdrawalPersonA, without.TSPWithdrawalPersonA)
+	}
+	if !with.FederalTax.LessThan(without.FederalTax) {
+		t.Fatalf("expected lower federal tax with QCD: with=%s without=%s", with.FederalTax, without.FederalTax)
+	}
+}
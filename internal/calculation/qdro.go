@@ -0,0 +1,40 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// qdroTransferYear returns the projection year index at whose start a QDRO's TSP transfer is made (0 for
+// orders already in effect), or -1 when there is no TSP award
+func qdroTransferYear(qdro *domain.QDRO, projectionStartYear int) int {
+	if qdro == nil || !qdro.TSPPercentage.IsPositive() {
+		return -1
+	}
+	year := qdro.EffectiveDate.Year() - projectionStartYear
+	if year < 0 {
+		return 0
+	}
+	return year
+}
+
+// applyQDROTransfer returns the TSP balances left after a QDRO's percentage is transferred to the former
+// spouse. The award comes from the traditional and Roth balances in proportion.
+func applyQDROTransfer(qdro *domain.QDRO, traditional, roth decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	kept := decimal.NewFromInt(1).Sub(qdro.TSPPercentage)
+	return traditional.Mul(kept), roth.Mul(kept)
+}
+
+// qdroPensionFactor returns the share of a calendar year's annuity payments the employee keeps under a
+// QDRO. In the year the order takes effect only the payments from the effective date on are shared.
+func qdroPensionFactor(qdro *domain.QDRO, calendarYear int) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	if qdro == nil || !qdro.PensionShare.IsPositive() || calendarYear < qdro.EffectiveDate.Year() {
+		return one
+	}
+	share := qdro.PensionShare
+	if calendarYear == qdro.EffectiveDate.Year() {
+		share = share.Mul(one.Sub(fractionOfYearBefore(qdro.EffectiveDate)))
+	}
+	return one.Sub(share)
+}
@@ -0,0 +1,39 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestQDROHalvesTSPAndSharesPension(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(2)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+
+	ce := NewCalculationEngine()
+	without := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)[0]
+
+	personA.QDRO = &domain.QDRO{
+		EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		TSPPercentage: decimal.NewFromFloat(0.5),
+		PensionShare:  decimal.NewFromFloat(0.25),
+	}
+	with := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)[0]
+
+	// The 300,000 starting balance is halved, so the 4% rule withdraws 6,000 and leaves 144,000
+	if !with.TSPWithdrawalPersonA.Equal(without.TSPWithdrawalPersonA.Div(decimal.NewFromInt(2))) {
+		t.Fatalf("expected the 4%% withdrawal to halve, got %s vs %s", with.TSPWithdrawalPersonA, without.TSPWithdrawalPersonA)
+	}
+	if !with.TSPBalancePersonA.Equal(decimal.NewFromInt(144000)) {
+		t.Fatalf("expected TSP balance 144000 after the QDRO, got %s", with.TSPBalancePersonA)
+	}
+	wantPension := without.PensionPersonA.Mul(decimal.NewFromFloat(0.75))
+	if !with.PensionPersonA.Equal(wantPension) {
+		t.Fatalf("expected pension %s after the former spouse's share, got %s", wantPension, with.PensionPersonA)
+	}
+	if !with.PensionPersonB.Equal(without.PensionPersonB) {
+		t.Fatalf("person B's pension should be unaffected, got %s vs %s", with.PensionPersonB, without.PensionPersonB)
+	}
+}
@@ -0,0 +1,53 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// rateSchedule is an annual rate that can change from one calendar year to the next. Years covered by
+// byYear, counted from firstYear, use their own rate; every other year uses rate. Deterministic projections
+// leave byYear empty, while market sequences (Monte Carlo, backtests, stress tests) supply one rate per
+// projection year.
+type rateSchedule struct {
+	rate      decimal.Decimal
+	firstYear int
+	byYear    []decimal.Decimal
+}
+
+// constantRate returns a schedule that applies rate in every year
+func constantRate(rate decimal.Decimal) rateSchedule {
+	return rateSchedule{rate: rate}
+}
+
+// forYear returns the rate in effect during calendarYear
+func (s rateSchedule) forYear(calendarYear int) decimal.Decimal {
+	if i := calendarYear - s.firstYear; i >= 0 && i < len(s.byYear) {
+		return s.byYear[i]
+	}
+	return s.rate
+}
+
+// growth returns the compound growth over the calendar years from fromYear up to, but not including, toYear
+func (s rateSchedule) growth(fromYear, toYear int) decimal.Decimal {
+	if len(s.byYear) == 0 {
+		return decimal.NewFromInt(1).Add(s.rate).Pow(decimal.NewFromInt(int64(toYear - fromYear)))
+	}
+	factor := decimal.NewFromInt(1)
+	for y := fromYear; y < toYear; y++ {
+		factor = factor.Mul(decimal.NewFromInt(1).Add(s.forYear(y)))
+	}
+	return factor
+}
+
+// inflationSchedule returns the projection's inflation: ce.InflationByYear when a market sequence sets it,
+// otherwise the constant assumption
+func (ce *CalculationEngine) inflationSchedule(assumptions *domain.GlobalAssumptions) rateSchedule {
+	return rateSchedule{rate: assumptions.InflationRate, firstYear: ProjectionStartYear(assumptions), byYear: ce.InflationByYear}
+}
+
+// colaSchedule returns the projection's general COLA: ce.COLAByYear when a market sequence sets it,
+// otherwise the constant assumption
+func (ce *CalculationEngine) colaSchedule(assumptions *domain.GlobalAssumptions) rateSchedule {
+	return rateSchedule{rate: assumptions.COLAGeneralRate, firstYear: ProjectionStartYear(assumptions), byYear: ce.COLAByYear}
+}
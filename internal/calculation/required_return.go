@@ -0,0 +1,92 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Search bounds and precision for SolveRequiredReturn
+var (
+	requiredReturnLow       = decimal.NewFromFloat(-0.10)
+	requiredReturnHigh      = decimal.NewFromFloat(0.20)
+	requiredReturnTolerance = decimal.NewFromFloat(0.0001)
+)
+
+// RequiredReturnResult is the minimum post-retirement TSP return that sustains a target net income
+type RequiredReturnResult struct {
+	Scenario         string          `json:"scenario"`
+	TargetNetIncome  decimal.Decimal `json:"target_net_income"`
+	Feasible         bool            `json:"feasible"`
+	RequiredReturn   decimal.Decimal `json:"required_return"`     // Zero when infeasible
+	MinRealNetIncome decimal.Decimal `json:"min_real_net_income"` // Lowest real net income at the required (or highest searched) return
+}
+
+// SolveRequiredReturn binary-searches the post-retirement TSP return for the lowest value at which the
+// scenario's real (today's dollar) net income stays at or above targetNetIncome in every projection year.
+// The search covers -10% to 20% to within one basis point; if even 20% falls short the result is reported
+// as infeasible. The scenario's other assumption overrides still apply; the searched return replaces its
+// post-retirement return override. With a glide path, the whole path shifts with the searched return, so
+// the result is the return before the path's first step.
+func (ce *CalculationEngine) SolveRequiredReturn(config *domain.Configuration, scenario *domain.Scenario, targetNetIncome decimal.Decimal) (*RequiredReturnResult, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+
+	config, trialScenario := scenarioAssumptions(config, scenario)
+	minIncomeAt := func(rate decimal.Decimal) (decimal.Decimal, bool, error) {
+		trial := *config
+		trial.GlobalAssumptions.SetPostRetirementReturn(rate)
+		summary, err := ce.RunScenario(context.Background(), &trial, trialScenario)
+		if err != nil {
+			return decimal.Zero, false, fmt.Errorf("required return at %s: %w", rate.String(), err)
+		}
+		minIncome := decimal.Zero
+		for i, year := range summary.Projection {
+			if i == 0 || year.RealNetIncome.LessThan(minIncome) {
+				minIncome = year.RealNetIncome
+			}
+		}
+		return minIncome, meetsIncomeFloor(summary.Projection, targetNetIncome), nil
+	}
+
+	result := &RequiredReturnResult{Scenario: scenario.Name, TargetNetIncome: targetNetIncome}
+	highIncome, ok, err := minIncomeAt(requiredReturnHigh)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		result.MinRealNetIncome = highIncome
+		return result, nil
+	}
+	result.Feasible = true
+	result.RequiredReturn, result.MinRealNetIncome = requiredReturnHigh, highIncome
+
+	lowIncome, ok, err := minIncomeAt(requiredReturnLow)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		result.RequiredReturn, result.MinRealNetIncome = requiredReturnLow, lowIncome
+		return result, nil
+	}
+
+	// Invariant: low fails, high succeeds
+	low, high := requiredReturnLow, requiredReturnHigh
+	for high.Sub(low).GreaterThan(requiredReturnTolerance) {
+		mid := low.Add(high).Div(decimal.NewFromInt(2)).Round(6)
+		midIncome, ok, err := minIncomeAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			high = mid
+			result.RequiredReturn, result.MinRealNetIncome = mid, midIncome
+		} else {
+			low = mid
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSolveRequiredReturnJustSustainsTarget(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	ce := NewCalculationEngine()
+
+	// Target the lowest real income of a 3% run: the 4% rule draws down the TSP, so lower returns
+	// deplete it and income drops once withdrawals stop
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.03)
+	reference, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	target := reference.Projection[0].RealNetIncome
+	for _, year := range reference.Projection {
+		target = decimal.Min(target, year.RealNetIncome)
+	}
+
+	result, err := ce.SolveRequiredReturn(cfg, scenario, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Feasible {
+		t.Fatalf("expected the target to be feasible")
+	}
+	if result.RequiredReturn.GreaterThan(decimal.NewFromFloat(0.03)) || !result.RequiredReturn.GreaterThan(requiredReturnLow) {
+		t.Fatalf("expected a required return between -10%% and 3%%, got %s", result.RequiredReturn)
+	}
+
+	sustains := func(rate decimal.Decimal) bool {
+		trial := *cfg
+		trial.GlobalAssumptions.TSPReturnPostRetirement = rate
+		summary, err := ce.RunScenario(context.Background(), &trial, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return meetsIncomeFloor(summary.Projection, target)
+	}
+	if !sustains(result.RequiredReturn) {
+		t.Fatalf("solved return %s should sustain the target %s", result.RequiredReturn, target)
+	}
+	if sustains(result.RequiredReturn.Sub(requiredReturnTolerance)) {
+		t.Fatalf("a return one basis point below %s should fall short of the target", result.RequiredReturn)
+	}
+
+	// An income beyond reach at any searched return is reported as infeasible
+	result, err = ce.SolveRequiredReturn(cfg, scenario, target.Mul(decimal.NewFromInt(10)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Feasible {
+		t.Fatalf("expected a tenfold target to be infeasible")
+	}
+}
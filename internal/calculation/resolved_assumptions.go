@@ -0,0 +1,78 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// ResolveAssumptions returns a copy of config's global assumptions with every default the engine fills in
+// at run time made explicit: the projection start year, discount rate and HSA return, the Monte Carlo
+// variabilities, income cap and default allocation, and the statistical model of each TSP fund. Written
+// next to a report, it records exactly what the run assumed.
+func ResolveAssumptions(config *domain.Configuration) domain.GlobalAssumptions {
+	resolved := config.GlobalAssumptions
+	resolved.ProjectionStartYear = ProjectionStartYear(&resolved)
+	discountRate := discountRateOrDefault(&resolved)
+	resolved.DiscountRate = &discountRate
+	hsaReturn := hsaReturnOrDefault(&resolved)
+	resolved.HSAReturn = &hsaReturn
+	resolved.MonteCarloSettings = resolveMonteCarloSettings(resolved.MonteCarloSettings)
+
+	models := &resolved.TSPStatisticalModels
+	for fund, stats := range map[string]*domain.TSPFundStats{"C": &models.CFund, "S": &models.SFund, "I": &models.IFund, "F": &models.FFund, "G": &models.GFund} {
+		if stats.Mean.IsZero() || stats.StandardDev.IsZero() {
+			stats.Mean, stats.StandardDev = defaultFundStatistics(fund)
+		}
+	}
+	return resolved
+}
+
+// resolveMonteCarloSettings fills unset Monte Carlo settings with their defaults
+func resolveMonteCarloSettings(settings domain.MonteCarloSettings) domain.MonteCarloSettings {
+	if settings.TSPReturnVariability.IsZero() {
+		settings.TSPReturnVariability = decimal.NewFromFloat(0.15) // 15% default - typical stock market variability
+	}
+	if settings.InflationVariability.IsZero() {
+		settings.InflationVariability = decimal.NewFromFloat(0.02) // 2% default - based on CPI historical variation
+	}
+	if settings.COLAVariability.IsZero() {
+		settings.COLAVariability = decimal.NewFromFloat(0.02) // 2% default - Social Security COLA variation
+	}
+	if settings.FEHBVariability.IsZero() {
+		settings.FEHBVariability = decimal.NewFromFloat(0.05) // 5% default - health insurance premium increases
+	}
+	if settings.MaxReasonableIncome.IsZero() {
+		settings.MaxReasonableIncome = decimal.NewFromInt(5000000) // $5M default cap
+	}
+	allocation := settings.DefaultTSPAllocation
+	if allocation.CFund.IsZero() && allocation.SFund.IsZero() && allocation.IFund.IsZero() && allocation.FFund.IsZero() && allocation.GFund.IsZero() {
+		// Conservative balanced allocation as the ultimate fallback
+		settings.DefaultTSPAllocation = domain.TSPAllocation{
+			CFund: decimal.NewFromFloat(0.60), // 60% Large Cap Stock Index
+			SFund: decimal.NewFromFloat(0.20), // 20% Small Cap Stock Index
+			IFund: decimal.NewFromFloat(0.10), // 10% International Stock Index
+			FFund: decimal.NewFromFloat(0.10), // 10% Fixed Income Index
+			GFund: decimal.NewFromFloat(0.00), // 0% Government Securities
+		}
+	}
+	return settings
+}
+
+// defaultFundStatistics returns the historical mean and standard deviation of a fund's annual return,
+// used when the configuration does not model the fund
+func defaultFundStatistics(fund string) (mean, stdDev decimal.Decimal) {
+	switch fund {
+	case "C":
+		return decimal.NewFromFloat(0.1125), decimal.NewFromFloat(0.1744) // TSP.gov 1988-2024
+	case "S":
+		return decimal.NewFromFloat(0.1117), decimal.NewFromFloat(0.1933) // TSP.gov 1988-2024
+	case "I":
+		return decimal.NewFromFloat(0.0634), decimal.NewFromFloat(0.1863) // TSP.gov 1988-2024
+	case "F":
+		return decimal.NewFromFloat(0.0532), decimal.NewFromFloat(0.0565) // TSP.gov 1988-2024
+	case "G":
+		return decimal.NewFromFloat(0.0493), decimal.NewFromFloat(0.0165) // TSP.gov 1988-2024, very stable
+	default:
+		return decimal.NewFromFloat(0.08), decimal.NewFromFloat(0.15) // Unknown funds
+	}
+}
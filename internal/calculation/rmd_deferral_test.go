@@ -0,0 +1,95 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestDeferFirstRMD_DoublesRMDTheFollowingYear(t *testing.T) {
+	run := func(deferFirst bool) []domain.AnnualCashFlow {
+		cfg, scenario := retiredCoupleTestConfig(20)
+		cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.05)
+		scenario.PersonA.DeferFirstRMD = deferFirst
+		summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return summary.Projection
+	}
+	base, deferred := run(false), run(true)
+
+	// Born 1963, person_a's RMDs begin at 75, in 2038
+	const firstRMDIndex = 2038 - testProjectionStartYear
+	if !base[firstRMDIndex].RMDAmount.IsPositive() {
+		t.Fatalf("expected an RMD in 2038 without deferral")
+	}
+	if !deferred[firstRMDIndex].RMDAmount.IsZero() {
+		t.Fatalf("expected no RMD in 2038 with the first RMD deferred, got %s", deferred[firstRMDIndex].RMDAmount)
+	}
+
+	// Nothing is withdrawn for the deferred RMD in 2038, so more stays in the traditional balance
+	deferralYear := deferred[firstRMDIndex]
+	if !deferralYear.TSPWithdrawalPersonA.LessThan(base[firstRMDIndex].TSPWithdrawalPersonA) {
+		t.Fatalf("expected a smaller 2038 withdrawal with the RMD deferred: deferred %s, base %s", deferralYear.TSPWithdrawalPersonA.StringFixed(2), base[firstRMDIndex].TSPWithdrawalPersonA.StringFixed(2))
+	}
+	if deferralYear.TSPWithdrawalPersonA.GreaterThanOrEqual(base[firstRMDIndex].RMDAmount) {
+		t.Fatalf("expected the 2038 withdrawal %s to stay below the deferred RMD %s", deferralYear.TSPWithdrawalPersonA.StringFixed(2), base[firstRMDIndex].RMDAmount.StringFixed(2))
+	}
+	if !deferralYear.TSPBalanceTraditional.GreaterThan(base[firstRMDIndex].TSPBalanceTraditional) {
+		t.Fatalf("expected a larger 2038 year-end balance with the RMD deferred: deferred %s, base %s", deferralYear.TSPBalanceTraditional.StringFixed(2), base[firstRMDIndex].TSPBalanceTraditional.StringFixed(2))
+	}
+
+	// The second year pays the full, unprorated first RMD divided from the 2037 year-end balance, plus its
+	// own divided from the 2038 year-end balance
+	secondYear := deferred[firstRMDIndex+1]
+	firstRMD := CalculateRMD(deferred[firstRMDIndex-1].TSPBalanceTraditional, 1963, 75)
+	ownRMD := CalculateRMD(deferred[firstRMDIndex].TSPBalanceTraditional, 1963, 76)
+	want := firstRMD.Add(ownRMD)
+	if !secondYear.RMDAmount.Round(2).Equal(want.Round(2)) {
+		t.Fatalf("expected a 2039 RMD of %s (deferred %s + own %s), got %s", want.StringFixed(2), firstRMD.StringFixed(2), ownRMD.StringFixed(2), secondYear.RMDAmount.StringFixed(2))
+	}
+	if !secondYear.RMDAmount.GreaterThan(base[firstRMDIndex+1].RMDAmount.Mul(decimal.NewFromFloat(1.8))) {
+		t.Fatalf("expected roughly a double RMD in 2039: deferred %s, base %s", secondYear.RMDAmount.StringFixed(2), base[firstRMDIndex+1].RMDAmount.StringFixed(2))
+	}
+	// The double RMD exceeds the 4% amount, so it is exactly what is withdrawn; the first RMD is not taken twice
+	if !secondYear.TSPWithdrawalPersonA.Round(2).Equal(secondYear.RMDAmount.Round(2)) {
+		t.Fatalf("expected the 2039 withdrawal %s to equal both RMDs %s", secondYear.TSPWithdrawalPersonA.StringFixed(2), secondYear.RMDAmount.StringFixed(2))
+	}
+	if !deferred[firstRMDIndex+2].RMDAmount.LessThan(secondYear.RMDAmount) {
+		t.Fatalf("expected RMDs to return to a single amount in 2040")
+	}
+}
+
+func TestDeferFirstRMD_CarriesTheFullUnproratedRMD(t *testing.T) {
+	run := func(deferFirst bool) []domain.AnnualCashFlow {
+		cfg, scenario := retiredCoupleTestConfig(20)
+		personA := cfg.PersonalDetails["person_a"]
+		personA.BirthDate = time.Date(1963, 7, 1, 0, 0, 0, 0, time.UTC) // Reaches 75 mid-2038
+		cfg.PersonalDetails["person_a"] = personA
+		scenario.PersonA.DeferFirstRMD = deferFirst
+		summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return summary.Projection
+	}
+	base, deferred := run(false), run(true)
+	const firstRMDIndex = 2038 - testProjectionStartYear
+
+	// Without deferral the first-year RMD is prorated for the half year after the birthday
+	fullFirstRMD := CalculateRMD(deferred[firstRMDIndex-1].TSPBalanceTraditional, 1963, 75)
+	if !base[firstRMDIndex].RMDAmount.IsPositive() || !base[firstRMDIndex].RMDAmount.LessThan(CalculateRMD(base[firstRMDIndex-1].TSPBalanceTraditional, 1963, 75)) {
+		t.Fatalf("expected a prorated 2038 RMD without deferral, got %s", base[firstRMDIndex].RMDAmount.StringFixed(2))
+	}
+
+	// Deferred, the full first RMD on the 2037 year-end balance is paid in 2039 with that year's own RMD
+	ownRMD := CalculateRMD(deferred[firstRMDIndex].TSPBalanceTraditional, 1963, 75)
+	want := fullFirstRMD.Add(ownRMD)
+	if got := deferred[firstRMDIndex+1].RMDAmount; !got.Round(2).Equal(want.Round(2)) {
+		t.Fatalf("expected a 2039 RMD of %s (full deferred %s + own %s), got %s", want.StringFixed(2), fullFirstRMD.StringFixed(2), ownRMD.StringFixed(2), got.StringFixed(2))
+	}
+}
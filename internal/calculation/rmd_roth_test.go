@@ -0,0 +1,64 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Test that a 1960-born person's first RMD is at 75 and that Roth balances are excluded from the RMD base
+func TestRMD_1960BirthAndRothExcluded(t *testing.T) {
+	ce := NewCalculationEngine()
+
+	traditional := decimal.NewFromInt(200000)
+	personA := domain.Employee{
+		Name:                  "PersonA",
+		BirthDate:             time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:              time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC),
+		TSPBalanceTraditional: traditional,
+		TSPBalanceRoth:        decimal.NewFromInt(800000),
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	retire := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := domain.Scenario{
+		Name:    "roth-rmd",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 70, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 70, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	// Zero returns and no inflation so balances only change through withdrawals
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 12}
+
+	proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &assumptions, assumptions.FederalRules)
+
+	// Age 74 in 2034: no RMD yet
+	if rmd := proj[2034-testProjectionStartYear].RMDAmount; !rmd.IsZero() {
+		t.Fatalf("expected no RMD at age 74 for a 1960 birth, got %s", rmd.StringFixed(2))
+	}
+
+	// Age 75 in 2035: RMD must be based on the traditional balance only
+	row := proj[2035-testProjectionStartYear]
+	if row.RMDAmount.LessThanOrEqual(decimal.Zero) {
+		t.Fatalf("expected RMD at age 75")
+	}
+	prev := proj[2034-testProjectionStartYear]
+	maxTraditionalOnlyRMD := prev.TSPBalanceTraditional.Div(decimal.NewFromFloat(24.6))
+	if row.RMDAmount.GreaterThan(maxTraditionalOnlyRMD.Add(decimal.NewFromFloat(0.01))) {
+		t.Fatalf("RMD %s exceeds traditional-only RMD %s; Roth must be excluded", row.RMDAmount.StringFixed(2), maxTraditionalOnlyRMD.StringFixed(2))
+	}
+	combinedRMD := prev.TotalTSPBalance().Div(decimal.NewFromFloat(24.6))
+	if !row.RMDAmount.LessThan(combinedRMD) {
+		t.Fatalf("RMD %s should be well below a Roth-inclusive RMD %s", row.RMDAmount.StringFixed(2), combinedRMD.StringFixed(2))
+	}
+
+	// The RMD must actually leave the traditional account even though Roth is drawn first otherwise
+	drop := prev.TSPBalanceTraditional.Sub(row.TSPBalanceTraditional)
+	if drop.LessThan(row.RMDAmount.Sub(decimal.NewFromFloat(0.01))) {
+		t.Fatalf("traditional balance fell by %s, expected at least the RMD %s", drop.StringFixed(2), row.RMDAmount.StringFixed(2))
+	}
+}
@@ -0,0 +1,89 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// rmdSmoothingFloor returns the traditional withdrawal RMD smoothing asks for in a year, given the rest of
+// the household's taxable income and the brackets and standard deduction of the year's filing status
+func rmdSmoothingFloor(smoothing *domain.RMDSmoothing, taxContext WithdrawalTaxContext) decimal.Decimal {
+	floor := smoothing.TargetAnnual
+	if smoothing.BracketRate != nil {
+		ceiling := bracketCeiling(taxContext.Brackets, *smoothing.BracketRate)
+		floor = decimal.Max(floor, ceiling.Add(taxContext.StandardDeduction).Sub(taxContext.OtherTaxableIncome))
+	}
+	return decimal.Max(floor, decimal.Zero)
+}
+
+// RMDSmoothingYear is one year of the smoothed withdrawal schedule beside the default one
+type RMDSmoothingYear struct {
+	Year                 int             `json:"year"`
+	DefaultWithdrawal    decimal.Decimal `json:"default_withdrawal"` // Household TSP withdrawals
+	SmoothedWithdrawal   decimal.Decimal `json:"smoothed_withdrawal"`
+	DefaultRMD           decimal.Decimal `json:"default_rmd"`
+	SmoothedRMD          decimal.Decimal `json:"smoothed_rmd"`
+	DefaultMarginalRate  decimal.Decimal `json:"default_marginal_rate"` // Federal bracket of the year's taxable income
+	SmoothedMarginalRate decimal.Decimal `json:"smoothed_marginal_rate"`
+}
+
+// RMDSmoothingComparison compares a scenario's rmd_smoothing settings with the same scenario without them
+type RMDSmoothingComparison struct {
+	Scenario                 string             `json:"scenario"`
+	Years                    []RMDSmoothingYear `json:"years"`
+	DefaultPeakRMD           decimal.Decimal    `json:"default_peak_rmd"`
+	SmoothedPeakRMD          decimal.Decimal    `json:"smoothed_peak_rmd"`
+	DefaultPeakMarginalRate  decimal.Decimal    `json:"default_peak_marginal_rate"`
+	SmoothedPeakMarginalRate decimal.Decimal    `json:"smoothed_peak_marginal_rate"`
+	DefaultFinalTSPBalance   decimal.Decimal    `json:"default_final_tsp_balance"`
+	SmoothedFinalTSPBalance  decimal.Decimal    `json:"smoothed_final_tsp_balance"`
+}
+
+// CompareRMDSmoothing runs a scenario with and without its rmd_smoothing settings and lays the two
+// withdrawal schedules side by side
+func (ce *CalculationEngine) CompareRMDSmoothing(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*RMDSmoothingComparison, error) {
+	if scenario.PersonA.RMDSmoothing == nil && scenario.PersonB.RMDSmoothing == nil {
+		return nil, fmt.Errorf("scenario %s has no rmd_smoothing settings", scenario.Name)
+	}
+	smoothed, err := ce.RunScenario(ctx, config, scenario)
+	if err != nil {
+		return nil, err
+	}
+	unsmoothed := *scenario
+	unsmoothed.PersonA.RMDSmoothing = nil
+	unsmoothed.PersonB.RMDSmoothing = nil
+	baseline, err := ce.RunScenario(ctx, config, &unsmoothed)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &RMDSmoothingComparison{
+		Scenario:                scenario.Name,
+		DefaultFinalTSPBalance:  baseline.FinalTSPBalance,
+		SmoothedFinalTSPBalance: smoothed.FinalTSPBalance,
+	}
+	for i := range smoothed.Projection {
+		if i >= len(baseline.Projection) {
+			break
+		}
+		d, s := baseline.Projection[i], smoothed.Projection[i]
+		row := RMDSmoothingYear{
+			Year:                 s.Date.Year(),
+			DefaultWithdrawal:    d.TSPWithdrawalPersonA.Add(d.TSPWithdrawalPersonB),
+			SmoothedWithdrawal:   s.TSPWithdrawalPersonA.Add(s.TSPWithdrawalPersonB),
+			DefaultRMD:           d.RMDAmount,
+			SmoothedRMD:          s.RMDAmount,
+			DefaultMarginalRate:  d.MarginalBracket,
+			SmoothedMarginalRate: s.MarginalBracket,
+		}
+		comparison.DefaultPeakRMD = decimal.Max(comparison.DefaultPeakRMD, row.DefaultRMD)
+		comparison.SmoothedPeakRMD = decimal.Max(comparison.SmoothedPeakRMD, row.SmoothedRMD)
+		comparison.DefaultPeakMarginalRate = decimal.Max(comparison.DefaultPeakMarginalRate, row.DefaultMarginalRate)
+		comparison.SmoothedPeakMarginalRate = decimal.Max(comparison.SmoothedPeakMarginalRate, row.SmoothedMarginalRate)
+		comparison.Years = append(comparison.Years, row)
+	}
+	return comparison, nil
+}
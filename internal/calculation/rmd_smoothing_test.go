@@ -0,0 +1,76 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestRMDSmoothingLowersPeakMarginalBracket(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	personA := cfg.PersonalDetails["person_a"]
+	personA.TSPBalanceTraditional = decimal.NewFromInt(2000000)
+	cfg.PersonalDetails["person_a"] = personA
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.06)
+	rate := decimal.NewFromFloat(0.22)
+	scenario.PersonA.RMDSmoothing = &domain.RMDSmoothing{BracketRate: &rate}
+
+	comparison, err := NewCalculationEngine().CompareRMDSmoothing(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !comparison.DefaultPeakMarginalRate.Equal(decimal.NewFromFloat(0.24)) {
+		t.Fatalf("expected RMDs to push the default schedule into the 24%% bracket, got %s", comparison.DefaultPeakMarginalRate)
+	}
+	if !comparison.SmoothedPeakMarginalRate.Equal(rate) {
+		t.Fatalf("expected smoothing to hold the peak at 22%%, got %s", comparison.SmoothedPeakMarginalRate)
+	}
+	if !comparison.SmoothedPeakRMD.LessThan(comparison.DefaultPeakRMD) {
+		t.Fatalf("expected a smaller peak RMD, got %s vs %s", comparison.SmoothedPeakRMD, comparison.DefaultPeakRMD)
+	}
+	first := comparison.Years[0]
+	if !first.SmoothedWithdrawal.GreaterThan(first.DefaultWithdrawal) {
+		t.Fatalf("expected larger withdrawals before RMD age, got %s vs %s", first.SmoothedWithdrawal, first.DefaultWithdrawal)
+	}
+}
+
+func TestRMDSmoothingFollowsSurvivorFilingStatus(t *testing.T) {
+	withdrawalAfterDeath := func(filingStatusSwitch string) domain.AnnualCashFlow {
+		cfg, scenario := retiredCoupleTestConfig(10)
+		personA := cfg.PersonalDetails["person_a"]
+		personA.TSPBalanceTraditional = decimal.NewFromInt(1000000)
+		cfg.PersonalDetails["person_a"] = personA
+		cfg.GlobalAssumptions.FederalRules.FederalTaxConfig = domain.FederalTaxConfig{StandardDeductionMFJ: decimal.NewFromInt(30000), StandardDeductionSingle: decimal.NewFromInt(15000), AdditionalStandardDeduction: decimal.NewFromInt(1550)}
+		rate := decimal.NewFromFloat(0.22)
+		scenario.PersonA.RMDSmoothing = &domain.RMDSmoothing{BracketRate: &rate}
+		deathDate := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+		scenario.Mortality = &domain.ScenarioMortality{PersonB: &domain.MortalitySpec{DeathDate: &deathDate}, Assumptions: &domain.MortalityAssumptions{FilingStatusSwitch: filingStatusSwitch}}
+
+		summary, err := NewCalculationEngineWithConfig(cfg.GlobalAssumptions.FederalRules).RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, year := range summary.Projection {
+			if year.Date.Year() == 2028 {
+				return year
+			}
+		}
+		t.Fatalf("projection has no 2028")
+		return domain.AnnualCashFlow{}
+	}
+
+	joint := withdrawalAfterDeath("")
+	single := withdrawalAfterDeath("immediate")
+	if single.FederalFilingStatus != "single" {
+		t.Fatalf("expected the survivor to file single, got %q", single.FederalFilingStatus)
+	}
+	if !single.TSPWithdrawalPersonA.LessThan(joint.TSPWithdrawalPersonA) {
+		t.Fatalf("expected the single 22%% bracket to cap smoothing lower than the joint one, got %s vs %s", single.TSPWithdrawalPersonA, joint.TSPWithdrawalPersonA)
+	}
+	if !single.TSPWithdrawalPersonA.GreaterThan(decimal.NewFromInt(40000)) {
+		t.Fatalf("expected smoothing to still raise the survivor's withdrawal above the 4%% rule, got %s", single.TSPWithdrawalPersonA)
+	}
+}
@@ -0,0 +1,15 @@
+package calculation
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// applyRothConversion moves up to amount from the traditional balance to the Roth balance and returns the
+// updated balances and the amount converted
+func applyRothConversion(traditional, roth, amount decimal.Decimal) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	converted := decimal.Min(amount, decimal.Max(traditional, decimal.Zero))
+	if !converted.IsPositive() {
+		return traditional, roth, decimal.Zero
+	}
+	return traditional.Sub(converted), roth.Add(converted), converted
+}
@@ -0,0 +1,116 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// safeSpendingTolerance is the precision, in dollars, of the SafeSpending search
+var safeSpendingTolerance = decimal.NewFromInt(10)
+
+// SafeSpendingResult is the largest flat real annual withdrawal that at least TargetSuccessRate of the
+// simulated market paths sustain
+type SafeSpendingResult struct {
+	TargetSuccessRate decimal.Decimal `json:"target_success_rate"`
+	AnnualWithdrawal  decimal.Decimal `json:"annual_withdrawal"` // First-year amount, raised with each path's inflation
+	WithdrawalRate    decimal.Decimal `json:"withdrawal_rate"`   // AnnualWithdrawal as a share of the initial balance
+	SuccessRate       decimal.Decimal `json:"success_rate"`      // Share of paths that last at AnnualWithdrawal
+	NumSimulations    int             `json:"num_simulations"`
+	ProjectionYears   int             `json:"projection_years"`
+}
+
+// marketPath is one simulated sequence of portfolio returns and inflation
+type marketPath struct {
+	returns   []decimal.Decimal
+	inflation []decimal.Decimal
+}
+
+// SafeSpending returns the "safe spending" amount for a target success probability, e.g. 0.9 for the
+// amount that 90% of simulations sustain. Market paths are drawn once under config's asset allocation and
+// the first-year withdrawal is binary-searched, to within $10, over those same paths; the withdrawal then
+// rises each year with the path's inflation, so it is flat in real terms. As in RunSimulation, returns are
+// applied before each year's withdrawal and a path succeeds if the balance lasts all ProjectionYears.
+func (mcs *MonteCarloSimulator) SafeSpending(ctx context.Context, config MonteCarloConfig, targetSuccessRate decimal.Decimal) (*SafeSpendingResult, error) {
+	if mcs.HistoricalData == nil || !mcs.HistoricalData.IsLoaded {
+		return nil, ErrHistoricalDataNotLoaded
+	}
+	if !targetSuccessRate.IsPositive() || targetSuccessRate.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("target success rate must be above 0 and at most 1, got %s", targetSuccessRate)
+	}
+	if mcs.NumSimulations <= 0 || mcs.ProjectionYears <= 0 {
+		return nil, fmt.Errorf("simulations and projection years must be positive")
+	}
+	if !config.InitialBalance.IsPositive() {
+		return nil, fmt.Errorf("initial balance must be positive")
+	}
+
+	paths := make([]marketPath, mcs.NumSimulations)
+	for i := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("safe spending search cancelled: %w", err)
+		}
+		paths[i] = mcs.sampleMarketPath(config)
+	}
+
+	successRate := func(withdrawal decimal.Decimal) decimal.Decimal {
+		successes := 0
+		for _, path := range paths {
+			if path.sustains(config.InitialBalance, withdrawal) {
+				successes++
+			}
+		}
+		return decimal.NewFromInt(int64(successes)).Div(decimal.NewFromInt(int64(len(paths))))
+	}
+
+	// Withdrawing the whole initial balance each year fails any multi-year path, so it bounds the search
+	low, high := decimal.Zero, config.InitialBalance
+	for high.Sub(low).GreaterThan(safeSpendingTolerance) {
+		mid := low.Add(high).Div(decimal.NewFromInt(2))
+		if successRate(mid).GreaterThanOrEqual(targetSuccessRate) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	// Rounding down keeps the success rate at or above the target
+	withdrawal := low.Floor()
+
+	return &SafeSpendingResult{
+		TargetSuccessRate: targetSuccessRate,
+		AnnualWithdrawal:  withdrawal,
+		WithdrawalRate:    withdrawal.Div(config.InitialBalance),
+		SuccessRate:       successRate(withdrawal),
+		NumSimulations:    mcs.NumSimulations,
+		ProjectionYears:   mcs.ProjectionYears,
+	}, nil
+}
+
+// sampleMarketPath draws ProjectionYears of portfolio returns and inflation
+func (mcs *MonteCarloSimulator) sampleMarketPath(config MonteCarloConfig) marketPath {
+	path := marketPath{
+		returns:   make([]decimal.Decimal, mcs.ProjectionYears),
+		inflation: make([]decimal.Decimal, mcs.ProjectionYears),
+	}
+	for year := range path.returns {
+		marketData := mcs.sampleMarketConditions()
+		path.returns[year] = mcs.calculatePortfolioReturn(config.AssetAllocation, marketData)
+		path.inflation[year] = marketData.Inflation
+	}
+	return path
+}
+
+// sustains reports whether the path keeps a positive balance through every year while paying a
+// first-year withdrawal raised by each prior year's inflation
+func (p marketPath) sustains(initialBalance, firstYearWithdrawal decimal.Decimal) bool {
+	balance, withdrawal := initialBalance, firstYearWithdrawal
+	for year := range p.returns {
+		balance = balance.Mul(decimal.NewFromInt(1).Add(p.returns[year])).Sub(withdrawal)
+		if !balance.IsPositive() {
+			return false
+		}
+		withdrawal = withdrawal.Mul(decimal.NewFromInt(1).Add(p.inflation[year]))
+	}
+	return true
+}
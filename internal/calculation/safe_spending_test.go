@@ -0,0 +1,52 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSafeSpending_HigherConfidenceLowersSpending(t *testing.T) {
+	testDataPath := t.TempDir()
+	if err := createTestDataFiles(testDataPath); err != nil {
+		t.Fatalf("Failed to create test data files: %v", err)
+	}
+	hdm := NewHistoricalDataManager(testDataPath)
+	if err := hdm.LoadAllData(); err != nil {
+		t.Fatalf("Failed to load historical data: %v", err)
+	}
+
+	config := MonteCarloConfig{
+		NumSimulations:  300,
+		ProjectionYears: 30,
+		AssetAllocation: map[string]decimal.Decimal{
+			"C": decimal.NewFromFloat(0.6),
+			"F": decimal.NewFromFloat(0.4),
+		},
+		InitialBalance: decimal.NewFromInt(1000000),
+	}
+	simulator := NewMonteCarloSimulator(hdm, config)
+
+	var previous decimal.Decimal
+	for i, target := range []float64{0.5, 0.9, 0.99} {
+		result, err := simulator.SafeSpending(context.Background(), config, decimal.NewFromFloat(target))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.SuccessRate.LessThan(decimal.NewFromFloat(target)) {
+			t.Fatalf("%.0f%%: success rate %s is below the target", target*100, result.SuccessRate)
+		}
+		if !result.AnnualWithdrawal.IsPositive() {
+			t.Fatalf("%.0f%%: expected positive safe spending", target*100)
+		}
+		if i > 0 && !result.AnnualWithdrawal.LessThan(previous) {
+			t.Fatalf("%.0f%%: safe spending %s should be below %s at the lower target", target*100, result.AnnualWithdrawal, previous)
+		}
+		previous = result.AnnualWithdrawal
+	}
+
+	if _, err := simulator.SafeSpending(context.Background(), config, decimal.NewFromFloat(1.5)); err == nil {
+		t.Fatalf("expected an error for a target above 100%%")
+	}
+}
@@ -0,0 +1,60 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestSalaryGrowthRaisesPension(t *testing.T) {
+	run := func(growth *decimal.Decimal) (pension, salary2028 decimal.Decimal) {
+		// Work five more years, retiring at the end of 2029 at 66
+		personA := domain.Employee{
+			Name:             "PersonA",
+			BirthDate:        time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+			HireDate:         time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			CurrentSalary:    decimal.NewFromInt(100000),
+			High3Salary:      decimal.NewFromInt(100000),
+			SalaryGrowthRate: growth,
+		}
+		personB := domain.Employee{
+			Name:      "PersonB",
+			BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC),
+			HireDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		cfg := &domain.Configuration{
+			PersonalDetails:   map[string]domain.Employee{"person_a": personA, "person_b": personB},
+			GlobalAssumptions: domain.GlobalAssumptions{ProjectionYears: 8},
+		}
+		scenario := &domain.Scenario{
+			Name:    "salary-growth",
+			PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC), SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+			PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"},
+		}
+
+		summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return summary.Projection[5].PensionPersonA, summary.Projection[3].SalaryPersonA
+	}
+
+	staticPension, staticSalary := run(nil)
+	growth := decimal.NewFromFloat(0.02)
+	grownPension, grownSalary := run(&growth)
+
+	if !staticSalary.Equal(decimal.NewFromInt(100000)) {
+		t.Fatalf("expected a static salary of 100000, got %s", staticSalary)
+	}
+	if want := decimal.NewFromInt(100000).Mul(decimal.NewFromFloat(1.02).Pow(decimal.NewFromInt(3))); grownSalary.Sub(want).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("expected the 2028 salary to grow to %s, got %s", want, grownSalary)
+	}
+	// High-3 rises from the configured 100000 to the 2027-2029 average, about 106135
+	ratio := grownPension.Div(staticPension)
+	if ratio.LessThan(decimal.NewFromFloat(1.06)) || ratio.GreaterThan(decimal.NewFromFloat(1.065)) {
+		t.Fatalf("expected the pension to rise with the projected High-3: static %s, grown %s", staticPension, grownPension)
+	}
+}
@@ -0,0 +1,163 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// scenarioSummaryAccumulator builds a scenario's summary from its projection one year at a time, so
+// RunScenario and RunScenarioSummaryOnly report the same metrics whether or not the projection is kept.
+// It keeps only the household TSP balance and the guaranteed income streams of each year, which plan
+// horizon success and blackout years need once the projection is complete.
+type scenarioSummaryAccumulator struct {
+	summary         *domain.ScenarioSummary
+	discountRate    decimal.Decimal
+	spendingNeed    bool
+	comparisonYears []int
+	planToAge       [2]int // PersonA's and PersonB's plan_to_age, 0 when unset
+	planToAgeYears  [2]int // Projection years through the year each person reaches plan_to_age, 0 until then
+	foundGuaranteed bool
+	tspBalances     []decimal.Decimal
+	guaranteed      []guaranteedIncomeYear
+}
+
+// newScenarioSummaryAccumulator starts a summary for scenario, filling the pre-retirement baseline from the
+// household's current net income
+func (ce *CalculationEngine) newScenarioSummaryAccumulator(config *domain.Configuration, scenario *domain.Scenario) *scenarioSummaryAccumulator {
+	personA := config.PersonalDetails["person_a"]
+	personB := config.PersonalDetails["person_b"]
+	startYear := ProjectionStartYear(&config.GlobalAssumptions)
+	comparisonYears := domain.ComparisonYears(startYear)
+
+	// Pre-retirement baseline projections with COLA growth
+	currentNetIncome := ce.NetIncomeCalc.Calculate(&personA, &personB, config.GlobalAssumptions.FederalRules.FEHBConfig, ce.TaxCalc.LocalTaxCalculatorFor(config.GlobalAssumptions.CurrentLocation.Municipality), startYear, ce.Debug)
+	colaRate := config.GlobalAssumptions.COLAGeneralRate
+	summary := &domain.ScenarioSummary{
+		Name:                 scenario.Name,
+		ComparisonYears:      comparisonYears,
+		PreRetirementNet2030: ce.projectPreRetirementNetIncome(currentNetIncome, startYear, comparisonYears[0], colaRate),
+		PreRetirementNet2035: ce.projectPreRetirementNetIncome(currentNetIncome, startYear, comparisonYears[1], colaRate),
+		PreRetirementNet2040: ce.projectPreRetirementNetIncome(currentNetIncome, startYear, comparisonYears[2], colaRate),
+	}
+
+	return &scenarioSummaryAccumulator{
+		summary:         summary,
+		discountRate:    discountRateOrDefault(&config.GlobalAssumptions),
+		spendingNeed:    scenario.SpendingNeed != nil,
+		comparisonYears: comparisonYears,
+		planToAge:       [2]int{personA.PlanToAge, personB.PlanToAge},
+		tspBalances:     make([]decimal.Decimal, 0, config.GlobalAssumptions.ProjectionYears),
+		guaranteed:      make([]guaranteedIncomeYear, 0, config.GlobalAssumptions.ProjectionYears),
+	}
+}
+
+// add folds the next projection year into the summary. It does not retain cf.
+func (a *scenarioSummaryAccumulator) add(cf *domain.AnnualCashFlow) {
+	summary := a.summary
+	year := len(a.tspBalances)
+	tspBalance := cf.TSPBalancePersonA.Add(cf.TSPBalancePersonB)
+
+	// Guard Year5/Year10 for short projections by leaving them zero
+	switch year {
+	case 0:
+		summary.FirstYearNetIncome = cf.NetIncome
+		summary.InitialTSPBalance = tspBalance
+	case 4:
+		summary.Year5NetIncome = cf.NetIncome
+	case 9:
+		summary.Year10NetIncome = cf.NetIncome
+	}
+
+	// Absolute calendar year comparisons for apples-to-apples analysis
+	switch cf.Date.Year() {
+	case a.comparisonYears[0]:
+		summary.NetIncome2030 = cf.NetIncome
+	case a.comparisonYears[1]:
+		summary.NetIncome2035 = cf.NetIncome
+	case a.comparisonYears[2]:
+		summary.NetIncome2040 = cf.NetIncome
+	}
+
+	// Total lifetime income (present value at 3%) and NPV at the configured discount rate
+	summary.TotalLifetimeIncome = summary.TotalLifetimeIncome.Add(cf.NetIncome.Mul(DiscountFactor(defaultDiscountRate, year)))
+	summary.NetPresentValue = summary.NetPresentValue.Add(cf.NetIncome.Mul(DiscountFactor(a.discountRate, year)))
+
+	summary.TSPAnnuitized = summary.TSPAnnuitized || cf.TSPAnnuitized
+	if summary.TSPLongevity == 0 && cf.IsTSPDepleted() {
+		summary.TSPLongevity = year + 1
+	}
+	// The guaranteed income floor once retired
+	if cf.IsRetired && (!a.foundGuaranteed || cf.GuaranteedIncome.LessThan(summary.MinGuaranteedIncome)) {
+		summary.MinGuaranteedIncome, a.foundGuaranteed = cf.GuaranteedIncome, true
+	}
+	// Years net income falls short of the spending need
+	if a.spendingNeed && cf.SpendingSurplus.IsNegative() {
+		summary.ShortfallYears++
+	}
+	if cf.TaxTorpedo {
+		summary.TaxTorpedoYears++
+	}
+	summary.TotalLifetimeFederalTax = summary.TotalLifetimeFederalTax.Add(cf.FederalTax)
+	summary.TotalLifetimeStateTax = summary.TotalLifetimeStateTax.Add(cf.StateTax)
+	summary.TotalLifetimeTax = summary.TotalLifetimeTax.Add(cf.IncomeTax())
+	summary.TotalLifetimeTaxPV = summary.TotalLifetimeTaxPV.Add(cf.IncomeTax().Mul(DiscountFactor(a.discountRate, year)))
+	summary.FinalTSPBalance = tspBalance
+
+	for i, age := range [2]int{cf.AgePersonA, cf.AgePersonB} {
+		if a.planToAge[i] > 0 && a.planToAgeYears[i] == 0 && age >= a.planToAge[i] {
+			a.planToAgeYears[i] = year + 1
+		}
+	}
+	a.tspBalances = append(a.tspBalances, tspBalance)
+	a.guaranteed = append(a.guaranteed, newGuaranteedIncomeYear(cf))
+}
+
+// finish completes the metrics that need the whole projection and returns the summary
+func (a *scenarioSummaryAccumulator) finish() *domain.ScenarioSummary {
+	summary := a.summary
+	years := len(a.tspBalances)
+	if summary.TSPLongevity == 0 {
+		summary.TSPLongevity = years // Lasted full projection
+	}
+	summary.PlanHorizonYears = a.planHorizonYears()
+
+	blackout, gaps := blackoutGaps(a.guaranteed)
+	summary.BlackoutYears, summary.MaxBlackoutGap = 0, decimal.Zero
+	for i := range blackout {
+		if blackout[i] {
+			summary.BlackoutYears++
+			summary.MaxBlackoutGap = decimal.Max(summary.MaxBlackoutGap, gaps[i])
+		}
+	}
+
+	// Success rate for deterministic scenarios based on TSP sustainability over the plan horizon
+	if years > 0 {
+		horizon := summary.PlanHorizonYears
+		summary.SuccessRate = deterministicSuccessRate(horizon, a.tspBalances[0], a.tspBalances[horizon-1], summary.TSPLongevity, summary.TSPAnnuitized)
+	}
+	return summary
+}
+
+// planHorizonYears returns how many projection years TSP longevity and success are judged over: through
+// the year the later person reaches their plan_to_age, or the whole projection when neither person sets
+// one or a plan-to age falls beyond it
+func (a *scenarioSummaryAccumulator) planHorizonYears() int {
+	years := len(a.tspBalances)
+	horizon := 0
+	for i, planToAge := range a.planToAge {
+		if planToAge == 0 {
+			continue
+		}
+		reached := a.planToAgeYears[i]
+		if reached == 0 {
+			reached = years
+		}
+		if reached > horizon {
+			horizon = reached
+		}
+	}
+	if horizon == 0 {
+		return years
+	}
+	return horizon
+}
@@ -0,0 +1,91 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// sensitivityParameters maps sweepable global assumption names (their YAML keys) to setters
+var sensitivityParameters = map[string]func(*domain.GlobalAssumptions, decimal.Decimal){
+	"inflation_rate":             func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.InflationRate = v },
+	"fehb_premium_inflation":     func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.FEHBPremiumInflation = v },
+	"tsp_return_pre_retirement":  func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.TSPReturnPreRetirement = v },
+	"tsp_return_post_retirement": func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.SetPostRetirementReturn(v) },
+	"cola_general_rate":          func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.COLAGeneralRate = v },
+	"discount_rate":              func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.DiscountRate = &v },
+	"hsa_return":                 func(ga *domain.GlobalAssumptions, v decimal.Decimal) { ga.HSAReturn = &v },
+}
+
+// SensitivityParameters returns the assumption names accepted by RunSensitivity
+func SensitivityParameters() []string {
+	names := make([]string, 0, len(sensitivityParameters))
+	for name := range sensitivityParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SensitivityPoint holds the key output metrics for one value of the swept assumption
+type SensitivityPoint struct {
+	Value              decimal.Decimal `json:"value"`
+	FirstYearNetIncome decimal.Decimal `json:"first_year_net_income"`
+	Year10NetIncome    decimal.Decimal `json:"year_10_net_income"`
+	LifetimeIncome     decimal.Decimal `json:"lifetime_income"`
+	NetPresentValue    decimal.Decimal `json:"net_present_value"`
+	TSPLongevity       int             `json:"tsp_longevity"`
+	FinalTSPBalance    decimal.Decimal `json:"final_tsp_balance"`
+}
+
+// SensitivityResult is the table of outputs produced by sweeping one assumption
+type SensitivityResult struct {
+	Parameter string             `json:"parameter"`
+	Scenario  string             `json:"scenario"`
+	Points    []SensitivityPoint `json:"points"`
+}
+
+// RunSensitivity re-runs the baseline (first) scenario once per value of the named global assumption,
+// holding everything else constant. paramPath is the assumption's YAML key, optionally prefixed with
+// "global_assumptions.". The caller's configuration is not modified.
+func (ce *CalculationEngine) RunSensitivity(config *domain.Configuration, paramPath string, values []decimal.Decimal) (*SensitivityResult, error) {
+	if config == nil || len(config.Scenarios) == 0 {
+		return nil, fmt.Errorf("configuration with at least one scenario is required")
+	}
+	name := strings.TrimPrefix(paramPath, "global_assumptions.")
+	set, ok := sensitivityParameters[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sensitivity parameter %q (supported: %s)", paramPath, strings.Join(SensitivityParameters(), ", "))
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one value is required for sensitivity parameter %s", name)
+	}
+
+	// The scenario's own overrides are applied first so the swept value is the one each run uses
+	config, scenario := scenarioAssumptions(config, &config.Scenarios[0])
+	result := &SensitivityResult{Parameter: name, Scenario: scenario.Name}
+	for _, value := range values {
+		// Shallow copy is enough: GlobalAssumptions is a value and RunScenario copies the employees
+		trial := *config
+		set(&trial.GlobalAssumptions, value)
+
+		summary, err := ce.RunScenario(context.Background(), &trial, scenario)
+		if err != nil {
+			return nil, fmt.Errorf("sensitivity %s=%s: %w", name, value.String(), err)
+		}
+		result.Points = append(result.Points, SensitivityPoint{
+			Value:              value,
+			FirstYearNetIncome: summary.FirstYearNetIncome,
+			Year10NetIncome:    summary.Year10NetIncome,
+			LifetimeIncome:     summary.TotalLifetimeIncome,
+			NetPresentValue:    summary.NetPresentValue,
+			TSPLongevity:       summary.TSPLongevity,
+			FinalTSPBalance:    summary.FinalTSPBalance,
+		})
+	}
+	return result, nil
+}
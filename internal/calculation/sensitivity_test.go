@@ -0,0 +1,82 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestRunSensitivity_TSPLongevityRisesWithReturn(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	target := decimal.NewFromInt(3000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+	cfg.Scenarios = []domain.Scenario{*scenario}
+	original := cfg.GlobalAssumptions.TSPReturnPostRetirement
+
+	values := []decimal.Decimal{decimal.Zero, decimal.NewFromFloat(0.03), decimal.NewFromFloat(0.06), decimal.NewFromFloat(0.09)}
+	result, err := NewCalculationEngine().RunSensitivity(cfg, "global_assumptions.tsp_return_post_retirement", values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Points) != len(values) {
+		t.Fatalf("expected %d points, got %d", len(values), len(result.Points))
+	}
+	for i := 1; i < len(result.Points); i++ {
+		if result.Points[i].TSPLongevity < result.Points[i-1].TSPLongevity {
+			t.Fatalf("expected longevity to be non-decreasing in return: %+v", result.Points)
+		}
+	}
+	if result.Points[len(values)-1].TSPLongevity <= result.Points[0].TSPLongevity {
+		t.Fatalf("expected higher returns to extend TSP longevity: %+v", result.Points)
+	}
+	if !cfg.GlobalAssumptions.TSPReturnPostRetirement.Equal(original) {
+		t.Fatalf("expected caller config to be unchanged, got %s", cfg.GlobalAssumptions.TSPReturnPostRetirement)
+	}
+
+	if _, err := NewCalculationEngine().RunSensitivity(cfg, "not_a_parameter", values); err == nil {
+		t.Fatalf("expected an error for an unknown parameter")
+	}
+}
+
+func TestRunSensitivity_SweepWinsOverScenarioOverride(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	target := decimal.NewFromInt(3000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+	pinned := decimal.NewFromFloat(0.04)
+	scenario.AssumptionOverrides = &domain.AssumptionOverrides{TSPReturnPostRetirement: &pinned}
+	cfg.Scenarios = []domain.Scenario{*scenario}
+
+	values := []decimal.Decimal{decimal.Zero, decimal.NewFromFloat(0.09)}
+	result, err := NewCalculationEngine().RunSensitivity(cfg, "tsp_return_post_retirement", values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Points[1].FinalTSPBalance.LessThanOrEqual(result.Points[0].FinalTSPBalance) {
+		t.Fatalf("expected the swept return, not the scenario's override, to drive each run: %+v", result.Points)
+	}
+	if cfg.Scenarios[0].AssumptionOverrides == nil || !cfg.Scenarios[0].AssumptionOverrides.TSPReturnPostRetirement.Equal(pinned) {
+		t.Fatalf("expected the caller's scenario overrides to be unchanged")
+	}
+}
+
+func TestRunSensitivity_PostRetirementSweepShiftsGlidePath(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	// Both retirees are past the only glide path step, so an unshifted path would ignore the sweep
+	cfg.GlobalAssumptions.TSPReturnGlidePath = map[int]decimal.Decimal{60: decimal.NewFromFloat(0.04)}
+	cfg.Scenarios = []domain.Scenario{*scenario}
+
+	values := []decimal.Decimal{decimal.Zero, decimal.NewFromFloat(0.09)}
+	result, err := NewCalculationEngine().RunSensitivity(cfg, "tsp_return_post_retirement", values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Points[1].FinalTSPBalance.LessThanOrEqual(result.Points[0].FinalTSPBalance) {
+		t.Fatalf("expected the swept return to move the glide path: %+v", result.Points)
+	}
+	if !cfg.GlobalAssumptions.TSPReturnGlidePath[60].Equal(decimal.NewFromFloat(0.04)) {
+		t.Fatalf("expected the caller's glide path to be unchanged")
+	}
+}
@@ -0,0 +1,103 @@
+package calculation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// sequenceRiskYears is the number of early projection years whose returns sequence risk is measured on
+const sequenceRiskYears = 5
+
+// SequenceRisk measures how much Monte Carlo outcomes depend on returns early in the projection. Each
+// simulation's average portfolio return over its first Years years is correlated with its final TSP
+// balance and with its success; values near 1 mean outcomes are driven by early performance and near 0
+// mean they are not. The quintile success rates compare the simulations with the worst and best early
+// returns.
+type SequenceRisk struct {
+	Years                    int             `json:"years"`
+	BalanceCorrelation       decimal.Decimal `json:"balance_correlation"`
+	SuccessCorrelation       decimal.Decimal `json:"success_correlation"`
+	WorstQuintileSuccessRate decimal.Decimal `json:"worst_quintile_success_rate"`
+	BestQuintileSuccessRate  decimal.Decimal `json:"best_quintile_success_rate"`
+}
+
+// calculateSequenceRisk computes SequenceRisk from each simulation's market series, weighting fund returns
+// by the household's TSP allocations (see weightedTSPReturn)
+func (fmce *FERSMonteCarloEngine) calculateSequenceRisk(simulations []FERSMonteCarloSimulation, config *domain.Configuration) SequenceRisk {
+	risk := SequenceRisk{Years: sequenceRiskYears}
+	type outcome struct {
+		earlyReturn, finalBalance, success float64
+	}
+	outcomes := make([]outcome, 0, len(simulations))
+	for _, sim := range simulations {
+		years := sim.MarketSeries.Years
+		if len(years) > sequenceRiskYears {
+			years = years[:sequenceRiskYears]
+		}
+		if len(years) == 0 {
+			continue
+		}
+		var total float64
+		for _, market := range years {
+			total += fmce.weightedTSPReturn(market, config).InexactFloat64()
+		}
+		o := outcome{earlyReturn: total / float64(len(years)), finalBalance: sim.TSPMetrics.FinalBalance.InexactFloat64()}
+		if sim.Success {
+			o.success = 1
+		}
+		outcomes = append(outcomes, o)
+	}
+	if len(outcomes) == 0 {
+		return risk
+	}
+
+	early := make([]float64, len(outcomes))
+	balances := make([]float64, len(outcomes))
+	successes := make([]float64, len(outcomes))
+	for i, o := range outcomes {
+		early[i], balances[i], successes[i] = o.earlyReturn, o.finalBalance, o.success
+	}
+	risk.BalanceCorrelation = decimal.NewFromFloat(pearsonCorrelation(early, balances))
+	risk.SuccessCorrelation = decimal.NewFromFloat(pearsonCorrelation(early, successes))
+
+	sort.Slice(outcomes, func(i, j int) bool { return outcomes[i].earlyReturn < outcomes[j].earlyReturn })
+	quintile := max(len(outcomes)/5, 1)
+	successRate := func(group []outcome) decimal.Decimal {
+		var count float64
+		for _, o := range group {
+			count += o.success
+		}
+		return decimal.NewFromFloat(count / float64(len(group)))
+	}
+	risk.WorstQuintileSuccessRate = successRate(outcomes[:quintile])
+	risk.BestQuintileSuccessRate = successRate(outcomes[len(outcomes)-quintile:])
+	return risk
+}
+
+// pearsonCorrelation returns the correlation coefficient of x and y, or 0 when either does not vary
+func pearsonCorrelation(x, y []float64) float64 {
+	n := float64(len(x))
+	if n == 0 {
+		return 0
+	}
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX, meanY = meanX/n, meanY/n
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,84 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// sequenceRiskSimulation builds a simulation that earns bad returns for five years starting at badStart
+// and good returns otherwise, so every simulation has the same average return over its whole horizon
+func sequenceRiskSimulation(badStart int) FERSMonteCarloSimulation {
+	balance := decimal.NewFromInt(1000000)
+	withdrawal := decimal.NewFromInt(50000)
+	var sim FERSMonteCarloSimulation
+	for year := 0; year < 20; year++ {
+		r := decimal.NewFromFloat(0.10)
+		if year >= badStart && year < badStart+5 {
+			r = decimal.NewFromFloat(-0.15)
+		}
+		returns := map[string]decimal.Decimal{"C": r, "S": r, "I": r, "F": r, "G": r}
+		sim.MarketSeries.Years = append(sim.MarketSeries.Years, MarketCondition{Year: year, TSPReturns: returns})
+		balance = decimal.Max(balance.Mul(decimal.NewFromInt(1).Add(r)).Sub(withdrawal), decimal.Zero)
+	}
+	sim.TSPMetrics.FinalBalance = balance
+	sim.Success = balance.IsPositive()
+	return sim
+}
+
+func TestSequenceRiskFrontLoadedBadReturns(t *testing.T) {
+	config := &domain.Configuration{}
+	// The bad stretch hits somewhere in the first five years or at the very end
+	badStarts := []int{0, 1, 2, 3, 4, 15}
+	var simulations []FERSMonteCarloSimulation
+	for i := 0; i < 60; i++ {
+		simulations = append(simulations, sequenceRiskSimulation(badStarts[i%len(badStarts)]))
+	}
+
+	risk := (&FERSMonteCarloEngine{}).calculateSequenceRisk(simulations, config)
+	if risk.Years != sequenceRiskYears {
+		t.Fatalf("years = %d, want %d", risk.Years, sequenceRiskYears)
+	}
+	if risk.BalanceCorrelation.LessThan(decimal.NewFromFloat(0.7)) {
+		t.Fatalf("balance correlation = %s, want a strong positive correlation with early returns", risk.BalanceCorrelation)
+	}
+	if !risk.SuccessCorrelation.IsPositive() {
+		t.Fatalf("success correlation = %s, want positive", risk.SuccessCorrelation)
+	}
+	if !risk.WorstQuintileSuccessRate.LessThan(risk.BestQuintileSuccessRate) {
+		t.Fatalf("worst quintile success %s should be below best quintile success %s", risk.WorstQuintileSuccessRate, risk.BestQuintileSuccessRate)
+	}
+}
+
+func TestSequenceRiskWithoutVariation(t *testing.T) {
+	simulations := []FERSMonteCarloSimulation{sequenceRiskSimulation(0), sequenceRiskSimulation(0)}
+	risk := (&FERSMonteCarloEngine{}).calculateSequenceRisk(simulations, &domain.Configuration{})
+	if !risk.BalanceCorrelation.IsZero() || !risk.SuccessCorrelation.IsZero() {
+		t.Fatalf("identical simulations should have no correlation, got %s and %s", risk.BalanceCorrelation, risk.SuccessCorrelation)
+	}
+}
+
+func TestSequenceRiskUsesHouseholdAllocation(t *testing.T) {
+	// Stocks crash early when the bonds gain, and the reverse, so the sign of the early return depends on
+	// which funds the household holds
+	simulation := func(stocks, bonds float64, finalBalance int64) FERSMonteCarloSimulation {
+		var sim FERSMonteCarloSimulation
+		for year := 0; year < sequenceRiskYears; year++ {
+			returns := map[string]decimal.Decimal{"C": decimal.NewFromFloat(stocks), "S": decimal.NewFromFloat(stocks), "I": decimal.NewFromFloat(stocks), "F": decimal.NewFromFloat(bonds), "G": decimal.NewFromFloat(bonds)}
+			sim.MarketSeries.Years = append(sim.MarketSeries.Years, MarketCondition{Year: year, TSPReturns: returns})
+		}
+		sim.TSPMetrics.FinalBalance = decimal.NewFromInt(finalBalance)
+		return sim
+	}
+	// An all-G household does better when bonds gain
+	simulations := []FERSMonteCarloSimulation{simulation(-0.2, 0.05, 1500000), simulation(0.2, 0.01, 1000000)}
+	config := &domain.Configuration{PersonalDetails: map[string]domain.Employee{
+		"person_a": {TSPBalanceTraditional: decimal.NewFromInt(1000000), TSPAllocation: &domain.TSPAllocation{GFund: decimal.NewFromInt(1)}},
+	}}
+
+	risk := (&FERSMonteCarloEngine{}).calculateSequenceRisk(simulations, config)
+	if !risk.BalanceCorrelation.IsPositive() {
+		t.Fatalf("balance correlation = %s, want positive for the G fund household's own early returns", risk.BalanceCorrelation)
+	}
+}
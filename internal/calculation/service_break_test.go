@@ -0,0 +1,54 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestServiceBreak_PausesSalaryContributionsAndService(t *testing.T) {
+	run := func(breaks []domain.ServiceBreak) *domain.ScenarioSummary {
+		cfg, scenario := retiredCoupleTestConfig(8)
+		personA := cfg.PersonalDetails["person_a"]
+		personA.CurrentSalary = decimal.NewFromInt(100000)
+		personA.TSPContributionPercent = decimal.NewFromFloat(0.05)
+		personA.ServiceBreaks = breaks
+		cfg.PersonalDetails["person_a"] = personA
+		scenario.PersonA.RetirementDate = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return summary
+	}
+	continuous := run(nil)
+	withBreak := run([]domain.ServiceBreak{{
+		SeparationDate:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ReemploymentDate: time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC),
+	}})
+
+	for _, cf := range withBreak.Projection {
+		year := cf.Date.Year()
+		switch {
+		case year == 2026 || year == 2027:
+			if !cf.SalaryPersonA.IsZero() || !cf.TSPContributions.IsZero() {
+				t.Fatalf("%d: expected no salary or contributions during the break, got %s and %s", year, cf.SalaryPersonA, cf.TSPContributions)
+			}
+		case year == 2025 || year == 2028 || year == 2029:
+			if !cf.SalaryPersonA.IsPositive() || !cf.TSPContributions.IsPositive() {
+				t.Fatalf("%d: expected salary and contributions while employed", year)
+			}
+		}
+	}
+
+	// Two years out of service cost two years of credit, so the pension is 38/40 of the continuous one
+	pensionYear := 2030 - testProjectionStartYear
+	got, want := withBreak.Projection[pensionYear].PensionPersonA, continuous.Projection[pensionYear].PensionPersonA
+	if ratio := got.Div(want); ratio.Sub(decimal.NewFromFloat(38.0 / 40.0)).Abs().GreaterThan(decimal.NewFromFloat(0.002)) {
+		t.Fatalf("expected the pension to shrink by two years of service, got %s of %s", got, want)
+	}
+}
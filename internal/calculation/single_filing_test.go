@@ -45,7 +45,7 @@ func TestMortalityFilingAffectsSSTaxation(t *testing.T) {
 	ce := NewCalculationEngine()
 	proj := ce.GenerateAnnualProjection(personA, personB, scenario, assumptions, domain.FederalRules{})
 	// Find first year after death (same year since immediate switch) and prior year
-	deathIdx := 2029 - ProjectionBaseYear
+	deathIdx := 2029 - testProjectionStartYear
 	if deathIdx <= 0 || deathIdx >= len(proj) {
 		t.Fatalf("deathIdx out of range")
 	}
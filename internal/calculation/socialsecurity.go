@@ -26,41 +26,10 @@ func NewSocialSecurityCalculator(birthYear int, benefitAtFRA decimal.Decimal) *S
 
 // CalculateBenefitAtAge calculates the Social Security benefit at a specific claiming age
 func (ssc *SocialSecurityCalculator) CalculateBenefitAtAge(claimingAge int) decimal.Decimal {
-	if claimingAge < 62 {
-		return decimal.Zero
-	}
-
-	if claimingAge < ssc.FullRetirementAge {
-		// Early retirement reduction
-		monthsEarly := (ssc.FullRetirementAge - claimingAge) * 12
-		var reductionRate decimal.Decimal
-
-		if monthsEarly <= 36 {
-			// 5/9 of 1% per month for first 36 months
-			reductionRate = decimal.NewFromFloat(5.0 / 9.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsEarly)))
-		} else {
-			// 5/9 of 1% for first 36 months, 5/12 of 1% for additional months
-			firstReduction := decimal.NewFromFloat(5.0 / 9.0 / 100.0).Mul(decimal.NewFromInt(36))
-			additionalMonths := monthsEarly - 36
-			additionalReduction := decimal.NewFromFloat(5.0 / 12.0 / 100.0).Mul(decimal.NewFromInt(int64(additionalMonths)))
-			reductionRate = firstReduction.Add(additionalReduction)
-		}
-
-		return ssc.BenefitAtFRA.Mul(decimal.NewFromFloat(1).Sub(reductionRate))
+	if claimingAge == ssc.FullRetirementAge {
+		return ssc.BenefitAtFRA
 	}
-
-	if claimingAge > ssc.FullRetirementAge {
-		// Delayed retirement credits: 8% per year (2/3% per month)
-		monthsDelayed := (claimingAge - ssc.FullRetirementAge) * 12
-		if monthsDelayed > 48 { // Cap at age 70
-			monthsDelayed = 48
-		}
-
-		delayCredit := decimal.NewFromFloat(2.0 / 3.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsDelayed)))
-		return ssc.BenefitAtFRA.Mul(decimal.NewFromFloat(1).Add(delayCredit))
-	}
-
-	return ssc.BenefitAtFRA // At Full Retirement Age
+	return ssc.BenefitAtFRA.Mul(domain.SSClaimingAgeFactor(ssc.FullRetirementAge, claimingAge))
 }
 
 // CalculateMonthlySSBenefitAtAge calculates the monthly SS benefit based on claiming age
@@ -83,7 +52,7 @@ func ProjectSocialSecurityBenefits(employee *domain.Employee, ssStartAge int, pr
 	currentBenefit := initialBenefit
 
 	for year := 0; year < projectionYears; year++ {
-		projectionDate := nowFunc().AddDate(year, 0, 0)
+		projectionDate := domain.Now().AddDate(year, 0, 0)
 		age := employee.Age(projectionDate)
 
 		// Check if Social Security has started
@@ -109,57 +78,46 @@ func NewSSTaxCalculator() *SSTaxCalculator {
 	return &SSTaxCalculator{}
 }
 
-// CalculateTaxableSocialSecurity determines the federally taxable portion of SS benefits
-// Provisional Income = (AGI - deductions) + Non-taxable interest + 1/2 of Social Security benefits
-// Thresholds for Married Filing Jointly:
-// - Provisional Income <= $32,000: 0% of SS benefits are taxable
-// - Provisional Income > $32,000 and <= $44,000: Up to 50% of SS benefits are taxable
-// - Provisional Income > $44,000: Up to 85% of SS benefits are taxable
+// Social Security taxation base amounts (worksheet line 8) and the width of the 50% band up to the
+// adjusted base amount (line 10)
+var (
+	ssTaxBaseJoint       = decimal.NewFromInt(32000)
+	ssTaxBandJoint       = decimal.NewFromInt(12000)
+	ssTaxBaseSingle      = decimal.NewFromInt(25000)
+	ssTaxBandSingle      = decimal.NewFromInt(9000)
+	ssTaxFirstTierShare  = decimal.NewFromFloat(0.5)
+	ssTaxSecondTierShare = decimal.NewFromFloat(0.85)
+)
+
+// CalculateTaxableSocialSecurity determines the federally taxable portion of SS benefits for married
+// filing jointly. Provisional Income = AGI + non-taxable interest + 1/2 of Social Security benefits. Up to
+// $32,000 none is taxable; between $32,000 and $44,000 half the excess is, up to half the benefits; above
+// $44,000, 85% of the excess is added to that, up to 85% of the benefits.
 func (sstc *SSTaxCalculator) CalculateTaxableSocialSecurity(totalSSBenefitAnnual decimal.Decimal, provisionalIncome decimal.Decimal) decimal.Decimal {
-	threshold1 := decimal.NewFromInt(32000)
-	threshold2 := decimal.NewFromInt(44000)
+	return taxableSocialSecurity(totalSSBenefitAnnual, provisionalIncome, ssTaxBaseJoint, ssTaxBandJoint)
+}
 
-	if provisionalIncome.LessThanOrEqual(threshold1) {
-		return decimal.Zero
-	} else if provisionalIncome.LessThanOrEqual(threshold2) {
-		// Taxable amount is the lesser of:
-		// 1. 50% of (Provisional Income - Threshold 1)
-		// 2. 50% of Total SS Benefit
-		taxablePart1 := provisionalIncome.Sub(threshold1).Mul(decimal.NewFromFloat(0.5))
-		taxablePart2 := totalSSBenefitAnnual.Mul(decimal.NewFromFloat(0.5))
-		return decimal.Min(taxablePart1, taxablePart2)
-	} else { // Provisional Income > Threshold 2
-		// For very high provisional income, use simplified approach:
-		// Most high-income retirees end up with 85% of benefits being taxable
-		// This matches the test expectations and is a reasonable approximation
-		return totalSSBenefitAnnual.Mul(decimal.NewFromFloat(0.85))
-	}
-}
-
-// CalculateTaxableSocialSecuritySingle determines the federally taxable portion for single filers
+// CalculateTaxableSocialSecuritySingle determines the federally taxable portion for single filers, with
+// thresholds of $25,000 and $34,000
 func (sstc *SSTaxCalculator) CalculateTaxableSocialSecuritySingle(totalSSBenefitAnnual decimal.Decimal, provisionalIncome decimal.Decimal) decimal.Decimal {
-	threshold1 := decimal.NewFromInt(25000)
-	threshold2 := decimal.NewFromInt(34000)
+	return taxableSocialSecurity(totalSSBenefitAnnual, provisionalIncome, ssTaxBaseSingle, ssTaxBandSingle)
+}
 
-	if provisionalIncome.LessThanOrEqual(threshold1) {
+// taxableSocialSecurity follows the IRS Social Security Benefits Worksheet (Form 1040 instructions) from
+// provisional income, which covers lines 1-7
+func taxableSocialSecurity(benefits, provisionalIncome, baseAmount, band decimal.Decimal) decimal.Decimal {
+	// Lines 8-9: provisional income above the base amount
+	excess := provisionalIncome.Sub(baseAmount)
+	if excess.LessThanOrEqual(decimal.Zero) || benefits.LessThanOrEqual(decimal.Zero) {
 		return decimal.Zero
-	} else if provisionalIncome.GreaterThan(threshold1) && provisionalIncome.LessThanOrEqual(threshold2) {
-		// Taxable amount is the lesser of:
-		// 1. 50% of (Provisional Income - Threshold 1)
-		// 2. 50% of Total SS Benefit
-		taxablePart1 := provisionalIncome.Sub(threshold1).Mul(decimal.NewFromFloat(0.5))
-		taxablePart2 := totalSSBenefitAnnual.Mul(decimal.NewFromFloat(0.5))
-		return decimal.Min(taxablePart1, taxablePart2)
-	} else { // Provisional Income > Threshold 2
-		// Taxable amount is the lesser of:
-		// 1. 85% of (Provisional Income - Threshold 2) + Lesser of (50% of Threshold 2 - Threshold 1) or 50% of SS
-		// 2. 85% of Total SS Benefit
-		taxableAmountA := totalSSBenefitAnnual.Mul(decimal.NewFromFloat(0.85))
-		taxableAmountB := provisionalIncome.Sub(threshold2).Mul(decimal.NewFromFloat(0.85)).Add(
-			decimal.NewFromFloat(0.5).Mul(threshold2.Sub(threshold1)),
-		)
-		return decimal.Min(taxableAmountA, taxableAmountB)
 	}
+	// Lines 10-12: split the excess at the adjusted base amount
+	aboveBand := decimal.Max(excess.Sub(band), decimal.Zero)
+	withinBand := decimal.Min(excess, band)
+	// Lines 13-14: half the first tier, up to half the benefits
+	firstTier := decimal.Min(benefits.Mul(ssTaxFirstTierShare), withinBand.Mul(ssTaxFirstTierShare))
+	// Lines 15-18: plus 85% of the second tier, up to 85% of the benefits
+	return decimal.Min(firstTier.Add(aboveBand.Mul(ssTaxSecondTierShare)), benefits.Mul(ssTaxSecondTierShare))
 }
 
 // CalculateProvisionalIncome calculates the provisional income for Social Security taxation
@@ -216,33 +174,159 @@ func CalculateSurvivorSSBenefit(deceasedCurrent decimal.Decimal, survivorAge int
 	return deceasedCurrent.Mul(factor)
 }
 
-// CalculateSSBenefitForYear calculates the Social Security benefit for a specific year
-func CalculateSSBenefitForYear(employee *domain.Employee, ssStartAge int, year int, colaRate decimal.Decimal) decimal.Decimal {
-	// Start projection from 2025, not current year
-	projectionStartYear := 2025
+// CalculateSpousalSSBenefit returns the monthly spousal benefit: 50% of the other spouse's PIA
+// (benefit at FRA), reduced when the claimant starts before their own FRA. The reduction is
+// 25/36 of 1% per month for the first 36 months and 5/12 of 1% for each additional month.
+// Delayed retirement credits do not apply to spousal benefits.
+func CalculateSpousalSSBenefit(otherPIA decimal.Decimal, claimantBirthDate time.Time, claimingAge int) decimal.Decimal {
+	if claimingAge < 62 || otherPIA.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+	full := otherPIA.Mul(decimal.NewFromFloat(0.5))
+	fra := dateutil.FullRetirementAge(claimantBirthDate)
+	if claimingAge >= fra {
+		return full
+	}
+
+	monthsEarly := (fra - claimingAge) * 12
+	firstMonths := monthsEarly
+	if firstMonths > 36 {
+		firstMonths = 36
+	}
+	reduction := decimal.NewFromFloat(25.0 / 36.0 / 100.0).Mul(decimal.NewFromInt(int64(firstMonths)))
+	if monthsEarly > 36 {
+		reduction = reduction.Add(decimal.NewFromFloat(5.0 / 12.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsEarly - 36))))
+	}
+	return full.Mul(decimal.NewFromInt(1).Sub(reduction))
+}
 
-	// Use end of year for age calculation to account for people who turn eligible during the year
-	endOfYearDate := time.Date(projectionStartYear+year, 12, 31, 0, 0, 0, 0, time.UTC)
-	age := employee.Age(endOfYearDate)
+// CalculateSpousalSSBenefitForYear calculates the annual spousal benefit a claimant receives in a projection
+// year based on the other spouse's PIA. Spousal benefits begin only once both spouses have filed; the
+// early-claiming reduction uses the claimant's age when that happens. year is a calendar year.
+func CalculateSpousalSSBenefitForYear(claimant, other *domain.Employee, claimantStartAge, otherStartAge int, year int, colaRate decimal.Decimal) decimal.Decimal {
+	return spousalSSBenefitForYear(claimant, other, claimantStartAge, otherStartAge, year, constantRate(colaRate))
+}
 
-	// Check if Social Security has started
-	if age < ssStartAge {
+// spousalSSBenefitForYear is CalculateSpousalSSBenefitForYear with a COLA schedule; each December COLA
+// follows that calendar year's rate
+func spousalSSBenefitForYear(claimant, other *domain.Employee, claimantStartAge, otherStartAge int, year int, cola rateSchedule) decimal.Decimal {
+	endOfYearDate := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
+	claimantAge := claimant.Age(endOfYearDate)
+	if claimantAge < claimantStartAge || other.Age(endOfYearDate) < otherStartAge {
 		return decimal.Zero
 	}
 
-	// Calculate initial benefit at claiming age
-	initialBenefit := CalculateMonthlySSBenefitAtAge(employee.SSBenefitFRA, employee.BirthDate, ssStartAge)
+	// Claimant's age in the year the other spouse files
+	entitlementAge := claimantStartAge
+	if ageAtOtherFiling := other.BirthDate.Year() + otherStartAge - claimant.BirthDate.Year(); ageAtOtherFiling > entitlementAge {
+		entitlementAge = ageAtOtherFiling
+	}
 
-	// Apply COLA for each year after the first year of benefits
-	currentBenefit := initialBenefit
-	yearsSinceStart := age - ssStartAge
+	currentBenefit := CalculateSpousalSSBenefit(other.SSBenefitFRA, claimant.BirthDate, entitlementAge)
+	for y := year - (claimantAge - entitlementAge); y < year; y++ {
+		currentBenefit = ApplySSCOLA(currentBenefit, cola.forYear(y))
+	}
+	return currentBenefit.Mul(decimal.NewFromInt(12))
+}
 
-	// Only apply COLA if this is not the first year of benefits
-	if yearsSinceStart > 0 {
-		for y := 0; y < yearsSinceStart; y++ {
-			currentBenefit = ApplySSCOLA(currentBenefit, colaRate)
-		}
+// SSEntitlementMonth returns the first day of the first month a benefit claimed at claimingAge is payable.
+// SSA counts an age as attained the day before the birthday. Before Full Retirement Age a claimant must be
+// the claiming age throughout the month, so entitlement starts the month after the birthday month unless
+// the birthday falls on the 1st or 2nd; from FRA on it starts in the month the age is attained.
+func SSEntitlementMonth(birthDate time.Time, claimingAge int) time.Time {
+	attained := birthDate.AddDate(claimingAge, 0, -1)
+	month := time.Date(attained.Year(), attained.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if claimingAge < dateutil.FullRetirementAge(birthDate) && attained.Day() != 1 {
+		month = month.AddDate(0, 1, 0)
+	}
+	return month
+}
+
+// SSBenefitMonthsInYear returns how many months of a calendar year a benefit claimed at claimingAge is
+// paid for: none before the entitlement year, the months from the entitlement month on in that year, and
+// all twelve afterwards
+func SSBenefitMonthsInYear(birthDate time.Time, claimingAge int, year int) int {
+	entitlement := SSEntitlementMonth(birthDate, claimingAge)
+	switch {
+	case year < entitlement.Year():
+		return 0
+	case year == entitlement.Year():
+		return 13 - int(entitlement.Month())
+	default:
+		return 12
+	}
+}
+
+// CalculateSSBenefitForYear calculates the annual rate of the Social Security benefit paid in a calendar
+// year: zero before the entitlement year (see SSEntitlementMonth), otherwise twelve times the monthly
+// benefit. The December COLA is paid from January, so the entitlement year is at the initial benefit and
+// each later year carries one more COLA. Callers prorate the entitlement year with SSBenefitMonthsInYear.
+func CalculateSSBenefitForYear(employee *domain.Employee, ssStartAge int, year int, colaRate decimal.Decimal) decimal.Decimal {
+	return ssBenefitForYear(employee, ssStartAge, year, constantRate(colaRate))
+}
+
+// ssBenefitForYear is CalculateSSBenefitForYear with a COLA schedule; each December COLA follows that
+// calendar year's rate
+func ssBenefitForYear(employee *domain.Employee, ssStartAge int, year int, cola rateSchedule) decimal.Decimal {
+	entitlementYear := SSEntitlementMonth(employee.BirthDate, ssStartAge).Year()
+	if year < entitlementYear {
+		return decimal.Zero
+	}
+
+	currentBenefit := CalculateMonthlySSBenefitAtAge(employee.SSBenefitFRA, employee.BirthDate, ssStartAge)
+	for y := entitlementYear; y < year; y++ {
+		currentBenefit = ApplySSCOLA(currentBenefit, cola.forYear(y))
 	}
 
 	return currentBenefit.Mul(decimal.NewFromInt(12)) // Convert to annual
 }
+
+// Default 2025 retirement earnings test exempt amounts
+var (
+	defaultSSEarningsTestExemptAmount        = decimal.NewFromInt(23400)
+	defaultSSEarningsTestFRAYearExemptAmount = decimal.NewFromInt(62160)
+)
+
+// earningsTestExemptAmount returns the configured annual exempt amount for years before the FRA year, or
+// the 2025 default when unset
+func earningsTestExemptAmount(rules domain.SocialSecurityRules) decimal.Decimal {
+	if rules.EarningsTestExemptAmount.IsZero() {
+		return defaultSSEarningsTestExemptAmount
+	}
+	return rules.EarningsTestExemptAmount
+}
+
+// ApplySSEarningsTest withholds annual Social Security benefits for a beneficiary who has not reached Full
+// Retirement Age and still has wage income. Before the FRA year, $1 is withheld for every $2 of wages above
+// the exempt amount; in the FRA year only wages earned before the FRA birthday month count, and $1 is
+// withheld for every $3 above the higher exempt amount. Benefits are never reduced below zero.
+func ApplySSEarningsTest(annualBenefit, wages decimal.Decimal, birthDate time.Time, calendarYear int, rules domain.SocialSecurityRules) decimal.Decimal {
+	if annualBenefit.LessThanOrEqual(decimal.Zero) || wages.LessThanOrEqual(decimal.Zero) {
+		return annualBenefit
+	}
+
+	fraYear := birthDate.Year() + dateutil.FullRetirementAge(birthDate)
+	var exempt, divisor, countedWages decimal.Decimal
+	switch {
+	case calendarYear < fraYear:
+		exempt = earningsTestExemptAmount(rules)
+		divisor = decimal.NewFromInt(2)
+		countedWages = wages
+	case calendarYear == fraYear:
+		exempt = rules.EarningsTestFRAYearExemptAmount
+		if exempt.IsZero() {
+			exempt = defaultSSEarningsTestFRAYearExemptAmount
+		}
+		divisor = decimal.NewFromInt(3)
+		// Wages are assumed to be earned evenly; only months before the FRA birthday month are tested
+		countedWages = wages.Mul(decimal.NewFromInt(int64(birthDate.Month() - 1))).Div(decimal.NewFromInt(12))
+	default:
+		return annualBenefit
+	}
+
+	excess := countedWages.Sub(exempt)
+	if excess.LessThanOrEqual(decimal.Zero) {
+		return annualBenefit
+	}
+	return decimal.Max(annualBenefit.Sub(excess.Div(divisor)), decimal.Zero)
+}
@@ -281,7 +281,7 @@ func TestSocialSecurityTaxation(t *testing.T) {
 			name:                  "Above second threshold: 85% taxation",
 			annualSSBenefit:       decimal.NewFromInt(30000),
 			otherIncome:           decimal.NewFromInt(50000), // Provisional income = 65000
-			expectedTaxableAmount: decimal.NewFromInt(25500), // 85% of benefits
+			expectedTaxableAmount: decimal.NewFromInt(23850), // $6,000 first tier plus 85% of the $21,000 above $44k
 			description:           "Provisional income above $44,000",
 		},
 		{
@@ -410,3 +410,92 @@ func TestInterpolateBenefits(t *testing.T) {
 		})
 	}
 }
+
+// TestSpousalBenefit tests the spousal benefit amount and early-claiming reduction
+func TestSpousalBenefit(t *testing.T) {
+	birthDate := time.Date(1960, 5, 1, 0, 0, 0, 0, time.UTC) // FRA = 67
+	otherPIA := decimal.NewFromInt(3000)
+
+	tests := []struct {
+		name        string
+		claimingAge int
+		expected    decimal.Decimal
+	}{
+		{"at FRA receives 50% of PIA", 67, decimal.NewFromInt(1500)},
+		{"after FRA earns no delayed credits", 70, decimal.NewFromInt(1500)},
+		{"36 months early reduced 25%", 64, decimal.NewFromInt(1125)},
+		{"60 months early reduced 35%", 62, decimal.NewFromInt(975)},
+		{"before 62 not eligible", 61, decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateSpousalSSBenefit(otherPIA, birthDate, tt.claimingAge)
+			assert.True(t, got.Sub(tt.expected).Abs().LessThan(decimal.NewFromFloat(0.01)),
+				"expected %s, got %s", tt.expected.StringFixed(2), got.StringFixed(2))
+		})
+	}
+}
+
+// TestSpousalBenefitInProjection verifies a spouse with near-zero SS receives ~50% of the other's FRA benefit
+func TestSpousalBenefitInProjection(t *testing.T) {
+	ce := NewCalculationEngine()
+	personA := domain.Employee{
+		Name:         "PersonA",
+		BirthDate:    time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:     time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC),
+		SSBenefitFRA: decimal.NewFromInt(3000),
+	}
+	personB := domain.Employee{
+		Name:         "PersonB",
+		BirthDate:    time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:     time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC),
+		SSBenefitFRA: decimal.NewFromInt(10),
+	}
+	retire := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario := domain.Scenario{
+		Name:    "spousal",
+		PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+		PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+	}
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 6, COLAGeneralRate: decimal.Zero}
+
+	proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &assumptions, assumptions.FederalRules)
+	row := proj[2028-testProjectionStartYear] // both are 68 for the full year
+
+	assert.True(t, row.SSBenefitPersonA.Equal(decimal.NewFromInt(36000)), "PersonA own benefit, got %s", row.SSBenefitPersonA)
+	assert.True(t, row.SSBenefitPersonB.Sub(decimal.NewFromInt(18000)).Abs().LessThan(decimal.NewFromFloat(0.01)),
+		"PersonB should receive 50%% of PersonA's FRA benefit, got %s", row.SSBenefitPersonB)
+}
+
+func TestTaxableSocialSecurityWorksheet(t *testing.T) {
+	calculator := NewSSTaxCalculator()
+	tests := []struct {
+		name        string
+		single      bool
+		benefits    int64
+		provisional int64
+		expected    string
+	}{
+		{"MFJ within the first tier", false, 24000, 38000, "3000"},
+		{"MFJ at the adjusted base", false, 24000, 44000, "6000"},
+		{"MFJ just above the adjusted base", false, 24000, 45000, "6850"}, // 6,000 + 85% of 1,000
+		{"MFJ in the second tier", false, 24000, 50000, "11100"},          // 6,000 + 85% of 6,000
+		{"MFJ first tier capped at half the benefits", false, 8000, 40000, "4000"},
+		{"MFJ small benefit in the second tier", false, 8000, 46000, "5700"}, // 4,000 + 85% of 2,000
+		{"MFJ capped at 85% of benefits", false, 8000, 60000, "6800"},
+		{"Single within the first tier", true, 20000, 30000, "2500"},
+		{"Single in the second tier", true, 20000, 35000, "5350"},              // 4,500 + 85% of 1,000
+		{"Single small benefit in the second tier", true, 8000, 36000, "5700"}, // 4,000 + 85% of 2,000
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			benefits, provisional := decimal.NewFromInt(tt.benefits), decimal.NewFromInt(tt.provisional)
+			got := calculator.CalculateTaxableSocialSecurity(benefits, provisional)
+			if tt.single {
+				got = calculator.CalculateTaxableSocialSecuritySingle(benefits, provisional)
+			}
+			assert.True(t, got.Equal(decimal.RequireFromString(tt.expected)), "expected %s, got %s", tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestSpendingCurveShapesNeedBasedWithdrawals(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(30)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	// A Roth balance keeps RMDs from overriding the target
+	personA.TSPBalanceTraditional = decimal.Zero
+	personA.TSPBalanceRoth = decimal.NewFromInt(1000000)
+	target := decimal.NewFromInt(2000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+
+	ce := NewCalculationEngine()
+	flat := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	scenario.PersonA.SpendingCurve = &domain.SpendingCurve{
+		EarlyRate: decimal.NewFromFloat(-0.01),
+		PivotAge:  80,
+		LateRate:  decimal.NewFromFloat(0.02),
+	}
+	smile := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	annual := decimal.NewFromInt(24000)
+	if !smile[0].TSPWithdrawalPersonA.Equal(annual) || !flat[0].TSPWithdrawalPersonA.Equal(annual) {
+		t.Fatalf("first year should withdraw the full target, got %s (smile) and %s (flat)", smile[0].TSPWithdrawalPersonA, flat[0].TSPWithdrawalPersonA)
+	}
+
+	// Age 75 (2038): 13 years of -1%
+	mid := smile[13].TSPWithdrawalPersonA
+	wantMid := annual.Mul(decimal.NewFromFloat(0.99).Pow(decimal.NewFromInt(13)))
+	if !mid.Equal(wantMid) || !mid.LessThan(flat[13].TSPWithdrawalPersonA) {
+		t.Fatalf("expected a lower mid-retirement withdrawal %s (flat %s), got %s", wantMid, flat[13].TSPWithdrawalPersonA, mid)
+	}
+
+	// Age 91 (2054): 18 years of -1% to 80, then 11 years of +2%
+	late := smile[29].TSPWithdrawalPersonA
+	wantLate := annual.Mul(decimal.NewFromFloat(0.99).Pow(decimal.NewFromInt(18))).Mul(decimal.NewFromFloat(1.02).Pow(decimal.NewFromInt(11)))
+	if !late.Equal(wantLate) || !late.GreaterThan(flat[29].TSPWithdrawalPersonA) {
+		t.Fatalf("expected a higher late-life withdrawal %s (flat %s), got %s", wantLate, flat[29].TSPWithdrawalPersonA, late)
+	}
+}
+
+func TestSpendingCurveAppliesOnTopOfInflation(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(15)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.025)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.TSPBalanceTraditional = decimal.Zero
+	personA.TSPBalanceRoth = decimal.NewFromInt(1000000)
+	target := decimal.NewFromInt(2000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+
+	ce := NewCalculationEngine()
+	flat := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	scenario.PersonA.SpendingCurve = &domain.SpendingCurve{EarlyRate: decimal.NewFromFloat(-0.01), PivotAge: 80}
+	smile := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	annual := decimal.NewFromInt(24000)
+	if !flat[13].TSPWithdrawalPersonA.Equal(annual) {
+		t.Fatalf("without a curve the target should stay flat at %s, got %s", annual, flat[13].TSPWithdrawalPersonA)
+	}
+	// 13 years of 2.5% inflation, less 1% a year in real terms
+	want := annual.Mul(decimal.NewFromFloat(1.025).Pow(decimal.NewFromInt(13))).Mul(decimal.NewFromFloat(0.99).Pow(decimal.NewFromInt(13)))
+	if got := smile[13].TSPWithdrawalPersonA; !got.Equal(want) {
+		t.Fatalf("expected the curve to apply to the inflation-indexed target %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,49 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestSpendingShortfallBeforeSocialSecurity(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(10)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.02)
+	scenario.PersonA.SSStartAge = 67
+	scenario.PersonB.SSStartAge = 67
+	scenario.SpendingNeed = &domain.SpendingNeed{AnnualAmount: decimal.NewFromInt(80000)}
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Both retire at 62 in 2025 and claim Social Security at 67 (2030): pensions and TSP alone fall short
+	for _, cf := range summary.Projection {
+		shortfall := cf.SpendingSurplus.IsNegative()
+		if cf.Date.Year() < 2030 && !shortfall {
+			t.Fatalf("expected a shortfall in %d before Social Security: net %s need %s", cf.Date.Year(), cf.NetIncome, cf.SpendingNeed)
+		}
+		if cf.Date.Year() >= 2030 && shortfall {
+			t.Fatalf("expected Social Security to close the gap in %d: net %s need %s", cf.Date.Year(), cf.NetIncome, cf.SpendingNeed)
+		}
+	}
+	if summary.ShortfallYears != 5 {
+		t.Fatalf("expected 5 shortfall years, got %d", summary.ShortfallYears)
+	}
+	if want := decimal.NewFromInt(80000).Mul(decimal.NewFromFloat(1.02)); !summary.Projection[1].SpendingNeed.Equal(want) {
+		t.Fatalf("expected the need to grow with inflation to %s, got %s", want, summary.Projection[1].SpendingNeed)
+	}
+	if summary.TSPLongevity != len(summary.Projection) {
+		t.Fatalf("shortfalls should occur with the TSP intact, longevity %d", summary.TSPLongevity)
+	}
+}
+
+func TestSpendingNeedScheduleOverridesFlatAmount(t *testing.T) {
+	need := &domain.SpendingNeed{AnnualAmount: decimal.NewFromInt(80000), Schedule: map[int]decimal.Decimal{2027: decimal.NewFromInt(120000)}}
+	if !need.ForYear(2027).Equal(decimal.NewFromInt(120000)) || !need.ForYear(2028).Equal(decimal.NewFromInt(80000)) {
+		t.Fatalf("expected the schedule to override only its listed years")
+	}
+}
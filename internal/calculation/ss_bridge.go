@@ -0,0 +1,133 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// SSBridgeStrategy is the tsp_withdrawal_strategy that draws the TSP harder until Social Security starts
+const SSBridgeStrategy = "ss_bridge"
+
+// SSBridgeWithdrawal follows the 4% rule (at TSPWithdrawalRate when set) and adds the expected Social
+// Security benefit on top until the benefit starts, so the TSP bridges the years before Social Security and
+// withdrawals step down by about the benefit once it is paid. RMDs still set a floor.
+//
+// BridgeAmount is not raised with inflation. The projection pays the benefit at the claiming age as given,
+// with COLAs only from the entitlement year on, so the flat amount matches the benefit that replaces it.
+type SSBridgeWithdrawal struct {
+	*FourPercentRule
+	BridgeAmount   decimal.Decimal // Annual Social Security benefit at the claiming age, as first paid
+	SSStartDate    time.Time       // First day of the entitlement month
+	RetirementYear int             // Calendar year of withdrawal year 1
+}
+
+// NewSSBridgeWithdrawal creates a new SSBridgeWithdrawal strategy
+func NewSSBridgeWithdrawal(initialBalance, initialRate, inflationRate, bridgeAmount decimal.Decimal, ssStartDate time.Time, retirementYear int) *SSBridgeWithdrawal {
+	return &SSBridgeWithdrawal{
+		FourPercentRule: NewInitialRateWithdrawal(initialBalance, initialRate, inflationRate),
+		BridgeAmount:    bridgeAmount,
+		SSStartDate:     ssStartDate,
+		RetirementYear:  retirementYear,
+	}
+}
+
+// BridgeForYear returns the amount added to a withdrawal year: the whole benefit before Social Security
+// starts and, in the year it starts, the share of the year before SSStartDate
+func (sbw *SSBridgeWithdrawal) BridgeForYear(year int) decimal.Decimal {
+	calendarYear := sbw.RetirementYear + year - 1
+	switch {
+	case calendarYear < sbw.SSStartDate.Year():
+		return sbw.BridgeAmount
+	case calendarYear == sbw.SSStartDate.Year():
+		return sbw.BridgeAmount.Mul(fractionOfYearBefore(sbw.SSStartDate))
+	default:
+		return decimal.Zero
+	}
+}
+
+// CalculateWithdrawal calculates the withdrawal amount for a given year
+func (sbw *SSBridgeWithdrawal) CalculateWithdrawal(currentBalance decimal.Decimal, year int, targetIncome decimal.Decimal, age int, isRMDYear bool, rmdAmount decimal.Decimal) decimal.Decimal {
+	withdrawal := sbw.FourPercentRule.CalculateWithdrawal(currentBalance, year, targetIncome, age, false, decimal.Zero).Add(sbw.BridgeForYear(year))
+	if isRMDYear && withdrawal.LessThan(rmdAmount) {
+		withdrawal = rmdAmount
+	}
+	return decimal.Min(withdrawal, currentBalance)
+}
+
+// GetStrategyName returns the name of this strategy
+func (sbw *SSBridgeWithdrawal) GetStrategyName() string {
+	return SSBridgeStrategy
+}
+
+// newSSBridgeWithdrawal sets up the bridge for an employee's own Social Security benefit at the scenario's
+// claiming age
+func newSSBridgeWithdrawal(employee *domain.Employee, scenario *domain.RetirementScenario, initialBalance, inflationRate decimal.Decimal) *SSBridgeWithdrawal {
+	rate := decimal.NewFromFloat(0.04)
+	if scenario.TSPWithdrawalRate != nil {
+		rate = *scenario.TSPWithdrawalRate
+	}
+	benefit := CalculateMonthlySSBenefitAtAge(employee.SSBenefitFRA, employee.BirthDate, scenario.SSStartAge).Mul(decimal.NewFromInt(12))
+	return NewSSBridgeWithdrawal(initialBalance, rate, inflationRate, benefit, SSEntitlementMonth(employee.BirthDate, scenario.SSStartAge), scenario.RetirementDate.Year())
+}
+
+// SSBridgeYear is one year of net income under the bridge beside the plain 4% rule, in today's dollars
+type SSBridgeYear struct {
+	Year              int             `json:"year"`
+	FourPercentIncome decimal.Decimal `json:"four_percent_income"`
+	BridgeIncome      decimal.Decimal `json:"bridge_income"`
+}
+
+// SSBridgeComparison compares a scenario's real net income under ss_bridge withdrawals with the same
+// scenario under the 4% rule. MaxChange is the largest year-over-year change in real net income between
+// years in which both persons are retired, so a smoother line has a smaller value.
+type SSBridgeComparison struct {
+	Scenario             string          `json:"scenario"`
+	Years                []SSBridgeYear  `json:"years"`
+	FourPercentMaxChange decimal.Decimal `json:"four_percent_max_change"`
+	BridgeMaxChange      decimal.Decimal `json:"bridge_max_change"`
+}
+
+// CompareSSBridge runs a scenario with both persons on ss_bridge withdrawals and again on the 4% rule, keeping
+// any tsp_withdrawal_rate, and returns the two real net income lines
+func (ce *CalculationEngine) CompareSSBridge(config *domain.Configuration, scenario *domain.Scenario) (*SSBridgeComparison, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	run := func(strategy string) (*domain.ScenarioSummary, error) {
+		trialScenario := *scenario
+		trialScenario.PersonA.TSPWithdrawalStrategy = strategy
+		trialScenario.PersonB.TSPWithdrawalStrategy = strategy
+		summary, err := ce.RunScenario(context.Background(), config, &trialScenario)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strategy, err)
+		}
+		return summary, nil
+	}
+	fourPercent, err := run(initialRateStrategy)
+	if err != nil {
+		return nil, err
+	}
+	bridge, err := run(SSBridgeStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &SSBridgeComparison{Scenario: scenario.Name}
+	for i := range bridge.Projection {
+		if i >= len(fourPercent.Projection) {
+			break
+		}
+		f, b := fourPercent.Projection[i], bridge.Projection[i]
+		comparison.Years = append(comparison.Years, SSBridgeYear{Year: b.Date.Year(), FourPercentIncome: f.RealNetIncome, BridgeIncome: b.RealNetIncome})
+		if i == 0 || !bridge.Projection[i-1].IsRetired {
+			continue
+		}
+		comparison.FourPercentMaxChange = decimal.Max(comparison.FourPercentMaxChange, f.RealNetIncome.Sub(fourPercent.Projection[i-1].RealNetIncome).Abs())
+		comparison.BridgeMaxChange = decimal.Max(comparison.BridgeMaxChange, b.RealNetIncome.Sub(bridge.Projection[i-1].RealNetIncome).Abs())
+	}
+	return comparison, nil
+}
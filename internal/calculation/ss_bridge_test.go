@@ -0,0 +1,74 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSSBridgeLevelsNetIncomeAcrossSSStart(t *testing.T) {
+	// PersonA claims at 67, entitled from December 2029 since a January 1st birthday attains 67 on
+	// December 31st; PersonB's benefit is already paid from 62
+	cfg, scenario := retiredCoupleTestConfig(8)
+	personA := cfg.PersonalDetails["person_a"]
+	personA.TSPBalanceTraditional = decimal.NewFromInt(600000)
+	cfg.PersonalDetails["person_a"] = personA
+	scenario.PersonA.SSStartAge = 67
+
+	comparison, err := NewCalculationEngine().CompareSSBridge(cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var before, after SSBridgeYear
+	for _, y := range comparison.Years {
+		switch y.Year {
+		case 2029:
+			before = y
+		case 2030:
+			after = y
+		}
+	}
+	fourPercentStep := after.FourPercentIncome.Sub(before.FourPercentIncome).Abs()
+	bridgeStep := after.BridgeIncome.Sub(before.BridgeIncome).Abs()
+	if fourPercentStep.LessThan(decimal.NewFromInt(25000)) {
+		t.Fatalf("expected the 4%% rule to jump when Social Security starts, got %s", fourPercentStep)
+	}
+	if bridgeStep.GreaterThan(fourPercentStep.Div(decimal.NewFromInt(10))) {
+		t.Fatalf("expected the bridge to level net income across 2030: step %s vs %s under the 4%% rule", bridgeStep, fourPercentStep)
+	}
+	if !comparison.BridgeMaxChange.LessThan(comparison.FourPercentMaxChange) {
+		t.Fatalf("expected a smoother bridge line: %s vs %s", comparison.BridgeMaxChange, comparison.FourPercentMaxChange)
+	}
+
+	// Once Social Security is paid both strategies withdraw the same amount
+	if !after.BridgeIncome.Equal(after.FourPercentIncome) {
+		t.Fatalf("expected the bridge to end in 2030, got %s vs %s", after.BridgeIncome, after.FourPercentIncome)
+	}
+}
+
+func TestSSBridgeProratesTheClaimingYear(t *testing.T) {
+	strategy := NewSSBridgeWithdrawal(decimal.NewFromInt(500000), decimal.NewFromFloat(0.04), decimal.Zero, decimal.NewFromInt(36500),
+		time.Date(2027, 7, 2, 0, 0, 0, 0, time.UTC), 2025)
+	expected := []string{"56500", "56500", "38200", "20000"} // 182 of 365 days bridged in 2027
+	for i, want := range expected {
+		got := strategy.CalculateWithdrawal(decimal.NewFromInt(500000), i+1, decimal.Zero, 60, false, decimal.Zero)
+		if !got.Round(2).Equal(decimal.RequireFromString(want)) {
+			t.Fatalf("year %d: expected %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestSSBridgeAmountMatchesFirstYearBenefit(t *testing.T) {
+	// The bridge is not COLA indexed because the benefit it stands in for is only indexed once paid
+	cfg, scenario := retiredCoupleTestConfig(8)
+	personA := cfg.PersonalDetails["person_a"]
+	scenario.PersonA.SSStartAge = 67
+	cola := constantRate(decimal.NewFromFloat(0.03))
+
+	strategy := newSSBridgeWithdrawal(&personA, &scenario.PersonA, decimal.NewFromInt(500000), decimal.NewFromFloat(0.03))
+	entitlementYear := SSEntitlementMonth(personA.BirthDate, scenario.PersonA.SSStartAge).Year()
+	if first := ssBenefitForYear(&personA, scenario.PersonA.SSStartAge, entitlementYear, cola); !strategy.BridgeAmount.Equal(first) {
+		t.Fatalf("bridge amount = %s, want the first year's annual benefit %s", strategy.BridgeAmount, first)
+	}
+}
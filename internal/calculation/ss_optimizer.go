@@ -0,0 +1,116 @@
+package calculation
+
+import (
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// SSClaimingObjective selects the metric used to rank Social Security claiming ages
+type SSClaimingObjective string
+
+const (
+	// SSObjectiveLifetimeNetIncome maximizes undiscounted cumulative net income over the projection
+	SSObjectiveLifetimeNetIncome SSClaimingObjective = "lifetime_net_income"
+	// SSObjectiveNetPresentValue maximizes net income discounted at the supplied rate
+	SSObjectiveNetPresentValue SSClaimingObjective = "npv"
+)
+
+const (
+	minSSClaimingAge = 62
+	maxSSClaimingAge = 70
+)
+
+// SSClaimingOption is the projected outcome for one pair of claiming ages
+type SSClaimingOption struct {
+	PersonAStartAge     int               `json:"person_a_start_age"`
+	PersonBStartAge     int               `json:"person_b_start_age"`
+	LifetimeNetIncome   decimal.Decimal   `json:"lifetime_net_income"`
+	NetPresentValue     decimal.Decimal   `json:"net_present_value"`
+	LifetimeSSBenefits  decimal.Decimal   `json:"lifetime_ss_benefits"`
+	CumulativeNetIncome []decimal.Decimal `json:"cumulative_net_income"` // Running total by projection year, for break-even crossover
+}
+
+// SSClaimingResult contains every evaluated claiming-age combination and the best one
+type SSClaimingResult struct {
+	Objective    SSClaimingObjective `json:"objective"`
+	DiscountRate decimal.Decimal     `json:"discount_rate"`
+	Best         SSClaimingOption    `json:"best"`
+	Options      []SSClaimingOption  `json:"options"`
+}
+
+// OptimizeSSClaimingAge sweeps SS start ages 62-70 for both people, runs the full projection for each
+// combination, and returns the combination that maximizes the chosen objective. All other scenario
+// settings are held constant. discountRate is only used for the NPV objective.
+func (ce *CalculationEngine) OptimizeSSClaimingAge(config *domain.Configuration, scenario *domain.Scenario, objective SSClaimingObjective, discountRate decimal.Decimal) (*SSClaimingResult, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	if objective == "" {
+		objective = SSObjectiveLifetimeNetIncome
+	}
+	if objective != SSObjectiveLifetimeNetIncome && objective != SSObjectiveNetPresentValue {
+		return nil, fmt.Errorf("unsupported SS claiming objective: %s", objective)
+	}
+
+	personA, okA := config.PersonalDetails["person_a"]
+	personB, okB := config.PersonalDetails["person_b"]
+	if !okA || !okB {
+		return nil, fmt.Errorf("configuration must include person_a and person_b")
+	}
+
+	result := &SSClaimingResult{Objective: objective, DiscountRate: discountRate}
+	bestScore := decimal.Zero
+	for ageA := minSSClaimingAge; ageA <= maxSSClaimingAge; ageA++ {
+		for ageB := minSSClaimingAge; ageB <= maxSSClaimingAge; ageB++ {
+			trial := *scenario
+			trial.PersonA.SSStartAge = ageA
+			trial.PersonB.SSStartAge = ageB
+
+			a, b := personA, personB
+			projection := ce.GenerateAnnualProjection(&a, &b, &trial, &config.GlobalAssumptions, config.GlobalAssumptions.FederalRules)
+			option := summarizeSSClaimingOption(ageA, ageB, projection, discountRate)
+			result.Options = append(result.Options, option)
+
+			score := option.LifetimeNetIncome
+			if objective == SSObjectiveNetPresentValue {
+				score = option.NetPresentValue
+			}
+			if len(result.Options) == 1 || score.GreaterThan(bestScore) {
+				bestScore = score
+				result.Best = option
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// summarizeSSClaimingOption totals a projection for one claiming-age combination
+func summarizeSSClaimingOption(ageA, ageB int, projection []domain.AnnualCashFlow, discountRate decimal.Decimal) SSClaimingOption {
+	option := SSClaimingOption{
+		PersonAStartAge:     ageA,
+		PersonBStartAge:     ageB,
+		CumulativeNetIncome: make([]decimal.Decimal, len(projection)),
+	}
+	one := decimal.NewFromInt(1)
+	for i, year := range projection {
+		option.LifetimeNetIncome = option.LifetimeNetIncome.Add(year.NetIncome)
+		option.LifetimeSSBenefits = option.LifetimeSSBenefits.Add(year.SSBenefitPersonA).Add(year.SSBenefitPersonB)
+		discountFactor := one.Add(discountRate).Pow(decimal.NewFromInt(int64(i)))
+		option.NetPresentValue = option.NetPresentValue.Add(year.NetIncome.Div(discountFactor))
+		option.CumulativeNetIncome[i] = option.LifetimeNetIncome
+	}
+	return option
+}
+
+// Option returns the evaluated option for a specific pair of claiming ages
+func (r *SSClaimingResult) Option(personAStartAge, personBStartAge int) (SSClaimingOption, bool) {
+	for _, o := range r.Options {
+		if o.PersonAStartAge == personAStartAge && o.PersonBStartAge == personBStartAge {
+			return o, true
+		}
+	}
+	return SSClaimingOption{}, false
+}
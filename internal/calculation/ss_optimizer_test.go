@@ -0,0 +1,64 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestOptimizeSSClaimingAge_SweepsAllCombinations(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(12)
+	ce := NewCalculationEngine()
+
+	result, err := ce.OptimizeSSClaimingAge(cfg, scenario, SSObjectiveLifetimeNetIncome, decimal.Zero)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Options) != 81 {
+		t.Fatalf("expected 81 claiming combinations, got %d", len(result.Options))
+	}
+	for _, o := range result.Options {
+		if o.LifetimeNetIncome.GreaterThan(result.Best.LifetimeNetIncome) {
+			t.Fatalf("option %d/%d beats reported best", o.PersonAStartAge, o.PersonBStartAge)
+		}
+		if len(o.CumulativeNetIncome) != 12 {
+			t.Fatalf("expected cumulative series for each projection year")
+		}
+	}
+	if scenario.PersonA.SSStartAge != 62 || scenario.PersonB.SSStartAge != 62 {
+		t.Fatalf("input scenario should not be modified")
+	}
+}
+
+func TestOptimizeSSClaimingAge_HorizonDrivesCrossover(t *testing.T) {
+	ce := NewCalculationEngine()
+
+	shortCfg, scenario := retiredCoupleTestConfig(10)
+	short, err := ce.OptimizeSSClaimingAge(shortCfg, scenario, SSObjectiveLifetimeNetIncome, decimal.Zero)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	early, _ := short.Option(62, 62)
+	late, _ := short.Option(70, 70)
+	if !early.LifetimeSSBenefits.GreaterThan(late.LifetimeSSBenefits) {
+		t.Fatalf("with a short horizon early claiming should collect more SS: early=%s late=%s", early.LifetimeSSBenefits, late.LifetimeSSBenefits)
+	}
+
+	longCfg, scenario := retiredCoupleTestConfig(35)
+	long, err := ce.OptimizeSSClaimingAge(longCfg, scenario, SSObjectiveLifetimeNetIncome, decimal.Zero)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	early, _ = long.Option(62, 62)
+	late, _ = long.Option(70, 70)
+	if !late.LifetimeSSBenefits.GreaterThan(early.LifetimeSSBenefits) {
+		t.Fatalf("with a long horizon late claiming should collect more SS: early=%s late=%s", early.LifetimeSSBenefits, late.LifetimeSSBenefits)
+	}
+}
+
+func TestOptimizeSSClaimingAge_RejectsUnknownObjective(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(5)
+	if _, err := NewCalculationEngine().OptimizeSSClaimingAge(cfg, scenario, "bogus", decimal.Zero); err == nil {
+		t.Fatalf("expected error for unknown objective")
+	}
+}
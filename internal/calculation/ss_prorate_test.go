@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
-	"github.com/rpgo/retirement-calculator/pkg/dateutil"
 	"github.com/shopspring/decimal"
 )
 
@@ -40,16 +39,10 @@ func TestSSProrate_FirstYearBirthdayMidYear(t *testing.T) {
 		t.Fatalf("expected projection rows")
 	}
 
-	// Year 0 is 2025; PersonA turns 62 on July 1, so SS should be prorated (less than full annual benefit)
+	// Year 0 is 2025; PersonA turns 62 on July 1, so SS is paid for the six months from July
 	row := proj[0]
-	full := CalculateSSBenefitForYear(&personA, rs.SSStartAge, 0, decimal.Zero)
-	// Compute expected prorated fraction: days after birthday / days in year
-	yearEnd := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
-	birthdayThisYear := time.Date(2025, personA.BirthDate.Month(), personA.BirthDate.Day(), 0, 0, 0, 0, time.UTC)
-	daysAfter := yearEnd.Sub(birthdayThisYear).Hours() / 24.0
-	daysInYear := float64(dateutil.DaysInYear(2025))
-	frac := daysAfter / daysInYear
-	expected := full.Mul(decimal.NewFromFloat(frac))
+	full := CalculateSSBenefitForYear(&personA, rs.SSStartAge, testProjectionStartYear, decimal.Zero)
+	expected := full.Mul(decimal.NewFromInt(6)).Div(decimal.NewFromInt(12))
 
 	// Allow small rounding tolerance
 	diff := row.SSBenefitPersonA.Sub(expected).Abs()
@@ -57,3 +50,46 @@ func TestSSProrate_FirstYearBirthdayMidYear(t *testing.T) {
 		t.Fatalf("prorated SS mismatch; expected %s, got %s (diff %s)", expected.StringFixed(2), row.SSBenefitPersonA.StringFixed(2), diff.StringFixed(2))
 	}
 }
+
+// A claim entitled in October pays three months in the claiming year, and the first COLA shows up the
+// following January
+func TestSSProrate_OctoberClaim(t *testing.T) {
+	// Turns 62 on September 15, 2025, so is 62 throughout October; retires in March before then
+	personA := domain.Employee{
+		BirthDate:    time.Date(1963, 9, 15, 0, 0, 0, 0, time.UTC),
+		HireDate:     time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		SSBenefitFRA: decimal.NewFromInt(2000),
+	}
+	personB := domain.Employee{
+		BirthDate: time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1992, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	rs := domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"}
+	ds := domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"}
+	scenario := domain.Scenario{Name: "october-claim", PersonA: rs, PersonB: ds}
+	assumptions := domain.GlobalAssumptions{ProjectionYears: 3, COLAGeneralRate: decimal.NewFromFloat(0.02)}
+
+	if got := SSEntitlementMonth(personA.BirthDate, 62); !got.Equal(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected October 2025 entitlement, got %s", got.Format("2006-01"))
+	}
+	if months := SSBenefitMonthsInYear(personA.BirthDate, 62, 2025); months != 3 {
+		t.Fatalf("expected 3 months of benefits in 2025, got %d", months)
+	}
+
+	proj := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, &scenario, &assumptions, domain.FederalRules{})
+	monthly := CalculateMonthlySSBenefitAtAge(personA.SSBenefitFRA, personA.BirthDate, 62)
+	if want := monthly.Mul(decimal.NewFromInt(3)); !proj[0].SSBenefitPersonA.Round(6).Equal(want.Round(6)) {
+		t.Fatalf("2025: expected three months at the initial benefit (%s), got %s", want, proj[0].SSBenefitPersonA)
+	}
+	if want := monthly.Mul(decimal.NewFromFloat(1.02)).Mul(decimal.NewFromInt(12)); !proj[1].SSBenefitPersonA.Round(6).Equal(want.Round(6)) {
+		t.Fatalf("2026: expected twelve months with one COLA (%s), got %s", want, proj[1].SSBenefitPersonA)
+	}
+	if want := monthly.Mul(decimal.NewFromFloat(1.02).Pow(decimal.NewFromInt(2))).Mul(decimal.NewFromInt(12)); !proj[2].SSBenefitPersonA.Round(6).Equal(want.Round(6)) {
+		t.Fatalf("2027: expected two COLAs (%s), got %s", want, proj[2].SSBenefitPersonA)
+	}
+
+	// Birthdays on the 1st or 2nd attain the age in the birthday month
+	if got := SSEntitlementMonth(time.Date(1963, 10, 2, 0, 0, 0, 0, time.UTC), 62); got.Month() != time.October {
+		t.Fatalf("expected a birthday on October 2nd to be entitled in October, got %s", got.Format("2006-01"))
+	}
+}
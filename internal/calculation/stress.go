@@ -0,0 +1,159 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// stressMarket is one year of a stress preset: fund returns and the inflation (and COLA) rate
+type stressMarket struct {
+	C, S, I, F, G float64
+	Inflation     float64
+}
+
+// StressTestPreset is a named, prescribed adverse market sequence. Shocks lists the stressed years in
+// order, starting Offset years after the first retirement; every other year gets the scenario's
+// baseline return and inflation.
+type StressTestPreset struct {
+	Name        string
+	Description string
+	Offset      int
+	Shocks      []stressMarket
+}
+
+// repeatMarket returns market repeated for years years
+func repeatMarket(market stressMarket, years int) []stressMarket {
+	shocks := make([]stressMarket, years)
+	for i := range shocks {
+		shocks[i] = market
+	}
+	return shocks
+}
+
+// crash2008 approximates the 2008 TSP fund returns
+var crash2008 = stressMarket{C: -0.37, S: -0.38, I: -0.42, F: 0.055, G: 0.0375, Inflation: 0.001}
+
+// StressTestPresets is the library of prescribed adverse sequences run by RunStressTests
+var StressTestPresets = []StressTestPreset{
+	{
+		Name:        "crash_early",
+		Description: "2008-style crash in the first year of retirement",
+		Shocks:      []stressMarket{crash2008},
+	},
+	{
+		Name:        "crash_late",
+		Description: "2008-style crash ten years into retirement",
+		Offset:      10,
+		Shocks:      []stressMarket{crash2008},
+	},
+	{
+		Name:        "stagflation",
+		Description: "A decade of 7% inflation with flat stocks and falling bond prices, starting at retirement",
+		Shocks:      repeatMarket(stressMarket{C: 0.02, S: 0.02, I: 0.01, F: -0.01, G: 0.05, Inflation: 0.07}, 10),
+	},
+	{
+		Name:        "lost_decade",
+		Description: "A decade of slightly negative stock returns (2000-2009), starting at retirement",
+		Shocks:      repeatMarket(stressMarket{C: -0.01, S: 0.03, I: 0.01, F: 0.06, G: 0.045, Inflation: 0.025}, 10),
+	},
+}
+
+// StressTestResult is a scenario's outcome under one stress preset
+type StressTestResult struct {
+	Preset           string          `json:"preset"`
+	Description      string          `json:"description"`
+	TSPLongevity     int             `json:"tsp_longevity"`
+	Depleted         bool            `json:"depleted"` // TSP ran out within the projection
+	FinalTSPBalance  decimal.Decimal `json:"final_tsp_balance"`
+	MinNetIncome     decimal.Decimal `json:"min_net_income"`
+	MinRealNetIncome decimal.Decimal `json:"min_real_net_income"`
+}
+
+// RunStressTests runs the scenario through each of StressTestPresets. Shocks are timed from the first
+// year either person is retired. Portfolio returns in stressed years use the Monte Carlo default TSP
+// allocation, and each year's inflation and COLA follow the preset.
+func (ce *CalculationEngine) RunStressTests(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) ([]StressTestResult, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	// The scenario's overrides size the projection and set the unstressed rates
+	config, scenario = scenarioAssumptions(config, scenario)
+	years := config.GlobalAssumptions.ProjectionYears
+	if years < 1 {
+		return nil, fmt.Errorf("stress tests need a projection of at least one year")
+	}
+
+	results := make([]StressTestResult, 0, len(StressTestPresets))
+	for _, preset := range StressTestPresets {
+		series, portfolioReturns := stressSequence(preset, config, scenario)
+		summary, err := ce.runMarketSequence(ctx, config, scenario, series, portfolioReturns)
+		if err != nil {
+			return nil, fmt.Errorf("stress test %s: %w", preset.Name, err)
+		}
+		result := StressTestResult{
+			Preset:          preset.Name,
+			Description:     preset.Description,
+			TSPLongevity:    summary.TSPLongevity,
+			FinalTSPBalance: summary.FinalTSPBalance,
+		}
+		for i, cf := range summary.Projection {
+			result.Depleted = result.Depleted || cf.IsTSPDepleted()
+			if i == 0 || cf.NetIncome.LessThan(result.MinNetIncome) {
+				result.MinNetIncome = cf.NetIncome
+			}
+			if i == 0 || cf.RealNetIncome.LessThan(result.MinRealNetIncome) {
+				result.MinRealNetIncome = cf.RealNetIncome
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// stressSequence builds a preset's market series and portfolio returns over the projection. Unstressed
+// years earn the scenario's pre- or post-retirement return in every fund.
+func stressSequence(preset StressTestPreset, config *domain.Configuration, scenario *domain.Scenario) (MarketConditionSeries, []decimal.Decimal) {
+	assumptions := config.GlobalAssumptions
+	years := assumptions.ProjectionYears
+	retirementYear := scenario.PersonA.RetirementDate.Year()
+	if scenario.PersonB.RetirementDate.Year() < retirementYear {
+		retirementYear = scenario.PersonB.RetirementDate.Year()
+	}
+	startYear := ProjectionStartYear(&assumptions)
+	retirementIndex := retirementYear - startYear
+	if retirementIndex < 0 {
+		retirementIndex = 0
+	}
+	shockStart := retirementIndex + preset.Offset
+
+	series := MarketConditionSeries{Years: make([]MarketCondition, years)}
+	portfolioReturns := make([]decimal.Decimal, years)
+	for i := range series.Years {
+		market := MarketCondition{Year: startYear + i, InflationRate: assumptions.InflationRate, COLARate: assumptions.COLAGeneralRate}
+		if shock := i - shockStart; shock >= 0 && shock < len(preset.Shocks) {
+			s := preset.Shocks[shock]
+			market.TSPReturns = map[string]decimal.Decimal{
+				"C": decimal.NewFromFloat(s.C),
+				"S": decimal.NewFromFloat(s.S),
+				"I": decimal.NewFromFloat(s.I),
+				"F": decimal.NewFromFloat(s.F),
+				"G": decimal.NewFromFloat(s.G),
+			}
+			market.InflationRate = decimal.NewFromFloat(s.Inflation)
+			market.COLARate = market.InflationRate
+			portfolioReturns[i] = defaultAllocationReturn(market, config)
+		} else {
+			baseline := assumptions.TSPReturnPostRetirement
+			if i < retirementIndex {
+				baseline = assumptions.TSPReturnPreRetirement
+			}
+			market.TSPReturns = map[string]decimal.Decimal{"C": baseline, "S": baseline, "I": baseline, "F": baseline, "G": baseline}
+			portfolioReturns[i] = baseline
+		}
+		series.Years[i] = market
+	}
+	return series, portfolioReturns
+}
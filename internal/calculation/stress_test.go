@@ -0,0 +1,97 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRunStressTestsCrashEarlyAndStagflation(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.05)
+	cfg.GlobalAssumptions.COLAGeneralRate = decimal.NewFromFloat(0.02)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.02)
+	ce := NewCalculationEngine()
+
+	baseline, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("baseline: %v", err)
+	}
+	results, err := ce.RunStressTests(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("stress tests: %v", err)
+	}
+	byName := make(map[string]StressTestResult)
+	for _, r := range results {
+		byName[r.Preset] = r
+	}
+	if len(byName) != len(StressTestPresets) {
+		t.Fatalf("expected a result for each of %d presets, got %d", len(StressTestPresets), len(results))
+	}
+
+	// A crash in the first retirement year leaves less in the TSP than steady baseline returns
+	crash := byName["crash_early"]
+	if !crash.FinalTSPBalance.LessThan(baseline.FinalTSPBalance) {
+		t.Fatalf("crash_early: expected final TSP below baseline %s, got %s", baseline.FinalTSPBalance, crash.FinalTSPBalance)
+	}
+	if crashLate := byName["crash_late"]; !crash.FinalTSPBalance.LessThan(crashLate.FinalTSPBalance) {
+		t.Fatalf("expected an early crash to hurt more than a late one: %s vs %s", crash.FinalTSPBalance, crashLate.FinalTSPBalance)
+	}
+
+	// Stagflation erodes real income
+	stagflation := byName["stagflation"]
+	baselineMinReal := baseline.Projection[0].RealNetIncome
+	for _, cf := range baseline.Projection {
+		baselineMinReal = decimal.Min(baselineMinReal, cf.RealNetIncome)
+	}
+	if !stagflation.MinRealNetIncome.LessThan(baselineMinReal) {
+		t.Fatalf("stagflation: expected minimum real net income below baseline %s, got %s", baselineMinReal, stagflation.MinRealNetIncome)
+	}
+}
+
+func TestRunStressTestsFollowsYearlyInflation(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	cfg.GlobalAssumptions.COLAGeneralRate = decimal.NewFromFloat(0.02)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.02)
+	preset := StressTestPresets[0]
+	preset.Offset = 1 // Shock the second projection year only
+
+	series, portfolioReturns := stressSequence(preset, cfg, scenario)
+	summary, err := NewCalculationEngine().runMarketSequence(context.Background(), cfg, scenario, series, portfolioReturns)
+	if err != nil {
+		t.Fatalf("runMarketSequence: %v", err)
+	}
+
+	// Social Security paid in a year carries the COLA set by the previous year's inflation
+	shocked := decimal.NewFromFloat(1 + crash2008.Inflation)
+	baseline := decimal.NewFromFloat(1.02)
+	ss := func(i int) decimal.Decimal { return summary.Projection[i].SSBenefitPersonA }
+	if got := ss(2).Div(ss(1)).Round(6); !got.Equal(shocked.Round(6)) {
+		t.Fatalf("expected the shocked year's %s COLA in the next year's benefit, got a %s increase", shocked, got)
+	}
+	if got := ss(3).Div(ss(2)).Round(6); !got.Equal(baseline.Round(6)) {
+		t.Fatalf("expected the baseline COLA once the shock has passed, got a %s increase", got)
+	}
+
+	// Real dollars deflate by each year's own rate
+	cf := summary.Projection[3]
+	wantReal := cf.NetIncome.Div(baseline.Mul(shocked).Mul(baseline))
+	if !cf.RealNetIncome.Round(2).Equal(wantReal.Round(2)) {
+		t.Fatalf("expected real net income %s from the year-by-year inflation path, got %s", wantReal.Round(2), cf.RealNetIncome.Round(2))
+	}
+}
+
+func TestRunStressTestsAnnuitizedTSPIsNotDepleted(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(20)
+	scenario.PersonA.TSPWithdrawalStrategy = TSPAnnuityStrategy
+	results, err := NewCalculationEngine().RunStressTests(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("stress tests: %v", err)
+	}
+	for _, r := range results {
+		if r.Depleted {
+			t.Fatalf("%s: expected an annuitized TSP not to count as depleted", r.Preset)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
+
+// RunScenarioSummaryOnly computes a scenario's summary metrics as the projection streams, without keeping
+// its year-by-year cash flows, for batch runs over many scenario variations that only need the summary.
+// The summary has no Projection; every other metric matches RunScenario exactly.
+func (ce *CalculationEngine) RunScenarioSummaryOnly(ctx context.Context, config *domain.Configuration, scenario *domain.Scenario) (*domain.ScenarioSummary, error) {
+	config, err := scenarioConfiguration(ctx, config, scenario)
+	if err != nil {
+		return nil, err
+	}
+	personA := config.PersonalDetails["person_a"]
+	personB := config.PersonalDetails["person_b"]
+
+	accumulator := ce.newScenarioSummaryAccumulator(config, scenario)
+	err = ce.streamAnnualProjection(ctx, &personA, &personB, scenario, &config.GlobalAssumptions, config.GlobalAssumptions.FederalRules, accumulator.add)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
+	}
+	return accumulator.finish(), nil
+}
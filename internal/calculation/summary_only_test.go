@@ -0,0 +1,100 @@
+package calculation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/config"
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestRunScenarioSummaryOnlyMatchesRunScenario(t *testing.T) {
+	ce := NewCalculationEngine()
+	for _, tc := range goldenProjectionCases {
+		cfg, err := config.NewInputParser().LoadFromFile(tc.configPath)
+		if err != nil {
+			t.Fatalf("%s: load config: %v", tc.name, err)
+		}
+		for i := range cfg.Scenarios {
+			assertSummaryOnlyMatches(t, ce, cfg, &cfg.Scenarios[i])
+		}
+	}
+
+	// Plan-to-age horizon, blackout years and deterministic success need the whole projection
+	cfg, scenario := retiredCoupleTestConfig(30)
+	target := decimal.NewFromInt(2000)
+	scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+	scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+	scenario.PersonA.SSStartAge = 70
+	scenario.PersonB.SSStartAge = 70
+	personA := cfg.PersonalDetails["person_a"]
+	personA.PlanToAge = 85
+	cfg.PersonalDetails["person_a"] = personA
+	full := assertSummaryOnlyMatches(t, ce, cfg, scenario)
+	if full.BlackoutYears == 0 || full.PlanHorizonYears >= 30 || full.SuccessRate.IsZero() {
+		t.Fatalf("expected blackout years, a plan-to-age horizon and a success rate, got %d, %d, %s", full.BlackoutYears, full.PlanHorizonYears, full.SuccessRate)
+	}
+}
+
+// assertSummaryOnlyMatches checks that every ScenarioSummary field except Projection is the same from
+// RunScenario and RunScenarioSummaryOnly, so a new summary field fails here until both paths fill it
+func assertSummaryOnlyMatches(t *testing.T, ce *CalculationEngine, cfg *domain.Configuration, scenario *domain.Scenario) *domain.ScenarioSummary {
+	t.Helper()
+	full, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", scenario.Name, err)
+	}
+	streamed, err := ce.RunScenarioSummaryOnly(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", scenario.Name, err)
+	}
+	if streamed.Projection != nil {
+		t.Fatalf("%s: expected no projection to be kept", scenario.Name)
+	}
+
+	fullValue, streamedValue := reflect.ValueOf(*full), reflect.ValueOf(*streamed)
+	for i := 0; i < fullValue.NumField(); i++ {
+		name := fullValue.Type().Field(i).Name
+		if name == "Projection" {
+			continue
+		}
+		want, got := fullValue.Field(i).Interface(), streamedValue.Field(i).Interface()
+		if d, ok := want.(decimal.Decimal); ok {
+			if !d.Equal(got.(decimal.Decimal)) {
+				t.Fatalf("%s: %s differs: full %s, streamed %s", scenario.Name, name, d, got)
+			}
+		} else if !reflect.DeepEqual(want, got) {
+			t.Fatalf("%s: %s differs: full %v, streamed %v", scenario.Name, name, want, got)
+		}
+	}
+	return full
+}
+
+func benchmarkScenario(b *testing.B, run func(*CalculationEngine, *domain.Configuration, *domain.Scenario) error) {
+	cfg, scenario := retiredCoupleTestConfig(40)
+	ce := NewCalculationEngine()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := run(ce, cfg, scenario); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+// Compare with: go test -bench 'RunScenario(Full|SummaryOnly)' -benchmem
+func BenchmarkRunScenarioFull(b *testing.B) {
+	benchmarkScenario(b, func(ce *CalculationEngine, cfg *domain.Configuration, scenario *domain.Scenario) error {
+		_, err := ce.RunScenario(context.Background(), cfg, scenario)
+		return err
+	})
+}
+
+func BenchmarkRunScenarioSummaryOnly(b *testing.B) {
+	benchmarkScenario(b, func(ce *CalculationEngine, cfg *domain.Configuration, scenario *domain.Scenario) error {
+		_, err := ce.RunScenarioSummaryOnly(context.Background(), cfg, scenario)
+		return err
+	})
+}
@@ -0,0 +1,111 @@
+package calculation
+
+import (
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// survivorElectionOptions are the FERS survivor annuity elections compared by AnalyzeSurvivorElection
+var survivorElectionOptions = []decimal.Decimal{decimal.Zero, decimal.NewFromFloat(0.25), decimal.NewFromFloat(0.5)}
+
+// SurvivorElectionOption is the projected outcome of one survivor annuity election
+type SurvivorElectionOption struct {
+	ElectionPercent     decimal.Decimal `json:"election_percent"`
+	AnnuityCost         decimal.Decimal `json:"annuity_cost"`          // Pension given up while the retiree is alive, versus no election
+	SurvivorAnnuity     decimal.Decimal `json:"survivor_annuity"`      // Total survivor annuity paid after the retiree's death
+	BothAliveNetIncome  decimal.Decimal `json:"both_alive_net_income"` // Household net income while both spouses are alive
+	SurvivorNetIncome   decimal.Decimal `json:"survivor_net_income"`   // Net income in the years after the retiree's death
+	LifetimeNetIncome   decimal.Decimal `json:"lifetime_net_income"`   // Net income over the whole projection
+	SurvivorYears       int             `json:"survivor_years"`        // Projection years after the retiree's death
+	FirstSurvivorIncome decimal.Decimal `json:"first_survivor_income"` // Survivor's net income in the first full year after the death
+}
+
+// SurvivorElectionAnalysis compares survivor annuity elections for one retiree assumed to die at DeathAge
+type SurvivorElectionAnalysis struct {
+	Employee string                   `json:"employee"`
+	DeathAge int                      `json:"death_age"`
+	Options  []SurvivorElectionOption `json:"options"`
+}
+
+// AnalyzeSurvivorElection runs the scenario under each FERS survivor election (0%, 25%, 50%) for employee
+// ("person_a" or "person_b"), with that person dying at deathAge, and reports the cost of each election
+// (the reduced annuity while alive) against its benefit (the survivor's income after the death).
+// Other mortality assumptions on the scenario are kept.
+func (ce *CalculationEngine) AnalyzeSurvivorElection(config *domain.Configuration, scenario *domain.Scenario, employee string, deathAge int) (*SurvivorElectionAnalysis, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+	if employee != "person_a" && employee != "person_b" {
+		return nil, fmt.Errorf("%w, got %q", ErrUnknownEmployee, employee)
+	}
+	personA, okA := config.PersonalDetails["person_a"]
+	personB, okB := config.PersonalDetails["person_b"]
+	if !okA || !okB {
+		return nil, fmt.Errorf("configuration must include person_a and person_b")
+	}
+
+	trial := *scenario
+	mortality := domain.ScenarioMortality{}
+	if scenario.Mortality != nil {
+		mortality.Assumptions = scenario.Mortality.Assumptions
+	}
+	age := deathAge
+	if employee == "person_a" {
+		mortality.PersonA = &domain.MortalitySpec{DeathAge: &age}
+	} else {
+		mortality.PersonB = &domain.MortalitySpec{DeathAge: &age}
+	}
+	trial.Mortality = &mortality
+
+	analysis := &SurvivorElectionAnalysis{Employee: employee, DeathAge: deathAge}
+	var baselineLivingPension decimal.Decimal
+	for i, election := range survivorElectionOptions {
+		a, b := personA, personB
+		if employee == "person_a" {
+			a.SurvivorBenefitElectionPercent = election
+		} else {
+			b.SurvivorBenefitElectionPercent = election
+		}
+		projection := ce.GenerateAnnualProjection(&a, &b, &trial, &config.GlobalAssumptions, config.GlobalAssumptions.FederalRules)
+
+		option := SurvivorElectionOption{ElectionPercent: election}
+		var livingPension decimal.Decimal
+		for _, year := range projection {
+			option.LifetimeNetIncome = option.LifetimeNetIncome.Add(year.NetIncome)
+			deceased, pension, survivorAnnuity := year.PersonADeceased, year.PensionPersonA, year.SurvivorPensionPersonB
+			if employee == "person_b" {
+				deceased, pension, survivorAnnuity = year.PersonBDeceased, year.PensionPersonB, year.SurvivorPensionPersonA
+			}
+			if !deceased {
+				option.BothAliveNetIncome = option.BothAliveNetIncome.Add(year.NetIncome)
+				livingPension = livingPension.Add(pension)
+				continue
+			}
+			option.SurvivorNetIncome = option.SurvivorNetIncome.Add(year.NetIncome)
+			option.SurvivorAnnuity = option.SurvivorAnnuity.Add(survivorAnnuity)
+			option.SurvivorYears++
+			if option.SurvivorYears == 2 {
+				option.FirstSurvivorIncome = year.NetIncome
+			}
+		}
+		if i == 0 {
+			baselineLivingPension = livingPension
+		}
+		option.AnnuityCost = baselineLivingPension.Sub(livingPension)
+		analysis.Options = append(analysis.Options, option)
+	}
+
+	return analysis, nil
+}
+
+// Option returns the evaluated option for an election percent (0, 0.25 or 0.5)
+func (a *SurvivorElectionAnalysis) Option(electionPercent decimal.Decimal) (SurvivorElectionOption, bool) {
+	for _, o := range a.Options {
+		if o.ElectionPercent.Equal(electionPercent) {
+			return o, true
+		}
+	}
+	return SurvivorElectionOption{}, false
+}
@@ -44,7 +44,7 @@ func TestSurvivorPensionFlow(t *testing.T) {
 	projection := ce.GenerateAnnualProjection(personA, personB, scenario, assumptions, federal)
 
 	// Find death index
-	deathIdx := deathYear - ProjectionBaseYear
+	deathIdx := deathYear - testProjectionStartYear
 	if deathIdx < 0 || deathIdx >= len(projection) {
 		t.Fatalf("death index out of range")
 	}
@@ -75,7 +75,7 @@ func TestSurvivorPensionFlow(t *testing.T) {
 				t.Errorf("expected survivor pension for PersonB year %d", cf.Date.Year())
 			}
 			// Survivor pension should approximate elected share of unreduced base with COLA (allow small tolerance)
-			yearsSinceRet := y - (retDate.Year() - ProjectionBaseYear)
+			yearsSinceRet := y - (retDate.Year() - testProjectionStartYear)
 			if yearsSinceRet < 0 {
 				yearsSinceRet = 0
 			}
@@ -94,3 +94,32 @@ func TestSurvivorPensionFlow(t *testing.T) {
 		}
 	}
 }
+
+func TestAnalyzeSurvivorElection(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(25)
+	ce := NewCalculationEngine()
+
+	analysis, err := ce.AnalyzeSurvivorElection(cfg, scenario, "person_a", 75)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analysis.Options) != 3 {
+		t.Fatalf("expected 0%%, 25%% and 50%% options, got %d", len(analysis.Options))
+	}
+	none, _ := analysis.Option(decimal.Zero)
+	half, _ := analysis.Option(decimal.NewFromFloat(0.5))
+
+	if !half.BothAliveNetIncome.LessThan(none.BothAliveNetIncome) || !half.AnnuityCost.GreaterThan(decimal.Zero) {
+		t.Fatalf("expected the 50%% election to cost income while both are alive: none=%s half=%s cost=%s", none.BothAliveNetIncome, half.BothAliveNetIncome, half.AnnuityCost)
+	}
+	if !half.SurvivorNetIncome.GreaterThan(none.SurvivorNetIncome) || !none.SurvivorAnnuity.IsZero() || !half.SurvivorAnnuity.GreaterThan(decimal.Zero) {
+		t.Fatalf("expected the 50%% election to raise survivor income: none=%s half=%s annuity=%s", none.SurvivorNetIncome, half.SurvivorNetIncome, half.SurvivorAnnuity)
+	}
+	if half.SurvivorYears != 12 {
+		t.Fatalf("expected survivor years 2038-2049 after death at 75, got %d", half.SurvivorYears)
+	}
+
+	if _, err := ce.AnalyzeSurvivorElection(cfg, scenario, "person_c", 75); err == nil {
+		t.Fatalf("expected an error for an unknown employee")
+	}
+}
@@ -0,0 +1,35 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMarginalBracketAndEffectiveTaxRate(t *testing.T) {
+	// $1.5M drawn at 4% on top of the pensions leaves about $135k of taxable income, squarely in the 22% bracket
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA := cfg.PersonalDetails["person_a"]
+	personA.TSPBalanceTraditional = decimal.NewFromInt(1500000)
+	cfg.PersonalDetails["person_a"] = personA
+
+	summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cf := range summary.Projection {
+		if !cf.MarginalBracket.Equal(decimal.NewFromFloat(0.22)) {
+			t.Fatalf("%d: expected a 22%% marginal bracket, got %s", cf.Date.Year(), cf.MarginalBracket)
+		}
+		if want := cf.FederalTax.Div(cf.TotalGrossIncome); !cf.EffectiveTaxRate.Equal(want) {
+			t.Fatalf("%d: expected an effective rate of %s, got %s", cf.Date.Year(), want, cf.EffectiveTaxRate)
+		}
+		if !cf.EffectiveTaxRate.IsPositive() || !cf.EffectiveTaxRate.LessThan(cf.MarginalBracket) {
+			t.Fatalf("%d: the effective rate %s should be positive and below the marginal bracket", cf.Date.Year(), cf.EffectiveTaxRate)
+		}
+		if cf.EffectiveTotalTaxRate.LessThan(cf.EffectiveTaxRate) {
+			t.Fatalf("%d: the total effective rate should include federal tax", cf.Date.Year())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTaxSmartWithdrawal_SourceWithdrawal(t *testing.T) {
+	strategy := NewTaxSmartWithdrawal(decimal.NewFromInt(1000000), decimal.Zero, decimal.NewFromInt(96950))
+	taxContext := WithdrawalTaxContext{OtherTaxableIncome: decimal.NewFromInt(100000), StandardDeduction: decimal.NewFromInt(30000)}
+
+	tests := []struct {
+		name                       string
+		withdrawal, trad, roth     decimal.Decimal
+		rmd                        decimal.Decimal
+		expectedTrad, expectedRoth decimal.Decimal
+	}{
+		// headroom = 96950 + 30000 - 100000 = 26950
+		{"fills bracket then Roth", decimal.NewFromInt(40000), decimal.NewFromInt(500000), decimal.NewFromInt(500000), decimal.Zero, decimal.NewFromInt(26950), decimal.NewFromInt(13050)},
+		{"RMD above headroom", decimal.NewFromInt(40000), decimal.NewFromInt(500000), decimal.NewFromInt(500000), decimal.NewFromInt(35000), decimal.NewFromInt(35000), decimal.NewFromInt(5000)},
+		{"Roth exhausted", decimal.NewFromInt(40000), decimal.NewFromInt(500000), decimal.NewFromInt(3050), decimal.Zero, decimal.NewFromInt(36950), decimal.NewFromInt(3050)},
+	}
+	for _, tt := range tests {
+		trad, roth := strategy.SourceWithdrawal(tt.withdrawal, tt.trad, tt.roth, tt.rmd, taxContext)
+		if !trad.Equal(tt.expectedTrad) || !roth.Equal(tt.expectedRoth) {
+			t.Fatalf("%s: expected %s/%s, got %s/%s", tt.name, tt.expectedTrad, tt.expectedRoth, trad, roth)
+		}
+	}
+}
+
+func TestTaxSmartWithdrawal_LowersLifetimeFederalTax(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(25)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.TSPBalanceTraditional, personA.TSPBalanceRoth = decimal.NewFromInt(500000), decimal.NewFromInt(400000)
+	personB.TSPBalanceTraditional, personB.TSPBalanceRoth = decimal.NewFromInt(500000), decimal.NewFromInt(400000)
+	ce := NewCalculationEngine()
+
+	lifetimeFederalTax := func(strategy string) decimal.Decimal {
+		s := *scenario
+		s.PersonA.TSPWithdrawalStrategy = strategy
+		s.PersonB.TSPWithdrawalStrategy = strategy
+		a, b := personA, personB
+		total := decimal.Zero
+		for _, year := range ce.GenerateAnnualProjection(&a, &b, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules) {
+			total = total.Add(year.FederalTax)
+		}
+		return total
+	}
+
+	fourPercent := lifetimeFederalTax("4_percent_rule")
+	taxSmart := lifetimeFederalTax("tax_smart")
+	if !taxSmart.LessThan(fourPercent) {
+		t.Fatalf("expected lower lifetime federal tax with tax_smart: tax_smart=%s 4_percent_rule=%s", taxSmart, fourPercent)
+	}
+}
+
+// Test that a withdrawal drawn from Roth is not taxed: under the 4% rule the remainder above the RMD comes
+// from Roth first, so a first-year draw the Roth balance covers adds nothing to federal taxable income
+func TestFourPercentRuleTaxesOnlyTraditionalPortion(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(1)
+	personB := cfg.PersonalDetails["person_b"]
+	firstYear := func(traditional, roth int64) domain.AnnualCashFlow {
+		personA := cfg.PersonalDetails["person_a"]
+		personA.TSPBalanceTraditional, personA.TSPBalanceRoth = decimal.NewFromInt(traditional), decimal.NewFromInt(roth)
+		b := personB
+		return NewCalculationEngine().GenerateAnnualProjection(&personA, &b, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)[0]
+	}
+
+	mixed := firstYear(300000, 400000)
+	noTSP := firstYear(0, 0)
+	if !mixed.TSPWithdrawalPersonA.IsPositive() || mixed.TSPWithdrawalPersonA.GreaterThan(decimal.NewFromInt(400000)) {
+		t.Fatalf("expected a positive withdrawal the Roth balance covers, got %s", mixed.TSPWithdrawalPersonA)
+	}
+	if !mixed.FederalTaxableIncome.Equal(noTSP.FederalTaxableIncome) {
+		t.Fatalf("expected the Roth-sourced withdrawal to be untaxed: taxable income %s, %s without a TSP",
+			mixed.FederalTaxableIncome.StringFixed(2), noTSP.FederalTaxableIncome.StringFixed(2))
+	}
+}
@@ -0,0 +1,20 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// taxTorpedoProbe is the extra traditional withdrawal a year's effective marginal rate is measured with
+var taxTorpedoProbe = decimal.NewFromInt(100)
+
+// markTaxTorpedo sets a retired year's effective marginal rate from its federal tax and federal taxable
+// income recomputed with taxTorpedoProbe more traditional withdrawal. The year is in the tax torpedo when
+// that withdrawal also makes more Social Security taxable (so taxable income rises by more than the probe)
+// and the rate comes out above the year's bracket: between the provisional income thresholds each extra
+// dollar adds up to 85 cents of taxable benefits, taxing it at up to 1.85 times the bracket rate.
+func markTaxTorpedo(cf *domain.AnnualCashFlow, probedFederalTax, probedTaxableIncome decimal.Decimal) {
+	cf.EffectiveMarginalRate = probedFederalTax.Sub(cf.FederalTax).Div(taxTorpedoProbe)
+	extraTaxableSS := probedTaxableIncome.Sub(cf.FederalTaxableIncome).Sub(taxTorpedoProbe)
+	cf.TaxTorpedo = extraTaxableSS.GreaterThanOrEqual(decimal.NewFromFloat(0.01)) && cf.EffectiveMarginalRate.GreaterThan(cf.MarginalBracket)
+}
@@ -0,0 +1,46 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTaxTorpedoFlagsYearsMakingSocialSecurityTaxable(t *testing.T) {
+	// Small pensions put provisional income just above the $44,000 joint threshold, where each extra
+	// withdrawal dollar also makes 85 cents of Social Security taxable in the 10% bracket
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.High3Salary = decimal.NewFromInt(30000)
+	personB.High3Salary = decimal.NewFromInt(20000)
+	cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"] = personA, personB
+
+	ce := NewCalculationEngine()
+	summary, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := summary.Projection[0]
+	if !first.TaxTorpedo {
+		t.Fatalf("expected the first year to be in the tax torpedo: marginal bracket %s, effective marginal rate %s", first.MarginalBracket, first.EffectiveMarginalRate)
+	}
+	if !first.MarginalBracket.Equal(decimal.NewFromFloat(0.10)) || !first.EffectiveMarginalRate.Round(4).Equal(decimal.NewFromFloat(0.185)) {
+		t.Fatalf("expected a 10%% bracket taxed at 18.5%%, got %s and %s", first.MarginalBracket, first.EffectiveMarginalRate)
+	}
+	if summary.TaxTorpedoYears != len(summary.Projection) {
+		t.Fatalf("expected every year in the torpedo, got %d of %d", summary.TaxTorpedoYears, len(summary.Projection))
+	}
+
+	// With the full pensions 85% of the benefits is already taxable, so the next dollar is taxed at the bracket rate
+	cfg, scenario = retiredCoupleTestConfig(3)
+	summary, err = ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cf := range summary.Projection {
+		if cf.TaxTorpedo || !cf.EffectiveMarginalRate.Round(4).Equal(cf.MarginalBracket) {
+			t.Fatalf("%d: expected no torpedo and an effective marginal rate at the %s bracket, got %s", cf.Date.Year(), cf.MarginalBracket, cf.EffectiveMarginalRate)
+		}
+	}
+}
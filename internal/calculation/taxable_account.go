@@ -0,0 +1,61 @@
+package calculation
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// taxableAccount tracks a brokerage account's balance and cost basis through the projection
+type taxableAccount struct {
+	Balance       decimal.Decimal
+	CostBasis     decimal.Decimal
+	Return        decimal.Decimal // Total return, dividends included
+	DividendYield decimal.Decimal
+}
+
+// newTaxableAccount opens an employee's brokerage account, using defaultReturn when the employee sets no
+// return of their own. A missing cost basis is treated as no unrealized gain.
+func newTaxableAccount(employee *domain.Employee, defaultReturn decimal.Decimal) *taxableAccount {
+	account := &taxableAccount{
+		Balance:       employee.TaxableAccountBalance,
+		CostBasis:     employee.TaxableAccountCostBasis,
+		Return:        defaultReturn,
+		DividendYield: employee.TaxableAccountDividendYield,
+	}
+	if employee.TaxableAccountReturn != nil {
+		account.Return = *employee.TaxableAccountReturn
+	}
+	if account.CostBasis.IsZero() || account.CostBasis.GreaterThan(account.Balance) {
+		account.CostBasis = account.Balance
+	}
+	return account
+}
+
+// Withdraw sells up to amount from the account and returns the proceeds and the gain they realize. Basis
+// is averaged across the account, so each dollar sold carries the account's share of unrealized gain.
+func (ta *taxableAccount) Withdraw(amount decimal.Decimal) (proceeds, gain decimal.Decimal) {
+	proceeds = decimal.Min(decimal.Max(amount, decimal.Zero), ta.Balance)
+	if proceeds.IsZero() {
+		return decimal.Zero, decimal.Zero
+	}
+	basisSold := ta.CostBasis.Mul(proceeds).Div(ta.Balance)
+	ta.Balance = ta.Balance.Sub(proceeds)
+	ta.CostBasis = ta.CostBasis.Sub(basisSold)
+	return proceeds, decimal.Max(proceeds.Sub(basisSold), decimal.Zero)
+}
+
+// Grow applies a year's total return and returns the dividends paid, which are taxable in the year and
+// reinvested, adding to the cost basis
+func (ta *taxableAccount) Grow(returnRate decimal.Decimal) decimal.Decimal {
+	dividends := ta.Balance.Mul(ta.DividendYield)
+	ta.Balance = ta.Balance.Mul(decimal.NewFromInt(1).Add(returnRate))
+	ta.CostBasis = ta.CostBasis.Add(dividends)
+	return dividends
+}
+
+// Inherit moves a deceased spouse's account into this one with the basis stepped up to market value
+func (ta *taxableAccount) Inherit(decedent *taxableAccount) {
+	ta.Balance = ta.Balance.Add(decedent.Balance)
+	ta.CostBasis = ta.CostBasis.Add(decedent.Balance)
+	decedent.Balance, decedent.CostBasis = decimal.Zero, decimal.Zero
+}
@@ -0,0 +1,66 @@
+package calculation
+
+import (
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestTaxableAccountDividendsTaxedYearlyAndGainsOnWithdrawal(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(4)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	scenario.PersonA.SSStartAge, scenario.PersonB.SSStartAge = 70, 70 // keep SS taxation out of the comparison
+	ce := NewCalculationEngine()
+
+	accountReturn := decimal.NewFromFloat(0.05)
+	run := func(withAccount bool, strategy string) []domain.AnnualCashFlow {
+		a, b := personA, personB
+		if withAccount {
+			a.TaxableAccountBalance = decimal.NewFromInt(200000)
+			a.TaxableAccountCostBasis = decimal.NewFromInt(100000)
+			a.TaxableAccountDividendYield = decimal.NewFromFloat(0.02)
+			a.TaxableAccountReturn = &accountReturn
+		}
+		s := *scenario
+		s.PersonA.TSPWithdrawalStrategy = strategy
+		if strategy == "need_based" {
+			target := decimal.NewFromInt(12000)
+			s.PersonA.TSPWithdrawalTargetMonthly = &target
+		}
+		return ce.GenerateAnnualProjection(&a, &b, &s, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	}
+
+	// Without sales, only the dividends are taxable, every year
+	baseline, holding := run(false, "4_percent_rule"), run(true, "4_percent_rule")
+	balance := decimal.NewFromInt(200000)
+	for i := range holding {
+		dividends := balance.Mul(decimal.NewFromFloat(0.02))
+		if !holding[i].TaxableAccountWithdrawal.IsZero() {
+			t.Fatalf("year %d: 4%% rule should not sell from the brokerage account", i)
+		}
+		if !holding[i].CapitalGains.Equal(dividends) {
+			t.Fatalf("year %d: expected dividends %s as the only gains, got %s", i, dividends, holding[i].CapitalGains)
+		}
+		if added := holding[i].FederalTaxableIncome.Sub(baseline[i].FederalTaxableIncome); !added.Equal(dividends) {
+			t.Fatalf("year %d: expected dividends %s added to taxable income, got %s", i, dividends, added)
+		}
+		balance = balance.Mul(decimal.NewFromFloat(1.05))
+	}
+
+	// Need-based spending sells from the account first, realizing half of each sale as gain
+	tspOnly, selling := run(false, "need_based"), run(true, "need_based")
+	sold := selling[0].TaxableAccountWithdrawal
+	if !sold.IsPositive() {
+		t.Fatalf("expected need-based spending to draw from the brokerage account")
+	}
+	if !tspOnly[0].TSPWithdrawalPersonA.Sub(selling[0].TSPWithdrawalPersonA).Equal(sold) {
+		t.Fatalf("expected the TSP withdrawal to drop by the sale: tsp-only=%s with-account=%s sold=%s",
+			tspOnly[0].TSPWithdrawalPersonA, selling[0].TSPWithdrawalPersonA, sold)
+	}
+	dividends := decimal.NewFromInt(200000).Sub(sold).Mul(decimal.NewFromFloat(0.02))
+	realized := selling[0].CapitalGains.Sub(dividends)
+	if realized.Sub(sold.Div(decimal.NewFromInt(2))).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("expected half of the %s sale realized as gain, got %s", sold, realized)
+	}
+}
@@ -1,6 +1,7 @@
 package calculation
 
 import (
+	"strings"
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
@@ -23,6 +24,13 @@ import (
 //    - Base premium: $185/month per person (2025 estimate)
 //    - IRMAA surcharge: $200/month placeholder (needs AGI-based calculation)
 //
+// 5. Net Investment Income Tax: 3.8% on the lesser of investment income and MAGI over $250,000 (MFJ)
+//    or $200,000 (single). Thresholds are fixed by statute and not indexed.
+//
+// 6. Long-Term Capital Gains: Dividends and realized gains are stacked on top of ordinary taxable income
+//    and taxed at 0/15/20% using 2025 thresholds (no inflation indexing). All dividends are treated as
+//    qualified and all gains as long-term.
+//
 // TODO: Consider adding inflation indexing for long-term projections
 
 // TaxBracket represents a federal tax bracket
@@ -125,9 +133,11 @@ func (ftc *FederalTaxCalculator) CalculateFederalTax(grossIncome decimal.Decimal
 	return totalTax
 }
 
-// PennsylvaniaTaxCalculator handles Pennsylvania state tax calculations
+// PennsylvaniaTaxCalculator handles Pennsylvania state tax calculations. With RetirementIncome rules it
+// also taxes retirement income at the same flat rate, for states that do.
 type PennsylvaniaTaxCalculator struct {
-	Rate decimal.Decimal
+	Rate             decimal.Decimal
+	RetirementIncome *domain.StateRetirementIncomeRules
 }
 
 // NewPennsylvaniaTaxCalculator creates a new Pennsylvania tax calculator
@@ -140,7 +150,8 @@ func NewPennsylvaniaTaxCalculator() *PennsylvaniaTaxCalculator {
 // NewPennsylvaniaTaxCalculatorWithConfig creates a new Pennsylvania tax calculator with configurable rate
 func NewPennsylvaniaTaxCalculatorWithConfig(config domain.StateLocalTaxConfig) *PennsylvaniaTaxCalculator {
 	return &PennsylvaniaTaxCalculator{
-		Rate: config.PennsylvaniaRate,
+		Rate:             config.PennsylvaniaRate,
+		RetirementIncome: config.RetirementIncome,
 	}
 }
 
@@ -152,51 +163,130 @@ func (ptc *PennsylvaniaTaxCalculator) CalculateTax(income domain.TaxableIncome,
 	if isRetired {
 		// PA exempts retirement income: pensions, TSP, Social Security
 		// Only tax earned income (wages) and interest income
-		taxablePA := income.WageIncome.Add(income.InterestIncome).Add(income.OtherTaxableIncome)
+		taxablePA := income.WageIncome.Add(income.InterestIncome).Add(income.CapitalGains).Add(income.OtherTaxableIncome)
 		return taxablePA.Mul(ptc.Rate)
 	}
 
-	// While working: tax wages at configured rate
-	return income.WageIncome.Mul(ptc.Rate)
+	// While working: tax wages and interest at configured rate
+	return income.WageIncome.Add(income.InterestIncome).Mul(ptc.Rate)
 }
 
-// UpperMakefieldEITCalculator handles Upper Makefield Township local tax calculations
-type UpperMakefieldEITCalculator struct {
-	Rate decimal.Decimal
+// CalculateTaxForTaxpayers is CalculateTax plus the tax on retirement income under the configured rules.
+// taxpayers are the living household members, each with their own retirement income; see
+// stateTaxableRetirementIncome for how the age-based exclusions apply.
+func (ptc *PennsylvaniaTaxCalculator) CalculateTaxForTaxpayers(income domain.TaxableIncome, isRetired bool, taxpayers ...StateRetirementTaxpayer) decimal.Decimal {
+	tax := ptc.CalculateTax(income, isRetired)
+	rules := ptc.RetirementIncome
+	if rules == nil {
+		return tax
+	}
+
+	taxable := stateTaxableRetirementIncome(*rules, taxpayers)
+	if rules.TaxSocialSecurity {
+		taxable = taxable.Add(income.TaxableSSBenefits)
+	}
+	return tax.Add(taxable.Mul(ptc.Rate))
+}
+
+// StateRetirementTaxpayer is one living household member's age and their own pension (including any
+// survivor annuity they receive) and traditional TSP income, for state retirement income exclusions
+type StateRetirementTaxpayer struct {
+	Age              int
+	RetirementIncome decimal.Decimal
+}
+
+// stateTaxableRetirementIncome returns the pension and TSP income a state taxes under rules. Each
+// taxpayer at or above the minimum age excludes up to ExclusionPerPerson of their own retirement income;
+// an exclusion one spouse cannot use does not shelter the other's income.
+func stateTaxableRetirementIncome(rules domain.StateRetirementIncomeRules, taxpayers []StateRetirementTaxpayer) decimal.Decimal {
+	taxable := decimal.Zero
+	for _, taxpayer := range taxpayers {
+		income := taxpayer.RetirementIncome
+		if taxpayer.Age >= rules.ExclusionMinAge {
+			income = decimal.Max(income.Sub(rules.ExclusionPerPerson), decimal.Zero)
+		}
+		taxable = taxable.Add(income)
+	}
+	return taxable
+}
+
+// UpperMakefieldTownship is the municipality upper_makefield_eit_rate applies to
+const UpperMakefieldTownship = "Upper Makefield Township"
+
+// LocalTaxCalculator handles a municipality's local income tax, such as a Pennsylvania Earned Income Tax
+type LocalTaxCalculator struct {
+	Municipality          string
+	Rate                  decimal.Decimal
+	TaxesRetirementIncome bool // Pensions and traditional TSP withdrawals are taxed as well as earned income
+}
+
+// NewLocalTaxCalculator creates a local tax calculator for a municipality's rule
+func NewLocalTaxCalculator(municipality string, rule domain.LocalTaxRule) *LocalTaxCalculator {
+	return &LocalTaxCalculator{
+		Municipality:          municipality,
+		Rate:                  rule.Rate,
+		TaxesRetirementIncome: rule.TaxesRetirementIncome,
+	}
 }
 
 // NewUpperMakefieldEITCalculator creates a new Upper Makefield EIT calculator
-func NewUpperMakefieldEITCalculator() *UpperMakefieldEITCalculator {
-	return &UpperMakefieldEITCalculator{
-		Rate: decimal.NewFromFloat(0.01), // Default rate
+func NewUpperMakefieldEITCalculator() *LocalTaxCalculator {
+	return NewLocalTaxCalculator(UpperMakefieldTownship, domain.LocalTaxRule{Rate: decimal.NewFromFloat(0.01)})
+}
+
+// localTaxRules returns the configured local tax rules by municipality, adding Upper Makefield Township at
+// upper_makefield_eit_rate unless local_taxes lists it
+func localTaxRules(config domain.StateLocalTaxConfig) map[string]domain.LocalTaxRule {
+	rules := make(map[string]domain.LocalTaxRule, len(config.LocalTaxes)+1)
+	for municipality, rule := range config.LocalTaxes {
+		rules[municipality] = rule
+	}
+	if _, ok := lookupLocalTaxRule(rules, UpperMakefieldTownship); !ok && !config.UpperMakefieldEITRate.IsZero() {
+		rules[UpperMakefieldTownship] = domain.LocalTaxRule{Rate: config.UpperMakefieldEITRate}
 	}
+	return rules
 }
 
-// NewUpperMakefieldEITCalculatorWithConfig creates a new Upper Makefield EIT calculator with configurable rate
-func NewUpperMakefieldEITCalculatorWithConfig(config domain.StateLocalTaxConfig) *UpperMakefieldEITCalculator {
-	return &UpperMakefieldEITCalculator{
-		Rate: config.UpperMakefieldEITRate,
+// lookupLocalTaxRule finds a municipality's rule, ignoring case and surrounding spaces
+func lookupLocalTaxRule(rules map[string]domain.LocalTaxRule, municipality string) (domain.LocalTaxRule, bool) {
+	municipality = strings.TrimSpace(municipality)
+	for name, rule := range rules {
+		if strings.EqualFold(strings.TrimSpace(name), municipality) {
+			return rule, true
+		}
 	}
+	return domain.LocalTaxRule{}, false
 }
 
-// CalculateEIT calculates the Earned Income Tax for Upper Makefield Township
+// CalculateEIT calculates the Earned Income Tax on wages
 // EIT only applies to earned income, not retirement income
-func (ume *UpperMakefieldEITCalculator) CalculateEIT(wageIncome decimal.Decimal, isRetired bool) decimal.Decimal {
+func (ltc *LocalTaxCalculator) CalculateEIT(wageIncome decimal.Decimal, isRetired bool) decimal.Decimal {
 	if isRetired {
 		return decimal.Zero // EIT only applies to earned income
 	}
 
-	return wageIncome.Mul(ume.Rate)
+	return wageIncome.Mul(ltc.Rate)
+}
+
+// CalculateTax calculates the local tax on a year's earned income, plus its pensions and traditional TSP
+// withdrawals in municipalities that tax retirement income
+func (ltc *LocalTaxCalculator) CalculateTax(wageIncome, retirementIncome decimal.Decimal) decimal.Decimal {
+	taxable := wageIncome
+	if ltc.TaxesRetirementIncome {
+		taxable = taxable.Add(retirementIncome)
+	}
+	return taxable.Mul(ltc.Rate)
 }
 
 // FICACalculator handles FICA tax calculations
 type FICACalculator struct {
-	Year                int
-	SSWageBase          decimal.Decimal
-	SSRate              decimal.Decimal
-	MedicareRate        decimal.Decimal
-	AdditionalRate      decimal.Decimal
-	HighIncomeThreshold decimal.Decimal
+	Year                 int // Tax year SSWageBase applies to
+	SSWageBase           decimal.Decimal
+	WageBaseIndexingRate decimal.Decimal // Annual growth of the wage base after Year
+	SSRate               decimal.Decimal
+	MedicareRate         decimal.Decimal
+	AdditionalRate       decimal.Decimal
+	HighIncomeThreshold  decimal.Decimal
 }
 
 // NewFICACalculator2025 creates a new FICA calculator for 2025
@@ -211,22 +301,43 @@ func NewFICACalculator2025() *FICACalculator {
 	}
 }
 
-// NewFICACalculator creates a new FICA calculator with configurable values
+// NewFICACalculator creates a new FICA calculator with configurable values. The wage base is for
+// config.Year, or 2025 when unset.
 func NewFICACalculator(config domain.FICATaxConfig) *FICACalculator {
+	year := config.Year
+	if year == 0 {
+		year = 2025
+	}
 	return &FICACalculator{
-		Year:                2025, // TODO: Make year configurable
-		SSWageBase:          config.SocialSecurityWageBase,
-		SSRate:              config.SocialSecurityRate,
-		MedicareRate:        config.MedicareRate,
-		AdditionalRate:      config.AdditionalMedicareRate,
-		HighIncomeThreshold: config.HighIncomeThresholdMFJ,
+		Year:                 year,
+		SSWageBase:           config.SocialSecurityWageBase,
+		WageBaseIndexingRate: config.WageBaseIndexingRate,
+		SSRate:               config.SocialSecurityRate,
+		MedicareRate:         config.MedicareRate,
+		AdditionalRate:       config.AdditionalMedicareRate,
+		HighIncomeThreshold:  config.HighIncomeThresholdMFJ,
+	}
+}
+
+// WageBaseForYear returns the Social Security wage base for a tax year: SSWageBase indexed by
+// WageBaseIndexingRate for each year after Year. Earlier years use SSWageBase.
+func (fc *FICACalculator) WageBaseForYear(year int) decimal.Decimal {
+	if year <= fc.Year || fc.WageBaseIndexingRate.IsZero() {
+		return fc.SSWageBase
 	}
+	growth := decimal.NewFromInt(1).Add(fc.WageBaseIndexingRate).Pow(decimal.NewFromInt(int64(year - fc.Year)))
+	return fc.SSWageBase.Mul(growth)
 }
 
-// CalculateFICA calculates FICA taxes (Social Security and Medicare)
+// CalculateFICA calculates FICA taxes (Social Security and Medicare) for the calculator's base year
 func (fc *FICACalculator) CalculateFICA(wages decimal.Decimal, totalHouseholdWages decimal.Decimal) decimal.Decimal {
+	return fc.CalculateFICAForYear(wages, totalHouseholdWages, fc.Year)
+}
+
+// CalculateFICAForYear calculates FICA taxes against the indexed wage base of a tax year
+func (fc *FICACalculator) CalculateFICAForYear(wages decimal.Decimal, totalHouseholdWages decimal.Decimal, year int) decimal.Decimal {
 	// Social Security tax (capped per individual)
-	ssWages := decimal.Min(wages, fc.SSWageBase)
+	ssWages := decimal.Min(wages, fc.WageBaseForYear(year))
 	ssTax := ssWages.Mul(fc.SSRate)
 
 	// Medicare tax (no cap)
@@ -271,13 +382,101 @@ func (fc *FICACalculator) CalculateFICAWithProration(wages decimal.Decimal, tota
 	return ssTax.Add(medicareTax).Add(additionalMedicare)
 }
 
+// NIITCalculator handles the Net Investment Income Tax
+type NIITCalculator struct {
+	Rate            decimal.Decimal
+	ThresholdMFJ    decimal.Decimal
+	ThresholdSingle decimal.Decimal
+}
+
+// NewNIITCalculator creates a NIIT calculator with the statutory rate and thresholds
+func NewNIITCalculator() *NIITCalculator {
+	return &NIITCalculator{
+		Rate:            decimal.NewFromFloat(0.038),
+		ThresholdMFJ:    decimal.NewFromInt(250000),
+		ThresholdSingle: decimal.NewFromInt(200000),
+	}
+}
+
+// CalculateNIIT applies the NIIT rate to the lesser of net investment income and MAGI over the
+// filing-status threshold
+func (nc *NIITCalculator) CalculateNIIT(netInvestmentIncome, magi decimal.Decimal, filingStatus string) decimal.Decimal {
+	threshold := nc.ThresholdMFJ
+	if filingStatus == "single" {
+		threshold = nc.ThresholdSingle
+	}
+	excess := magi.Sub(threshold)
+	if excess.LessThanOrEqual(decimal.Zero) || netInvestmentIncome.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+	return decimal.Min(netInvestmentIncome, excess).Mul(nc.Rate)
+}
+
+// CapitalGainsCalculator applies the preferential long-term capital gains rates
+type CapitalGainsCalculator struct {
+	Brackets       []TaxBracket // MFJ, by taxable income including the gains
+	BracketsSingle []TaxBracket
+}
+
+// NewCapitalGainsCalculator creates a capital gains calculator from the configured brackets, falling back to
+// the 2025 thresholds when none are supplied
+func NewCapitalGainsCalculator(config domain.FederalTaxConfig) *CapitalGainsCalculator {
+	var mfj, single []TaxBracket
+	for _, b := range config.CapitalGainsBrackets2025 {
+		mfj = append(mfj, TaxBracket{Min: b.Min, Max: b.Max, Rate: b.Rate})
+	}
+	for _, b := range config.CapitalGainsBrackets2025Single {
+		single = append(single, TaxBracket{Min: b.Min, Max: b.Max, Rate: b.Rate})
+	}
+	if len(mfj) == 0 {
+		mfj = []TaxBracket{
+			{decimal.Zero, decimal.NewFromInt(96700), decimal.Zero},
+			{decimal.NewFromInt(96700), decimal.NewFromInt(600050), decimal.NewFromFloat(0.15)},
+			{decimal.NewFromInt(600050), decimal.NewFromInt(999999999), decimal.NewFromFloat(0.20)},
+		}
+	}
+	if len(single) == 0 {
+		single = []TaxBracket{
+			{decimal.Zero, decimal.NewFromInt(48350), decimal.Zero},
+			{decimal.NewFromInt(48350), decimal.NewFromInt(533400), decimal.NewFromFloat(0.15)},
+			{decimal.NewFromInt(533400), decimal.NewFromInt(999999999), decimal.NewFromFloat(0.20)},
+		}
+	}
+	return &CapitalGainsCalculator{Brackets: mfj, BracketsSingle: single}
+}
+
+// CalculateTax returns the tax on long-term gains stacked on top of the ordinary taxable income, so the
+// gains fill whatever remains of the 0% bracket before reaching the 15% and 20% rates
+func (cgc *CapitalGainsCalculator) CalculateTax(gains, ordinaryTaxableIncome decimal.Decimal, filingStatus string) decimal.Decimal {
+	if gains.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+	brackets := cgc.Brackets
+	if filingStatus == "single" {
+		brackets = cgc.BracketsSingle
+	}
+	bottom := decimal.Max(ordinaryTaxableIncome, decimal.Zero)
+	top := bottom.Add(gains)
+	tax := decimal.Zero
+	for _, b := range brackets {
+		taxed := decimal.Min(top, b.Max).Sub(decimal.Max(bottom, b.Min))
+		if taxed.GreaterThan(decimal.Zero) {
+			tax = tax.Add(taxed.Mul(b.Rate))
+		}
+	}
+	return tax
+}
+
 // ComprehensiveTaxCalculator handles all tax calculations
 type ComprehensiveTaxCalculator struct {
 	FederalTaxCalc *FederalTaxCalculator
 	StateTaxCalc   *PennsylvaniaTaxCalculator
-	LocalTaxCalc   *UpperMakefieldEITCalculator
+	LocalTaxCalc   *LocalTaxCalculator // Upper Makefield Township, for callers without a location
+	LocalTaxRules  map[string]domain.LocalTaxRule
 	FICATaxCalc    *FICACalculator
 	SSTaxCalc      *SSTaxCalculator
+	NIITCalc       *NIITCalculator
+	CapGainsCalc   *CapitalGainsCalculator
 }
 
 // NewComprehensiveTaxCalculator creates a new comprehensive tax calculator
@@ -286,8 +485,11 @@ func NewComprehensiveTaxCalculator() *ComprehensiveTaxCalculator {
 		FederalTaxCalc: NewFederalTaxCalculator2025(),
 		StateTaxCalc:   NewPennsylvaniaTaxCalculator(),
 		LocalTaxCalc:   NewUpperMakefieldEITCalculator(),
+		LocalTaxRules:  map[string]domain.LocalTaxRule{UpperMakefieldTownship: {Rate: decimal.NewFromFloat(0.01)}},
 		FICATaxCalc:    NewFICACalculator2025(),
 		SSTaxCalc:      NewSSTaxCalculator(),
+		NIITCalc:       NewNIITCalculator(),
+		CapGainsCalc:   NewCapitalGainsCalculator(domain.FederalTaxConfig{}),
 	}
 }
 
@@ -296,22 +498,38 @@ func NewComprehensiveTaxCalculatorWithConfig(federalRules domain.FederalRules) *
 	return &ComprehensiveTaxCalculator{
 		FederalTaxCalc: NewFederalTaxCalculator(federalRules.FederalTaxConfig),
 		StateTaxCalc:   NewPennsylvaniaTaxCalculatorWithConfig(federalRules.StateLocalTaxConfig),
-		LocalTaxCalc:   NewUpperMakefieldEITCalculatorWithConfig(federalRules.StateLocalTaxConfig),
+		LocalTaxCalc:   NewLocalTaxCalculator(UpperMakefieldTownship, domain.LocalTaxRule{Rate: federalRules.StateLocalTaxConfig.UpperMakefieldEITRate}),
+		LocalTaxRules:  localTaxRules(federalRules.StateLocalTaxConfig),
 		FICATaxCalc:    NewFICACalculator(federalRules.FICATaxConfig),
 		SSTaxCalc:      NewSSTaxCalculator(),
+		NIITCalc:       NewNIITCalculator(),
+		CapGainsCalc:   NewCapitalGainsCalculator(federalRules.FederalTaxConfig),
 	}
 }
 
-// CalculateTotalTaxes calculates all applicable taxes with inflation-adjusted tax brackets
-func (ctc *ComprehensiveTaxCalculator) CalculateTotalTaxes(taxableIncome domain.TaxableIncome, isRetired bool, agePersonA, agePersonB int, workingIncome decimal.Decimal) (decimal.Decimal, decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+// LocalTaxCalculatorFor returns the local tax calculator for a municipality, taxing nothing when the
+// municipality has no configured rule
+func (ctc *ComprehensiveTaxCalculator) LocalTaxCalculatorFor(municipality string) *LocalTaxCalculator {
+	rule, _ := lookupLocalTaxRule(ctc.LocalTaxRules, municipality)
+	return NewLocalTaxCalculator(municipality, rule)
+}
+
+// CalculateTotalTaxes calculates all applicable taxes with inflation-adjusted tax brackets.
+// The federal amount includes the Net Investment Income Tax; the local amount uses localTaxCalc, the
+// household's municipality (see LocalTaxCalculatorFor).
+func (ctc *ComprehensiveTaxCalculator) CalculateTotalTaxes(taxableIncome domain.TaxableIncome, isRetired bool, agePersonA, agePersonB int, workingIncome decimal.Decimal, localTaxCalc *LocalTaxCalculator) (decimal.Decimal, decimal.Decimal, decimal.Decimal, decimal.Decimal) {
 	// Calculate federal tax with inflation-adjusted brackets
 	federalTax := ctc.calculateFederalTaxWithInflation(taxableIncome, agePersonA, agePersonB)
+	federalTax = federalTax.Add(ctc.calculateNIIT(taxableIncome, "mfj"))
 
-	// Calculate state tax
-	stateTax := ctc.StateTaxCalc.CalculateTax(taxableIncome, isRetired)
+	// Calculate state tax. taxableIncome holds household totals, so its retirement income is split evenly
+	// between the spouses for the per-taxpayer exclusions.
+	half := taxableIncome.FERSPension.Add(taxableIncome.TSPWithdrawalsTrad).Div(decimal.NewFromInt(2))
+	stateTax := ctc.StateTaxCalc.CalculateTaxForTaxpayers(taxableIncome, isRetired,
+		StateRetirementTaxpayer{Age: agePersonA, RetirementIncome: half}, StateRetirementTaxpayer{Age: agePersonB, RetirementIncome: half})
 
 	// Calculate local tax (only on earned income)
-	localTax := ctc.LocalTaxCalc.CalculateEIT(workingIncome, isRetired)
+	localTax := localTaxCalc.CalculateEIT(workingIncome, isRetired)
 
 	// Calculate FICA tax (only on earned income)
 	ficaTax := ctc.FICATaxCalc.CalculateFICA(workingIncome, workingIncome)
@@ -322,7 +540,7 @@ func (ctc *ComprehensiveTaxCalculator) CalculateTotalTaxes(taxableIncome domain.
 // calculateFederalTaxWithInflation calculates federal tax with inflation-adjusted brackets
 func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithInflation(taxableIncome domain.TaxableIncome, agePersonA, agePersonB int) decimal.Decimal {
 	// Calculate total taxable income
-	totalIncome := taxableIncome.Salary.Add(taxableIncome.FERSPension).Add(taxableIncome.TSPWithdrawalsTrad).Add(taxableIncome.TaxableSSBenefits).Add(taxableIncome.OtherTaxableIncome)
+	totalIncome := grossTaxableIncome(taxableIncome)
 
 	// Apply standard deduction with age-based adjustments
 	standardDeduction := ctc.FederalTaxCalc.StandardDeduction
@@ -335,12 +553,16 @@ func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithInflation(taxableI
 		standardDeduction = standardDeduction.Add(ctc.FederalTaxCalc.AdditionalStdDed)
 	}
 
-	// Calculate adjusted gross income
-	agi := totalIncome.Sub(standardDeduction)
+	// Calculate adjusted gross income, itemizing when that beats the standard deduction
+	agi := totalIncome.Sub(federalDeduction(taxableIncome, standardDeduction))
 	if agi.LessThan(decimal.Zero) {
 		agi = decimal.Zero
 	}
 
+	// Long-term gains sit on top of ordinary income and are taxed separately at preferential rates
+	gains := decimal.Min(decimal.Max(taxableIncome.CapitalGains, decimal.Zero), agi)
+	agi = agi.Sub(gains)
+
 	// Apply inflation adjustment to tax brackets
 	// Note: For current tests and 2025 calculations, we do not adjust brackets
 	// Set to 1.0 to keep bracket thresholds unchanged
@@ -377,14 +599,58 @@ func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithInflation(taxableI
 		}
 	}
 
-	return tax
+	return tax.Add(ctc.capitalGainsTax(gains, agi, "mfj"))
 }
 
-// calculateFederalTaxWithStatus allows specifying filing status ("mfj" or "single") and number of seniors 65+.
-func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithStatus(agiComponents domain.TaxableIncome, filingStatus string, seniors int) decimal.Decimal {
-	totalIncome := agiComponents.Salary.Add(agiComponents.FERSPension).Add(agiComponents.TSPWithdrawalsTrad).Add(agiComponents.TaxableSSBenefits).Add(agiComponents.OtherTaxableIncome)
+// Itemized deduction limits
+var (
+	saltDeductionCap = decimal.NewFromInt(10000)
+	medicalAGIFloor  = decimal.NewFromFloat(0.075)
+)
+
+// ItemizedDeductionTotal returns the allowable itemized deductions for a year with the given AGI: mortgage
+// interest and charitable gifts in full, state and local taxes up to the $10,000 SALT cap, and medical
+// expenses above 7.5% of AGI
+func ItemizedDeductionTotal(items *domain.ItemizedDeductions, agi decimal.Decimal) decimal.Decimal {
+	if items == nil {
+		return decimal.Zero
+	}
+	medical := decimal.Max(decimal.Zero, items.MedicalExpenses.Sub(decimal.Max(agi, decimal.Zero).Mul(medicalAGIFloor)))
+	return items.MortgageInterest.
+		Add(decimal.Min(items.StateAndLocalTaxes, saltDeductionCap)).
+		Add(items.CharitableContributions).
+		Add(medical)
+}
+
+// federalDeduction returns the larger of the standard deduction and the itemized deductions in ti
+func federalDeduction(ti domain.TaxableIncome, standardDeduction decimal.Decimal) decimal.Decimal {
+	return decimal.Max(standardDeduction, ItemizedDeductionTotal(ti.ItemizedDeductions, grossTaxableIncome(ti)))
+}
+
+// grossTaxableIncome sums the income components that make up AGI (used as MAGI for NIIT)
+func grossTaxableIncome(ti domain.TaxableIncome) decimal.Decimal {
+	return ti.Salary.Add(ti.FERSPension).Add(ti.TSPWithdrawalsTrad).Add(ti.TaxableSSBenefits).Add(ti.OtherTaxableIncome).Add(ti.NetInvestmentIncome())
+}
+
+// calculateNIIT returns the Net Investment Income Tax owed on the investment income in ti
+func (ctc *ComprehensiveTaxCalculator) calculateNIIT(ti domain.TaxableIncome, filingStatus string) decimal.Decimal {
+	if ctc.NIITCalc == nil {
+		return decimal.Zero
+	}
+	return ctc.NIITCalc.CalculateNIIT(ti.NetInvestmentIncome(), grossTaxableIncome(ti), filingStatus)
+}
+
+// capitalGainsTax returns the long-term capital gains tax on gains stacked above the ordinary taxable income
+func (ctc *ComprehensiveTaxCalculator) capitalGainsTax(gains, ordinaryTaxableIncome decimal.Decimal, filingStatus string) decimal.Decimal {
+	if ctc.CapGainsCalc == nil {
+		ctc.CapGainsCalc = NewCapitalGainsCalculator(domain.FederalTaxConfig{})
+	}
+	return ctc.CapGainsCalc.CalculateTax(gains, ordinaryTaxableIncome, filingStatus)
+}
 
-	// Standard deduction based on filing status
+// federalDeductionAndBrackets returns the standard deduction, including the additional amount for each
+// senior 65+, and the ordinary brackets for a filing status ("mfj" or "single")
+func (ctc *ComprehensiveTaxCalculator) federalDeductionAndBrackets(filingStatus string, seniors int) (decimal.Decimal, []TaxBracket) {
 	standardDed := ctc.FederalTaxCalc.StandardDeduction
 	brackets := ctc.FederalTaxCalc.Brackets
 	if filingStatus == "single" {
@@ -396,11 +662,22 @@ func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithStatus(agiComponen
 	for i := 0; i < seniors; i++ {
 		standardDed = standardDed.Add(ctc.FederalTaxCalc.AdditionalStdDed)
 	}
+	return standardDed, brackets
+}
+
+// calculateFederalTaxWithStatus allows specifying filing status ("mfj" or "single") and number of seniors 65+.
+// The result includes the Net Investment Income Tax.
+func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithStatus(agiComponents domain.TaxableIncome, filingStatus string, seniors int) decimal.Decimal {
+	totalIncome := grossTaxableIncome(agiComponents)
 
-	agi := totalIncome.Sub(standardDed)
+	standardDed, brackets := ctc.federalDeductionAndBrackets(filingStatus, seniors)
+
+	agi := totalIncome.Sub(federalDeduction(agiComponents, standardDed))
 	if agi.LessThan(decimal.Zero) {
 		agi = decimal.Zero
 	}
+	gains := decimal.Min(decimal.Max(agiComponents.CapitalGains, decimal.Zero), agi)
+	agi = agi.Sub(gains)
 
 	inflationAdjustment := decimal.NewFromFloat(1.0)
 	remaining := agi
@@ -421,19 +698,38 @@ func (ctc *ComprehensiveTaxCalculator) calculateFederalTaxWithStatus(agiComponen
 			remaining = remaining.Sub(incomeInBracket)
 		}
 	}
-	return tax
+	return tax.Add(ctc.capitalGainsTax(gains, agi, filingStatus)).Add(ctc.calculateNIIT(agiComponents, filingStatus))
 }
 
-// CalculateTaxableIncome creates a TaxableIncome struct from cash flow data
+// yearMarginalRate returns the federal bracket rate of the last dollar of a projection year's ordinary
+// taxable income (after the deduction taken, excluding capital gains)
+func (ctc *ComprehensiveTaxCalculator) yearMarginalRate(cf domain.AnnualCashFlow) decimal.Decimal {
+	taxable := cf.FederalTaxableIncome.Sub(cf.CapitalGains).Sub(cf.FederalStandardDeduction)
+	_, brackets := ctc.federalDeductionAndBrackets(cf.FederalFilingStatus, cf.FederalSeniors65Plus)
+	for _, b := range brackets {
+		if taxable.LessThanOrEqual(b.Max) {
+			return b.Rate
+		}
+	}
+	if len(brackets) == 0 {
+		return decimal.Zero
+	}
+	return brackets[len(brackets)-1].Rate
+}
+
+// CalculateTaxableIncome creates a TaxableIncome struct from cash flow data, reading the same
+// PersonA/PersonB fields the projection populates. Wages are included for working (partial) years and
+// part-time work after separation, TSP annuity payments count with TSP withdrawals, and qualified charitable distributions are excluded from TSP withdrawals.
 func CalculateTaxableIncome(cashFlow domain.AnnualCashFlow, isRetired bool) domain.TaxableIncome {
+	wages := cashFlow.SalaryPersonA.Add(cashFlow.SalaryPersonB).Add(cashFlow.PostRetirementWagesPersonA).Add(cashFlow.PostRetirementWagesPersonB)
 	return domain.TaxableIncome{
-		Salary:             decimal.Zero,
+		Salary:             wages,
 		FERSPension:        cashFlow.PensionPersonA.Add(cashFlow.PensionPersonB).Add(cashFlow.SurvivorPensionPersonA).Add(cashFlow.SurvivorPensionPersonB),
-		TSPWithdrawalsTrad: cashFlow.TSPWithdrawalPersonA.Add(cashFlow.TSPWithdrawalPersonB),
+		TSPWithdrawalsTrad: cashFlow.TSPWithdrawalPersonA.Add(cashFlow.TSPWithdrawalPersonB).Add(cashFlow.TSPAnnuityPersonA).Add(cashFlow.TSPAnnuityPersonB).Sub(cashFlow.QCDPersonA).Sub(cashFlow.QCDPersonB),
 		TaxableSSBenefits:  cashFlow.SSBenefitPersonA.Add(cashFlow.SSBenefitPersonB),
 		OtherTaxableIncome: decimal.Zero,
-		WageIncome:         decimal.Zero,
-		InterestIncome:     decimal.Zero,
+		WageIncome:         wages,
+		InterestIncome:     cashFlow.InterestIncome,
 	}
 }
 
@@ -461,27 +757,33 @@ func (ctc *ComprehensiveTaxCalculator) CalculateSocialSecurityTaxation(ssBenefit
 	return ctc.SSTaxCalc.CalculateTaxableSocialSecurity(ssBenefits, provisionalIncome)
 }
 
-// calculateTaxes calculates all applicable taxes
-func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, scenario *domain.Scenario, year int, isRetired bool, pensionPersonA, pensionPersonB, survivorPensionPersonA, survivorPensionPersonB, tspWithdrawalPersonA, tspWithdrawalPersonB, ssPersonA, ssPersonB decimal.Decimal, workingIncomePersonA, workingIncomePersonB decimal.Decimal) (federal decimal.Decimal, state decimal.Decimal, local decimal.Decimal, fica decimal.Decimal, taxableIncomeTotal decimal.Decimal, stdDed decimal.Decimal, filingStatusOut string, seniorsOut int) {
-	projectionStartYear := ProjectionBaseYear
-	projectionDate := time.Date(projectionStartYear, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(year, 0, 0)
-	agePersonA := personA.Age(projectionDate)
-	agePersonB := personB.Age(projectionDate)
+// isSeniorForTaxYear reports whether a person gets the 65+ additional standard deduction for a tax year.
+// The IRS counts a person as 65 on the day before their 65th birthday, so they qualify when they are 65
+// by January 1 of the following year: a December 31 birthday qualifies in the year they turn 65, and a
+// January 1 birthday already in the year before.
+func isSeniorForTaxYear(person *domain.Employee, taxYear int) bool {
+	return person.Age(time.Date(taxYear+1, 1, 1, 0, 0, 0, 0, time.UTC)) >= 65
+}
 
-	// Determine mortality & filing status for this year
-	filingStatus := "mfj"
-	seniors := 0
-	if agePersonA >= 65 {
+// filingStatusForYear returns the federal filing status ("mfj" or "single") and number of seniors 65+ for
+// projection year index year, counted from projectionStartYear, and which spouses have died by then. A
+// survivor files single as the scenario's mortality filing_status_switch says.
+func filingStatusForYear(scenario *domain.Scenario, personA, personB *domain.Employee, projectionStartYear, year int) (filingStatus string, seniors int, personADeceased, personBDeceased bool) {
+	taxYear := projectionStartYear + year
+	filingStatus = "mfj"
+	seniorPersonA := isSeniorForTaxYear(personA, taxYear)
+	seniorPersonB := isSeniorForTaxYear(personB, taxYear)
+	if seniorPersonA {
 		seniors++
 	}
-	if agePersonB >= 65 {
+	if seniorPersonB {
 		seniors++
 	}
 
 	// Use shared helper for death year indexes (projection horizon not needed here; pass year+1 as conservative bound)
-	personADeathYearIndex, personBDeathYearIndex := deriveDeathYearIndexes(scenario, personA, personB, year+1+5) // simple upper bound
-	personADeceased := personADeathYearIndex != nil && year >= *personADeathYearIndex
-	personBDeceased := personBDeathYearIndex != nil && year >= *personBDeathYearIndex
+	personADeathYearIndex, personBDeathYearIndex := deriveDeathYearIndexes(scenario, personA, personB, projectionStartYear, year+1+5) // simple upper bound
+	personADeceased = personADeathYearIndex != nil && year >= *personADeathYearIndex
+	personBDeceased = personBDeathYearIndex != nil && year >= *personBDeathYearIndex
 	if (personADeceased || personBDeceased) && !(personADeceased && personBDeceased) {
 		// One survivor; evaluate filing status switch policy
 		if scenario != nil && scenario.Mortality != nil && scenario.Mortality.Assumptions != nil {
@@ -491,10 +793,10 @@ func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, s
 				filingStatus = "single"
 				seniors = 0
 				// Count surviving senior for additional deduction
-				if !personADeceased && agePersonA >= 65 {
+				if !personADeceased && seniorPersonA {
 					seniors = 1
 				}
-				if !personBDeceased && agePersonB >= 65 {
+				if !personBDeceased && seniorPersonB {
 					seniors = 1
 				}
 			case "next_year":
@@ -509,16 +811,42 @@ func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, s
 				if year > deathYear {
 					filingStatus = "single"
 					seniors = 0
-					if !personADeceased && agePersonA >= 65 {
+					if !personADeceased && seniorPersonA {
 						seniors = 1
 					}
-					if !personBDeceased && agePersonB >= 65 {
+					if !personBDeceased && seniorPersonB {
 						seniors = 1
 					}
 				}
 			}
 		}
 	}
+	return filingStatus, seniors, personADeceased, personBDeceased
+}
+
+// calculateTaxes calculates all applicable taxes for projection year index year, counted from projectionStartYear.
+// The deduction returned is the one taken: the standard deduction, or the itemized deductions when larger.
+func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, scenario *domain.Scenario, localTaxCalc *LocalTaxCalculator, projectionStartYear, year int, isRetired bool, pensionPersonA, pensionPersonB, survivorPensionPersonA, survivorPensionPersonB, tspWithdrawalPersonA, tspWithdrawalPersonB, ssPersonA, ssPersonB decimal.Decimal, workingIncomePersonA, workingIncomePersonB decimal.Decimal, otherTaxableIncome, capitalGains, interestIncome decimal.Decimal) (federal decimal.Decimal, state decimal.Decimal, local decimal.Decimal, fica decimal.Decimal, taxableIncomeTotal decimal.Decimal, deduction decimal.Decimal, filingStatusOut string, seniorsOut int) {
+	projectionDate := time.Date(projectionStartYear, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(year, 0, 0)
+	agePersonA := personA.Age(projectionDate)
+	agePersonB := personB.Age(projectionDate)
+
+	var itemized *domain.ItemizedDeductions
+	if scenario != nil {
+		itemized = scenario.ItemizedDeductions
+	}
+
+	// Determine mortality & filing status for this year
+	filingStatus, seniors, personADeceased, personBDeceased := filingStatusForYear(scenario, personA, personB, projectionStartYear, year)
+
+	// The living spouses' own retirement income, for age-based state retirement income exclusions
+	var taxpayers []StateRetirementTaxpayer
+	if !personADeceased {
+		taxpayers = append(taxpayers, StateRetirementTaxpayer{Age: agePersonA, RetirementIncome: pensionPersonA.Add(survivorPensionPersonA).Add(tspWithdrawalPersonA)})
+	}
+	if !personBDeceased {
+		taxpayers = append(taxpayers, StateRetirementTaxpayer{Age: agePersonB, RetirementIncome: pensionPersonB.Add(survivorPensionPersonB).Add(tspWithdrawalPersonB)})
+	}
 
 	// Check if this is a transition year (has both working and retirement income)
 	isTransitionYear := (workingIncomePersonA.GreaterThan(decimal.Zero) || workingIncomePersonB.GreaterThan(decimal.Zero)) &&
@@ -531,7 +859,7 @@ func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, s
 
 		// Calculate Social Security taxation (filing status aware thresholds)
 		totalSSBenefits := ssPersonA.Add(ssPersonB)
-		provisional := ce.TaxCalc.SSTaxCalc.CalculateProvisionalIncome(totalRetirementIncome, decimal.Zero, totalSSBenefits)
+		provisional := ce.TaxCalc.SSTaxCalc.CalculateProvisionalIncome(totalRetirementIncome.Add(otherTaxableIncome).Add(capitalGains).Add(interestIncome), decimal.Zero, totalSSBenefits)
 		var taxableSS decimal.Decimal
 		if filingStatus == "single" {
 			taxableSS = ce.TaxCalc.SSTaxCalc.CalculateTaxableSocialSecuritySingle(totalSSBenefits, provisional)
@@ -545,35 +873,32 @@ func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, s
 			FERSPension:        pensionPersonA.Add(pensionPersonB).Add(survivorPensionPersonA).Add(survivorPensionPersonB),
 			TSPWithdrawalsTrad: tspWithdrawalPersonA.Add(tspWithdrawalPersonB),
 			TaxableSSBenefits:  taxableSS,
-			OtherTaxableIncome: decimal.Zero,
+			OtherTaxableIncome: otherTaxableIncome,
 			WageIncome:         totalWorkingIncome,
-			InterestIncome:     decimal.Zero,
+			InterestIncome:     interestIncome,
+			CapitalGains:       capitalGains,
+			ItemizedDeductions: itemized,
 		}
 
 		// Calculate taxes for transition year (FICA only on working income, with proration)
 		// Federal tax using filing status logic
 		federalTax := ce.TaxCalc.calculateFederalTaxWithStatus(taxableIncome, filingStatus, seniors)
-		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTax(taxableIncome, false)
-		localTax := ce.TaxCalc.LocalTaxCalc.CalculateEIT(totalWorkingIncome, false)
-		personAFICA := ce.TaxCalc.FICATaxCalc.CalculateFICA(workingIncomePersonA, totalWorkingIncome)
-		personBFICA := ce.TaxCalc.FICATaxCalc.CalculateFICA(workingIncomePersonB, totalWorkingIncome)
+		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTaxForTaxpayers(taxableIncome, false, taxpayers...)
+		localTax := localTaxCalc.CalculateTax(totalWorkingIncome, taxableIncome.FERSPension.Add(taxableIncome.TSPWithdrawalsTrad))
+		personAFICA := ce.TaxCalc.FICATaxCalc.CalculateFICAForYear(workingIncomePersonA, totalWorkingIncome, projectionStartYear+year)
+		personBFICA := ce.TaxCalc.FICATaxCalc.CalculateFICAForYear(workingIncomePersonB, totalWorkingIncome, projectionStartYear+year)
 		ficaTax := personAFICA.Add(personBFICA)
-		std := ce.TaxCalc.FederalTaxCalc.StandardDeduction
-		if filingStatus == "single" {
-			std = ce.TaxCalc.FederalTaxCalc.StandardDeductionSingle
-		}
-		for i := 0; i < seniors; i++ {
-			std = std.Add(ce.TaxCalc.FederalTaxCalc.AdditionalStdDed)
-		}
-		return federalTax, stateTax, localTax, ficaTax, taxableIncome.Salary.Add(taxableIncome.FERSPension).Add(taxableIncome.TSPWithdrawalsTrad).Add(taxableIncome.TaxableSSBenefits), std, filingStatus, seniors
-	} else if isRetired {
-		// Fully retired year
+		std, _ := ce.TaxCalc.federalDeductionAndBrackets(filingStatus, seniors)
+		deduction = federalDeduction(taxableIncome, std)
+		return federalTax, stateTax, localTax, ficaTax, taxableIncome.Salary.Add(taxableIncome.FERSPension).Add(taxableIncome.TSPWithdrawalsTrad).Add(taxableIncome.TaxableSSBenefits).Add(taxableIncome.OtherTaxableIncome).Add(capitalGains).Add(interestIncome), deduction, filingStatus, seniors
+	} else if isRetired && workingIncomePersonA.IsZero() && workingIncomePersonB.IsZero() {
+		// Fully retired year (retirees with only part-time wages fall through to the wage branch)
 		// Calculate other income (excluding Social Security)
 		otherIncome := pensionPersonA.Add(pensionPersonB).Add(survivorPensionPersonA).Add(survivorPensionPersonB).Add(tspWithdrawalPersonA).Add(tspWithdrawalPersonB)
 
 		// Calculate Social Security taxation with filing status thresholds
 		totalSSBenefits := ssPersonA.Add(ssPersonB)
-		provisional := ce.TaxCalc.SSTaxCalc.CalculateProvisionalIncome(otherIncome, decimal.Zero, totalSSBenefits)
+		provisional := ce.TaxCalc.SSTaxCalc.CalculateProvisionalIncome(otherIncome.Add(otherTaxableIncome).Add(capitalGains).Add(interestIncome), decimal.Zero, totalSSBenefits)
 		var taxableSS decimal.Decimal
 		if filingStatus == "single" {
 			taxableSS = ce.TaxCalc.SSTaxCalc.CalculateTaxableSocialSecuritySingle(totalSSBenefits, provisional)
@@ -587,37 +912,34 @@ func (ce *CalculationEngine) calculateTaxes(personA, personB *domain.Employee, s
 			FERSPension:        pensionPersonA.Add(pensionPersonB).Add(survivorPensionPersonA).Add(survivorPensionPersonB),
 			TSPWithdrawalsTrad: tspWithdrawalPersonA.Add(tspWithdrawalPersonB), // Assuming all TSP withdrawals are from traditional
 			TaxableSSBenefits:  taxableSS,
-			OtherTaxableIncome: decimal.Zero,
+			OtherTaxableIncome: otherTaxableIncome,
 			WageIncome:         decimal.Zero,
-			InterestIncome:     decimal.Zero,
+			InterestIncome:     interestIncome,
+			CapitalGains:       capitalGains,
+			ItemizedDeductions: itemized,
 		}
 
 		// Calculate taxes (no FICA in retirement)
 		federalTax := ce.TaxCalc.calculateFederalTaxWithStatus(taxableIncome, filingStatus, seniors)
-		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTax(taxableIncome, true)
-		localTax := ce.TaxCalc.LocalTaxCalc.CalculateEIT(decimal.Zero, true)
-		std := ce.TaxCalc.FederalTaxCalc.StandardDeduction
-		if filingStatus == "single" {
-			std = ce.TaxCalc.FederalTaxCalc.StandardDeductionSingle
-		}
-		for i := 0; i < seniors; i++ {
-			std = std.Add(ce.TaxCalc.FederalTaxCalc.AdditionalStdDed)
-		}
-		return federalTax, stateTax, localTax, decimal.Zero, taxableIncome.Salary.Add(taxableIncome.FERSPension).Add(taxableIncome.TSPWithdrawalsTrad).Add(taxableIncome.TaxableSSBenefits), std, filingStatus, seniors
+		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTaxForTaxpayers(taxableIncome, true, taxpayers...)
+		localTax := localTaxCalc.CalculateTax(decimal.Zero, taxableIncome.FERSPension.Add(taxableIncome.TSPWithdrawalsTrad))
+		std, _ := ce.TaxCalc.federalDeductionAndBrackets(filingStatus, seniors)
+		deduction = federalDeduction(taxableIncome, std)
+		return federalTax, stateTax, localTax, decimal.Zero, taxableIncome.Salary.Add(taxableIncome.FERSPension).Add(taxableIncome.TSPWithdrawalsTrad).Add(taxableIncome.TaxableSSBenefits).Add(taxableIncome.OtherTaxableIncome).Add(capitalGains).Add(interestIncome), deduction, filingStatus, seniors
 	} else {
 		// Pre-retirement: calculate current working income
-		currentTaxableIncome := CalculateCurrentTaxableIncome(personA.CurrentSalary, personB.CurrentSalary)
+		totalWorkingIncome := workingIncomePersonA.Add(workingIncomePersonB)
+		currentTaxableIncome := CalculateCurrentTaxableIncome(workingIncomePersonA, workingIncomePersonB)
+		currentTaxableIncome.OtherTaxableIncome = otherTaxableIncome
+		currentTaxableIncome.CapitalGains = capitalGains
+		currentTaxableIncome.InterestIncome = interestIncome
+		currentTaxableIncome.ItemizedDeductions = itemized
 		federalTax := ce.TaxCalc.calculateFederalTaxWithStatus(currentTaxableIncome, filingStatus, seniors)
-		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTax(currentTaxableIncome, false)
-		localTax := ce.TaxCalc.LocalTaxCalc.CalculateEIT(personA.CurrentSalary.Add(personB.CurrentSalary), false)
-		ficaTax := ce.TaxCalc.FICATaxCalc.CalculateFICA(personA.CurrentSalary, personA.CurrentSalary.Add(personB.CurrentSalary)).Add(ce.TaxCalc.FICATaxCalc.CalculateFICA(personB.CurrentSalary, personA.CurrentSalary.Add(personB.CurrentSalary)))
-		std := ce.TaxCalc.FederalTaxCalc.StandardDeduction
-		if filingStatus == "single" {
-			std = ce.TaxCalc.FederalTaxCalc.StandardDeductionSingle
-		}
-		for i := 0; i < seniors; i++ {
-			std = std.Add(ce.TaxCalc.FederalTaxCalc.AdditionalStdDed)
-		}
-		return federalTax, stateTax, localTax, ficaTax, currentTaxableIncome.Salary, std, filingStatus, seniors
+		stateTax := ce.TaxCalc.StateTaxCalc.CalculateTaxForTaxpayers(currentTaxableIncome, false, taxpayers...)
+		localTax := localTaxCalc.CalculateTax(totalWorkingIncome, decimal.Zero)
+		ficaTax := ce.TaxCalc.FICATaxCalc.CalculateFICAForYear(workingIncomePersonA, totalWorkingIncome, projectionStartYear+year).Add(ce.TaxCalc.FICATaxCalc.CalculateFICAForYear(workingIncomePersonB, totalWorkingIncome, projectionStartYear+year))
+		std, _ := ce.TaxCalc.federalDeductionAndBrackets(filingStatus, seniors)
+		deduction = federalDeduction(currentTaxableIncome, std)
+		return federalTax, stateTax, localTax, ficaTax, currentTaxableIncome.Salary.Add(otherTaxableIncome).Add(capitalGains).Add(interestIncome), deduction, filingStatus, seniors
 	}
 }
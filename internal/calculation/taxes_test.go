@@ -2,6 +2,7 @@ package calculation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
@@ -162,6 +163,40 @@ func TestPennsylvaniaTaxCalculation(t *testing.T) {
 	}
 }
 
+func TestStateRetirementIncomeExclusion(t *testing.T) {
+	income := domain.TaxableIncome{
+		FERSPension:        decimal.NewFromInt(50000),
+		TSPWithdrawalsTrad: decimal.NewFromInt(10000),
+		TaxableSSBenefits:  decimal.NewFromInt(20000),
+	}
+	config := domain.StateLocalTaxConfig{
+		PennsylvaniaRate: decimal.NewFromFloat(0.05),
+		RetirementIncome: &domain.StateRetirementIncomeRules{ExclusionMinAge: 59},
+	}
+	retiree := func(age int, income int64) StateRetirementTaxpayer {
+		return StateRetirementTaxpayer{Age: age, RetirementIncome: decimal.NewFromInt(income)}
+	}
+	noExclusion := NewPennsylvaniaTaxCalculatorWithConfig(config).CalculateTaxForTaxpayers(income, true, retiree(62, 60000))
+	assert.True(t, decimal.NewFromInt(3000).Equal(noExclusion), "expected 5%% of $60k pension and TSP income, got %s", noExclusion)
+
+	// A $20k exclusion at 59+ removes $1,000 of tax; Social Security stays exempt
+	config.RetirementIncome = &domain.StateRetirementIncomeRules{ExclusionPerPerson: decimal.NewFromInt(20000), ExclusionMinAge: 59}
+	calc := NewPennsylvaniaTaxCalculatorWithConfig(config)
+	assert.True(t, decimal.NewFromInt(2000).Equal(calc.CalculateTaxForTaxpayers(income, true, retiree(62, 60000))))
+	// Each qualifying spouse excludes their own income; those under the minimum age do not
+	assert.True(t, decimal.NewFromInt(1000).Equal(calc.CalculateTaxForTaxpayers(income, true, retiree(62, 30000), retiree(60, 30000))))
+	assert.True(t, decimal.NewFromInt(2000).Equal(calc.CalculateTaxForTaxpayers(income, true, retiree(62, 30000), retiree(55, 30000))))
+	// A spouse without retirement income cannot pass their exclusion to the other
+	assert.True(t, decimal.NewFromInt(2000).Equal(calc.CalculateTaxForTaxpayers(income, true, retiree(62, 60000), retiree(60, 0))))
+
+	// Taxing Social Security adds its federally taxable portion
+	config.RetirementIncome.TaxSocialSecurity = true
+	assert.True(t, decimal.NewFromInt(3000).Equal(NewPennsylvaniaTaxCalculatorWithConfig(config).CalculateTaxForTaxpayers(income, true, retiree(62, 60000))))
+
+	// Without rules, retirement income is exempt as in Pennsylvania
+	assert.True(t, NewPennsylvaniaTaxCalculator().CalculateTaxForTaxpayers(income, true, retiree(62, 60000)).IsZero())
+}
+
 // TestUpperMakefieldEIT tests local Earned Income Tax
 func TestUpperMakefieldEIT(t *testing.T) {
 	calculator := NewUpperMakefieldEITCalculator()
@@ -331,6 +366,36 @@ func TestFICAWithProration(t *testing.T) {
 	}
 }
 
+// TestFICAWageBaseIndexing checks that the SS wage base grows by the indexing rate after the base year
+func TestFICAWageBaseIndexing(t *testing.T) {
+	calculator := NewFICACalculator(domain.FICATaxConfig{
+		SocialSecurityWageBase: decimal.NewFromInt(176100),
+		SocialSecurityRate:     decimal.NewFromFloat(0.062),
+		MedicareRate:           decimal.NewFromFloat(0.0145),
+		AdditionalMedicareRate: decimal.NewFromFloat(0.009),
+		HighIncomeThresholdMFJ: decimal.NewFromInt(250000),
+		WageBaseIndexingRate:   decimal.NewFromFloat(0.05),
+	})
+	assert.Equal(t, 2025, calculator.Year, "unset year should default to 2025")
+
+	salary := decimal.NewFromInt(300000)
+	fica2025 := calculator.CalculateFICAForYear(salary, salary, 2025)
+	fica2030 := calculator.CalculateFICAForYear(salary, salary, 2030)
+
+	assert.True(t, fica2025.Equal(calculator.CalculateFICA(salary, salary)), "base-year FICA should match CalculateFICA")
+	assert.InDelta(t, 15718.20, fica2025.InexactFloat64(), 0.01)
+
+	// 2030 wage base: 176100 * 1.05^5 = 224753.18; the extra 48653.18 of wages is taxed at 6.2%
+	base2030 := calculator.WageBaseForYear(2030)
+	assert.InDelta(t, 224753.18, base2030.InexactFloat64(), 0.01)
+	assert.InDelta(t, 48653.18*0.062, fica2030.Sub(fica2025).InexactFloat64(), 0.01)
+
+	// Years before the base year, and a zero rate, keep the configured wage base
+	assert.True(t, calculator.WageBaseForYear(2020).Equal(decimal.NewFromInt(176100)))
+	flat := NewFICACalculator2025()
+	assert.True(t, flat.CalculateFICAForYear(salary, salary, 2030).Equal(fica2025))
+}
+
 // TestSocialSecurityTaxationComprehensive tests comprehensive SS taxation scenarios
 func TestSocialSecurityTaxationComprehensive(t *testing.T) {
 	calculator := NewSSTaxCalculator()
@@ -364,7 +429,7 @@ func TestSocialSecurityTaxationComprehensive(t *testing.T) {
 			annualSSBenefit:    decimal.NewFromInt(36000),
 			otherIncome:        decimal.NewFromInt(50000),
 			nontaxableInterest: decimal.Zero,
-			expectedTaxable:    decimal.NewFromInt(30600), // 85% of benefits
+			expectedTaxable:    decimal.NewFromInt(26400), // $6,000 first tier plus 85% of the $24,000 above $44k
 			description:        "Above second threshold: 85% taxation",
 		},
 		{
@@ -380,7 +445,7 @@ func TestSocialSecurityTaxationComprehensive(t *testing.T) {
 			annualSSBenefit:    decimal.NewFromInt(30000),
 			otherIncome:        decimal.NewFromInt(30000),
 			nontaxableInterest: decimal.NewFromInt(10000), // Municipal bond interest
-			expectedTaxable:    decimal.NewFromInt(25500), // 85% taxation due to higher provisional income
+			expectedTaxable:    decimal.NewFromInt(15350), // Provisional income of $55,000: $6,000 plus 85% of $11,000
 			description:        "Municipal bond interest affects SS taxation",
 		},
 	}
@@ -481,7 +546,7 @@ func TestComprehensiveTaxCalculation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			federal, state, local, fica := calculator.CalculateTotalTaxes(
-				tt.income, tt.isRetired, tt.age1, tt.age2, tt.totalWages)
+				tt.income, tt.isRetired, tt.age1, tt.age2, tt.totalWages, calculator.LocalTaxCalc)
 
 			// Allow for larger rounding differences due to complex calculations
 			tolerance := decimal.NewFromInt(200)
@@ -508,3 +573,188 @@ func TestComprehensiveTaxCalculation(t *testing.T) {
 		})
 	}
 }
+
+// TestCalculateTaxableIncomeUsesProjectionFields confirms CalculateTaxableIncome reads the PersonA/PersonB
+// fields that GenerateAnnualProjection fills in, so no income is silently dropped
+func TestCalculateTaxableIncomeUsesProjectionFields(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	projection := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	cf := projection[1]
+	income := CalculateTaxableIncome(cf, cf.IsRetired)
+
+	assert.True(t, cf.PensionPersonA.IsPositive(), "projection should populate PensionPersonA")
+	assert.True(t, cf.TSPWithdrawalPersonA.IsPositive(), "projection should populate TSPWithdrawalPersonA")
+	assert.True(t, income.FERSPension.Equal(cf.PensionPersonA.Add(cf.PensionPersonB).Add(cf.SurvivorPensionPersonA).Add(cf.SurvivorPensionPersonB)))
+	assert.True(t, income.TSPWithdrawalsTrad.Equal(cf.TSPWithdrawalPersonA.Add(cf.TSPWithdrawalPersonB)))
+	assert.True(t, income.TaxableSSBenefits.Equal(cf.SSBenefitPersonA.Add(cf.SSBenefitPersonB)))
+	assert.True(t, income.Salary.Equal(cf.SalaryPersonA.Add(cf.SalaryPersonB)))
+}
+
+func TestNIITCalculation(t *testing.T) {
+	niit := NewNIITCalculator()
+
+	// $60k of investment income with $300k MAGI: taxed on the $50k of MAGI over the MFJ threshold
+	assert.True(t, decimal.NewFromInt(1900).Equal(niit.CalculateNIIT(decimal.NewFromInt(60000), decimal.NewFromInt(300000), "mfj")))
+	// Investment income smaller than the excess is taxed in full
+	assert.True(t, decimal.NewFromInt(380).Equal(niit.CalculateNIIT(decimal.NewFromInt(10000), decimal.NewFromInt(300000), "mfj")))
+	// Below the threshold there is no NIIT
+	assert.True(t, niit.CalculateNIIT(decimal.NewFromInt(60000), decimal.NewFromInt(240000), "mfj").IsZero())
+	// Single filers use the lower $200k threshold
+	assert.True(t, decimal.NewFromInt(2280).Equal(niit.CalculateNIIT(decimal.NewFromInt(60000), decimal.NewFromInt(300000), "single")))
+}
+
+func TestComprehensiveTaxIncludesNIIT(t *testing.T) {
+	calc := NewComprehensiveTaxCalculator()
+	income := domain.TaxableIncome{
+		Salary:       decimal.NewFromInt(240000),
+		WageIncome:   decimal.NewFromInt(240000),
+		CapitalGains: decimal.NewFromInt(60000),
+	}
+
+	withNIIT, _, _, _ := calc.CalculateTotalTaxes(income, false, 60, 60, decimal.NewFromInt(240000), calc.LocalTaxCalc)
+	calc.NIITCalc = nil
+	withoutNIIT, _, _, _ := calc.CalculateTotalTaxes(income, false, 60, 60, decimal.NewFromInt(240000), calc.LocalTaxCalc)
+
+	assert.True(t, decimal.NewFromInt(1900).Equal(withNIIT.Sub(withoutNIIT)), "expected $1,900 NIIT, got %s", withNIIT.Sub(withoutNIIT))
+}
+
+func TestCapitalGainsStackOnOrdinaryIncome(t *testing.T) {
+	cg := NewCapitalGainsCalculator(domain.FederalTaxConfig{})
+
+	// Gains that fit under the 0% threshold are untaxed
+	assert.True(t, cg.CalculateTax(decimal.NewFromInt(20000), decimal.NewFromInt(60000), "mfj").IsZero())
+	// $20k of gains on $86,700 of ordinary income: $10k at 0%, $10k at 15%
+	assert.True(t, decimal.NewFromInt(1500).Equal(cg.CalculateTax(decimal.NewFromInt(20000), decimal.NewFromInt(86700), "mfj")))
+	// Single filers reach 15% sooner
+	assert.True(t, decimal.NewFromInt(3000).Equal(cg.CalculateTax(decimal.NewFromInt(20000), decimal.NewFromInt(60000), "single")))
+
+	// Gains are taxed at 15% rather than the 22% ordinary bracket they would otherwise fall in
+	calc := NewComprehensiveTaxCalculator()
+	wages := domain.TaxableIncome{Salary: decimal.NewFromInt(150000), WageIncome: decimal.NewFromInt(150000)}
+	withGains := wages
+	withGains.CapitalGains = decimal.NewFromInt(10000)
+	base := calc.calculateFederalTaxWithStatus(wages, "mfj", 0)
+	assert.True(t, decimal.NewFromInt(1500).Equal(calc.calculateFederalTaxWithStatus(withGains, "mfj", 0).Sub(base)))
+}
+
+func TestItemizedDeductionTotal(t *testing.T) {
+	items := &domain.ItemizedDeductions{
+		MortgageInterest:        decimal.NewFromInt(8000),
+		StateAndLocalTaxes:      decimal.NewFromInt(14000),
+		CharitableContributions: decimal.NewFromInt(5000),
+		MedicalExpenses:         decimal.NewFromInt(12000),
+	}
+	// SALT capped at $10k; medical above 7.5% of $100k AGI = $4,500
+	assert.True(t, decimal.NewFromInt(27500).Equal(ItemizedDeductionTotal(items, decimal.NewFromInt(100000))))
+	assert.True(t, ItemizedDeductionTotal(nil, decimal.NewFromInt(100000)).IsZero())
+}
+
+func TestItemizingLowersTaxForHighCharitableRetiree(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	ce := NewCalculationEngine()
+
+	standard := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	itemizing := *scenario
+	itemizing.ItemizedDeductions = &domain.ItemizedDeductions{
+		StateAndLocalTaxes:      decimal.NewFromInt(12000),
+		CharitableContributions: decimal.NewFromInt(30000),
+	}
+	itemized := ce.GenerateAnnualProjection(&personA, &personB, &itemizing, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	// $10k capped SALT plus $30k of gifts beats the $30k standard deduction by $10k
+	saved := standard[0].FederalTax.Sub(itemized[0].FederalTax)
+	assert.True(t, saved.GreaterThan(decimal.Zero), "expected itemizing to lower federal tax, saved %s", saved)
+	assert.True(t, saved.LessThanOrEqual(decimal.NewFromInt(2200)), "savings %s exceed $10k at the 22%% bracket", saved)
+	assert.True(t, decimal.NewFromInt(40000).Equal(itemized[0].FederalStandardDeduction), "expected the itemized deduction taken, got %s", itemized[0].FederalStandardDeduction)
+
+	// A small itemized total falls back to the standard deduction
+	itemizing.ItemizedDeductions = &domain.ItemizedDeductions{CharitableContributions: decimal.NewFromInt(1000)}
+	small := ce.GenerateAnnualProjection(&personA, &personB, &itemizing, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	assert.True(t, standard[0].FederalTax.Equal(small[0].FederalTax))
+	assert.True(t, standard[0].FederalStandardDeduction.Equal(small[0].FederalStandardDeduction))
+}
+
+// TestSeniorDeductionAt65Boundary checks the IRS rule that a person is 65 for a tax year when they turn 65
+// by January 1 of the following year
+func TestSeniorDeductionAt65Boundary(t *testing.T) {
+	jan1 := &domain.Employee{BirthDate: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)}
+	dec31 := &domain.Employee{BirthDate: time.Date(1960, 12, 31, 0, 0, 0, 0, time.UTC)}
+
+	// Born January 1, 1960: turns 65 on January 1, 2025, so is 65 for all of 2024
+	assert.False(t, isSeniorForTaxYear(jan1, 2023))
+	assert.True(t, isSeniorForTaxYear(jan1, 2024))
+	// Born December 31, 1960: turns 65 on the last day of 2025 and is 65 for 2025
+	assert.False(t, isSeniorForTaxYear(dec31, 2024))
+	assert.True(t, isSeniorForTaxYear(dec31, 2025))
+
+	// The projection's standard deduction follows the same rule
+	cfg, scenario := retiredCoupleTestConfig(2)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.BirthDate = time.Date(1960, 12, 31, 0, 0, 0, 0, time.UTC)
+	personB.BirthDate = time.Date(1961, 1, 1, 0, 0, 0, 0, time.UTC)
+	ce := NewCalculationEngine()
+	proj := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	// 2025: person A turns 65 on December 31 and person B on January 1, 2026
+	assert.Equal(t, 2, proj[0].FederalSeniors65Plus)
+	want := ce.TaxCalc.FederalTaxCalc
+	assert.True(t, proj[0].FederalStandardDeduction.Equal(want.StandardDeduction.Add(want.AdditionalStdDed.Mul(decimal.NewFromInt(2)))),
+		"expected two additional deductions, got %s", proj[0].FederalStandardDeduction)
+
+	// Born January 2, 1961, person B is not 65 until 2026
+	personB.BirthDate = time.Date(1961, 1, 2, 0, 0, 0, 0, time.UTC)
+	proj = ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	assert.Equal(t, 1, proj[0].FederalSeniors65Plus)
+	assert.Equal(t, 2, proj[1].FederalSeniors65Plus)
+}
+
+func TestLocalTaxSelectedByMunicipality(t *testing.T) {
+	ce := NewCalculationEngine()
+	ce.TaxCalc.LocalTaxRules = localTaxRules(domain.StateLocalTaxConfig{LocalTaxes: map[string]domain.LocalTaxRule{
+		"Doylestown Borough": {Rate: decimal.NewFromFloat(0.01)},
+		"Columbus":           {Rate: decimal.NewFromFloat(0.025), TaxesRetirementIncome: true},
+	}})
+
+	cfg, scenario := retiredCoupleTestConfig(2)
+	scenario.PersonA.RetirementDate = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.CurrentSalary = decimal.NewFromInt(100000)
+	run := func(municipality string) []domain.AnnualCashFlow {
+		assumptions := cfg.GlobalAssumptions
+		assumptions.CurrentLocation = domain.Location{Municipality: municipality}
+		a, b := personA, personB
+		return ce.GenerateAnnualProjection(&a, &b, scenario, &assumptions, assumptions.FederalRules)
+	}
+
+	// A municipality without a local tax, such as one outside Pennsylvania, pays none
+	if unlisted := run("Austin"); !unlisted[0].LocalTax.IsZero() || !unlisted[1].LocalTax.IsZero() {
+		t.Fatalf("expected no local tax in an unconfigured municipality, got %s and %s", unlisted[0].LocalTax, unlisted[1].LocalTax)
+	}
+
+	// A 1% Pennsylvania EIT taxes the 2025 salary but not the 2026 pension
+	pa := run("doylestown borough")
+	if !pa[0].LocalTax.Equal(decimal.NewFromInt(1000)) {
+		t.Fatalf("expected 1%% EIT on $100,000 of wages, got %s", pa[0].LocalTax)
+	}
+	if !pa[1].LocalTax.IsZero() {
+		t.Fatalf("expected no EIT on retirement income, got %s", pa[1].LocalTax)
+	}
+
+	// A locality that taxes retirement income taxes the pension and TSP withdrawals too
+	columbus := run("Columbus")
+	retirementIncome := columbus[1].PensionPersonA.Add(columbus[1].PensionPersonB).Add(columbus[1].TSPWithdrawalPersonA).Add(columbus[1].TSPWithdrawalPersonB)
+	if want := retirementIncome.Mul(decimal.NewFromFloat(0.025)); !columbus[1].LocalTax.Equal(want) {
+		t.Fatalf("expected 2.5%% of %s in retirement, got %s", retirementIncome, columbus[1].LocalTax)
+	}
+
+	// The pre-retirement baseline pays the same municipality's EIT as the projection
+	fehbConfig := cfg.GlobalAssumptions.FederalRules.FEHBConfig
+	untaxed := ce.NetIncomeCalc.Calculate(&personA, &personB, fehbConfig, ce.TaxCalc.LocalTaxCalculatorFor("Austin"), testProjectionStartYear, false)
+	taxed := ce.NetIncomeCalc.Calculate(&personA, &personB, fehbConfig, ce.TaxCalc.LocalTaxCalculatorFor("Doylestown Borough"), testProjectionStartYear, false)
+	if !untaxed.Sub(taxed).Equal(decimal.NewFromInt(1000)) {
+		t.Fatalf("expected the baseline to pay the 1%% Doylestown EIT on $100,000 of wages, got %s", untaxed.Sub(taxed))
+	}
+}
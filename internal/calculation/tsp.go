@@ -20,16 +20,26 @@ type FourPercentRule struct {
 	InflationRate            decimal.Decimal
 	InitialBalance           decimal.Decimal
 	FirstWithdrawalAmount    decimal.Decimal
+
+	// Year-by-year inflation from firstYear, the calendar year of withdrawal year 1; set by
+	// setInflationSchedule when the projection follows a market sequence
+	inflation *rateSchedule
+	firstYear int
 }
 
 // NewFourPercentRule creates a new FourPercentRule strategy
 func NewFourPercentRule(initialBalance decimal.Decimal, inflationRate decimal.Decimal) *FourPercentRule {
-	initialWithdrawal := initialBalance.Mul(decimal.NewFromFloat(0.04))
+	return NewInitialRateWithdrawal(initialBalance, decimal.NewFromFloat(0.04), inflationRate)
+}
+
+// NewInitialRateWithdrawal creates a FourPercentRule strategy that withdraws initialRate of the initial
+// balance in the first year instead of 4%, then the same amount adjusted for inflation
+func NewInitialRateWithdrawal(initialBalance, initialRate, inflationRate decimal.Decimal) *FourPercentRule {
 	return &FourPercentRule{
-		InitialWithdrawalPercent: decimal.NewFromFloat(0.04),
+		InitialWithdrawalPercent: initialRate,
 		InflationRate:            inflationRate,
 		InitialBalance:           initialBalance,
-		FirstWithdrawalAmount:    initialWithdrawal,
+		FirstWithdrawalAmount:    initialBalance.Mul(initialRate),
 	}
 }
 
@@ -39,6 +49,9 @@ func (fpr *FourPercentRule) CalculateWithdrawal(currentBalance decimal.Decimal,
 
 	if year == 1 {
 		withdrawal = fpr.FirstWithdrawalAmount
+	} else if fpr.inflation != nil {
+		// Inflate by each year's actual rate since the first withdrawal
+		withdrawal = fpr.FirstWithdrawalAmount.Mul(fpr.inflation.growth(fpr.firstYear, fpr.firstYear+year-1))
 	} else {
 		// Inflate previous year's withdrawal
 		inflationFactor := decimal.NewFromFloat(1).Add(fpr.InflationRate)
@@ -58,6 +71,22 @@ func (fpr *FourPercentRule) CalculateWithdrawal(currentBalance decimal.Decimal,
 	return withdrawal
 }
 
+// setInflationSchedule makes later withdrawals follow a year-by-year inflation schedule, with withdrawal
+// year 1 falling in calendar year firstYear
+func (fpr *FourPercentRule) setInflationSchedule(inflation rateSchedule, firstYear int) {
+	fpr.inflation, fpr.firstYear = &inflation, firstYear
+}
+
+// initialRateStrategy is the internal tsp_withdrawal_strategy for the 4% rule at the scenario's
+// tsp_withdrawal_rate, used by the withdrawal rate solver and comparisons. Configs cannot select it, so a
+// 4_percent_rule scenario withdraws 4% whatever tsp_withdrawal_rate it also sets.
+const initialRateStrategy = "initial_rate"
+
+// isInitialRateStrategy reports whether strategy withdraws a share of the initial balance raised with inflation
+func isInitialRateStrategy(strategy string) bool {
+	return strategy == "4_percent_rule" || strategy == initialRateStrategy
+}
+
 // GetStrategyName returns the name of this strategy
 func (fpr *FourPercentRule) GetStrategyName() string {
 	return "4_percent_rule"
@@ -66,6 +95,12 @@ func (fpr *FourPercentRule) GetStrategyName() string {
 // NeedBasedWithdrawal implements a strategy to withdraw based on a target monthly amount
 type NeedBasedWithdrawal struct {
 	TargetMonthlyWithdrawal decimal.Decimal
+	Net                     bool                  // Target is after tax; see GrossUpWithdrawal
+	Curve                   *domain.SpendingCurve // Optional age-based change to the target
+
+	// Inflation that indexes a curved target from firstYear, the calendar year of withdrawal year 1
+	inflation rateSchedule
+	firstYear int
 }
 
 // NewNeedBasedWithdrawal creates a new NeedBasedWithdrawal strategy
@@ -75,10 +110,29 @@ func NewNeedBasedWithdrawal(targetMonthly decimal.Decimal) *NeedBasedWithdrawal
 	}
 }
 
+// AnnualTarget returns the year's withdrawal target: twelve times the monthly target. Without a spending
+// curve the target stays flat in nominal dollars. With one, the curve's rates are real, so the target is
+// first indexed to inflation since the first withdrawal year and then scaled by the curve for the
+// retiree's age in the given (1-based) withdrawal year.
+func (nbw *NeedBasedWithdrawal) AnnualTarget(year, age int) decimal.Decimal {
+	annual := nbw.TargetMonthlyWithdrawal.Mul(decimal.NewFromInt(12))
+	if nbw.Curve == nil {
+		return annual
+	}
+	annual = annual.Mul(nbw.inflation.growth(nbw.firstYear, nbw.firstYear+year-1))
+	return annual.Mul(nbw.Curve.Factor(age-(year-1), age))
+}
+
+// setInflationSchedule indexes a curved target to a year-by-year inflation schedule, with withdrawal year 1
+// falling in calendar year firstYear
+func (nbw *NeedBasedWithdrawal) setInflationSchedule(inflation rateSchedule, firstYear int) {
+	nbw.inflation, nbw.firstYear = inflation, firstYear
+}
+
 // CalculateWithdrawal calculates the withdrawal amount based on target income
 func (nbw *NeedBasedWithdrawal) CalculateWithdrawal(currentBalance decimal.Decimal, year int, targetIncome decimal.Decimal, age int, isRMDYear bool, rmdAmount decimal.Decimal) decimal.Decimal {
 	// Calculate annual target withdrawal (this is the amount we want to withdraw)
-	annualTarget := nbw.TargetMonthlyWithdrawal.Mul(decimal.NewFromInt(12))
+	annualTarget := nbw.AnnualTarget(year, age)
 
 	// The withdrawal should be the target amount, not the gap
 	withdrawal := annualTarget
@@ -106,6 +160,36 @@ func (nbw *NeedBasedWithdrawal) GetStrategyName() string {
 	return "need_based"
 }
 
+// grossUpMaxIterations bounds the fixed-point search in GrossUpWithdrawal
+const grossUpMaxIterations = 100
+
+// grossUpTolerance is how close successive gross-up estimates must be to stop iterating
+var grossUpTolerance = decimal.NewFromFloat(0.005)
+
+// GrossUpWithdrawal raises a net (after-tax) withdrawal so that, once the tax it adds to the year's other
+// income is paid, the net amount remains. taxOn reports that added tax for a gross withdrawal. Withdrawals
+// above the year's target (set by an RMD) already cover the need and are returned unchanged, as are
+// withdrawals for strategies whose target is gross. The result never exceeds the available balance.
+func (nbw *NeedBasedWithdrawal) GrossUpWithdrawal(withdrawal, currentBalance decimal.Decimal, year, age int, taxOn func(gross decimal.Decimal) decimal.Decimal) decimal.Decimal {
+	if !nbw.Net || withdrawal.LessThanOrEqual(decimal.Zero) || withdrawal.GreaterThan(nbw.AnnualTarget(year, age)) {
+		return withdrawal
+	}
+
+	// Iterate gross = net + tax(gross); the marginal rate is below 100%, so this converges
+	gross := withdrawal
+	for i := 0; i < grossUpMaxIterations; i++ {
+		next := withdrawal.Add(taxOn(gross))
+		if next.GreaterThanOrEqual(currentBalance) {
+			return currentBalance
+		}
+		if next.Sub(gross).Abs().LessThan(grossUpTolerance) {
+			return next
+		}
+		gross = next
+	}
+	return gross
+}
+
 // VariablePercentageWithdrawal implements a strategy with a configurable percentage rate of current balance
 type VariablePercentageWithdrawal struct {
 	WithdrawalRate decimal.Decimal
@@ -141,6 +225,70 @@ func (vpw *VariablePercentageWithdrawal) GetStrategyName() string {
 	return "variable_percentage"
 }
 
+// WithdrawalTaxContext describes the rest of a year's household taxable income, so a strategy can
+// tell how much bracket headroom is left for traditional TSP withdrawals
+type WithdrawalTaxContext struct {
+	OtherTaxableIncome decimal.Decimal // Ordinary income already expected this year, before deductions
+	StandardDeduction  decimal.Decimal
+	Brackets           []TaxBracket // Ordinary brackets for the year's filing status
+}
+
+// TaxAwareWithdrawalStrategy is implemented by strategies that choose which TSP balance a withdrawal is
+// drawn from. Strategies that do not implement it have their withdrawals taxed as traditional.
+type TaxAwareWithdrawalStrategy interface {
+	TSPWithdrawalStrategy
+	SourceWithdrawal(withdrawal, traditional, roth, rmd decimal.Decimal, taxContext WithdrawalTaxContext) (fromTraditional, fromRoth decimal.Decimal)
+}
+
+// TaxSmartWithdrawal sizes withdrawals with the 4% rule, then fills taxable income up to the top of a
+// target bracket from traditional TSP and takes the rest from Roth. RMDs always come from traditional.
+type TaxSmartWithdrawal struct {
+	*FourPercentRule
+	BracketCeiling decimal.Decimal // Taxable income at the top of the target bracket
+}
+
+// NewTaxSmartWithdrawal creates a new TaxSmartWithdrawal strategy
+func NewTaxSmartWithdrawal(initialBalance, inflationRate, bracketCeiling decimal.Decimal) *TaxSmartWithdrawal {
+	return &TaxSmartWithdrawal{
+		FourPercentRule: NewFourPercentRule(initialBalance, inflationRate),
+		BracketCeiling:  bracketCeiling,
+	}
+}
+
+// SourceWithdrawal splits a withdrawal between traditional and Roth balances
+func (tsw *TaxSmartWithdrawal) SourceWithdrawal(withdrawal, traditional, roth, rmd decimal.Decimal, taxContext WithdrawalTaxContext) (decimal.Decimal, decimal.Decimal) {
+	headroom := tsw.BracketCeiling.Add(taxContext.StandardDeduction).Sub(taxContext.OtherTaxableIncome)
+	headroom = decimal.Max(headroom, decimal.Zero)
+
+	fromTraditional := decimal.Min(decimal.Max(decimal.Min(withdrawal, headroom), rmd), traditional)
+	fromRoth := decimal.Min(withdrawal.Sub(fromTraditional), roth)
+
+	// Fall back to traditional once Roth is exhausted
+	if shortfall := withdrawal.Sub(fromTraditional).Sub(fromRoth); shortfall.GreaterThan(decimal.Zero) {
+		fromTraditional = fromTraditional.Add(decimal.Min(shortfall, traditional.Sub(fromTraditional)))
+	}
+	return fromTraditional, fromRoth
+}
+
+// GetStrategyName returns the name of this strategy
+func (tsw *TaxSmartWithdrawal) GetStrategyName() string {
+	return "tax_smart"
+}
+
+// defaultTargetBracketRate is the bracket tax_smart fills with traditional withdrawals when none is configured
+var defaultTargetBracketRate = decimal.NewFromFloat(0.12)
+
+// bracketCeiling returns the top of the highest bracket taxed at or below rate
+func bracketCeiling(brackets []TaxBracket, rate decimal.Decimal) decimal.Decimal {
+	ceiling := decimal.Zero
+	for _, b := range brackets {
+		if b.Rate.LessThanOrEqual(rate) && b.Max.GreaterThan(ceiling) {
+			ceiling = b.Max
+		}
+	}
+	return ceiling
+}
+
 // RMDCalculator calculates Required Minimum Distributions
 type RMDCalculator struct {
 	BirthYear int
@@ -158,65 +306,114 @@ func (rmd *RMDCalculator) GetRMDAge() int {
 	return dateutil.GetRMDAge(rmd.BirthYear)
 }
 
-// CalculateRMD calculates the Required Minimum Distribution for a given age and balance
+// uniformLifetimeTable is the IRS Uniform Lifetime Table (Treas. Reg. 1.401(a)(9)-9(c)) effective for
+// distribution years beginning 2022. Age 72 is kept for pre-1951 birth years; 120 applies to all older ages.
+var uniformLifetimeTable = map[int]decimal.Decimal{
+	72:  decimal.NewFromFloat(27.4),
+	73:  decimal.NewFromFloat(26.5),
+	74:  decimal.NewFromFloat(25.5),
+	75:  decimal.NewFromFloat(24.6),
+	76:  decimal.NewFromFloat(23.7),
+	77:  decimal.NewFromFloat(22.9),
+	78:  decimal.NewFromFloat(22.0),
+	79:  decimal.NewFromFloat(21.1),
+	80:  decimal.NewFromFloat(20.2),
+	81:  decimal.NewFromFloat(19.4),
+	82:  decimal.NewFromFloat(18.5),
+	83:  decimal.NewFromFloat(17.7),
+	84:  decimal.NewFromFloat(16.8),
+	85:  decimal.NewFromFloat(16.0),
+	86:  decimal.NewFromFloat(15.2),
+	87:  decimal.NewFromFloat(14.4),
+	88:  decimal.NewFromFloat(13.7),
+	89:  decimal.NewFromFloat(12.9),
+	90:  decimal.NewFromFloat(12.2),
+	91:  decimal.NewFromFloat(11.5),
+	92:  decimal.NewFromFloat(10.8),
+	93:  decimal.NewFromFloat(10.1),
+	94:  decimal.NewFromFloat(9.5),
+	95:  decimal.NewFromFloat(8.9),
+	96:  decimal.NewFromFloat(8.4),
+	97:  decimal.NewFromFloat(7.8),
+	98:  decimal.NewFromFloat(7.3),
+	99:  decimal.NewFromFloat(6.8),
+	100: decimal.NewFromFloat(6.4),
+	101: decimal.NewFromFloat(6.0),
+	102: decimal.NewFromFloat(5.6),
+	103: decimal.NewFromFloat(5.2),
+	104: decimal.NewFromFloat(4.9),
+	105: decimal.NewFromFloat(4.6),
+	106: decimal.NewFromFloat(4.3),
+	107: decimal.NewFromFloat(4.1),
+	108: decimal.NewFromFloat(3.9),
+	109: decimal.NewFromFloat(3.7),
+	110: decimal.NewFromFloat(3.5),
+	111: decimal.NewFromFloat(3.4),
+	112: decimal.NewFromFloat(3.3),
+	113: decimal.NewFromFloat(3.1),
+	114: decimal.NewFromFloat(3.0),
+	115: decimal.NewFromFloat(2.9),
+	116: decimal.NewFromFloat(2.8),
+	117: decimal.NewFromFloat(2.7),
+	118: decimal.NewFromFloat(2.5),
+	119: decimal.NewFromFloat(2.3),
+	120: decimal.NewFromFloat(2.0),
+}
+
+const (
+	uniformLifetimeMinAge = 72
+	uniformLifetimeMaxAge = 120
+)
+
+// uniformLifetimeDivisor returns the distribution period for an age, clamping to the ends of the table
+func uniformLifetimeDivisor(age int) decimal.Decimal {
+	if age < uniformLifetimeMinAge {
+		age = uniformLifetimeMinAge
+	}
+	if age > uniformLifetimeMaxAge {
+		age = uniformLifetimeMaxAge
+	}
+	return uniformLifetimeTable[age]
+}
+
+// CalculateRMD calculates the Required Minimum Distribution for a given age and balance.
+// Only the traditional balance is subject to RMDs; Roth TSP balances are exempt under SECURE 2.0
+// (effective 2024) and must not be included in traditionalBalance.
 func (rmd *RMDCalculator) CalculateRMD(traditionalBalance decimal.Decimal, age int) decimal.Decimal {
 	if age < rmd.GetRMDAge() {
 		return decimal.Zero
 	}
 
-	// IRS Uniform Lifetime Table (simplified version)
-	distributionPeriods := map[int]decimal.Decimal{
-		72:  decimal.NewFromFloat(27.4),
-		73:  decimal.NewFromFloat(26.5),
-		74:  decimal.NewFromFloat(25.5),
-		75:  decimal.NewFromFloat(24.6),
-		76:  decimal.NewFromFloat(23.7),
-		77:  decimal.NewFromFloat(22.9),
-		78:  decimal.NewFromFloat(22.0),
-		79:  decimal.NewFromFloat(21.1),
-		80:  decimal.NewFromFloat(20.2),
-		81:  decimal.NewFromFloat(19.4),
-		82:  decimal.NewFromFloat(18.5),
-		83:  decimal.NewFromFloat(17.7),
-		84:  decimal.NewFromFloat(16.8),
-		85:  decimal.NewFromFloat(16.0),
-		86:  decimal.NewFromFloat(15.2),
-		87:  decimal.NewFromFloat(14.4),
-		88:  decimal.NewFromFloat(13.7),
-		89:  decimal.NewFromFloat(12.9),
-		90:  decimal.NewFromFloat(12.2),
-		91:  decimal.NewFromFloat(11.5),
-		92:  decimal.NewFromFloat(10.8),
-		93:  decimal.NewFromFloat(10.1),
-		94:  decimal.NewFromFloat(9.5),
-		95:  decimal.NewFromFloat(8.9),
-		96:  decimal.NewFromFloat(8.4),
-		97:  decimal.NewFromFloat(7.8),
-		98:  decimal.NewFromFloat(7.3),
-		99:  decimal.NewFromFloat(6.8),
-		100: decimal.NewFromFloat(6.4),
-	}
-
-	if period, exists := distributionPeriods[age]; exists {
-		return traditionalBalance.Div(period)
-	}
-
-	// For ages beyond 100, use a reasonable estimate
-	if age > 100 {
-		return traditionalBalance.Div(decimal.NewFromFloat(6.0))
-	}
-
-	return decimal.Zero
-}
-
-// createTSPStrategy creates a TSP withdrawal strategy based on scenario configuration
-func (ce *CalculationEngine) createTSPStrategy(scenario *domain.RetirementScenario, initialBalance decimal.Decimal, inflationRate decimal.Decimal) TSPWithdrawalStrategy {
+	return traditionalBalance.Div(uniformLifetimeDivisor(age))
+}
+
+// createTSPStrategy creates a TSP withdrawal strategy based on scenario configuration. Strategies that
+// raise withdrawals with inflation follow the schedule's year-by-year rates when it has them.
+func (ce *CalculationEngine) createTSPStrategy(employee *domain.Employee, scenario *domain.RetirementScenario, initialBalance decimal.Decimal, inflation rateSchedule) TSPWithdrawalStrategy {
+	strategy := ce.newTSPStrategy(employee, scenario, initialBalance, inflation.rate)
+	if scheduled, ok := strategy.(interface{ setInflationSchedule(rateSchedule, int) }); ok && len(inflation.byYear) > 0 {
+		scheduled.setInflationSchedule(inflation, scenario.RetirementDate.Year())
+	}
+	return strategy
+}
+
+// newTSPStrategy creates the configured TSP withdrawal strategy with a constant inflation rate
+func (ce *CalculationEngine) newTSPStrategy(employee *domain.Employee, scenario *domain.RetirementScenario, initialBalance decimal.Decimal, inflationRate decimal.Decimal) TSPWithdrawalStrategy {
 	switch scenario.TSPWithdrawalStrategy {
 	case "4_percent_rule":
 		return NewFourPercentRule(initialBalance, inflationRate)
+	case initialRateStrategy:
+		if scenario.TSPWithdrawalRate != nil {
+			return NewInitialRateWithdrawal(initialBalance, *scenario.TSPWithdrawalRate, inflationRate)
+		}
+		return NewFourPercentRule(initialBalance, inflationRate)
 	case "need_based":
 		if scenario.TSPWithdrawalTargetMonthly != nil {
-			return NewNeedBasedWithdrawal(*scenario.TSPWithdrawalTargetMonthly)
+			strategy := NewNeedBasedWithdrawal(*scenario.TSPWithdrawalTargetMonthly)
+			strategy.Net = scenario.TSPWithdrawalTargetNet
+			strategy.Curve = scenario.SpendingCurve
+			strategy.inflation = constantRate(inflationRate)
+			return strategy
 		}
 		// Fallback to 4% rule if target not specified
 		return NewFourPercentRule(initialBalance, inflationRate)
@@ -226,6 +423,14 @@ func (ce *CalculationEngine) createTSPStrategy(scenario *domain.RetirementScenar
 		}
 		// Fallback to 4% rule if rate not specified
 		return NewFourPercentRule(initialBalance, inflationRate)
+	case "tax_smart":
+		rate := defaultTargetBracketRate
+		if scenario.TSPTargetBracketRate != nil {
+			rate = *scenario.TSPTargetBracketRate
+		}
+		return NewTaxSmartWithdrawal(initialBalance, inflationRate, bracketCeiling(ce.TaxCalc.FederalTaxCalc.Brackets, rate))
+	case SSBridgeStrategy:
+		return newSSBridgeWithdrawal(employee, scenario, initialBalance, inflationRate)
 	default:
 		// Default to 4% rule
 		return NewFourPercentRule(initialBalance, inflationRate)
@@ -234,31 +439,43 @@ func (ce *CalculationEngine) createTSPStrategy(scenario *domain.RetirementScenar
 
 // updateTSPBalances updates TSP balances after withdrawal
 func (ce *CalculationEngine) updateTSPBalances(traditional, roth, withdrawal, returnRate decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	return ce.updateTSPBalancesWithRMD(traditional, roth, withdrawal, decimal.Zero, returnRate)
+}
+
+// withdrawSourced takes a withdrawal already split between traditional and Roth, flooring balances at zero
+func withdrawSourced(traditional, roth, fromTraditional, fromRoth decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	return decimal.Max(traditional.Sub(fromTraditional), decimal.Zero), decimal.Max(roth.Sub(fromRoth), decimal.Zero)
+}
+
+// updateTSPBalancesWithRMD updates TSP balances after withdrawal, taking at least the RMD from the
+// traditional balance (Roth TSP has no lifetime RMD under SECURE 2.0) before drawing Roth first
+func (ce *CalculationEngine) updateTSPBalancesWithRMD(traditional, roth, withdrawal, rmd, returnRate decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
 	// Apply growth first
 	traditional = traditional.Mul(decimal.NewFromFloat(1).Add(returnRate))
 	roth = roth.Mul(decimal.NewFromFloat(1).Add(returnRate))
 
-	// Withdraw from Roth first, then traditional
-	if withdrawal.LessThanOrEqual(roth) {
-		roth = roth.Sub(withdrawal)
-	} else {
-		remainingWithdrawal := withdrawal.Sub(roth)
-		roth = decimal.Zero
-		traditional = traditional.Sub(remainingWithdrawal)
-		if traditional.LessThan(decimal.Zero) {
-			traditional = decimal.Zero
-		}
-	}
+	fromTraditional, fromRoth := sourceRMDThenRoth(withdrawal, traditional, roth, rmd)
+	return withdrawSourced(traditional, roth, fromTraditional, fromRoth)
+}
 
-	// Ensure balances never go negative
-	if traditional.LessThan(decimal.Zero) {
-		traditional = decimal.Zero
-	}
-	if roth.LessThan(decimal.Zero) {
-		roth = decimal.Zero
-	}
+// sourceRMDThenRoth splits a withdrawal between traditional and Roth: the RMD (or other traditional minimum)
+// comes from traditional, the rest from Roth first and then traditional, never more than either balance holds
+func sourceRMDThenRoth(withdrawal, traditional, roth, rmd decimal.Decimal) (fromTraditional, fromRoth decimal.Decimal) {
+	traditional = decimal.Max(traditional, decimal.Zero)
+	roth = decimal.Max(roth, decimal.Zero)
+	fromTraditional = decimal.Max(decimal.Min(rmd, withdrawal, traditional), decimal.Zero)
+	remaining := decimal.Max(withdrawal.Sub(fromTraditional), decimal.Zero)
+	fromRoth = decimal.Min(remaining, roth)
+	fromTraditional = fromTraditional.Add(decimal.Min(remaining.Sub(fromRoth), traditional.Sub(fromTraditional)))
+	return fromTraditional, fromRoth
+}
 
-	return traditional, roth
+// sourceTraditionalFirst splits a withdrawal drawing traditional first and then Roth, never more than
+// either balance holds
+func sourceTraditionalFirst(withdrawal, traditional, roth decimal.Decimal) (fromTraditional, fromRoth decimal.Decimal) {
+	fromTraditional = decimal.Max(decimal.Min(withdrawal, traditional), decimal.Zero)
+	fromRoth = decimal.Max(decimal.Min(withdrawal.Sub(fromTraditional), roth), decimal.Zero)
+	return fromTraditional, fromRoth
 }
 
 // growTSPBalance grows a TSP balance with contributions and returns
@@ -267,15 +484,8 @@ func (ce *CalculationEngine) growTSPBalance(balance, contribution, returnRate de
 }
 
 // growTSPBalanceWithAllocation calculates TSP balance growth using lifecycle fund allocation data
-func (ce *CalculationEngine) growTSPBalanceWithAllocation(employee *domain.Employee, balance, contribution decimal.Decimal, targetDate time.Time) decimal.Decimal {
-	// Get the appropriate allocation for this date
-	allocation := ce.getTSPAllocationForEmployee(employee, targetDate)
-
-	// Calculate weighted return based on allocation
-	weightedReturn := ce.calculateTSPReturnWithAllocation(allocation, targetDate.Year())
-
-	// Apply growth with the weighted return
-	return balance.Add(contribution).Mul(decimal.NewFromFloat(1).Add(weightedReturn))
+func (ce *CalculationEngine) growTSPBalanceWithAllocation(employee *domain.Employee, balance, contribution decimal.Decimal, targetDate time.Time, yearIndex int) decimal.Decimal {
+	return ce.growTSPFunds(employee, TSPFundBalances{}, balance, contribution, targetDate, yearIndex).Total()
 }
 
 // getTSPAllocationForEmployee returns the TSP allocation for an employee at a specific date
@@ -307,72 +517,37 @@ func (ce *CalculationEngine) getTSPAllocationForEmployee(employee *domain.Employ
 }
 
 // calculateTSPReturnWithAllocation calculates TSP return using specific allocation and statistical models
-func (ce *CalculationEngine) calculateTSPReturnWithAllocation(allocation domain.TSPAllocation, year int) decimal.Decimal {
-	// Initialize return values
-	var cFundReturn, sFundReturn, iFundReturn, fFundReturn, gFundReturn decimal.Decimal
-
-	// Check if we have Monte Carlo fund returns available (higher priority than historical data)
-	usingMonteCarlo := len(ce.MonteCarloFundReturns) > 0
-
-	if usingMonteCarlo {
-		// Use Monte Carlo generated fund returns, fall back to historical/statistical for missing funds
-		if cReturn, exists := ce.MonteCarloFundReturns["C"]; exists {
-			cFundReturn = cReturn
-		} else {
-			cFundReturn = ce.getFallbackReturn("C", year)
-		}
-
-		if sReturn, exists := ce.MonteCarloFundReturns["S"]; exists {
-			sFundReturn = sReturn
-		} else {
-			sFundReturn = ce.getFallbackReturn("S", year)
-		}
-
-		if iReturn, exists := ce.MonteCarloFundReturns["I"]; exists {
-			iFundReturn = iReturn
-		} else {
-			iFundReturn = ce.getFallbackReturn("I", year)
-		}
-
-		if fReturn, exists := ce.MonteCarloFundReturns["F"]; exists {
-			fFundReturn = fReturn
-		} else {
-			fFundReturn = ce.getFallbackReturn("F", year)
-		}
-
-		if gReturn, exists := ce.MonteCarloFundReturns["G"]; exists {
-			gFundReturn = gReturn
-		} else {
-			gFundReturn = ce.getFallbackReturn("G", year)
-		}
-	} else {
-		// Use historical data or statistical models for all funds
-		cFundReturn = ce.getFallbackReturn("C", year)
-		sFundReturn = ce.getFallbackReturn("S", year)
-		iFundReturn = ce.getFallbackReturn("I", year)
-		fFundReturn = ce.getFallbackReturn("F", year)
-		gFundReturn = ce.getFallbackReturn("G", year)
-	}
+// for projection year yearIndex, which falls in calendar year year
+func (ce *CalculationEngine) calculateTSPReturnWithAllocation(allocation domain.TSPAllocation, yearIndex, year int) decimal.Decimal {
+	returns := ce.tspFundReturns(yearIndex, year)
 
 	// Weighted return calculation using actual allocation
 	weightedReturn := decimal.Zero
+	weightedReturn = weightedReturn.Add(allocation.CFund.Mul(returns["C"])) // C Fund (Large Cap)
+	weightedReturn = weightedReturn.Add(allocation.SFund.Mul(returns["S"])) // S Fund (Small Cap)
+	weightedReturn = weightedReturn.Add(allocation.IFund.Mul(returns["I"])) // I Fund (International)
+	weightedReturn = weightedReturn.Add(allocation.FFund.Mul(returns["F"])) // F Fund (Bonds)
+	weightedReturn = weightedReturn.Add(allocation.GFund.Mul(returns["G"])) // G Fund (Government)
 
-	// C Fund (Large Cap)
-	weightedReturn = weightedReturn.Add(allocation.CFund.Mul(cFundReturn))
-
-	// S Fund (Small Cap)
-	weightedReturn = weightedReturn.Add(allocation.SFund.Mul(sFundReturn))
-
-	// I Fund (International)
-	weightedReturn = weightedReturn.Add(allocation.IFund.Mul(iFundReturn))
-
-	// F Fund (Bonds)
-	weightedReturn = weightedReturn.Add(allocation.FFund.Mul(fFundReturn))
+	return weightedReturn
+}
 
-	// G Fund (Government)
-	weightedReturn = weightedReturn.Add(allocation.GFund.Mul(gFundReturn))
+// monteCarloFundReturnsForYear returns the Monte Carlo fund returns for a projection year index,
+// preferring the per-year series when one has been supplied
+func (ce *CalculationEngine) monteCarloFundReturnsForYear(yearIndex int) map[string]decimal.Decimal {
+	if yearIndex >= 0 && yearIndex < len(ce.MonteCarloFundReturnsByYear) {
+		return ce.MonteCarloFundReturnsByYear[yearIndex]
+	}
+	return ce.MonteCarloFundReturns
+}
 
-	return weightedReturn
+// portfolioReturnForYear returns the per-year Monte Carlo portfolio return for a projection year index,
+// or defaultReturn when no series is set
+func (ce *CalculationEngine) portfolioReturnForYear(yearIndex int, defaultReturn decimal.Decimal) decimal.Decimal {
+	if yearIndex >= 0 && yearIndex < len(ce.MonteCarloPortfolioReturnsByYear) {
+		return ce.MonteCarloPortfolioReturnsByYear[yearIndex]
+	}
+	return defaultReturn
 }
 
 // getFallbackReturn gets historical or statistical fallback return for a fund
@@ -410,8 +585,8 @@ func SimulateTSPGrowthPreRetirement(initialBalance decimal.Decimal, annualContri
 	return currentBalance
 }
 
-// ProjectTSP projects TSP balances and withdrawals over multiple years
-func ProjectTSP(initialBalance decimal.Decimal, strategy TSPWithdrawalStrategy, returnRate decimal.Decimal, years int, birthYear int, targetIncome []decimal.Decimal) []domain.TSPProjection {
+// ProjectTSP projects TSP balances and withdrawals over multiple years starting in calendar year startYear
+func ProjectTSP(initialBalance decimal.Decimal, strategy TSPWithdrawalStrategy, returnRate decimal.Decimal, startYear, years int, birthYear int, targetIncome []decimal.Decimal) []domain.TSPProjection {
 	projections := make([]domain.TSPProjection, years)
 	currentBalance := initialBalance
 	rmdCalc := NewRMDCalculator(birthYear)
@@ -421,7 +596,7 @@ func ProjectTSP(initialBalance decimal.Decimal, strategy TSPWithdrawalStrategy,
 		growth := currentBalance.Mul(returnRate)
 
 		// Determine if this is an RMD year
-		age := birthYear + year - 1
+		age := startYear + year - 1 - birthYear
 		isRMDYear := age >= rmdCalc.GetRMDAge()
 		rmdAmount := rmdCalc.CalculateRMD(currentBalance, age)
 
@@ -456,8 +631,9 @@ func ProjectTSP(initialBalance decimal.Decimal, strategy TSPWithdrawalStrategy,
 	return projections
 }
 
-// ProjectTSPWithTraditionalRoth projects TSP balances separately for Traditional and Roth accounts
-func ProjectTSPWithTraditionalRoth(initialTraditional decimal.Decimal, initialRoth decimal.Decimal, strategy TSPWithdrawalStrategy, returnRate decimal.Decimal, years int, birthYear int, targetIncome []decimal.Decimal) ([]decimal.Decimal, []decimal.Decimal, []decimal.Decimal) {
+// ProjectTSPWithTraditionalRoth projects TSP balances separately for Traditional and Roth accounts, starting
+// in calendar year startYear
+func ProjectTSPWithTraditionalRoth(initialTraditional decimal.Decimal, initialRoth decimal.Decimal, strategy TSPWithdrawalStrategy, returnRate decimal.Decimal, startYear, years int, birthYear int, targetIncome []decimal.Decimal) ([]decimal.Decimal, []decimal.Decimal, []decimal.Decimal) {
 	traditionalBalances := make([]decimal.Decimal, years)
 	rothBalances := make([]decimal.Decimal, years)
 	withdrawals := make([]decimal.Decimal, years)
@@ -472,7 +648,7 @@ func ProjectTSPWithTraditionalRoth(initialTraditional decimal.Decimal, initialRo
 		rothGrowth := currentRoth.Mul(returnRate)
 
 		// Determine if this is an RMD year (only affects Traditional)
-		age := birthYear + year - 1
+		age := startYear + year - 1 - birthYear
 		isRMDYear := age >= rmdCalc.GetRMDAge()
 		rmdAmount := rmdCalc.CalculateRMD(currentTraditional, age)
 
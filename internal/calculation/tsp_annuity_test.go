@@ -0,0 +1,94 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestEstimateTSPAnnuityMonthlyPayment(t *testing.T) {
+	balance := decimal.NewFromInt(300000)
+	inflation := decimal.NewFromFloat(0.025)
+	single := EstimateTSPAnnuityMonthlyPayment(balance, 62, 60, nil, inflation)
+	joint := EstimateTSPAnnuityMonthlyPayment(balance, 62, 60, &domain.TSPAnnuityElection{SurvivorPercentage: decimal.NewFromInt(1)}, inflation)
+	indexed := EstimateTSPAnnuityMonthlyPayment(balance, 62, 60, &domain.TSPAnnuityElection{InflationIndexed: true}, inflation)
+	older := EstimateTSPAnnuityMonthlyPayment(balance, 70, 60, nil, inflation)
+
+	if single.LessThan(decimal.NewFromInt(1400)) || single.GreaterThan(decimal.NewFromInt(2000)) {
+		t.Fatalf("expected a single-life payout near $1,700/month for $300k at 62, got %s", single)
+	}
+	if !joint.LessThan(single) || !indexed.LessThan(single) || !older.GreaterThan(single) {
+		t.Fatalf("unexpected payout ordering: single=%s joint=%s indexed=%s age70=%s", single, joint, indexed, older)
+	}
+}
+
+func TestTSPAnnuityReplacesWithdrawals(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(10)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	scenario.PersonA.TSPWithdrawalStrategy = TSPAnnuityStrategy
+	scenario.PersonA.TSPAnnuity = &domain.TSPAnnuityElection{SurvivorPercentage: decimal.NewFromFloat(0.5)}
+	ce := NewCalculationEngine()
+
+	projection := ce.GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	expected := EstimateTSPAnnuityMonthlyPayment(personA.TotalTSPBalance(), projection[0].AgePersonA, projection[0].AgePersonB, scenario.PersonA.TSPAnnuity, cfg.GlobalAssumptions.InflationRate).Mul(decimal.NewFromInt(12))
+	for _, cf := range projection {
+		if !cf.TSPBalancePersonA.IsZero() || !cf.TSPWithdrawalPersonA.IsZero() {
+			t.Fatalf("year %d: expected no TSP balance or withdrawals after annuitization, got balance %s withdrawal %s", cf.Date.Year(), cf.TSPBalancePersonA, cf.TSPWithdrawalPersonA)
+		}
+		if !cf.TSPAnnuityPersonA.Equal(expected) {
+			t.Fatalf("year %d: expected level annuity income %s, got %s", cf.Date.Year(), expected, cf.TSPAnnuityPersonA)
+		}
+	}
+}
+
+func TestTSPAnnuitySurvivorContinuation(t *testing.T) {
+	contract := purchaseTSPAnnuity(decimal.NewFromInt(200000), decimal.NewFromInt(100000), 0, 62, 60,
+		&domain.TSPAnnuityElection{SurvivorPercentage: decimal.NewFromFloat(0.5), InflationIndexed: true}, decimal.NewFromFloat(0.05))
+
+	first, taxable := contract.paymentForYear(0, true, true)
+	if !taxable.Round(2).Equal(first.Mul(decimal.NewFromInt(2)).Div(decimal.NewFromInt(3)).Round(2)) {
+		t.Fatalf("expected the Roth third of the payment to be tax-free, got %s of %s taxable", taxable, first)
+	}
+	second, _ := contract.paymentForYear(1, true, true)
+	if !second.Equal(first.Mul(decimal.NewFromFloat(1.03))) {
+		t.Fatalf("expected increases capped at 3%%: %s -> %s", first, second)
+	}
+	survivor, _ := contract.paymentForYear(1, false, true)
+	if !survivor.Equal(second.Mul(decimal.NewFromFloat(0.5))) {
+		t.Fatalf("expected half the payment to continue to the survivor, got %s of %s", survivor, second)
+	}
+	if none, _ := contract.paymentForYear(1, false, false); !none.IsZero() {
+		t.Fatalf("expected no payment after both deaths, got %s", none)
+	}
+}
+
+func TestTSPAnnuityIsNotDepletion(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(10)
+	scenario.PersonA.TSPWithdrawalStrategy = TSPAnnuityStrategy
+	scenario.PersonA.TSPAnnuity = &domain.TSPAnnuityElection{SurvivorPercentage: decimal.NewFromFloat(0.5)}
+	ce := NewCalculationEngine()
+
+	summary, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("RunScenario failed: %v", err)
+	}
+	if !summary.TSPAnnuitized {
+		t.Fatalf("expected the summary to record the TSP annuity")
+	}
+	if summary.TSPLongevity != len(summary.Projection) {
+		t.Fatalf("expected annuitized TSP money to last the projection (%d years), got longevity %d", len(summary.Projection), summary.TSPLongevity)
+	}
+	if !summary.SuccessRate.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("expected a 100%% deterministic success rate for an annuitized TSP, got %s", summary.SuccessRate)
+	}
+
+	summaries := []*domain.ScenarioSummary{summary}
+	for _, criteria := range []SuccessCriteria{{}, {Mode: SuccessBalanceAtAge, TargetAge: 70}} {
+		fmce := &FERSMonteCarloEngine{config: FERSMonteCarloConfig{SuccessCriteria: criteria}}
+		if !fmce.determineSuccess(summaries) {
+			t.Fatalf("expected Monte Carlo success under %q for an annuitized TSP", criteria.Mode)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package calculation
+
+import (
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// TSPFundBalances holds a single TSP account's balance broken out by fund
+type TSPFundBalances struct {
+	C decimal.Decimal `json:"c"`
+	S decimal.Decimal `json:"s"`
+	I decimal.Decimal `json:"i"`
+	F decimal.Decimal `json:"f"`
+	G decimal.Decimal `json:"g"`
+}
+
+// NewTSPFundBalances splits total across funds according to allocation. Allocation weights are
+// normalized so they need not sum exactly to 1; an all-zero allocation places everything in G.
+func NewTSPFundBalances(total decimal.Decimal, allocation domain.TSPAllocation) TSPFundBalances {
+	sum := allocation.CFund.Add(allocation.SFund).Add(allocation.IFund).Add(allocation.FFund).Add(allocation.GFund)
+	if sum.LessThanOrEqual(decimal.Zero) {
+		return TSPFundBalances{G: total}
+	}
+	share := func(weight decimal.Decimal) decimal.Decimal {
+		return total.Mul(weight).Div(sum)
+	}
+	return TSPFundBalances{
+		C: share(allocation.CFund),
+		S: share(allocation.SFund),
+		I: share(allocation.IFund),
+		F: share(allocation.FFund),
+		G: share(allocation.GFund),
+	}
+}
+
+// Total returns the combined balance across all funds
+func (b TSPFundBalances) Total() decimal.Decimal {
+	return b.C.Add(b.S).Add(b.I).Add(b.F).Add(b.G)
+}
+
+// Rebalance redistributes the current total to match the target allocation
+func (b TSPFundBalances) Rebalance(allocation domain.TSPAllocation) TSPFundBalances {
+	return NewTSPFundBalances(b.Total(), allocation)
+}
+
+// Deposit adds amount split according to allocation
+func (b TSPFundBalances) Deposit(amount decimal.Decimal, allocation domain.TSPAllocation) TSPFundBalances {
+	return b.Add(NewTSPFundBalances(amount, allocation))
+}
+
+// Add returns the fund-by-fund sum of two balances
+func (b TSPFundBalances) Add(other TSPFundBalances) TSPFundBalances {
+	return TSPFundBalances{
+		C: b.C.Add(other.C),
+		S: b.S.Add(other.S),
+		I: b.I.Add(other.I),
+		F: b.F.Add(other.F),
+		G: b.G.Add(other.G),
+	}
+}
+
+// Grow applies each fund's own annual return
+func (b TSPFundBalances) Grow(returns map[string]decimal.Decimal) TSPFundBalances {
+	one := decimal.NewFromInt(1)
+	return TSPFundBalances{
+		C: b.C.Mul(one.Add(returns["C"])),
+		S: b.S.Mul(one.Add(returns["S"])),
+		I: b.I.Mul(one.Add(returns["I"])),
+		F: b.F.Mul(one.Add(returns["F"])),
+		G: b.G.Mul(one.Add(returns["G"])),
+	}
+}
+
+// AsMap returns the balances keyed by fund letter
+func (b TSPFundBalances) AsMap() map[string]decimal.Decimal {
+	return map[string]decimal.Decimal{"C": b.C, "S": b.S, "I": b.I, "F": b.F, "G": b.G}
+}
+
+// tspFundReturns returns the return for every fund in projection year yearIndex, which falls in calendar
+// year year, preferring Monte Carlo draws and falling back to historical or statistical values for any
+// missing fund
+func (ce *CalculationEngine) tspFundReturns(yearIndex, year int) map[string]decimal.Decimal {
+	mcReturns := ce.monteCarloFundReturnsForYear(yearIndex)
+	returns := make(map[string]decimal.Decimal, len(tspFundOrder))
+	for _, fund := range tspFundOrder {
+		if r, ok := mcReturns[fund]; ok {
+			returns[fund] = r
+		} else {
+			returns[fund] = ce.getFallbackReturn(fund, year)
+		}
+	}
+	return returns
+}
+
+// scaledTo returns the balances resized to total with the fund mix unchanged, so withdrawals, transfers
+// and other changes to the account total come out of each fund in proportion to its balance. An account
+// with no fund balances yet starts at allocation.
+func (b TSPFundBalances) scaledTo(total decimal.Decimal, allocation domain.TSPAllocation) TSPFundBalances {
+	current := b.Total()
+	if !current.IsPositive() {
+		return NewTSPFundBalances(total, allocation)
+	}
+	if current.Equal(total) {
+		return b
+	}
+	ratio := total.Div(current)
+	return TSPFundBalances{
+		C: b.C.Mul(ratio),
+		S: b.S.Mul(ratio),
+		I: b.I.Mul(ratio),
+		F: b.F.Mul(ratio),
+		G: b.G.Mul(ratio),
+	}
+}
+
+// tspRebalanceDue reports whether the employee's TSP is brought back to its target allocation in projection
+// year yearIndex. Lifecycle funds rebalance inside the fund, so they are rebalanced every year.
+func tspRebalanceDue(employee *domain.Employee, yearIndex int) bool {
+	if employee.TSPLifecycleFund != nil || employee.TSPRebalanceYears <= 1 {
+		return true
+	}
+	return yearIndex%employee.TSPRebalanceYears == 0
+}
+
+// growTSPFunds carries an account's fund balances forward one year. funds is resized to the account's
+// current balance, rebalanced to the employee's target allocation for the date when a rebalance is due,
+// the contribution is added at the target allocation, and each fund grows at its own return.
+func (ce *CalculationEngine) growTSPFunds(employee *domain.Employee, funds TSPFundBalances, balance, contribution decimal.Decimal, targetDate time.Time, yearIndex int) TSPFundBalances {
+	allocation := ce.getTSPAllocationForEmployee(employee, targetDate)
+	funds = funds.scaledTo(balance, allocation)
+	if tspRebalanceDue(employee, yearIndex) {
+		funds = funds.Rebalance(allocation)
+	}
+	return funds.Deposit(contribution, allocation).Grow(ce.tspFundReturns(yearIndex, targetDate.Year()))
+}
@@ -0,0 +1,112 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewTSPFundBalancesNormalizesAllocation(t *testing.T) {
+	alloc := domain.TSPAllocation{CFund: decimal.NewFromInt(60), GFund: decimal.NewFromInt(40)}
+	funds := NewTSPFundBalances(decimal.NewFromInt(1000), alloc)
+	if !funds.C.Equal(decimal.NewFromInt(600)) || !funds.G.Equal(decimal.NewFromInt(400)) {
+		t.Fatalf("unexpected split: %+v", funds)
+	}
+	if !funds.Total().Equal(decimal.NewFromInt(1000)) {
+		t.Fatalf("total not preserved: %s", funds.Total())
+	}
+
+	empty := NewTSPFundBalances(decimal.NewFromInt(500), domain.TSPAllocation{})
+	if !empty.G.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("expected zero allocation to default to G fund, got %+v", empty)
+	}
+}
+
+// Test that single-fund allocations grow at exactly that fund's return
+func TestPerFundGrowthSingleFundAllocations(t *testing.T) {
+	one := decimal.NewFromInt(1)
+	cases := []struct {
+		name       string
+		allocation domain.TSPAllocation
+		fund       string
+	}{
+		{"100% G", domain.TSPAllocation{GFund: one}, "G"},
+		{"100% C", domain.TSPAllocation{CFund: one}, "C"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ce := NewCalculationEngine()
+			allocation := tc.allocation
+			personA := domain.Employee{
+				Name:                  "PersonA",
+				BirthDate:             time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				HireDate:              time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+				TSPBalanceTraditional: decimal.NewFromInt(100000),
+				TSPAllocation:         &allocation,
+			}
+			personB := domain.Employee{
+				Name:      "PersonB",
+				BirthDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				HireDate:  time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+			retire := time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC)
+			scenario := domain.Scenario{
+				Name:    "per-fund",
+				PersonA: domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retire, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+				PersonB: domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: retire, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"},
+			}
+			assumptions := domain.GlobalAssumptions{ProjectionYears: 2}
+
+			proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &assumptions, assumptions.FederalRules)
+			rate := ce.tspFundReturns(0, testProjectionStartYear)[tc.fund]
+			expected := decimal.NewFromInt(100000).Mul(one.Add(rate))
+
+			if proj[0].TSPBalancePersonA.Sub(expected).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+				t.Fatalf("expected balance %s growing at %s fund rate, got %s", expected.StringFixed(2), tc.fund, proj[0].TSPBalancePersonA.StringFixed(2))
+			}
+			if !proj[0].TSPFundBalancesPersonA[tc.fund].Equal(proj[0].TSPBalancePersonA) {
+				t.Fatalf("expected all dollars in %s fund, got %v", tc.fund, proj[0].TSPFundBalancesPersonA)
+			}
+		})
+	}
+}
+
+// Test that fund balances drift with their own returns between rebalances and return to target on schedule
+func TestGrowTSPFundsDriftsBetweenRebalances(t *testing.T) {
+	ce := NewCalculationEngine()
+	ce.MonteCarloFundReturns = map[string]decimal.Decimal{
+		"C": decimal.NewFromFloat(0.5), "S": decimal.Zero, "I": decimal.Zero, "F": decimal.Zero, "G": decimal.Zero,
+	}
+	half := decimal.NewFromFloat(0.5)
+	allocation := domain.TSPAllocation{CFund: half, GFund: half}
+	employee := &domain.Employee{TSPAllocation: &allocation, TSPRebalanceYears: 2}
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Year 0 rebalances: 500 C grows to 750, 500 G stays
+	funds := ce.growTSPFunds(employee, TSPFundBalances{}, decimal.NewFromInt(1000), decimal.Zero, date, 0)
+	if !funds.C.Equal(decimal.NewFromInt(750)) || !funds.G.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("year 0: expected C 750 and G 500, got %+v", funds)
+	}
+
+	// Year 1 is not a rebalance year: the 60/40 drift carries forward and C keeps compounding
+	funds = ce.growTSPFunds(employee, funds, funds.Total(), decimal.Zero, date.AddDate(1, 0, 0), 1)
+	if !funds.C.Equal(decimal.NewFromInt(1125)) || !funds.G.Equal(decimal.NewFromInt(500)) {
+		t.Fatalf("year 1: expected drifted C 1125 and G 500, got %+v", funds)
+	}
+
+	// Year 2 rebalances the 1625 total back to 50/50 before growth
+	funds = ce.growTSPFunds(employee, funds, funds.Total(), decimal.Zero, date.AddDate(2, 0, 0), 2)
+	if !funds.C.Equal(decimal.NewFromFloat(1218.75)) || !funds.G.Equal(decimal.NewFromFloat(812.5)) {
+		t.Fatalf("year 2: expected rebalanced C 1218.75 and G 812.50, got %+v", funds)
+	}
+
+	// A withdrawal between years comes out of each fund in proportion to its drifted balance
+	drifted := TSPFundBalances{C: decimal.NewFromInt(600), G: decimal.NewFromInt(400)}
+	scaled := drifted.scaledTo(decimal.NewFromInt(500), allocation)
+	if !scaled.C.Equal(decimal.NewFromInt(300)) || !scaled.G.Equal(decimal.NewFromInt(200)) {
+		t.Fatalf("expected proportional withdrawal to leave C 300 and G 200, got %+v", scaled)
+	}
+}
@@ -0,0 +1,34 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRothContributionSplitGrowsBothBalances(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(4)
+	cfg.GlobalAssumptions.TSPReturnPreRetirement = decimal.Zero
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.CurrentSalary = decimal.NewFromInt(100000)
+	personA.TSPContributionPercent = decimal.NewFromFloat(0.10)
+	personA.TSPRothContributionPercent = decimal.NewFromFloat(0.5)
+	retire := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario.PersonA.RetirementDate = retire
+	scenario.PersonB.RetirementDate = retire
+
+	projection := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	// Each working year $5,000 of the 10% deferral goes to Roth; the other $5,000 and the $5,000 match go to traditional
+	for year := 1; year < 3; year++ {
+		tradGain := projection[year].TSPBalanceTraditional.Sub(projection[year-1].TSPBalanceTraditional)
+		rothGain := projection[year].TSPBalanceRoth.Sub(projection[year-1].TSPBalanceRoth)
+		if !rothGain.Equal(decimal.NewFromInt(5000)) {
+			t.Fatalf("year %d: expected Roth to grow by 5000, got %s", year, rothGain)
+		}
+		if !tradGain.Equal(decimal.NewFromInt(10000)) {
+			t.Fatalf("year %d: expected traditional to grow by 10000, got %s", year, tradGain)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package calculation
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
@@ -183,6 +186,29 @@ func TestRMDCalculationExamples(t *testing.T) {
 	}
 }
 
+// TestUniformLifetimeTable2022 checks divisors against the published 2022 IRS Uniform Lifetime Table
+func TestUniformLifetimeTable2022(t *testing.T) {
+	published := map[int]float64{
+		73: 26.5, 75: 24.6, 80: 20.2, 85: 16.0, 90: 12.2, 95: 8.9,
+		100: 6.4, 105: 4.6, 110: 3.5, 115: 2.9, 119: 2.3, 120: 2.0,
+	}
+	for age, divisor := range published {
+		assert.True(t, uniformLifetimeDivisor(age).Equal(decimal.NewFromFloat(divisor)),
+			"age %d: expected divisor %.1f, got %s", age, divisor, uniformLifetimeDivisor(age))
+	}
+
+	for age := uniformLifetimeMinAge; age <= uniformLifetimeMaxAge; age++ {
+		_, ok := uniformLifetimeTable[age]
+		assert.True(t, ok, "table missing age %d", age)
+	}
+
+	// Ages past the end of the table use the 120+ divisor
+	calculator := NewRMDCalculator(1960)
+	balance := decimal.NewFromInt(100000)
+	assert.True(t, calculator.CalculateRMD(balance, 125).Equal(decimal.NewFromInt(50000)),
+		"age 125 should use the 120+ divisor of 2.0")
+}
+
 // TestTSPWithdrawalWithRMD tests TSP withdrawals when RMD is required
 func TestTSPWithdrawalWithRMD(t *testing.T) {
 	strategy := NewFourPercentRule(decimal.NewFromInt(1000000), decimal.NewFromFloat(0.025))
@@ -210,7 +236,7 @@ func TestTSPBalanceDepletion(t *testing.T) {
 	strategy := NewFourPercentRule(initialBalance, decimal.NewFromFloat(0.025))
 	returnRate := decimal.NewFromFloat(0.05) // 5% annual return
 
-	projections := ProjectTSP(initialBalance, strategy, returnRate, 10, 1960, nil)
+	projections := ProjectTSP(initialBalance, strategy, returnRate, testProjectionStartYear, 10, 1960, nil)
 
 	assert.Len(t, projections, 10, "Should have 10 years of projections")
 
@@ -246,8 +272,9 @@ func TestTSPProjectionWithTraditionalRoth(t *testing.T) {
 	strategy := NewFourPercentRule(initialTraditional.Add(initialRoth), decimal.NewFromFloat(0.025))
 	returnRate := decimal.NewFromFloat(0.05)
 
+	// Born in 1940, PersonA is 85 to 89: RMDs of over 6% exceed the return and draw the balance down
 	traditionalBalances, rothBalances, withdrawals := ProjectTSPWithTraditionalRoth(
-		initialTraditional, initialRoth, strategy, returnRate, 5, 1965, nil)
+		initialTraditional, initialRoth, strategy, returnRate, testProjectionStartYear, 5, 1940, nil)
 
 	assert.Len(t, traditionalBalances, 5, "Should have 5 years of traditional projections")
 	assert.Len(t, rothBalances, 5, "Should have 5 years of Roth projections")
@@ -263,3 +290,76 @@ func TestTSPProjectionWithTraditionalRoth(t *testing.T) {
 			"Year %d Traditional balance should decrease", i+1)
 	}
 }
+
+func TestProjectionCapsTSPContributionsAtElectiveDeferralLimit(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.CurrentSalary = decimal.NewFromInt(400000)
+	personA.TSPContributionPercent = decimal.NewFromFloat(0.20)
+	scenario.PersonA.RetirementDate = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenario.PersonB.RetirementDate = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	projection := NewCalculationEngine().GenerateAnnualProjection(&personA, &personB, scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+
+	// 20% of $400k is $80k; at 62 the employee share is capped at $23,500 + $11,250 catch-up, plus the 5% match
+	want := decimal.NewFromInt(23500 + 11250 + 20000)
+	if !projection[0].TSPContributions.Equal(want) {
+		t.Fatalf("expected capped contributions of %s, got %s", want, projection[0].TSPContributions)
+	}
+}
+
+func TestCurrentNetIncomeCapsTSPContributionsAtConfiguredLimit(t *testing.T) {
+	cfg, _ := retiredCoupleTestConfig(3)
+	personA, personB := cfg.PersonalDetails["person_a"], cfg.PersonalDetails["person_b"]
+	personA.CurrentSalary = decimal.NewFromInt(400000)
+	personA.TSPContributionPercent = decimal.NewFromFloat(0.20)
+
+	rules := domain.FederalRules{TSPContributionLimits: domain.TSPContributionLimits{
+		ElectiveDeferralLimit: decimal.NewFromInt(20000),
+		SuperCatchUpLimit:     decimal.NewFromInt(10000),
+	}}
+	configured := NewCalculationEngineWithConfig(rules)
+	defaults := NewCalculationEngineWithConfig(domain.FederalRules{})
+	netIncome := func(ce *CalculationEngine) decimal.Decimal {
+		return ce.NetIncomeCalc.Calculate(&personA, &personB, rules.FEHBConfig, ce.TaxCalc.LocalTaxCalc, testProjectionStartYear, false)
+	}
+
+	// At 62 the default cap is $23,500 + $11,250 and the configured one $20,000 + $10,000
+	if diff := netIncome(configured).Sub(netIncome(defaults)); !diff.Equal(decimal.NewFromInt(4750)) {
+		t.Fatalf("expected the configured limits to leave $4750 more take-home pay, got %s", diff)
+	}
+}
+
+func TestNeedBasedNetTargetGrossesUpForTax(t *testing.T) {
+	runFirstYear := func(targetMonthly int64, net bool) domain.AnnualCashFlow {
+		cfg, scenario := retiredCoupleTestConfig(3)
+		target := decimal.NewFromInt(targetMonthly)
+		scenario.PersonA.TSPWithdrawalStrategy = "need_based"
+		scenario.PersonA.TSPWithdrawalTargetMonthly = &target
+		scenario.PersonA.TSPWithdrawalTargetNet = net
+		summary, err := NewCalculationEngine().RunScenario(context.Background(), cfg, scenario)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return summary.Projection[0]
+	}
+	incomeTax := func(cf domain.AnnualCashFlow) decimal.Decimal {
+		return cf.FederalTax.Add(cf.StateTax).Add(cf.LocalTax)
+	}
+
+	base := runFirstYear(0, false)
+	gross := runFirstYear(2000, false)
+	net := runFirstYear(2000, true)
+	want := decimal.NewFromInt(24000)
+
+	if !gross.TSPWithdrawalPersonA.Equal(want) {
+		t.Fatalf("expected a gross target to withdraw %s, got %s", want, gross.TSPWithdrawalPersonA)
+	}
+	if !incomeTax(gross).GreaterThan(incomeTax(base)) {
+		t.Fatalf("expected the withdrawal to add tax: base %s, with withdrawal %s", incomeTax(base), incomeTax(gross))
+	}
+	afterTax := net.TSPWithdrawalPersonA.Sub(incomeTax(net).Sub(incomeTax(base)))
+	if afterTax.Sub(want).Abs().GreaterThan(decimal.NewFromInt(1)) {
+		t.Fatalf("expected %s after tax from a net target, got %s (withdrawal %s)", want, afterTax, net.TSPWithdrawalPersonA)
+	}
+}
@@ -0,0 +1,111 @@
+package calculation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Search bounds and precision for SolveSustainableWithdrawalRate
+var (
+	sustainableRateHigh      = decimal.NewFromFloat(0.20)
+	sustainableRateTolerance = decimal.NewFromFloat(0.0001)
+)
+
+// SustainableWithdrawalResult is the highest initial TSP withdrawal rate that lasts to a target age
+type SustainableWithdrawalResult struct {
+	Scenario  string          `json:"scenario"`
+	TargetAge int             `json:"target_age"`
+	Feasible  bool            `json:"feasible"` // False when the TSP runs out before TargetAge even with no withdrawals beyond RMDs
+	Rate      decimal.Decimal `json:"rate"`     // First-year withdrawal as a share of the balance at retirement
+	Capped    bool            `json:"capped"`   // The TSP lasts to TargetAge even at the 20% search ceiling
+	// Estimated age of PersonA in the year the TSP runs out at Rate (see estimateTSPExhaustedAge); zero when it lasts the whole projection
+	DepletionAge int `json:"depletion_age,omitempty"`
+}
+
+// SolveSustainableWithdrawalRate binary-searches the initial withdrawal rate, to within one basis point,
+// for the highest rate at which the household TSP is not depleted before PersonA reaches targetAge. Both
+// persons withdraw under the 4% rule at the trial rate: the rate times the balance at
+// retirement, then that amount raised with inflation each year. Unlike variable_percentage, which takes a
+// share of the current balance and so never runs out, this lets the TSP be depleted at the target age. The
+// projection's return assumptions apply, and it must run to targetAge.
+func (ce *CalculationEngine) SolveSustainableWithdrawalRate(config *domain.Configuration, scenario *domain.Scenario, targetAge int) (*SustainableWithdrawalResult, error) {
+	if config == nil || scenario == nil {
+		return nil, ErrMissingInput
+	}
+
+	depletionAgeAt := func(rate decimal.Decimal) (int, error) {
+		trialScenario := *scenario
+		trialScenario.PersonA.TSPWithdrawalStrategy, trialScenario.PersonA.TSPWithdrawalRate = initialRateStrategy, &rate
+		trialScenario.PersonB.TSPWithdrawalStrategy, trialScenario.PersonB.TSPWithdrawalRate = initialRateStrategy, &rate
+		summary, err := ce.RunScenario(context.Background(), config, &trialScenario)
+		if err != nil {
+			return 0, fmt.Errorf("withdrawal rate %s: %w", rate.String(), err)
+		}
+		projection := summary.Projection
+		if len(projection) == 0 || projection[len(projection)-1].AgePersonA < targetAge {
+			return 0, fmt.Errorf("projection must run until PersonA reaches age %d", targetAge)
+		}
+		return estimateTSPExhaustedAge(projection), nil
+	}
+	lasts := func(depletionAge int) bool { return depletionAge == 0 || depletionAge >= targetAge }
+
+	result := &SustainableWithdrawalResult{Scenario: scenario.Name, TargetAge: targetAge}
+	highAge, err := depletionAgeAt(sustainableRateHigh)
+	if err != nil {
+		return nil, err
+	}
+	if lasts(highAge) {
+		result.Feasible, result.Capped = true, true
+		result.Rate, result.DepletionAge = sustainableRateHigh, highAge
+		return result, nil
+	}
+	lowAge, err := depletionAgeAt(decimal.Zero)
+	if err != nil {
+		return nil, err
+	}
+	if !lasts(lowAge) {
+		result.DepletionAge = lowAge
+		return result, nil
+	}
+	result.Feasible = true
+	result.DepletionAge = lowAge
+
+	// Invariant: low lasts, high does not
+	low, high := decimal.Zero, sustainableRateHigh
+	for high.Sub(low).GreaterThan(sustainableRateTolerance) {
+		mid := low.Add(high).Div(decimal.NewFromInt(2)).Round(6)
+		midAge, err := depletionAgeAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if lasts(midAge) {
+			low = mid
+			result.DepletionAge = midAge
+		} else {
+			high = mid
+		}
+	}
+	result.Rate = low
+	return result, nil
+}
+
+// estimateTSPExhaustedAge estimates PersonA's age when the household TSP runs out as the age in the first
+// year it is exhausted, or zero if it never is. The projection steps in whole years, so the estimate is
+// only as precise as the year. Besides a zero balance, a year counts when its withdrawals take the whole
+// balance the TSP started the year with: the projection credits the year's return before the withdrawal,
+// so an exhausted TSP is left holding that return rather than exactly zero.
+func estimateTSPExhaustedAge(projection []domain.AnnualCashFlow) int {
+	for i, year := range projection {
+		if year.IsTSPDepleted() {
+			return year.AgePersonA
+		}
+		withdrawal := year.TSPWithdrawalPersonA.Add(year.TSPWithdrawalPersonB)
+		if i > 0 && withdrawal.IsPositive() && withdrawal.GreaterThanOrEqual(projection[i-1].TotalTSPBalance()) {
+			return year.AgePersonA
+		}
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package calculation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestSolveSustainableWithdrawalRateDepletesAtTargetAge(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(40)
+	cfg.GlobalAssumptions.TSPReturnPostRetirement = decimal.NewFromFloat(0.05)
+	cfg.GlobalAssumptions.InflationRate = decimal.NewFromFloat(0.025)
+	ce := NewCalculationEngine()
+
+	result, err := ce.SolveSustainableWithdrawalRate(cfg, scenario, 95)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Feasible || result.Capped {
+		t.Fatalf("expected an interior solution, got %+v", result)
+	}
+	if result.DepletionAge < 95 || result.DepletionAge > 96 {
+		t.Fatalf("expected the TSP to run out within a year of 95 at %s, got age %d", result.Rate, result.DepletionAge)
+	}
+
+	// A slightly higher rate runs out before 95
+	higher := result.Rate.Add(decimal.NewFromFloat(0.001))
+	scenario.PersonA.TSPWithdrawalStrategy, scenario.PersonA.TSPWithdrawalRate = initialRateStrategy, &higher
+	summary, err := ce.RunScenario(context.Background(), cfg, scenario)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age := estimateTSPExhaustedAge(summary.Projection); age == 0 || age >= 95 {
+		t.Fatalf("expected %s to deplete the TSP before 95, got %d", higher, age)
+	}
+}
+
+func TestSolveSustainableWithdrawalRateNeedsProjectionToTargetAge(t *testing.T) {
+	cfg, scenario := retiredCoupleTestConfig(10)
+	if _, err := NewCalculationEngine().SolveSustainableWithdrawalRate(cfg, scenario, 95); err == nil {
+		t.Fatalf("expected an error when the projection ends before the target age")
+	}
+}
+
+func TestFourPercentRuleIgnoresWithdrawalRate(t *testing.T) {
+	rate := decimal.NewFromFloat(0.06)
+	scenario := &domain.RetirementScenario{TSPWithdrawalStrategy: "4_percent_rule", TSPWithdrawalRate: &rate}
+	ce := NewCalculationEngine()
+	balance := decimal.NewFromInt(500000)
+
+	fourPercent := ce.newTSPStrategy(&domain.Employee{}, scenario, balance, decimal.Zero)
+	if got := fourPercent.CalculateWithdrawal(balance, 1, decimal.Zero, 65, false, decimal.Zero); !got.Equal(decimal.NewFromInt(20000)) {
+		t.Fatalf("expected 4_percent_rule to withdraw 4%% despite tsp_withdrawal_rate, got %s", got)
+	}
+	scenario.TSPWithdrawalStrategy = initialRateStrategy
+	initialRate := ce.newTSPStrategy(&domain.Employee{}, scenario, balance, decimal.Zero)
+	if got := initialRate.CalculateWithdrawal(balance, 1, decimal.Zero, 65, false, decimal.Zero); !got.Equal(decimal.NewFromInt(30000)) {
+		t.Fatalf("expected the initial rate strategy to withdraw 6%%, got %s", got)
+	}
+}
@@ -0,0 +1,55 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/rpgo/retirement-calculator/pkg/dateutil"
+	"github.com/shopspring/decimal"
+)
+
+// Test that the salary work fraction in a leap retirement year uses the actual day count (366)
+func TestWorkFraction_LeapYearRetirement(t *testing.T) {
+	ce := NewCalculationEngine()
+
+	salary := decimal.NewFromInt(100000)
+	personA := domain.Employee{
+		Name:          "PersonA",
+		BirthDate:     time.Date(1965, 3, 15, 0, 0, 0, 0, time.UTC),
+		HireDate:      time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+		CurrentSalary: salary,
+		High3Salary:   salary,
+	}
+	personB := domain.Employee{
+		Name:      "PersonB",
+		BirthDate: time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	retirementDate := time.Date(2028, 7, 1, 0, 0, 0, 0, time.UTC)
+	rs := domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: retirementDate, SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"}
+	ds := domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 67, TSPWithdrawalStrategy: "4_percent_rule"}
+	scenario := domain.Scenario{Name: "leap-retirement", PersonA: rs, PersonB: ds}
+
+	cfg := &domain.Configuration{
+		GlobalAssumptions: domain.GlobalAssumptions{ProjectionYears: 5, COLAGeneralRate: decimal.Zero},
+	}
+
+	proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &cfg.GlobalAssumptions, cfg.GlobalAssumptions.FederalRules)
+	idx := 2028 - testProjectionStartYear
+	if len(proj) <= idx {
+		t.Fatalf("expected projection row for 2028")
+	}
+
+	daysWorked := retirementDate.Sub(time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)).Hours() / 24
+	if daysWorked != 182 {
+		t.Fatalf("expected 182 days worked before July 1, 2028, got %v", daysWorked)
+	}
+	expected := salary.Mul(decimal.NewFromFloat(daysWorked / float64(dateutil.DaysInYear(2028))))
+
+	row := proj[idx]
+	if row.SalaryPersonA.Sub(expected).Abs().GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("salary work fraction mismatch; expected %s, got %s", expected.StringFixed(2), row.SalaryPersonA.StringFixed(2))
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import "errors"
+
+// Kinds of configuration problem. Every validation error matches ErrInvalidConfiguration and, where the
+// problem has a kind, one of the others; match them with errors.Is, or use errors.As with a
+// ValidationError for the offending field.
+var (
+	ErrInvalidConfiguration = errors.New("invalid configuration")
+	ErrMissingField         = errors.New("required field is missing")
+	ErrOutOfRange           = errors.New("value is out of range")
+	ErrUnsupportedValue     = errors.New("value is not one of the supported options")
+	ErrDateOrder            = errors.New("dates are out of order")
+	ErrNotEligible          = errors.New("retirement date earns no FERS annuity")
+)
@@ -1,11 +1,18 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/rpgo/retirement-calculator/pkg/lifetable"
 	"github.com/shopspring/decimal"
 	"gopkg.in/yaml.v3"
 )
@@ -26,13 +33,19 @@ func (ip *InputParser) LoadFromFile(filename string) (*domain.Configuration, err
 	}
 
 	var config domain.Configuration
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if isJSONConfig(filename, data) {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
 	// Legacy key mapping has been removed; configuration should use
 	// 'person_a' and 'person_b' keys exclusively.
 
+	applyDefaults(&config)
+
 	// Validate the configuration
 	if err := ip.ValidateConfiguration(&config); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
@@ -41,19 +54,57 @@ func (ip *InputParser) LoadFromFile(filename string) (*domain.Configuration, err
 	return &config, nil
 }
 
-// ValidateConfiguration validates the loaded configuration
+// maxProjectionYears is the longest projection validation accepts
+const maxProjectionYears = 50
+
+// applyDefaults fills in settings that are derived when left unset. Social Security benefits left empty
+// are computed from ss_indexed_earnings when given. Without projection_years the projection runs through
+// the couple's joint life expectancy (the expected years until the second death) at the projection start,
+// rounded up. The projection start is projection_start_year, or the current year from the domain clock when unset.
+func applyDefaults(config *domain.Configuration) {
+	for key, employee := range config.PersonalDetails {
+		if employee.DeriveSSBenefitsFromEarnings() {
+			config.PersonalDetails[key] = employee
+		}
+	}
+
+	personA, okA := config.PersonalDetails["person_a"]
+	personB, okB := config.PersonalDetails["person_b"]
+	if config.GlobalAssumptions.ProjectionYears != 0 || !okA || !okB {
+		return
+	}
+	startYear := domain.ProjectionStartYear(&config.GlobalAssumptions)
+	joint := lifetable.JointLifeExpectancy(startYear-personA.BirthDate.Year(), personA.Sex, startYear-personB.BirthDate.Year(), personB.Sex)
+	config.GlobalAssumptions.ProjectionYears = min(int(math.Ceil(joint)), maxProjectionYears)
+}
+
+// isJSONConfig reports whether a configuration file should be parsed as JSON: by a .json
+// extension, or for other extensions when the content starts with an object brace
+func isJSONConfig(filename string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return true
+	case ".yaml", ".yml":
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// ValidateConfiguration validates the loaded configuration, returning the first problem found
 func (ip *InputParser) ValidateConfiguration(config *domain.Configuration) error {
 	// Validate personal details
+	var report validationReport
 	if len(config.PersonalDetails) == 0 {
-		return fmt.Errorf("no personal details provided")
+		report.addErr("personal_details", ErrMissingField, "no personal details provided")
+		return report.first()
 	}
 
 	// Check for required employees
-	if _, exists := config.PersonalDetails["person_a"]; !exists {
-		return fmt.Errorf("person_a employee details are required")
-	}
-	if _, exists := config.PersonalDetails["person_b"]; !exists {
-		return fmt.Errorf("person_b employee details are required")
+	for _, required := range []string{"person_a", "person_b"} {
+		if _, exists := config.PersonalDetails[required]; !exists {
+			report.addErr("personal_details."+required, ErrMissingField, "%s employee details are required", required)
+			return report.first()
+		}
 	}
 
 	// Validate each employee
@@ -70,175 +121,611 @@ func (ip *InputParser) ValidateConfiguration(config *domain.Configuration) error
 
 	// Validate scenarios
 	if len(config.Scenarios) == 0 {
-		return fmt.Errorf("no scenarios provided")
+		report.addErr("scenarios", ErrMissingField, "no scenarios provided")
+		return report.first()
 	}
 
 	for i, scenario := range config.Scenarios {
 		if err := ip.validateScenario(i, &scenario); err != nil {
 			return fmt.Errorf("scenario %d validation failed: %w", i, err)
 		}
+		ip.checkRetirementEligibility("", config.PersonalDetails, &scenario, &report)
+		if err := report.first(); err != nil {
+			return fmt.Errorf("scenario %d validation failed: %w", i, err)
+		}
 	}
 
 	return nil
 }
 
+// ValidateConfigurationAll validates the configuration and reports every problem found, each with the
+// path of the offending field, so all mistakes can be fixed in one pass. It returns nil when the
+// configuration is valid.
+func (ip *InputParser) ValidateConfigurationAll(config *domain.Configuration) []ValidationError {
+	var report validationReport
+
+	if len(config.PersonalDetails) == 0 {
+		report.addErr("personal_details", ErrMissingField, "no personal details provided")
+	} else {
+		for _, required := range []string{"person_a", "person_b"} {
+			if _, exists := config.PersonalDetails[required]; !exists {
+				report.addErr("personal_details."+required, ErrMissingField, "%s employee details are required", required)
+			}
+		}
+	}
+	names := make([]string, 0, len(config.PersonalDetails))
+	for name := range config.PersonalDetails {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		employee := config.PersonalDetails[name]
+		ip.checkEmployee("personal_details."+name, &employee, &report)
+	}
+
+	ip.checkGlobalAssumptions("global_assumptions", &config.GlobalAssumptions, &report)
+
+	if len(config.Scenarios) == 0 {
+		report.addErr("scenarios", ErrMissingField, "no scenarios provided")
+	}
+	for i := range config.Scenarios {
+		ip.checkScenario(fmt.Sprintf("scenarios[%d]", i), &config.Scenarios[i], &report)
+		ip.checkRetirementEligibility(fmt.Sprintf("scenarios[%d]", i), config.PersonalDetails, &config.Scenarios[i], &report)
+		ip.checkQDRODates(fmt.Sprintf("scenarios[%d]", i), config.PersonalDetails, &config.Scenarios[i], &report)
+		ip.checkServiceBreakDates(fmt.Sprintf("scenarios[%d]", i), config.PersonalDetails, &config.Scenarios[i], &report)
+	}
+
+	return report.errors
+}
+
 // validateEmployee validates a single employee's data
 func (ip *InputParser) validateEmployee(_ string, employee *domain.Employee) error {
+	var report validationReport
+	ip.checkEmployee("", employee, &report)
+	return report.first()
+}
+
+// checkEmployee records every problem with a single employee's data
+func (ip *InputParser) checkEmployee(path string, employee *domain.Employee, report *validationReport) {
 	// Validate required fields
 	if employee.BirthDate.IsZero() {
-		return fmt.Errorf("birth date is required")
+		report.addErr(joinPath(path, "birth_date"), ErrMissingField, "birth date is required")
 	}
 	if employee.HireDate.IsZero() {
-		return fmt.Errorf("hire date is required")
+		report.addErr(joinPath(path, "hire_date"), ErrMissingField, "hire date is required")
 	}
 	if employee.CurrentSalary.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("current salary must be positive")
+		report.addErr(joinPath(path, "current_salary"), ErrOutOfRange, "current salary must be positive")
 	}
 	if employee.High3Salary.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("high 3 salary must be positive")
+		report.addErr(joinPath(path, "high_3_salary"), ErrOutOfRange, "high 3 salary must be positive")
+	}
+	if employee.SalaryGrowthRate != nil && (employee.SalaryGrowthRate.LessThan(decimal.NewFromFloat(-0.1)) || employee.SalaryGrowthRate.GreaterThan(decimal.NewFromFloat(0.2))) {
+		report.addErr(joinPath(path, "salary_growth_rate"), ErrOutOfRange, "salary growth rate must be between -10%% and 20%%")
 	}
 	if employee.TSPBalanceTraditional.LessThan(decimal.Zero) {
-		return fmt.Errorf("TSP traditional balance cannot be negative")
+		report.addErr(joinPath(path, "tsp_balance_traditional"), ErrOutOfRange, "TSP traditional balance cannot be negative")
 	}
 	if employee.TSPBalanceRoth.LessThan(decimal.Zero) {
-		return fmt.Errorf("TSP Roth balance cannot be negative")
+		report.addErr(joinPath(path, "tsp_balance_roth"), ErrOutOfRange, "TSP Roth balance cannot be negative")
+	}
+	if employee.TaxableAccountBalance.LessThan(decimal.Zero) {
+		report.addErr(joinPath(path, "taxable_account_balance"), ErrOutOfRange, "taxable account balance cannot be negative")
+	}
+	if employee.TaxableAccountCostBasis.LessThan(decimal.Zero) || employee.TaxableAccountCostBasis.GreaterThan(employee.TaxableAccountBalance) {
+		report.addErr(joinPath(path, "taxable_account_cost_basis"), ErrOutOfRange, "taxable account cost basis must be between 0 and the account balance")
+	}
+	if employee.TaxableAccountDividendYield.LessThan(decimal.Zero) || employee.TaxableAccountDividendYield.GreaterThan(decimal.NewFromFloat(0.2)) {
+		report.addErr(joinPath(path, "taxable_account_dividend_yield"), ErrOutOfRange, "taxable account dividend yield must be between 0 and 20%%")
+	}
+	if employee.TaxableAccountReturn != nil && (employee.TaxableAccountReturn.LessThan(decimal.NewFromFloat(-0.5)) || employee.TaxableAccountReturn.GreaterThan(decimal.NewFromFloat(0.5))) {
+		report.addErr(joinPath(path, "taxable_account_return"), ErrOutOfRange, "taxable account return must be between -50%% and 50%%")
 	}
 	if employee.TSPContributionPercent.LessThan(decimal.Zero) || employee.TSPContributionPercent.GreaterThan(decimal.NewFromFloat(1.0)) {
-		return fmt.Errorf("TSP contribution percent must be between 0 and 1")
+		report.addErr(joinPath(path, "tsp_contribution_percent"), ErrOutOfRange, "TSP contribution percent must be between 0 and 1")
+	}
+	if employee.TSPRothContributionPercent.LessThan(decimal.Zero) || employee.TSPRothContributionPercent.GreaterThan(decimal.NewFromInt(1)) {
+		report.addErr(joinPath(path, "tsp_roth_contribution_percent"), ErrOutOfRange, "TSP Roth contribution percent must be between 0 and 1")
+	}
+	for i, earnings := range employee.SSIndexedEarnings {
+		if earnings.IsNegative() {
+			report.addErr(joinPath(path, fmt.Sprintf("ss_indexed_earnings[%d]", i)), ErrOutOfRange, "indexed earnings cannot be negative")
+		}
 	}
 	if employee.SSBenefitFRA.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("social security benefit at FRA must be positive")
+		report.addErr(joinPath(path, "ss_benefit_fra"), ErrOutOfRange, "social security benefit at FRA must be positive")
 	}
 	if employee.SSBenefit62.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("social security benefit at 62 must be positive")
+		report.addErr(joinPath(path, "ss_benefit_62"), ErrOutOfRange, "social security benefit at 62 must be positive")
 	}
 	if employee.SSBenefit70.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("social security benefit at 70 must be positive")
+		report.addErr(joinPath(path, "ss_benefit_70"), ErrOutOfRange, "social security benefit at 70 must be positive")
 	}
 	if employee.FEHBPremiumPerPayPeriod.LessThan(decimal.Zero) {
-		return fmt.Errorf("FEHB premium per pay period cannot be negative")
+		report.addErr(joinPath(path, "fehb_premium_per_pay_period"), ErrOutOfRange, "FEHB premium per pay period cannot be negative")
+	}
+	if employee.CashBalance.IsNegative() {
+		report.addErr(joinPath(path, "cash_balance"), ErrOutOfRange, "cash balance cannot be negative")
 	}
-	if employee.SurvivorBenefitElectionPercent.LessThan(decimal.Zero) || employee.SurvivorBenefitElectionPercent.GreaterThan(decimal.NewFromFloat(1.0)) {
-		return fmt.Errorf("survivor benefit election percent must be between 0 and 1")
+	if employee.CashYield.IsNegative() || employee.CashYield.GreaterThan(decimal.NewFromFloat(0.2)) {
+		report.addErr(joinPath(path, "cash_yield"), ErrOutOfRange, "cash yield must be between 0 and 20%%")
+	}
+	if employee.PlanToAge != 0 && (employee.PlanToAge < 60 || employee.PlanToAge > 120) {
+		report.addErr(joinPath(path, "plan_to_age"), ErrOutOfRange, "plan-to age must be between 60 and 120")
+	}
+	if employee.Sex != "" && employee.Sex != lifetable.Male && employee.Sex != lifetable.Female {
+		report.addErr(joinPath(path, "sex"), ErrUnsupportedValue, "sex must be 'male' or 'female', or unset for the unisex life table")
+	}
+	if !domain.IsValidFEHBEnrollment(employee.FEHBEnrollment) {
+		report.addErr(joinPath(path, "fehb_enrollment"), ErrUnsupportedValue, "FEHB enrollment must be 'self_only', 'self_plus_one', or 'self_and_family'")
+	}
+	if tiers := employee.FEHBTierPremiums; tiers != nil && (tiers.SelfOnly.IsNegative() || tiers.SelfPlusOne.IsNegative() || tiers.SelfAndFamily.IsNegative()) {
+		report.addErr(joinPath(path, "fehb_tier_premiums"), ErrOutOfRange, "FEHB tier premiums cannot be negative")
+	}
+	if !domain.IsValidSurvivorElection(employee.SurvivorBenefitElectionPercent) {
+		report.addErr(joinPath(path, "survivor_benefit_election_percent"), ErrUnsupportedValue, "survivor benefit election percent must be 0 (none), 0.25 (partial), or 0.5 (full), got %s", employee.SurvivorBenefitElectionPercent)
+	}
+	if employee.TSPAllocation != nil {
+		if err := validateTSPAllocation(*employee.TSPAllocation); err != nil {
+			report.add(joinPath(path, "tsp_allocation"), "TSP allocation: %v", err)
+		}
+	}
+	if employee.TSPRebalanceYears < 0 {
+		report.addErr(joinPath(path, "tsp_rebalance_years"), ErrOutOfRange, "TSP rebalance interval cannot be negative")
+	}
+	if employee.TSPLifecycleFund != nil {
+		for series, points := range employee.TSPLifecycleFund.AllocationData {
+			for i, point := range points {
+				if err := validateTSPAllocation(point.Allocation); err != nil {
+					report.add(joinPath(path, fmt.Sprintf("tsp_lifecycle_fund.allocation_data.%s[%d]", series, i)),
+						"TSP lifecycle fund %s allocation data %s on %s: %v", employee.TSPLifecycleFund.FundName, series, point.Date, err)
+				}
+			}
+		}
+	}
+
+	for i, pension := range employee.ExternalPensions {
+		pensionPath := joinPath(path, fmt.Sprintf("external_pensions[%d]", i))
+		if pension.StartAge < 18 || pension.StartAge > 100 {
+			report.addErr(joinPath(pensionPath, "start_age"), ErrOutOfRange, "external pension start age must be between 18 and 100")
+		}
+		if !pension.MonthlyAmount.IsPositive() {
+			report.addErr(joinPath(pensionPath, "monthly_amount"), ErrOutOfRange, "external pension monthly amount must be positive")
+		}
+		if pension.COLARate != nil && (pension.COLARate.IsNegative() || pension.COLARate.GreaterThan(decimal.NewFromFloat(0.1))) {
+			report.addErr(joinPath(pensionPath, "cola_rate"), ErrOutOfRange, "external pension COLA rate must be between 0 and 10%%")
+		}
+		if pension.SurvivorPercentage.IsNegative() || pension.SurvivorPercentage.GreaterThan(decimal.NewFromInt(1)) {
+			report.addErr(joinPath(pensionPath, "survivor_percentage"), ErrOutOfRange, "external pension survivor percentage must be between 0 and 1")
+		}
+	}
+	if qdro := employee.QDRO; qdro != nil {
+		if qdro.EffectiveDate.IsZero() {
+			report.addErr(joinPath(path, "qdro.effective_date"), ErrMissingField, "QDRO effective date is required")
+		}
+		if qdro.TSPPercentage.IsNegative() || qdro.TSPPercentage.GreaterThan(decimal.NewFromInt(1)) {
+			report.addErr(joinPath(path, "qdro.tsp_percentage"), ErrOutOfRange, "QDRO TSP percentage must be between 0 and 1")
+		}
+		if qdro.PensionShare.IsNegative() || qdro.PensionShare.GreaterThan(decimal.NewFromInt(1)) {
+			report.addErr(joinPath(path, "qdro.pension_share"), ErrOutOfRange, "QDRO pension share must be between 0 and 1")
+		}
+		if qdro.TSPPercentage.IsZero() && qdro.PensionShare.IsZero() {
+			report.add(joinPath(path, "qdro"), "QDRO must award a tsp_percentage or a pension_share")
+		}
+	}
+
+	for i, b := range employee.ServiceBreaks {
+		breakPath := joinPath(path, fmt.Sprintf("service_breaks[%d]", i))
+		if b.SeparationDate.Before(employee.HireDate) {
+			report.addErr(joinPath(breakPath, "separation_date"), ErrDateOrder, "separation date cannot be before hire date")
+		}
+		if !b.ReemploymentDate.After(b.SeparationDate) {
+			report.addErr(joinPath(breakPath, "reemployment_date"), ErrDateOrder, "re-employment date must be after separation date")
+		}
 	}
 
 	// Validate date logic
 	if employee.BirthDate.After(employee.HireDate) {
-		return fmt.Errorf("birth date cannot be after hire date")
+		report.addErr(joinPath(path, "birth_date"), ErrDateOrder, "birth date cannot be after hire date")
 	}
 
 	// Validate Social Security benefit progression
 	if employee.SSBenefit62.GreaterThan(employee.SSBenefitFRA) {
-		return fmt.Errorf("SS benefit at 62 cannot be greater than at FRA")
+		report.addErr(joinPath(path, "ss_benefit_62"), ErrOutOfRange, "SS benefit at 62 cannot be greater than at FRA")
 	}
 	if employee.SSBenefitFRA.GreaterThan(employee.SSBenefit70) {
-		return fmt.Errorf("SS benefit at FRA cannot be greater than at 70")
+		report.addErr(joinPath(path, "ss_benefit_fra"), ErrOutOfRange, "SS benefit at FRA cannot be greater than at 70")
 	}
+}
+
+// tspAllocationTolerance is how far fund fractions may sum from 1.0 to allow for rounding
+var tspAllocationTolerance = decimal.NewFromFloat(0.001)
 
+// validateTSPAllocation checks that fund fractions are non-negative and sum to 1.0
+func validateTSPAllocation(allocation domain.TSPAllocation) error {
+	for _, fraction := range []decimal.Decimal{allocation.CFund, allocation.SFund, allocation.IFund, allocation.FFund, allocation.GFund} {
+		if fraction.LessThan(decimal.Zero) {
+			return fmt.Errorf("fund fractions cannot be negative")
+		}
+	}
+	total := allocation.Total()
+	if total.Sub(decimal.NewFromInt(1)).Abs().GreaterThan(tspAllocationTolerance) {
+		return fmt.Errorf("fund fractions must sum to 1.0, got %s", total.String())
+	}
 	return nil
 }
 
 // validateGlobalAssumptions validates global assumptions
 func (ip *InputParser) validateGlobalAssumptions(assumptions *domain.GlobalAssumptions) error {
+	var report validationReport
+	ip.checkGlobalAssumptions("", assumptions, &report)
+	return report.first()
+}
+
+// checkGlobalAssumptions records every problem with the global assumptions
+func (ip *InputParser) checkGlobalAssumptions(path string, assumptions *domain.GlobalAssumptions, report *validationReport) {
 	if assumptions.InflationRate.LessThan(decimal.NewFromFloat(-0.10)) {
-		return fmt.Errorf("inflation rate cannot be less than -10%% (extreme deflation)")
+		report.addErr(joinPath(path, "inflation_rate"), ErrOutOfRange, "inflation rate cannot be less than -10%% (extreme deflation)")
 	}
 	if assumptions.FEHBPremiumInflation.LessThan(decimal.Zero) {
-		return fmt.Errorf("FEHB premium inflation cannot be negative")
+		report.addErr(joinPath(path, "fehb_premium_inflation"), ErrOutOfRange, "FEHB premium inflation cannot be negative")
 	}
 	if assumptions.TSPReturnPreRetirement.LessThan(decimal.NewFromFloat(-1.0)) {
-		return fmt.Errorf("TSP return pre-retirement cannot be less than -100%%")
+		report.addErr(joinPath(path, "tsp_return_pre_retirement"), ErrOutOfRange, "TSP return pre-retirement cannot be less than -100%%")
 	}
 	if assumptions.TSPReturnPostRetirement.LessThan(decimal.NewFromFloat(-1.0)) {
-		return fmt.Errorf("TSP return post-retirement cannot be less than -100%%")
+		report.addErr(joinPath(path, "tsp_return_post_retirement"), ErrOutOfRange, "TSP return post-retirement cannot be less than -100%%")
+	}
+	glideAges := make([]int, 0, len(assumptions.TSPReturnGlidePath))
+	for age := range assumptions.TSPReturnGlidePath {
+		glideAges = append(glideAges, age)
+	}
+	sort.Ints(glideAges)
+	for _, age := range glideAges {
+		if age < 0 || age > 120 {
+			report.addErr(joinPath(path, fmt.Sprintf("tsp_return_glide_path.%d", age)), ErrOutOfRange, "TSP return glide path age must be between 0 and 120")
+		} else if assumptions.TSPReturnGlidePath[age].LessThan(decimal.NewFromFloat(-1.0)) {
+			report.addErr(joinPath(path, fmt.Sprintf("tsp_return_glide_path.%d", age)), ErrOutOfRange, "TSP return glide path return at age %d cannot be less than -100%%", age)
+		}
 	}
 	if assumptions.COLAGeneralRate.LessThan(decimal.Zero) {
-		return fmt.Errorf("COLA general rate cannot be negative")
+		report.addErr(joinPath(path, "cola_general_rate"), ErrOutOfRange, "COLA general rate cannot be negative")
+	}
+	if assumptions.ProjectionYears <= 0 || assumptions.ProjectionYears > maxProjectionYears {
+		report.addErr(joinPath(path, "projection_years"), ErrOutOfRange, "projection years must be between 1 and 50")
+	}
+	if start := assumptions.ProjectionStartYear; start != 0 && (start < 1900 || start > 2200) {
+		report.addErr(joinPath(path, "projection_start_year"), ErrOutOfRange, "projection start year must be between 1900 and 2200, or unset for the current year")
 	}
-	if assumptions.ProjectionYears <= 0 || assumptions.ProjectionYears > 50 {
-		return fmt.Errorf("projection years must be between 1 and 50")
+
+	if !domain.IsValidMedicareCoordination(assumptions.FederalRules.FEHBConfig.MedicareCoordination) {
+		report.addErr(joinPath(path, "federal_rules.fehb_config.medicare_coordination"), ErrUnsupportedValue, "FEHB medicare_coordination must be 'keep_both', 'medicare_primary', or 'suspend_fehb'")
+	}
+	if factor := assumptions.FederalRules.FEHBConfig.MedicarePrimaryPremiumFactor; factor.LessThan(decimal.Zero) || factor.GreaterThan(decimal.NewFromInt(1)) {
+		report.addErr(joinPath(path, "federal_rules.fehb_config.medicare_primary_premium_factor"), ErrOutOfRange, "FEHB medicare_primary_premium_factor must be between 0 and 1")
+	}
+	if share := assumptions.FederalRules.FEHBConfig.GovernmentShare; share.LessThan(decimal.Zero) || share.GreaterThan(decimal.NewFromInt(1)) {
+		report.addErr(joinPath(path, "federal_rules.fehb_config.government_share"), ErrOutOfRange, "FEHB government_share must be between 0 and 1")
+	}
+
+	fersRules := assumptions.FederalRules.FERSRules
+	if fersRules.COLAFullCPIThreshold.LessThan(decimal.Zero) || fersRules.COLACapThreshold.LessThan(decimal.Zero) {
+		report.addErr(joinPath(path, "federal_rules.fers_rules"), ErrOutOfRange, "FERS COLA thresholds cannot be negative")
+	} else if fersRules.COLAFullCPIThreshold.IsPositive() && fersRules.COLACapThreshold.IsPositive() && fersRules.COLACapThreshold.LessThan(fersRules.COLAFullCPIThreshold) {
+		report.addErr(joinPath(path, "federal_rules.fers_rules.cola_cap_threshold"), ErrOutOfRange, "FERS cola_cap_threshold cannot be below cola_full_cpi_threshold")
+	}
+
+	fica := assumptions.FederalRules.FICATaxConfig
+	if fica.Year != 0 && (fica.Year < 1900 || fica.Year > 2200) {
+		report.addErr(joinPath(path, "federal_rules.fica_tax_config.year"), ErrOutOfRange, "FICA tax year must be between 1900 and 2200, or unset for 2025")
+	}
+	if fica.WageBaseIndexingRate.IsNegative() || fica.WageBaseIndexingRate.GreaterThan(decimal.NewFromFloat(0.2)) {
+		report.addErr(joinPath(path, "federal_rules.fica_tax_config.wage_base_indexing_rate"), ErrOutOfRange, "FICA wage base indexing rate must be between 0 and 20%%")
+	}
+
+	if rules := assumptions.FederalRules.StateLocalTaxConfig.RetirementIncome; rules != nil {
+		if rules.ExclusionPerPerson.IsNegative() {
+			report.addErr(joinPath(path, "federal_rules.state_local_tax_config.retirement_income.exclusion_per_person"), ErrOutOfRange, "state retirement income exclusion cannot be negative")
+		}
+		if rules.ExclusionMinAge < 0 || rules.ExclusionMinAge > 100 {
+			report.addErr(joinPath(path, "federal_rules.state_local_tax_config.retirement_income.exclusion_min_age"), ErrOutOfRange, "state retirement income exclusion age must be between 0 and 100")
+		}
+	}
+
+	for municipality, rule := range assumptions.FederalRules.StateLocalTaxConfig.LocalTaxes {
+		if rule.Rate.IsNegative() || rule.Rate.GreaterThan(decimal.NewFromFloat(0.1)) {
+			report.addErr(joinPath(path, fmt.Sprintf("federal_rules.state_local_tax_config.local_taxes.%s.rate", municipality)), ErrOutOfRange, "local tax rate must be between 0 and 10%%")
+		}
+	}
+
+	limits := assumptions.FederalRules.TSPContributionLimits
+	if limits.ElectiveDeferralLimit.IsNegative() || limits.CatchUpLimit.IsNegative() || limits.SuperCatchUpLimit.IsNegative() {
+		report.addErr(joinPath(path, "federal_rules.tsp_contribution_limits"), ErrOutOfRange, "TSP contribution limits cannot be negative")
+	}
+
+	if defaultAllocation := assumptions.MonteCarloSettings.DefaultTSPAllocation; !defaultAllocation.IsZero() {
+		if err := validateTSPAllocation(defaultAllocation); err != nil {
+			report.add(joinPath(path, "monte_carlo_settings.default_tsp_allocation"), "default TSP allocation: %v", err)
+		}
 	}
 
 	// Validate location
 	if assumptions.CurrentLocation.State == "" {
-		return fmt.Errorf("state is required")
+		report.addErr(joinPath(path, "current_location.state"), ErrMissingField, "state is required")
 	}
-
-	return nil
 }
 
 // validateScenario validates a single scenario
 func (ip *InputParser) validateScenario(_ int, scenario *domain.Scenario) error {
+	var report validationReport
+	ip.checkScenario("", scenario, &report)
+	return report.first()
+}
+
+// checkScenario records every problem with a single scenario
+func (ip *InputParser) checkScenario(path string, scenario *domain.Scenario, report *validationReport) {
 	if scenario.Name == "" {
-		return fmt.Errorf("scenario name is required")
+		report.addErr(joinPath(path, "name"), ErrMissingField, "scenario name is required")
+	}
+
+	// Validate each person's retirement scenario
+	for _, person := range []struct {
+		key      string
+		scenario *domain.RetirementScenario
+	}{{"person_a", &scenario.PersonA}, {"person_b", &scenario.PersonB}} {
+		var personReport validationReport
+		ip.checkRetirementScenario(joinPath(path, person.key), person.scenario, &personReport)
+		for _, e := range personReport.errors {
+			report.addErr(e.Field, e.Err, "%s scenario validation failed: %s", person.key, e.Message)
+		}
 	}
 
-	// Validate PersonA scenario
-	if err := ip.validateRetirementScenario("person_a", &scenario.PersonA); err != nil {
-		return fmt.Errorf("person_a scenario validation failed: %w", err)
+	if items := scenario.ItemizedDeductions; items != nil {
+		for _, item := range []struct {
+			field  string
+			amount decimal.Decimal
+		}{
+			{"mortgage_interest", items.MortgageInterest},
+			{"state_and_local_taxes", items.StateAndLocalTaxes},
+			{"charitable_contributions", items.CharitableContributions},
+			{"medical_expenses", items.MedicalExpenses},
+		} {
+			if item.amount.LessThan(decimal.Zero) {
+				report.addErr(joinPath(path, "itemized_deductions."+item.field), ErrOutOfRange, "itemized deduction %s cannot be negative", item.field)
+			}
+		}
+	}
+
+	if need := scenario.SpendingNeed; need != nil {
+		if need.AnnualAmount.LessThan(decimal.Zero) {
+			report.addErr(joinPath(path, "spending_need.annual_amount"), ErrOutOfRange, "spending need cannot be negative")
+		}
+		years := make([]int, 0, len(need.Schedule))
+		for year := range need.Schedule {
+			years = append(years, year)
+		}
+		sort.Ints(years)
+		for _, year := range years {
+			if need.Schedule[year].LessThan(decimal.Zero) {
+				report.addErr(joinPath(path, fmt.Sprintf("spending_need.schedule.%d", year)), ErrOutOfRange, "spending need for %d cannot be negative", year)
+			}
+		}
+	}
+
+	if overrides := scenario.AssumptionOverrides; overrides != nil {
+		overridePath := joinPath(path, "assumption_overrides")
+		if overrides.InflationRate != nil && overrides.InflationRate.LessThan(decimal.NewFromFloat(-0.10)) {
+			report.addErr(joinPath(overridePath, "inflation_rate"), ErrOutOfRange, "inflation rate cannot be less than -10%% (extreme deflation)")
+		}
+		if overrides.FEHBPremiumInflation != nil && overrides.FEHBPremiumInflation.LessThan(decimal.Zero) {
+			report.addErr(joinPath(overridePath, "fehb_premium_inflation"), ErrOutOfRange, "FEHB premium inflation cannot be negative")
+		}
+		if overrides.TSPReturnPreRetirement != nil && overrides.TSPReturnPreRetirement.LessThan(decimal.NewFromFloat(-1.0)) {
+			report.addErr(joinPath(overridePath, "tsp_return_pre_retirement"), ErrOutOfRange, "TSP return pre-retirement cannot be less than -100%%")
+		}
+		if overrides.TSPReturnPostRetirement != nil && overrides.TSPReturnPostRetirement.LessThan(decimal.NewFromFloat(-1.0)) {
+			report.addErr(joinPath(overridePath, "tsp_return_post_retirement"), ErrOutOfRange, "TSP return post-retirement cannot be less than -100%%")
+		}
+		if overrides.COLAGeneralRate != nil && overrides.COLAGeneralRate.LessThan(decimal.Zero) {
+			report.addErr(joinPath(overridePath, "cola_general_rate"), ErrOutOfRange, "COLA general rate cannot be negative")
+		}
+		if overrides.ProjectionYears != nil && (*overrides.ProjectionYears <= 0 || *overrides.ProjectionYears > 50) {
+			report.addErr(joinPath(overridePath, "projection_years"), ErrOutOfRange, "projection years must be between 1 and 50")
+		}
 	}
 
-	// Validate PersonB scenario
-	if err := ip.validateRetirementScenario("person_b", &scenario.PersonB); err != nil {
-		return fmt.Errorf("person_b scenario validation failed: %w", err)
+	if !domain.IsValidMedicareCoordination(scenario.MedicareCoordination) {
+		report.addErr(joinPath(path, "medicare_coordination"), ErrUnsupportedValue, "medicare_coordination must be 'keep_both', 'medicare_primary', or 'suspend_fehb'")
+	}
+	for i, change := range scenario.FEHBEnrollmentChanges {
+		if change.Enrollment == "" || !domain.IsValidFEHBEnrollment(change.Enrollment) {
+			report.addErr(joinPath(path, fmt.Sprintf("fehb_enrollment_changes[%d].enrollment", i)), ErrUnsupportedValue, "FEHB enrollment must be 'self_only', 'self_plus_one', or 'self_and_family'")
+		}
 	}
 
 	// Validate optional mortality block
 	if scenario.Mortality != nil {
 		if scenario.Mortality.PersonA != nil {
 			if scenario.Mortality.PersonA.DeathDate != nil && scenario.Mortality.PersonA.DeathAge != nil {
-				return fmt.Errorf("mortality.person_a: specify either death_date or death_age, not both")
+				report.add(joinPath(path, "mortality.person_a"), "mortality.person_a: specify either death_date or death_age, not both")
 			}
 		}
 		if scenario.Mortality.PersonB != nil {
 			if scenario.Mortality.PersonB.DeathDate != nil && scenario.Mortality.PersonB.DeathAge != nil {
-				return fmt.Errorf("mortality.person_b: specify either death_date or death_age, not both")
+				report.add(joinPath(path, "mortality.person_b"), "mortality.person_b: specify either death_date or death_age, not both")
 			}
 		}
 		if scenario.Mortality.Assumptions != nil {
 			if !scenario.Mortality.Assumptions.SurvivorSpendingFactor.IsZero() && (scenario.Mortality.Assumptions.SurvivorSpendingFactor.LessThan(decimal.NewFromFloat(0.4)) || scenario.Mortality.Assumptions.SurvivorSpendingFactor.GreaterThan(decimal.NewFromFloat(1.0))) {
-				return fmt.Errorf("mortality.assumptions.survivor_spending_factor must be between 0.4 and 1.0")
+				report.addErr(joinPath(path, "mortality.assumptions.survivor_spending_factor"), ErrOutOfRange, "mortality.assumptions.survivor_spending_factor must be between 0.4 and 1.0")
 			}
 			if scenario.Mortality.Assumptions.TSPSpousalTransfer != "" && scenario.Mortality.Assumptions.TSPSpousalTransfer != "merge" && scenario.Mortality.Assumptions.TSPSpousalTransfer != "separate" {
-				return fmt.Errorf("mortality.assumptions.tsp_spousal_transfer must be 'merge' or 'separate'")
+				report.addErr(joinPath(path, "mortality.assumptions.tsp_spousal_transfer"), ErrUnsupportedValue, "mortality.assumptions.tsp_spousal_transfer must be 'merge' or 'separate'")
 			}
 			if scenario.Mortality.Assumptions.FilingStatusSwitch != "" && scenario.Mortality.Assumptions.FilingStatusSwitch != "next_year" && scenario.Mortality.Assumptions.FilingStatusSwitch != "immediate" {
-				return fmt.Errorf("mortality.assumptions.filing_status_switch must be 'next_year' or 'immediate'")
+				report.addErr(joinPath(path, "mortality.assumptions.filing_status_switch"), ErrUnsupportedValue, "mortality.assumptions.filing_status_switch must be 'next_year' or 'immediate'")
 			}
 		}
 	}
+}
 
-	return nil
+// checkRetirementEligibility records each retirement date at which the employee does not qualify for a
+// FERS annuity. A postponed annuity_start_date is checked at commencement, so an MRA+10 retiree who
+// defers the annuity is accepted.
+func (ip *InputParser) checkRetirementEligibility(path string, employees map[string]domain.Employee, scenario *domain.Scenario, report *validationReport) {
+	for _, person := range []struct {
+		key      string
+		scenario *domain.RetirementScenario
+	}{{"person_a", &scenario.PersonA}, {"person_b", &scenario.PersonB}} {
+		employee, ok := employees[person.key]
+		if !ok || person.scenario.RetirementDate.IsZero() || employee.BirthDate.IsZero() || employee.HireDate.IsZero() {
+			continue
+		}
+		commencement := person.scenario.RetirementDate
+		if person.scenario.AnnuityStartDate != nil {
+			commencement = *person.scenario.AnnuityStartDate
+		}
+		if eligibility := employee.RetirementEligibility(person.scenario.RetirementDate, commencement); !eligibility.Eligible {
+			report.addErr(joinPath(joinPath(path, person.key), "retirement_date"), ErrNotEligible, "%s retirement date: %s", person.key, eligibility.Reason)
+		}
+	}
+}
+
+// checkServiceBreakDates rejects a break in service that has not ended by the employee's retirement in a
+// scenario; a separation without re-employment is modeled as the retirement date itself
+func (ip *InputParser) checkServiceBreakDates(path string, employees map[string]domain.Employee, scenario *domain.Scenario, report *validationReport) {
+	for _, person := range []struct {
+		key      string
+		scenario *domain.RetirementScenario
+	}{{"person_a", &scenario.PersonA}, {"person_b", &scenario.PersonB}} {
+		employee, ok := employees[person.key]
+		if !ok || person.scenario.RetirementDate.IsZero() {
+			continue
+		}
+		for _, b := range employee.ServiceBreaks {
+			if b.ReemploymentDate.After(person.scenario.RetirementDate) {
+				report.addErr(joinPath(joinPath(path, person.key), "retirement_date"), ErrDateOrder, "%s re-employment on %s must come before the retirement date", person.key, b.ReemploymentDate.Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+// checkQDRODates rejects a QDRO TSP award dated after the employee's retirement in a scenario; the
+// transfer is modeled as a pre-retirement reduction of the balance
+func (ip *InputParser) checkQDRODates(path string, employees map[string]domain.Employee, scenario *domain.Scenario, report *validationReport) {
+	for _, person := range []struct {
+		key      string
+		scenario *domain.RetirementScenario
+	}{{"person_a", &scenario.PersonA}, {"person_b", &scenario.PersonB}} {
+		employee, ok := employees[person.key]
+		if !ok || employee.QDRO == nil || !employee.QDRO.TSPPercentage.IsPositive() || person.scenario.RetirementDate.IsZero() {
+			continue
+		}
+		if employee.QDRO.EffectiveDate.After(person.scenario.RetirementDate) {
+			report.addErr(joinPath(joinPath(path, person.key), "retirement_date"), ErrDateOrder, "%s QDRO TSP transfer on %s must take effect by the retirement date", person.key, employee.QDRO.EffectiveDate.Format("2006-01-02"))
+		}
+	}
 }
 
 // validateRetirementScenario validates a retirement scenario for an employee
 func (ip *InputParser) validateRetirementScenario(_ string, scenario *domain.RetirementScenario) error {
+	var report validationReport
+	ip.checkRetirementScenario("", scenario, &report)
+	return report.first()
+}
+
+// checkRetirementScenario records every problem with an employee's retirement scenario
+func (ip *InputParser) checkRetirementScenario(path string, scenario *domain.RetirementScenario, report *validationReport) {
 	if scenario.EmployeeName == "" {
-		return fmt.Errorf("employee name is required")
+		report.addErr(joinPath(path, "employee_name"), ErrMissingField, "employee name is required")
 	}
 	if scenario.RetirementDate.IsZero() {
-		return fmt.Errorf("retirement date is required")
+		report.addErr(joinPath(path, "retirement_date"), ErrMissingField, "retirement date is required")
+	}
+	if scenario.AnnuityStartDate != nil && scenario.AnnuityStartDate.Before(scenario.RetirementDate) {
+		report.addErr(joinPath(path, "annuity_start_date"), ErrDateOrder, "annuity start date cannot be before retirement date")
 	}
 	if scenario.SSStartAge < 62 || scenario.SSStartAge > 70 {
-		return fmt.Errorf("social security start age must be between 62 and 70")
+		report.addErr(joinPath(path, "ss_start_age"), ErrOutOfRange, "social security start age must be between 62 and 70")
 	}
-	if scenario.TSPWithdrawalStrategy != "4_percent_rule" && scenario.TSPWithdrawalStrategy != "need_based" && scenario.TSPWithdrawalStrategy != "variable_percentage" {
-		return fmt.Errorf("TSP withdrawal strategy must be '4_percent_rule', 'need_based', or 'variable_percentage'")
+	if scenario.TSPWithdrawalStrategy != "4_percent_rule" && scenario.TSPWithdrawalStrategy != "need_based" && scenario.TSPWithdrawalStrategy != "variable_percentage" && scenario.TSPWithdrawalStrategy != "tax_smart" && scenario.TSPWithdrawalStrategy != "annuity" && scenario.TSPWithdrawalStrategy != "ss_bridge" {
+		report.addErr(joinPath(path, "tsp_withdrawal_strategy"), ErrUnsupportedValue, "TSP withdrawal strategy must be '4_percent_rule', 'need_based', 'variable_percentage', 'tax_smart', 'annuity', or 'ss_bridge'")
 	}
 	if scenario.TSPWithdrawalStrategy == "need_based" && scenario.TSPWithdrawalTargetMonthly == nil {
-		return fmt.Errorf("TSP withdrawal target monthly is required for need_based strategy")
+		report.addErr(joinPath(path, "tsp_withdrawal_target_monthly"), ErrMissingField, "TSP withdrawal target monthly is required for need_based strategy")
+	}
+	if scenario.TSPWithdrawalTargetNet && scenario.TSPWithdrawalStrategy != "need_based" {
+		report.add(joinPath(path, "tsp_withdrawal_target_net"), "TSP withdrawal target net applies only to the need_based strategy")
 	}
 	if scenario.TSPWithdrawalStrategy == "variable_percentage" && scenario.TSPWithdrawalRate == nil {
-		return fmt.Errorf("TSP withdrawal rate is required for variable_percentage strategy")
+		report.addErr(joinPath(path, "tsp_withdrawal_rate"), ErrMissingField, "TSP withdrawal rate is required for variable_percentage strategy")
 	}
 	if scenario.TSPWithdrawalTargetMonthly != nil && scenario.TSPWithdrawalTargetMonthly.LessThanOrEqual(decimal.Zero) {
-		return fmt.Errorf("TSP withdrawal target monthly must be positive")
+		report.addErr(joinPath(path, "tsp_withdrawal_target_monthly"), ErrOutOfRange, "TSP withdrawal target monthly must be positive")
 	}
 	if scenario.TSPWithdrawalRate != nil && (scenario.TSPWithdrawalRate.LessThan(decimal.Zero) || scenario.TSPWithdrawalRate.GreaterThan(decimal.NewFromFloat(0.2))) {
-		return fmt.Errorf("TSP withdrawal rate must be between 0 and 20%%")
+		report.addErr(joinPath(path, "tsp_withdrawal_rate"), ErrOutOfRange, "TSP withdrawal rate must be between 0 and 20%%")
+	}
+	if scenario.TSPTargetBracketRate != nil && (scenario.TSPTargetBracketRate.LessThan(decimal.Zero) || scenario.TSPTargetBracketRate.GreaterThan(decimal.NewFromFloat(0.37))) {
+		report.addErr(joinPath(path, "tsp_target_bracket_rate"), ErrOutOfRange, "TSP target bracket rate must be between 0 and 37%%")
+	}
+	if a := scenario.TSPAnnuity; a != nil {
+		pct := a.SurvivorPercentage
+		if !pct.IsZero() && !pct.Equal(decimal.NewFromFloat(0.5)) && !pct.Equal(decimal.NewFromInt(1)) {
+			report.addErr(joinPath(path, "tsp_annuity.survivor_percentage"), ErrUnsupportedValue, "TSP annuity survivor percentage must be 0, 0.5, or 1.0")
+		}
+		if a.InterestRate.LessThan(decimal.Zero) || a.InterestRate.GreaterThan(decimal.NewFromFloat(0.15)) {
+			report.addErr(joinPath(path, "tsp_annuity.interest_rate"), ErrOutOfRange, "TSP annuity interest rate must be between 0 and 15%%")
+		}
+	}
+	if sm := scenario.RMDSmoothing; sm != nil {
+		if sm.TargetAnnual.IsNegative() {
+			report.addErr(joinPath(path, "rmd_smoothing.target_annual"), ErrOutOfRange, "RMD smoothing target cannot be negative")
+		}
+		if sm.BracketRate != nil && (sm.BracketRate.LessThan(decimal.Zero) || sm.BracketRate.GreaterThan(decimal.NewFromFloat(0.37))) {
+			report.addErr(joinPath(path, "rmd_smoothing.bracket_rate"), ErrOutOfRange, "RMD smoothing bracket rate must be between 0 and 37%%")
+		}
+		if sm.TargetAnnual.IsZero() && sm.BracketRate == nil {
+			report.add(joinPath(path, "rmd_smoothing"), "RMD smoothing needs a target_annual or a bracket_rate")
+		}
+	}
+	conversionYears := make([]int, 0, len(scenario.RothConversions))
+	for year := range scenario.RothConversions {
+		conversionYears = append(conversionYears, year)
+	}
+	sort.Ints(conversionYears)
+	for _, year := range conversionYears {
+		if scenario.RothConversions[year].IsNegative() {
+			report.addErr(joinPath(path, fmt.Sprintf("roth_conversions.%d", year)), ErrOutOfRange, "Roth conversion amount cannot be negative")
+		}
+	}
+	if c := scenario.SpendingCurve; c != nil {
+		if scenario.TSPWithdrawalStrategy != "need_based" {
+			report.add(joinPath(path, "spending_curve"), "spending curve applies only to the need_based strategy")
+		}
+		if c.PivotAge < 50 || c.PivotAge > 110 {
+			report.addErr(joinPath(path, "spending_curve.pivot_age"), ErrOutOfRange, "spending curve pivot age must be between 50 and 110")
+		}
+		limit := decimal.NewFromFloat(0.1)
+		if c.EarlyRate.Abs().GreaterThan(limit) || c.LateRate.Abs().GreaterThan(limit) {
+			report.addErr(joinPath(path, "spending_curve"), ErrOutOfRange, "spending curve rates must be between -10%% and 10%% a year")
+		}
+	}
+	if w := scenario.PostRetirementWages; w != nil {
+		if w.AnnualAmount.LessThan(decimal.Zero) || w.EndAge < 0 {
+			report.addErr(joinPath(path, "post_retirement_wages"), ErrOutOfRange, "post-retirement wages amount and end age cannot be negative")
+		}
+		years := make([]int, 0, len(w.Schedule))
+		for year := range w.Schedule {
+			years = append(years, year)
+		}
+		sort.Ints(years)
+		for _, year := range years {
+			if w.Schedule[year].LessThan(decimal.Zero) {
+				report.addErr(joinPath(path, fmt.Sprintf("post_retirement_wages.schedule.%d", year)), ErrOutOfRange, "post-retirement wages for %d cannot be negative", year)
+			}
+		}
 	}
-
-	return nil
 }
 
 // CreateExampleConfiguration creates an example configuration file
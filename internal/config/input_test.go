@@ -109,6 +109,125 @@ personal_details:
 	assert.Contains(t, err.Error(), "failed to parse YAML")
 }
 
+func TestLoadFromFile_JSONMatchesYAML(t *testing.T) {
+	yamlConfig := `personal_details:
+  person_a:
+    name: "PersonA"
+    birth_date: "1963-06-15T00:00:00Z"
+    hire_date: "1985-03-20T00:00:00Z"
+    current_salary: "95000.00"
+    high_3_salary: "93000.00"
+    tsp_balance_traditional: "450000.00"
+    ss_benefit_62: "1680.00"
+    ss_benefit_fra: "2400.00"
+    ss_benefit_70: "2976.00"
+  person_b:
+    name: "PersonB"
+    birth_date: "1965-08-22T00:00:00Z"
+    hire_date: "1988-07-10T00:00:00Z"
+    current_salary: "85000.00"
+    high_3_salary: "83000.00"
+    ss_benefit_62: "1400.00"
+    ss_benefit_fra: "2000.00"
+    ss_benefit_70: "2480.00"
+global_assumptions:
+  inflation_rate: "0.025"
+  projection_years: 30
+  current_location:
+    state: "PA"
+scenarios:
+  - name: "Standard Retirement"
+    person_a:
+      employee_name: "person_a"
+      retirement_date: "2025-12-31T00:00:00Z"
+      ss_start_age: 67
+      tsp_withdrawal_strategy: "need_based"
+      tsp_withdrawal_target_monthly: "5000.00"
+    person_b:
+      employee_name: "person_b"
+      retirement_date: "2025-12-31T00:00:00Z"
+      ss_start_age: 67
+      tsp_withdrawal_strategy: "variable_percentage"
+      tsp_withdrawal_rate: "0.04"
+`
+	// Decimal fields are given both as strings and as numbers
+	jsonConfig := `{
+  "personal_details": {
+    "person_a": {
+      "name": "PersonA",
+      "birth_date": "1963-06-15T00:00:00Z",
+      "hire_date": "1985-03-20T00:00:00Z",
+      "current_salary": "95000.00",
+      "high_3_salary": 93000.00,
+      "tsp_balance_traditional": "450000.00",
+      "ss_benefit_62": 1680.00,
+      "ss_benefit_fra": 2400.00,
+      "ss_benefit_70": "2976.00"
+    },
+    "person_b": {
+      "name": "PersonB",
+      "birth_date": "1965-08-22T00:00:00Z",
+      "hire_date": "1988-07-10T00:00:00Z",
+      "current_salary": 85000.00,
+      "high_3_salary": "83000.00",
+      "ss_benefit_62": "1400.00",
+      "ss_benefit_fra": "2000.00",
+      "ss_benefit_70": 2480.00
+    }
+  },
+  "global_assumptions": {
+    "inflation_rate": 0.025,
+    "projection_years": 30,
+    "current_location": {"state": "PA"}
+  },
+  "scenarios": [
+    {
+      "name": "Standard Retirement",
+      "person_a": {
+        "employee_name": "person_a",
+        "retirement_date": "2025-12-31T00:00:00Z",
+        "ss_start_age": 67,
+        "tsp_withdrawal_strategy": "need_based",
+        "tsp_withdrawal_target_monthly": 5000.00
+      },
+      "person_b": {
+        "employee_name": "person_b",
+        "retirement_date": "2025-12-31T00:00:00Z",
+        "ss_start_age": 67,
+        "tsp_withdrawal_strategy": "variable_percentage",
+        "tsp_withdrawal_rate": "0.04"
+      }
+    }
+  ]
+}`
+
+	dir := t.TempDir()
+	yamlPath := dir + "/config.yaml"
+	jsonPath := dir + "/config.json"
+	require.NoError(t, os.WriteFile(yamlPath, []byte(yamlConfig), 0644))
+	require.NoError(t, os.WriteFile(jsonPath, []byte(jsonConfig), 0644))
+
+	parser := NewInputParser()
+	fromYAML, err := parser.LoadFromFile(yamlPath)
+	require.NoError(t, err)
+	fromJSON, err := parser.LoadFromFile(jsonPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, fromYAML, fromJSON)
+	require.NotNil(t, fromJSON.Scenarios[0].PersonA.TSPWithdrawalTargetMonthly)
+	assert.True(t, fromJSON.Scenarios[0].PersonA.TSPWithdrawalTargetMonthly.Equal(decimal.NewFromInt(5000)))
+}
+
+func TestLoadFromFile_InvalidJSON(t *testing.T) {
+	path := t.TempDir() + "/config.json"
+	require.NoError(t, os.WriteFile(path, []byte(`{"scenarios": [{"person_a": {"tsp_withdrawal_rate": "abc"}}]}`), 0644))
+
+	config, err := NewInputParser().LoadFromFile(path)
+	assert.Error(t, err)
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "failed to parse JSON")
+}
+
 func TestValidateConfiguration_Success(t *testing.T) {
 	parser := NewInputParser()
 	config := createValidTestConfiguration()
@@ -117,6 +236,134 @@ func TestValidateConfiguration_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateConfigurationAll_Valid(t *testing.T) {
+	assert.Empty(t, NewInputParser().ValidateConfigurationAll(createValidTestConfiguration()))
+}
+
+func TestValidateConfigurationAll_ReportsEveryError(t *testing.T) {
+	parser := NewInputParser()
+	config := createValidTestConfiguration()
+	personA := config.PersonalDetails["person_a"]
+	personA.TSPBalanceTraditional = decimal.NewFromInt(-1)
+	config.PersonalDetails["person_a"] = personA
+	config.GlobalAssumptions.ProjectionYears = 0
+	config.Scenarios[0].PersonB.SSStartAge = 75
+
+	errs := parser.ValidateConfigurationAll(config)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "personal_details.person_a.tsp_balance_traditional", errs[0].Field)
+	assert.Equal(t, "global_assumptions.projection_years", errs[1].Field)
+	assert.Equal(t, "scenarios[0].person_b.ss_start_age", errs[2].Field)
+	assert.Contains(t, errs[2].Error(), "social security start age must be between 62 and 70")
+	assert.ErrorIs(t, errs[2], ErrOutOfRange)
+	assert.ErrorIs(t, errs[2], ErrInvalidConfiguration)
+
+	// The fail-fast method still stops at the first problem
+	err := parser.ValidateConfiguration(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TSP traditional balance cannot be negative")
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	var validationErr ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "tsp_balance_traditional", validationErr.Field)
+}
+
+func TestValidateConfiguration_RetirementEligibility(t *testing.T) {
+	parser := NewInputParser()
+
+	// PersonA (born 1963, hired 1985) retiring at 62 with 40 years is eligible
+	config := createValidTestConfiguration()
+	assert.NoError(t, parser.ValidateConfiguration(config))
+
+	// Retiring at 48 qualifies for no annuity
+	config.Scenarios[0].PersonA.RetirementDate = time.Date(2011, 12, 31, 0, 0, 0, 0, time.UTC)
+	err := parser.ValidateConfiguration(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not eligible for a FERS annuity at age 48")
+	assert.ErrorIs(t, err, ErrNotEligible)
+	errs := parser.ValidateConfigurationAll(config)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "scenarios[0].person_a.retirement_date", errs[0].Field)
+
+	// Separating at 48 is fine when the annuity is postponed to 62
+	deferred := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	config.Scenarios[0].PersonA.AnnuityStartDate = &deferred
+	assert.NoError(t, parser.ValidateConfiguration(config))
+
+	// At the MRA with 10-29 years an immediate MRA+10 annuity is allowed
+	config = createValidTestConfiguration()
+	personA := config.PersonalDetails["person_a"]
+	personA.HireDate = time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC)
+	config.PersonalDetails["person_a"] = personA
+	config.Scenarios[0].PersonA.RetirementDate = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, parser.ValidateConfiguration(config))
+}
+
+func TestValidateConfiguration_DisabilityRetirement(t *testing.T) {
+	parser := NewInputParser()
+
+	// At 55 with 20.5 years, person_a qualifies for no regular annuity
+	config := createValidTestConfiguration()
+	personA := config.PersonalDetails["person_a"]
+	personA.BirthDate = time.Date(1970, 7, 1, 0, 0, 0, 0, time.UTC)
+	personA.HireDate = time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC)
+	config.PersonalDetails["person_a"] = personA
+	config.Scenarios[0].PersonA.RetirementDate = time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	err := parser.ValidateConfiguration(config)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotEligible)
+
+	// A disability retirement has no age requirement
+	personA.DisabilityRetirement = true
+	config.PersonalDetails["person_a"] = personA
+	assert.NoError(t, parser.ValidateConfiguration(config))
+
+	// but still needs 18 months of service
+	personA.HireDate = time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	config.PersonalDetails["person_a"] = personA
+	err = parser.ValidateConfiguration(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires 18 months")
+	assert.ErrorIs(t, err, ErrNotEligible)
+}
+
+func TestValidateConfiguration_SurvivorElection(t *testing.T) {
+	parser := NewInputParser()
+	for _, election := range []float64{0, 0.25, 0.5} {
+		config := createValidTestConfiguration()
+		personA := config.PersonalDetails["person_a"]
+		personA.SurvivorBenefitElectionPercent = decimal.NewFromFloat(election)
+		config.PersonalDetails["person_a"] = personA
+		assert.NoError(t, parser.ValidateConfiguration(config), "election %v", election)
+	}
+
+	// Anything else is rejected rather than mapped to the nearest election
+	for _, election := range []float64{0.1, 0.3, 0.4, 0.55, 1} {
+		config := createValidTestConfiguration()
+		personA := config.PersonalDetails["person_a"]
+		personA.SurvivorBenefitElectionPercent = decimal.NewFromFloat(election)
+		config.PersonalDetails["person_a"] = personA
+		err := parser.ValidateConfiguration(config)
+		require.Error(t, err, "election %v", election)
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+		assert.Contains(t, err.Error(), "must be 0 (none), 0.25 (partial), or 0.5 (full)")
+		var validationErr ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "survivor_benefit_election_percent", validationErr.Field)
+	}
+}
+
+func TestValidateConfigurationAll_MissingSections(t *testing.T) {
+	errs := NewInputParser().ValidateConfigurationAll(&domain.Configuration{})
+	fields := make([]string, len(errs))
+	for i, e := range errs {
+		fields[i] = e.Field
+	}
+	assert.Contains(t, fields, "personal_details")
+	assert.Contains(t, fields, "scenarios")
+	assert.Contains(t, fields, "global_assumptions.current_location.state")
+}
+
 func TestValidateConfiguration_NoPersonalDetails(t *testing.T) {
 	parser := NewInputParser()
 	config := &domain.Configuration{
@@ -201,6 +448,7 @@ func TestValidateEmployee_ZeroBirthDate(t *testing.T) {
 	err := parser.validateEmployee("person_a", &employee)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "birth date is required")
+	assert.ErrorIs(t, err, ErrMissingField)
 }
 
 func TestValidateEmployee_ZeroHireDate(t *testing.T) {
@@ -243,6 +491,62 @@ func TestValidateEmployee_NegativeTSPBalance(t *testing.T) {
 	assert.Contains(t, err.Error(), "TSP traditional balance cannot be negative")
 }
 
+func TestValidateEmployee_TSPAllocationNotSummingToOne(t *testing.T) {
+	parser := NewInputParser()
+	employee := createValidEmployee("person_a", "1963-06-15", "1985-03-20")
+	employee.TSPAllocation = &domain.TSPAllocation{
+		CFund: decimal.NewFromFloat(0.50),
+		SFund: decimal.NewFromFloat(0.20),
+		IFund: decimal.NewFromFloat(0.10),
+		FFund: decimal.NewFromFloat(0.10),
+	}
+
+	err := parser.validateEmployee("person_a", &employee)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must sum to 1.0, got 0.9")
+}
+
+func TestValidateEmployee_TSPAllocationSumsToOne(t *testing.T) {
+	parser := NewInputParser()
+	employee := createValidEmployee("person_a", "1963-06-15", "1985-03-20")
+	employee.TSPAllocation = &domain.TSPAllocation{
+		CFund: decimal.NewFromFloat(0.60),
+		SFund: decimal.NewFromFloat(0.20),
+		IFund: decimal.NewFromFloat(0.10),
+		FFund: decimal.NewFromFloat(0.10),
+	}
+
+	assert.NoError(t, parser.validateEmployee("person_a", &employee))
+}
+
+func TestValidateEmployee_LifecycleAllocationNotSummingToOne(t *testing.T) {
+	parser := NewInputParser()
+	employee := createValidEmployee("person_a", "1963-06-15", "1985-03-20")
+	employee.TSPLifecycleFund = &domain.TSPLifecycleFund{
+		FundName: "L2030",
+		AllocationData: map[string][]domain.TSPAllocationDataPoint{
+			"L2030": {{Date: "2025-01-01", Allocation: domain.TSPAllocation{GFund: decimal.NewFromFloat(0.9)}}},
+		},
+	}
+
+	err := parser.validateEmployee("person_a", &employee)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "2025-01-01")
+}
+
+func TestValidateGlobalAssumptions_DefaultTSPAllocation(t *testing.T) {
+	parser := NewInputParser()
+	assumptions := domain.GlobalAssumptions{
+		ProjectionYears: 25,
+		CurrentLocation: domain.Location{State: "Pennsylvania"},
+	}
+	assumptions.MonteCarloSettings.DefaultTSPAllocation = domain.TSPAllocation{CFund: decimal.NewFromFloat(0.8)}
+
+	err := parser.validateGlobalAssumptions(&assumptions)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "default TSP allocation")
+}
+
 func TestValidateGlobalAssumptions_Success(t *testing.T) {
 	parser := NewInputParser()
 	assumptions := domain.GlobalAssumptions{
@@ -272,6 +576,7 @@ func TestValidateGlobalAssumptions_ExtremeDeflation(t *testing.T) {
 	err := parser.validateGlobalAssumptions(&assumptions)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "inflation rate cannot be less than -10%")
+	assert.ErrorIs(t, err, ErrOutOfRange)
 }
 
 func TestValidateGlobalAssumptions_NegativeFEHBInflation(t *testing.T) {
@@ -285,6 +590,20 @@ func TestValidateGlobalAssumptions_NegativeFEHBInflation(t *testing.T) {
 	assert.Contains(t, err.Error(), "FEHB premium inflation cannot be negative")
 }
 
+func TestValidateGlobalAssumptions_FEHBGovernmentShareOutOfRange(t *testing.T) {
+	parser := NewInputParser()
+	assumptions := domain.GlobalAssumptions{
+		ProjectionYears: 30,
+		CurrentLocation: domain.Location{State: "PA"},
+	}
+	assumptions.FederalRules.FEHBConfig.GovernmentShare = decimal.NewFromFloat(1.2)
+
+	err := parser.validateGlobalAssumptions(&assumptions)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "FEHB government_share must be between 0 and 1")
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
 func TestValidateGlobalAssumptions_ExtremeTSPReturn(t *testing.T) {
 	parser := NewInputParser()
 	assumptions := domain.GlobalAssumptions{
@@ -577,3 +896,66 @@ func createValidTestConfiguration() *domain.Configuration {
 		},
 	}
 }
+
+func TestApplyDefaults_ProjectionYearsFromJointLifeExpectancy(t *testing.T) {
+	config := &domain.Configuration{
+		PersonalDetails: map[string]domain.Employee{
+			"person_a": {BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC), Sex: "male"},
+			"person_b": {BirthDate: time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC), Sex: "female"},
+		},
+		GlobalAssumptions: domain.GlobalAssumptions{ProjectionStartYear: 2025},
+	}
+	applyDefaults(config)
+	assert.Equal(t, 27, config.GlobalAssumptions.ProjectionYears, "ceil of the 62/60 joint life expectancy")
+
+	// An explicit value is kept
+	config.GlobalAssumptions.ProjectionYears = 10
+	applyDefaults(config)
+	assert.Equal(t, 10, config.GlobalAssumptions.ProjectionYears)
+}
+
+func TestApplyDefaults_ProjectionYearsWithoutStartYearUsesClock(t *testing.T) {
+	defer domain.SetNowFunc(time.Now)
+	newConfig := func() *domain.Configuration {
+		return &domain.Configuration{
+			PersonalDetails: map[string]domain.Employee{
+				"person_a": {BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC), Sex: "male"},
+				"person_b": {BirthDate: time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC), Sex: "female"},
+			},
+		}
+	}
+
+	domain.SetNowFunc(func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) })
+	config := newConfig()
+	applyDefaults(config)
+	assert.Equal(t, 27, config.GlobalAssumptions.ProjectionYears, "same as an explicit 2025 start")
+
+	// Ten years later the couple is older and the joint life expectancy shorter
+	domain.SetNowFunc(func() time.Time { return time.Date(2035, 6, 1, 0, 0, 0, 0, time.UTC) })
+	later := newConfig()
+	applyDefaults(later)
+	assert.Less(t, later.GlobalAssumptions.ProjectionYears, 27)
+}
+
+func TestApplyDefaults_SSBenefitsFromIndexedEarnings(t *testing.T) {
+	earnings := make([]decimal.Decimal, 35)
+	for i := range earnings {
+		earnings[i] = decimal.NewFromInt(120000)
+	}
+	config := &domain.Configuration{
+		PersonalDetails: map[string]domain.Employee{
+			"person_a": {BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC), SSIndexedEarnings: earnings},
+			"person_b": {BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC), SSIndexedEarnings: earnings, SSBenefitFRA: decimal.NewFromInt(2000)},
+		},
+		GlobalAssumptions: domain.GlobalAssumptions{ProjectionYears: 10},
+	}
+	applyDefaults(config)
+
+	personA := config.PersonalDetails["person_a"]
+	assert.True(t, personA.SSBenefitFRA.IsPositive())
+	assert.True(t, personA.SSBenefit62.LessThan(personA.SSBenefitFRA))
+	assert.True(t, personA.SSBenefit70.GreaterThan(personA.SSBenefitFRA))
+	// Supplied benefits are kept
+	assert.True(t, config.PersonalDetails["person_b"].SSBenefitFRA.Equal(decimal.NewFromInt(2000)))
+	assert.True(t, config.PersonalDetails["person_b"].SSBenefit62.IsZero())
+}
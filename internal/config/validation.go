@@ -0,0 +1,73 @@
+package config
+
+import "fmt"
+
+// ValidationError describes one configuration problem and where it is
+type ValidationError struct {
+	Field   string `json:"field"`   // Path to the offending field, e.g. personal_details.person_a.birth_date
+	Message string `json:"message"` // Human-readable description of the problem
+	Err     error  `json:"-"`       // Kind of problem, such as ErrOutOfRange; nil when it has none
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Unwrap lets errors.Is match ErrInvalidConfiguration and the problem's kind
+func (e ValidationError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrInvalidConfiguration}
+	}
+	return []error{ErrInvalidConfiguration, e.Err}
+}
+
+// firstProblem is a ValidationError returned by fail-fast validation, which reads as the message alone
+type firstProblem struct {
+	ValidationError
+}
+
+// Error implements the error interface
+func (p firstProblem) Error() string {
+	return p.Message
+}
+
+// Unwrap returns the ValidationError, so errors.As finds the field
+func (p firstProblem) Unwrap() error {
+	return p.ValidationError
+}
+
+// validationReport collects validation problems in the order they are found
+type validationReport struct {
+	errors []ValidationError
+}
+
+// add records a problem with the field at path
+func (r *validationReport) add(path, format string, args ...interface{}) {
+	r.addErr(path, nil, format, args...)
+}
+
+// addErr records a problem of a given kind with the field at path
+func (r *validationReport) addErr(path string, kind error, format string, args ...interface{}) {
+	r.errors = append(r.errors, ValidationError{Field: path, Message: fmt.Sprintf(format, args...), Err: kind})
+}
+
+// first returns the first problem as an error for fail-fast callers, or nil if there were none. The error
+// reads as the message alone and unwraps to the ValidationError.
+func (r *validationReport) first() error {
+	if len(r.errors) == 0 {
+		return nil
+	}
+	return firstProblem{r.errors[0]}
+}
+
+// joinPath appends a field name to a dotted configuration path
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
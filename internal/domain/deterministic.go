@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// nowFunc returns the current time (override in tests for determinism).
+var nowFunc = time.Now
+
+// SetNowFunc overrides the time provider used for defaults derived from the current year (use only in tests).
+func SetNowFunc(f func() time.Time) { nowFunc = f }
+
+// Now returns the current time from the overridable clock
+func Now() time.Time { return nowFunc() }
+
+// ProjectionStartYear returns the calendar year of projection year 0: the configured
+// projection_start_year, or the current year when it is unset.
+func ProjectionStartYear(assumptions *GlobalAssumptions) int {
+	if assumptions != nil && assumptions.ProjectionStartYear > 0 {
+		return assumptions.ProjectionStartYear
+	}
+	return nowFunc().Year()
+}
@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/rpgo/retirement-calculator/pkg/dateutil"
 	"github.com/shopspring/decimal"
 	"gopkg.in/yaml.v3"
 )
@@ -22,7 +27,78 @@ type Employee struct {
 	SSBenefit62                    decimal.Decimal `yaml:"ss_benefit_62" json:"ss_benefit_62"`   // Monthly at age 62
 	SSBenefit70                    decimal.Decimal `yaml:"ss_benefit_70" json:"ss_benefit_70"`   // Monthly at age 70
 	FEHBPremiumPerPayPeriod        decimal.Decimal `yaml:"fehb_premium_per_pay_period" json:"fehb_premium_per_pay_period"`
-	SurvivorBenefitElectionPercent decimal.Decimal `yaml:"survivor_benefit_election_percent" json:"survivor_benefit_election_percent"`
+	SurvivorBenefitElectionPercent decimal.Decimal `yaml:"survivor_benefit_election_percent" json:"survivor_benefit_election_percent"` // FERS survivor annuity elected: 0, 0.25, or 0.5
+
+	// Wage-indexed Social Security earnings, one entry per year worked. When set and the ss_benefit amounts
+	// are left empty, the benefits are derived from the PIA over the highest 35 years.
+	SSIndexedEarnings []decimal.Decimal `yaml:"ss_indexed_earnings,omitempty" json:"ss_indexed_earnings,omitempty"`
+
+	// FEHB enrollment type that FEHBPremiumPerPayPeriod is for (default self_and_family), and optionally the
+	// plan's premium for each type so the premium can step down when fewer people remain covered
+	FEHBEnrollment   string            `yaml:"fehb_enrollment,omitempty" json:"fehb_enrollment,omitempty"`
+	FEHBTierPremiums *FEHBTierPremiums `yaml:"fehb_tier_premiums,omitempty" json:"fehb_tier_premiums,omitempty"`
+
+	// Date FEHB coverage began. A postponed MRA+10 annuity reinstates FEHB only after five years of coverage
+	// before separation; unset assumes the requirement is met.
+	FEHBEnrolledSince *time.Time `yaml:"fehb_enrolled_since,omitempty" json:"fehb_enrolled_since,omitempty"`
+
+	// Share (0-1) of the employee's own TSP contribution designated Roth. The agency match always goes to
+	// the traditional balance.
+	TSPRothContributionPercent decimal.Decimal `yaml:"tsp_roth_contribution_percent,omitempty" json:"tsp_roth_contribution_percent,omitempty"`
+
+	// Annual raise (step increases, locality and pay adjustments) applied to CurrentSalary each year until
+	// retirement. When set, High-3 is projected from the final three years of salary.
+	SalaryGrowthRate *decimal.Decimal `yaml:"salary_growth_rate,omitempty" json:"salary_growth_rate,omitempty"`
+
+	// Military service. Years bought back with a deposit are creditable service; without the deposit
+	// they count toward the annuity only until age 62 for Social Security-eligible retirees (Catch-62).
+	MilitaryServiceYears decimal.Decimal `yaml:"military_service_years,omitempty" json:"military_service_years,omitempty"`
+	MilitaryDepositPaid  bool            `yaml:"military_deposit_paid,omitempty" json:"military_deposit_paid,omitempty"`
+
+	// Periods out of federal service before retirement, such as a deferred resignation or a separation
+	// followed by re-employment. Salary, TSP contributions and service credit pause during each break.
+	ServiceBreaks []ServiceBreak `yaml:"service_breaks,omitempty" json:"service_breaks,omitempty"`
+
+	// Special-category retirees (law enforcement, firefighters, air traffic controllers) may retire
+	// unreduced at 50 with 20 years of service or at any age with 25, and receive FERS COLAs from the start
+	// of the annuity instead of waiting until age 62.
+	SpecialCategory bool `yaml:"special_category,omitempty" json:"special_category,omitempty"`
+
+	// Disability retirement under 62 pays 60% of High-3 for the first 12 months and 40% from then until 62,
+	// when the annuity is recomputed as an earned annuity crediting the time on disability as service. It
+	// is not age-reduced, receives COLAs from the second year, and carries no Special Retirement Supplement.
+	DisabilityRetirement bool `yaml:"disability_retirement,omitempty" json:"disability_retirement,omitempty"`
+
+	// Health Savings Account (HDHP enrollees). Contributions are payroll-deducted while working; in
+	// retirement the balance pays FEHB and Medicare premiums tax-free.
+	HSABalance      decimal.Decimal `yaml:"hsa_balance,omitempty" json:"hsa_balance,omitempty"`
+	HSAContribution decimal.Decimal `yaml:"hsa_annual_contribution,omitempty" json:"hsa_annual_contribution,omitempty"`
+
+	// Taxable brokerage account. Dividends are taxed each year and reinvested; withdrawals realize gains in
+	// proportion to the unrealized gain in the account. Both are taxed at long-term capital gains rates.
+	TaxableAccountBalance       decimal.Decimal  `yaml:"taxable_account_balance,omitempty" json:"taxable_account_balance,omitempty"`
+	TaxableAccountCostBasis     decimal.Decimal  `yaml:"taxable_account_cost_basis,omitempty" json:"taxable_account_cost_basis,omitempty"`
+	TaxableAccountReturn        *decimal.Decimal `yaml:"taxable_account_return,omitempty" json:"taxable_account_return,omitempty"` // Total return including dividends; defaults to the post-retirement TSP return
+	TaxableAccountDividendYield decimal.Decimal  `yaml:"taxable_account_dividend_yield,omitempty" json:"taxable_account_dividend_yield,omitempty"`
+
+	// Cash reserve (savings, money market, emergency fund). Interest at CashYield is taxed each year as
+	// ordinary income, including by Pennsylvania, and left in the account.
+	CashBalance decimal.Decimal `yaml:"cash_balance,omitempty" json:"cash_balance,omitempty"`
+	CashYield   decimal.Decimal `yaml:"cash_yield,omitempty" json:"cash_yield,omitempty"`
+
+	// Age to plan for. TSP longevity and success are judged up to the year this person reaches PlanToAge
+	// (the later of the two when both are set) instead of over the whole projection.
+	PlanToAge int `yaml:"plan_to_age,omitempty" json:"plan_to_age,omitempty"`
+
+	// "male" or "female", selecting the life table for life expectancy and simulated mortality. Unset uses
+	// the unisex table.
+	Sex string `yaml:"sex,omitempty" json:"sex,omitempty"`
+
+	// Pensions from non-federal employers (private-sector or state plans), paid from a set age
+	ExternalPensions []ExternalPension `yaml:"external_pensions,omitempty" json:"external_pensions,omitempty"`
+
+	// Court order (QDRO) awarding a former spouse part of the TSP and FERS annuity
+	QDRO *QDRO `yaml:"qdro,omitempty" json:"qdro,omitempty"`
 
 	// Sick Leave Credit (for pension calculation)
 	SickLeaveHours decimal.Decimal `yaml:"sick_leave_hours,omitempty" json:"sick_leave_hours,omitempty"`
@@ -30,6 +106,10 @@ type Employee struct {
 	// TSP Asset Allocation (optional - uses default allocation if not specified)
 	TSPAllocation *TSPAllocation `yaml:"tsp_allocation,omitempty" json:"tsp_allocation,omitempty"`
 
+	// Years between rebalances of the TSP back to its target allocation; in between, each fund grows at its
+	// own return and the mix drifts. 0 or 1 rebalances every year. Lifecycle funds always rebalance annually.
+	TSPRebalanceYears int `yaml:"tsp_rebalance_years,omitempty" json:"tsp_rebalance_years,omitempty"`
+
 	// TSP Lifecycle Fund (optional - overrides tsp_allocation if specified)
 	// If specified, allocation will change over time based on age
 	TSPLifecycleFund *TSPLifecycleFund `yaml:"tsp_lifecycle_fund,omitempty" json:"tsp_lifecycle_fund,omitempty"`
@@ -43,22 +123,152 @@ type Employee struct {
 type RetirementScenario struct {
 	EmployeeName               string           `yaml:"employee_name" json:"employee_name"`
 	RetirementDate             time.Time        `yaml:"retirement_date" json:"retirement_date"`
+	AnnuityStartDate           *time.Time       `yaml:"annuity_start_date,omitempty" json:"annuity_start_date,omitempty"` // Deferred/postponed FERS annuity start; defaults to RetirementDate
 	SSStartAge                 int              `yaml:"ss_start_age" json:"ss_start_age"`
 	TSPWithdrawalStrategy      string           `yaml:"tsp_withdrawal_strategy" json:"tsp_withdrawal_strategy"`
 	TSPWithdrawalTargetMonthly *decimal.Decimal `yaml:"tsp_withdrawal_target_monthly,omitempty" json:"tsp_withdrawal_target_monthly,omitempty"`
-	TSPWithdrawalRate          *decimal.Decimal `yaml:"tsp_withdrawal_rate,omitempty" json:"tsp_withdrawal_rate,omitempty"`
+	TSPWithdrawalTargetNet     bool             `yaml:"tsp_withdrawal_target_net,omitempty" json:"tsp_withdrawal_target_net,omitempty"` // need_based: the target is after tax, so withdrawals are grossed up
+	TSPWithdrawalRate          *decimal.Decimal `yaml:"tsp_withdrawal_rate,omitempty" json:"tsp_withdrawal_rate,omitempty"`             // variable_percentage: share of the current balance; ss_bridge: first-year rate (default 0.04)
+	TSPTargetBracketRate       *decimal.Decimal `yaml:"tsp_target_bracket_rate,omitempty" json:"tsp_target_bracket_rate,omitempty"`     // tax_smart: fill traditional withdrawals to the top of this bracket (default 0.12)
+	QCDAnnualAmount            decimal.Decimal  `yaml:"qcd_annual_amount,omitempty" json:"qcd_annual_amount,omitempty"`                 // Desired qualified charitable distribution per year
+	DeferFirstRMD              bool             `yaml:"defer_first_rmd,omitempty" json:"defer_first_rmd,omitempty"`                     // Take the first RMD by April 1 of the next year, so that year pays two
+
+	// Optional part-time / phased retirement earnings after separation
+	PostRetirementWages *PostRetirementWages `yaml:"post_retirement_wages,omitempty" json:"post_retirement_wages,omitempty"`
+
+	// TSP life annuity options, used when TSPWithdrawalStrategy is "annuity"
+	TSPAnnuity *TSPAnnuityElection `yaml:"tsp_annuity,omitempty" json:"tsp_annuity,omitempty"`
+
+	// Optional accelerated traditional TSP withdrawals before RMDs begin
+	RMDSmoothing *RMDSmoothing `yaml:"rmd_smoothing,omitempty" json:"rmd_smoothing,omitempty"`
+
+	// Optional Roth conversions: calendar year -> amount moved from the traditional to the Roth TSP balance,
+	// taxed as ordinary income that year
+	RothConversions map[int]decimal.Decimal `yaml:"roth_conversions,omitempty" json:"roth_conversions,omitempty"`
+
+	// Optional age-based change to the need_based withdrawal target (the "retirement smile")
+	SpendingCurve *SpendingCurve `yaml:"spending_curve,omitempty" json:"spending_curve,omitempty"`
+}
+
+// SpendingCurve models the "retirement smile": spending falls through the active years of retirement
+// and rises again with late-life healthcare costs. The need_based withdrawal target changes by EarlyRate
+// a year from the first withdrawal year until PivotAge, then by LateRate a year after it, compounding;
+// e.g. -0.01 through 80, then +0.02. The rates are real: the target is indexed to inflation first and
+// the curve applies on top, so -0.01 means spending trails inflation by about 1% a year.
+type SpendingCurve struct {
+	EarlyRate decimal.Decimal `yaml:"early_rate" json:"early_rate"`
+	PivotAge  int             `yaml:"pivot_age" json:"pivot_age"`
+	LateRate  decimal.Decimal `yaml:"late_rate" json:"late_rate"`
+}
+
+// Factor returns the multiple of the starting target that applies at age, for a retiree whose first
+// withdrawal year was at startAge
+func (c *SpendingCurve) Factor(startAge, age int) decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	if c == nil || age <= startAge {
+		return one
+	}
+	earlyYears := age
+	if c.PivotAge < earlyYears {
+		earlyYears = c.PivotAge
+	}
+	earlyYears -= startAge
+	lateStart := startAge
+	if c.PivotAge > lateStart {
+		lateStart = c.PivotAge
+	}
+	factor := one
+	if earlyYears > 0 {
+		factor = factor.Mul(one.Add(c.EarlyRate).Pow(decimal.NewFromInt(int64(earlyYears))))
+	}
+	if lateYears := age - lateStart; lateYears > 0 {
+		factor = factor.Mul(one.Add(c.LateRate).Pow(decimal.NewFromInt(int64(lateYears))))
+	}
+	return factor
+}
+
+// RMDSmoothing raises traditional TSP withdrawals in the retirement years before RMDs begin, drawing the
+// balance down earlier so the eventual RMDs, and the bracket and IRMAA jump they cause, are smaller. Each
+// year the withdrawal is at least TargetAnnual, or enough to fill household taxable income to the top of
+// the BracketRate federal bracket; when both are set the larger applies. Tax-aware strategies (tax_smart)
+// already fill a bracket and ignore it.
+type RMDSmoothing struct {
+	TargetAnnual decimal.Decimal  `yaml:"target_annual,omitempty" json:"target_annual,omitempty"`
+	BracketRate  *decimal.Decimal `yaml:"bracket_rate,omitempty" json:"bracket_rate,omitempty"` // e.g. 0.22
+}
+
+// TSPAnnuityElection describes a TSP life annuity purchase. The whole TSP balance is converted at
+// retirement into a guaranteed payment for life.
+type TSPAnnuityElection struct {
+	SurvivorPercentage decimal.Decimal `yaml:"survivor_percentage,omitempty" json:"survivor_percentage,omitempty"` // 0 for single life; 0.5 or 1.0 for a joint annuity with the spouse
+	InflationIndexed   bool            `yaml:"inflation_indexed,omitempty" json:"inflation_indexed,omitempty"`     // Increasing payments: adjusted each year by inflation, capped at 3%
+	InterestRate       decimal.Decimal `yaml:"interest_rate,omitempty" json:"interest_rate,omitempty"`             // Annuity interest rate index used to price the payout; defaults to 4.5%
+}
+
+// IsJoint reports whether the annuity continues to the spouse after the annuitant's death
+func (e *TSPAnnuityElection) IsJoint() bool {
+	return e != nil && e.SurvivorPercentage.GreaterThan(decimal.Zero)
+}
+
+// ServiceBreak is a break in federal service from SeparationDate until ReemploymentDate
+type ServiceBreak struct {
+	SeparationDate   time.Time `yaml:"separation_date" json:"separation_date"`
+	ReemploymentDate time.Time `yaml:"reemployment_date" json:"reemployment_date"`
+}
+
+// PostRetirementWages describes earned income after separation: either a flat annual amount paid until
+// EndAge, or a schedule of wages by calendar year (schedule entries take precedence)
+type PostRetirementWages struct {
+	AnnualAmount decimal.Decimal         `yaml:"annual_amount,omitempty" json:"annual_amount,omitempty"`
+	EndAge       int                     `yaml:"end_age,omitempty" json:"end_age,omitempty"`   // Flat wages stop in the year this age is reached; 0 means no end
+	Schedule     map[int]decimal.Decimal `yaml:"schedule,omitempty" json:"schedule,omitempty"` // Calendar year -> annual wages
+}
+
+// ForYear returns the wages for a calendar year given the person's age that year and whether the amount
+// came from the schedule (a full-year figure) rather than the flat amount
+func (w *PostRetirementWages) ForYear(calendarYear, age int) (decimal.Decimal, bool) {
+	if w == nil {
+		return decimal.Zero, false
+	}
+	if amount, ok := w.Schedule[calendarYear]; ok {
+		return amount, true
+	}
+	if w.EndAge > 0 && age >= w.EndAge {
+		return decimal.Zero, false
+	}
+	return w.AnnualAmount, false
+}
+
+// AnnuityCommencementDate returns when the FERS annuity starts: AnnuityStartDate for a deferred or
+// postponed annuity, otherwise the retirement (separation) date
+func (rs *RetirementScenario) AnnuityCommencementDate() time.Time {
+	if rs.AnnuityStartDate != nil {
+		return *rs.AnnuityStartDate
+	}
+	return rs.RetirementDate
 }
 
 // UnmarshalYAML implements custom YAML unmarshaling for RetirementScenario
 func (rs *RetirementScenario) UnmarshalYAML(value *yaml.Node) error {
 	// Define a temporary struct with string fields for parsing
 	type Alias struct {
-		EmployeeName               string    `yaml:"employee_name"`
-		RetirementDate             time.Time `yaml:"retirement_date"`
-		SSStartAge                 int       `yaml:"ss_start_age"`
-		TSPWithdrawalStrategy      string    `yaml:"tsp_withdrawal_strategy"`
-		TSPWithdrawalTargetMonthly *string   `yaml:"tsp_withdrawal_target_monthly,omitempty"`
-		TSPWithdrawalRate          *string   `yaml:"tsp_withdrawal_rate,omitempty"`
+		EmployeeName               string     `yaml:"employee_name"`
+		RetirementDate             time.Time  `yaml:"retirement_date"`
+		AnnuityStartDate           *time.Time `yaml:"annuity_start_date,omitempty"`
+		SSStartAge                 int        `yaml:"ss_start_age"`
+		TSPWithdrawalStrategy      string     `yaml:"tsp_withdrawal_strategy"`
+		TSPWithdrawalTargetMonthly *string    `yaml:"tsp_withdrawal_target_monthly,omitempty"`
+		TSPWithdrawalTargetNet     bool       `yaml:"tsp_withdrawal_target_net,omitempty"`
+		TSPWithdrawalRate          *string    `yaml:"tsp_withdrawal_rate,omitempty"`
+		TSPTargetBracketRate       *string    `yaml:"tsp_target_bracket_rate,omitempty"`
+		QCDAnnualAmount            *string    `yaml:"qcd_annual_amount,omitempty"`
+		DeferFirstRMD              bool       `yaml:"defer_first_rmd,omitempty"`
+
+		PostRetirementWages *PostRetirementWages    `yaml:"post_retirement_wages,omitempty"`
+		TSPAnnuity          *TSPAnnuityElection     `yaml:"tsp_annuity,omitempty"`
+		RMDSmoothing        *RMDSmoothing           `yaml:"rmd_smoothing,omitempty"`
+		RothConversions     map[int]decimal.Decimal `yaml:"roth_conversions,omitempty"`
+		SpendingCurve       *SpendingCurve          `yaml:"spending_curve,omitempty"`
 	}
 
 	var aux Alias
@@ -69,8 +279,16 @@ func (rs *RetirementScenario) UnmarshalYAML(value *yaml.Node) error {
 	// Copy non-decimal fields
 	rs.EmployeeName = aux.EmployeeName
 	rs.RetirementDate = aux.RetirementDate
+	rs.AnnuityStartDate = aux.AnnuityStartDate
 	rs.SSStartAge = aux.SSStartAge
 	rs.TSPWithdrawalStrategy = aux.TSPWithdrawalStrategy
+	rs.TSPWithdrawalTargetNet = aux.TSPWithdrawalTargetNet
+	rs.DeferFirstRMD = aux.DeferFirstRMD
+	rs.PostRetirementWages = aux.PostRetirementWages
+	rs.TSPAnnuity = aux.TSPAnnuity
+	rs.RMDSmoothing = aux.RMDSmoothing
+	rs.RothConversions = aux.RothConversions
+	rs.SpendingCurve = aux.SpendingCurve
 
 	// Convert string decimal fields to *decimal.Decimal
 	if aux.TSPWithdrawalTargetMonthly != nil {
@@ -89,15 +307,215 @@ func (rs *RetirementScenario) UnmarshalYAML(value *yaml.Node) error {
 		rs.TSPWithdrawalRate = &val
 	}
 
+	if aux.TSPTargetBracketRate != nil {
+		val, err := decimal.NewFromString(*aux.TSPTargetBracketRate)
+		if err != nil {
+			return err
+		}
+		rs.TSPTargetBracketRate = &val
+	}
+
+	if aux.QCDAnnualAmount != nil {
+		val, err := decimal.NewFromString(*aux.QCDAnnualAmount)
+		if err != nil {
+			return err
+		}
+		rs.QCDAnnualAmount = val
+	}
+
 	return nil
 }
 
+// UnmarshalJSON implements custom JSON unmarshaling for RetirementScenario so decimal fields
+// may be given either as strings or as numbers, matching the YAML behavior
+func (rs *RetirementScenario) UnmarshalJSON(data []byte) error {
+	type plain RetirementScenario
+	aux := struct {
+		*plain
+		TSPWithdrawalTargetMonthly json.RawMessage `json:"tsp_withdrawal_target_monthly,omitempty"`
+		TSPWithdrawalRate          json.RawMessage `json:"tsp_withdrawal_rate,omitempty"`
+		TSPTargetBracketRate       json.RawMessage `json:"tsp_target_bracket_rate,omitempty"`
+		QCDAnnualAmount            json.RawMessage `json:"qcd_annual_amount,omitempty"`
+	}{plain: (*plain)(rs)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if rs.TSPWithdrawalTargetMonthly, err = decimalFromJSON(aux.TSPWithdrawalTargetMonthly); err != nil {
+		return fmt.Errorf("tsp_withdrawal_target_monthly: %w", err)
+	}
+	if rs.TSPWithdrawalRate, err = decimalFromJSON(aux.TSPWithdrawalRate); err != nil {
+		return fmt.Errorf("tsp_withdrawal_rate: %w", err)
+	}
+	if rs.TSPTargetBracketRate, err = decimalFromJSON(aux.TSPTargetBracketRate); err != nil {
+		return fmt.Errorf("tsp_target_bracket_rate: %w", err)
+	}
+	qcd, err := decimalFromJSON(aux.QCDAnnualAmount)
+	if err != nil {
+		return fmt.Errorf("qcd_annual_amount: %w", err)
+	}
+	if qcd != nil {
+		rs.QCDAnnualAmount = *qcd
+	}
+
+	return nil
+}
+
+// decimalFromJSON parses a JSON string or number into a decimal; missing or null values return nil
+func decimalFromJSON(raw json.RawMessage) (*decimal.Decimal, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	text := string(raw)
+	if raw[0] == '"' {
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, err
+		}
+	}
+	val, err := decimal.NewFromString(text)
+	if err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
 // Scenario represents a complete retirement scenario for both employees
 type Scenario struct {
 	Name      string             `yaml:"name" json:"name"`
 	PersonA   RetirementScenario `yaml:"person_a" json:"person_a"`
 	PersonB   RetirementScenario `yaml:"person_b" json:"person_b"`
 	Mortality *ScenarioMortality `yaml:"mortality,omitempty" json:"mortality,omitempty"`
+	Events    []FinancialEvent   `yaml:"events,omitempty" json:"events,omitempty"`
+
+	// Overrides federal_rules.fehb_config.medicare_coordination for this scenario
+	MedicareCoordination string `yaml:"medicare_coordination,omitempty" json:"medicare_coordination,omitempty"`
+
+	// Scheduled FEHB enrollment type changes, applied in date order
+	FEHBEnrollmentChanges []FEHBEnrollmentChange `yaml:"fehb_enrollment_changes,omitempty" json:"fehb_enrollment_changes,omitempty"`
+
+	// Optional household itemized deductions; federal tax uses the larger of these and the standard deduction
+	ItemizedDeductions *ItemizedDeductions `yaml:"itemized_deductions,omitempty" json:"itemized_deductions,omitempty"`
+
+	// Optional required household spending, compared against net income each year
+	SpendingNeed *SpendingNeed `yaml:"spending_need,omitempty" json:"spending_need,omitempty"`
+
+	// Retirement is reported to SSA as a life-changing event (Form SSA-44), so IRMAA in each spouse's first
+	// two retirement years uses that year's MAGI instead of the higher pre-retirement MAGI from two years prior
+	IRMAALifeChangingEvent bool `yaml:"irmaa_life_changing_event,omitempty" json:"irmaa_life_changing_event,omitempty"`
+
+	// Optional replacements for global assumptions that apply to this scenario only
+	AssumptionOverrides *AssumptionOverrides `yaml:"assumption_overrides,omitempty" json:"assumption_overrides,omitempty"`
+}
+
+// SpendingNeed is the household's required annual spending in today's dollars, inflated over the projection.
+// Schedule entries (calendar year -> amount, also in today's dollars) override the flat amount.
+type SpendingNeed struct {
+	AnnualAmount decimal.Decimal         `yaml:"annual_amount,omitempty" json:"annual_amount,omitempty"`
+	Schedule     map[int]decimal.Decimal `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+}
+
+// ForYear returns the need for a calendar year in today's dollars
+func (n *SpendingNeed) ForYear(calendarYear int) decimal.Decimal {
+	if n == nil {
+		return decimal.Zero
+	}
+	if amount, ok := n.Schedule[calendarYear]; ok {
+		return amount
+	}
+	return n.AnnualAmount
+}
+
+// ItemizedDeductions holds a household's annual Schedule A expenses. Amounts are held constant across
+// the projection, like the tax brackets.
+type ItemizedDeductions struct {
+	MortgageInterest        decimal.Decimal `yaml:"mortgage_interest,omitempty" json:"mortgage_interest,omitempty"`
+	StateAndLocalTaxes      decimal.Decimal `yaml:"state_and_local_taxes,omitempty" json:"state_and_local_taxes,omitempty"` // Property and state/local income taxes paid; deduction capped at $10,000
+	CharitableContributions decimal.Decimal `yaml:"charitable_contributions,omitempty" json:"charitable_contributions,omitempty"`
+	MedicalExpenses         decimal.Decimal `yaml:"medical_expenses,omitempty" json:"medical_expenses,omitempty"` // Only the amount above 7.5% of AGI is deductible
+}
+
+// Financial event accounts
+const (
+	EventAccountCash = "cash"
+	EventAccountTSP  = "tsp"
+)
+
+// FinancialEvent is a one-time inflow (positive amount) or outflow (negative amount) applied in the
+// calendar year of Date, such as a home sale, inheritance, or large purchase
+type FinancialEvent struct {
+	Name    string          `yaml:"name" json:"name"`
+	Date    time.Time       `yaml:"date" json:"date"`
+	Amount  decimal.Decimal `yaml:"amount" json:"amount"`
+	Taxable bool            `yaml:"taxable" json:"taxable"`                     // Whether the amount is ordinary taxable income
+	Account string          `yaml:"account,omitempty" json:"account,omitempty"` // cash (default) or tsp
+}
+
+// IsTSP reports whether the event flows into or out of the TSP rather than cash
+func (fe FinancialEvent) IsTSP() bool {
+	return fe.Account == EventAccountTSP
+}
+
+// FEHB enrollment types
+const (
+	FEHBSelfOnly      = "self_only"
+	FEHBSelfPlusOne   = "self_plus_one"
+	FEHBSelfAndFamily = "self_and_family"
+)
+
+// IsValidFEHBEnrollment reports whether enrollment is empty (default) or a known FEHB enrollment type
+func IsValidFEHBEnrollment(enrollment string) bool {
+	switch enrollment {
+	case "", FEHBSelfOnly, FEHBSelfPlusOne, FEHBSelfAndFamily:
+		return true
+	}
+	return false
+}
+
+// IsValidSurvivorElection reports whether percent is a FERS survivor annuity election: none (0), partial
+// (0.25), or full (0.5)
+func IsValidSurvivorElection(percent decimal.Decimal) bool {
+	return percent.IsZero() || percent.Equal(decimal.NewFromFloat(0.25)) || percent.Equal(decimal.NewFromFloat(0.5))
+}
+
+// FEHBTierPremiums holds a plan's per-pay-period premium for each enrollment type. Unset types fall back
+// to the employee's FEHBPremiumPerPayPeriod.
+type FEHBTierPremiums struct {
+	SelfOnly      decimal.Decimal `yaml:"self_only,omitempty" json:"self_only,omitempty"`
+	SelfPlusOne   decimal.Decimal `yaml:"self_plus_one,omitempty" json:"self_plus_one,omitempty"`
+	SelfAndFamily decimal.Decimal `yaml:"self_and_family,omitempty" json:"self_and_family,omitempty"`
+}
+
+// QDRO is a court order dividing an employee's TSP and FERS annuity with a former spouse. TSPPercentage
+// (0-1) of the TSP balance, traditional and Roth alike, is transferred out on EffectiveDate, which must be
+// before retirement. PensionShare (0-1) of each annuity payment made from EffectiveDate on is paid to the
+// former spouse.
+type QDRO struct {
+	EffectiveDate time.Time       `yaml:"effective_date" json:"effective_date"`
+	TSPPercentage decimal.Decimal `yaml:"tsp_percentage,omitempty" json:"tsp_percentage,omitempty"`
+	PensionShare  decimal.Decimal `yaml:"pension_share,omitempty" json:"pension_share,omitempty"`
+}
+
+// ExternalPension is a pension from a non-federal employer, such as a frozen private-sector or state plan.
+// MonthlyAmount is paid from the birthday the employee reaches StartAge, in that year's dollars. With
+// COLA set it grows each January after the first payment year by COLARate, or by the general COLA rate
+// when COLARate is not given. SurvivorPercentage (0-1) of the payment continues to a surviving spouse.
+type ExternalPension struct {
+	Name               string           `yaml:"name,omitempty" json:"name,omitempty"`
+	StartAge           int              `yaml:"start_age" json:"start_age"`
+	MonthlyAmount      decimal.Decimal  `yaml:"monthly_amount" json:"monthly_amount"`
+	COLA               bool             `yaml:"cola,omitempty" json:"cola,omitempty"`
+	COLARate           *decimal.Decimal `yaml:"cola_rate,omitempty" json:"cola_rate,omitempty"`
+	SurvivorPercentage decimal.Decimal  `yaml:"survivor_percentage,omitempty" json:"survivor_percentage,omitempty"`
+}
+
+// FEHBEnrollmentChange switches the FEHB enrollment type from the calendar year of Date, e.g. to self only
+// when a spouse moves to Medicare and drops FEHB
+type FEHBEnrollmentChange struct {
+	Date       time.Time `yaml:"date" json:"date"`
+	Enrollment string    `yaml:"enrollment" json:"enrollment"`
 }
 
 // ScenarioMortality groups mortality specifications and assumptions for a scenario
@@ -122,13 +540,22 @@ type MortalityAssumptions struct {
 
 // GlobalAssumptions contains all the global parameters for calculations
 type GlobalAssumptions struct {
-	InflationRate           decimal.Decimal `yaml:"inflation_rate" json:"inflation_rate"`
-	FEHBPremiumInflation    decimal.Decimal `yaml:"fehb_premium_inflation" json:"fehb_premium_inflation"`
-	TSPReturnPreRetirement  decimal.Decimal `yaml:"tsp_return_pre_retirement" json:"tsp_return_pre_retirement"`
-	TSPReturnPostRetirement decimal.Decimal `yaml:"tsp_return_post_retirement" json:"tsp_return_post_retirement"`
-	COLAGeneralRate         decimal.Decimal `yaml:"cola_general_rate" json:"cola_general_rate"`
-	ProjectionYears         int             `yaml:"projection_years" json:"projection_years"`
-	CurrentLocation         Location        `yaml:"current_location" json:"current_location"`
+	InflationRate           decimal.Decimal  `yaml:"inflation_rate" json:"inflation_rate"`
+	FEHBPremiumInflation    decimal.Decimal  `yaml:"fehb_premium_inflation" json:"fehb_premium_inflation"`
+	TSPReturnPreRetirement  decimal.Decimal  `yaml:"tsp_return_pre_retirement" json:"tsp_return_pre_retirement"`
+	TSPReturnPostRetirement decimal.Decimal  `yaml:"tsp_return_post_retirement" json:"tsp_return_post_retirement"`
+	COLAGeneralRate         decimal.Decimal  `yaml:"cola_general_rate" json:"cola_general_rate"`
+	ProjectionYears         int              `yaml:"projection_years" json:"projection_years"`
+	ProjectionStartYear     int              `yaml:"projection_start_year,omitempty" json:"projection_start_year,omitempty"` // First calendar year projected; defaults to the current year when unset
+	DiscountRate            *decimal.Decimal `yaml:"discount_rate,omitempty" json:"discount_rate,omitempty"`                 // For net present value; defaults to 3% when unset, 0 disables discounting
+	HSAReturn               *decimal.Decimal `yaml:"hsa_return,omitempty" json:"hsa_return,omitempty"`                       // HSA growth; defaults to the post-retirement TSP return when unset, 0 holds the balance flat
+	CurrentLocation         Location         `yaml:"current_location" json:"current_location"`
+
+	// Post-retirement glide path: age -> annual TSP return from that age on, for employees without a
+	// lifecycle fund or allocation. Ages before the first entry use TSPReturnPostRetirement. The path
+	// applies to the TSP only: HSA and taxable account returns that default to the post-retirement
+	// return use the flat TSPReturnPostRetirement, since those accounts are not invested along the path.
+	TSPReturnGlidePath map[int]decimal.Decimal `yaml:"tsp_return_glide_path,omitempty" json:"tsp_return_glide_path,omitempty"`
 
 	// Monte Carlo Configuration
 	MonteCarloSettings MonteCarloSettings `yaml:"monte_carlo_settings" json:"monte_carlo_settings"`
@@ -140,9 +567,73 @@ type GlobalAssumptions struct {
 	TSPStatisticalModels TSPStatisticalModels `yaml:"tsp_statistical_models" json:"tsp_statistical_models"`
 }
 
+// AssumptionOverrides shadows selected GlobalAssumptions fields for a single scenario. Unset fields keep
+// the global value.
+type AssumptionOverrides struct {
+	InflationRate           *decimal.Decimal `yaml:"inflation_rate,omitempty" json:"inflation_rate,omitempty"`
+	FEHBPremiumInflation    *decimal.Decimal `yaml:"fehb_premium_inflation,omitempty" json:"fehb_premium_inflation,omitempty"`
+	TSPReturnPreRetirement  *decimal.Decimal `yaml:"tsp_return_pre_retirement,omitempty" json:"tsp_return_pre_retirement,omitempty"`
+	TSPReturnPostRetirement *decimal.Decimal `yaml:"tsp_return_post_retirement,omitempty" json:"tsp_return_post_retirement,omitempty"`
+	COLAGeneralRate         *decimal.Decimal `yaml:"cola_general_rate,omitempty" json:"cola_general_rate,omitempty"`
+	ProjectionYears         *int             `yaml:"projection_years,omitempty" json:"projection_years,omitempty"`
+}
+
+// Apply returns a copy of base with the overridden fields replaced
+func (o *AssumptionOverrides) Apply(base GlobalAssumptions) GlobalAssumptions {
+	if o == nil {
+		return base
+	}
+	if o.InflationRate != nil {
+		base.InflationRate = *o.InflationRate
+	}
+	if o.FEHBPremiumInflation != nil {
+		base.FEHBPremiumInflation = *o.FEHBPremiumInflation
+	}
+	if o.TSPReturnPreRetirement != nil {
+		base.TSPReturnPreRetirement = *o.TSPReturnPreRetirement
+	}
+	if o.TSPReturnPostRetirement != nil {
+		base.TSPReturnPostRetirement = *o.TSPReturnPostRetirement
+	}
+	if o.COLAGeneralRate != nil {
+		base.COLAGeneralRate = *o.COLAGeneralRate
+	}
+	if o.ProjectionYears != nil {
+		base.ProjectionYears = *o.ProjectionYears
+	}
+	return base
+}
+
+// PostRetirementReturnAtAge returns the post-retirement TSP return for an employee of the given age: the
+// glide path entry for the highest age not above it, or TSPReturnPostRetirement when none applies
+func (ga *GlobalAssumptions) PostRetirementReturnAtAge(age int) decimal.Decimal {
+	rate, from := ga.TSPReturnPostRetirement, -1
+	for stepAge, stepRate := range ga.TSPReturnGlidePath {
+		if stepAge <= age && stepAge > from {
+			rate, from = stepRate, stepAge
+		}
+	}
+	return rate
+}
+
+// SetPostRetirementReturn sets TSPReturnPostRetirement to rate and shifts every glide path step by the
+// same amount, so a sweep or search over the post-retirement return moves the whole path with it. The
+// glide path is replaced rather than modified, leaving copies that share it unchanged.
+func (ga *GlobalAssumptions) SetPostRetirementReturn(rate decimal.Decimal) {
+	if len(ga.TSPReturnGlidePath) > 0 {
+		shift := rate.Sub(ga.TSPReturnPostRetirement)
+		path := make(map[int]decimal.Decimal, len(ga.TSPReturnGlidePath))
+		for age, stepRate := range ga.TSPReturnGlidePath {
+			path[age] = stepRate.Add(shift)
+		}
+		ga.TSPReturnGlidePath = path
+	}
+	ga.TSPReturnPostRetirement = rate
+}
+
 // GenerateAssumptions creates dynamic assumptions list from actual config values
 func (ga *GlobalAssumptions) GenerateAssumptions() []string {
-	return []string{
+	assumptions := []string{
 		fmt.Sprintf("General COLA (FERS pension & SS): %.1f%% annually", ga.COLAGeneralRate.Mul(decimal.NewFromInt(100)).InexactFloat64()),
 		fmt.Sprintf("FEHB premium inflation: %.1f%% annually", ga.FEHBPremiumInflation.Mul(decimal.NewFromInt(100)).InexactFloat64()),
 		fmt.Sprintf("TSP growth pre-retirement: %.1f%% annually", ga.TSPReturnPreRetirement.Mul(decimal.NewFromInt(100)).InexactFloat64()),
@@ -150,6 +641,19 @@ func (ga *GlobalAssumptions) GenerateAssumptions() []string {
 		"Social Security wage base indexing: ~5% annually (2025 est: $168,600)",
 		"Tax brackets: 2025 levels held constant (no inflation indexing)",
 	}
+	if len(ga.TSPReturnGlidePath) > 0 {
+		ages := make([]int, 0, len(ga.TSPReturnGlidePath))
+		for age := range ga.TSPReturnGlidePath {
+			ages = append(ages, age)
+		}
+		sort.Ints(ages)
+		steps := make([]string, len(ages))
+		for i, age := range ages {
+			steps[i] = fmt.Sprintf("%.1f%% from age %d", ga.TSPReturnGlidePath[age].Mul(decimal.NewFromInt(100)).InexactFloat64(), age)
+		}
+		assumptions = append(assumptions, "TSP post-retirement glide path: "+strings.Join(steps, ", "))
+	}
+	return assumptions
 }
 
 // Location represents the geographic location for tax calculations
@@ -183,6 +687,16 @@ type TSPAllocation struct {
 	GFund decimal.Decimal `yaml:"g_fund" json:"g_fund"` // Default: 0.00 (0% - Government Securities)
 }
 
+// Total returns the sum of the fund fractions, which should be 1.0
+func (a TSPAllocation) Total() decimal.Decimal {
+	return a.CFund.Add(a.SFund).Add(a.IFund).Add(a.FFund).Add(a.GFund)
+}
+
+// IsZero reports whether no fund has a weight, i.e. the allocation was not provided
+func (a TSPAllocation) IsZero() bool {
+	return a.CFund.IsZero() && a.SFund.IsZero() && a.IFund.IsZero() && a.FFund.IsZero() && a.GFund.IsZero()
+}
+
 // TSPLifecycleFund represents a TSP Lifecycle Fund with age-based allocation changes
 type TSPLifecycleFund struct {
 	FundName       string                              `yaml:"fund_name" json:"fund_name"`             // e.g., "L2030", "L2035", "L2040", "L Income"
@@ -220,6 +734,70 @@ type FederalRules struct {
 
 	// FEHB configuration
 	FEHBConfig FEHBConfig `yaml:"fehb_config" json:"fehb_config"`
+
+	// IRS elective deferral limits for employee TSP contributions
+	TSPContributionLimits TSPContributionLimits `yaml:"tsp_contribution_limits,omitempty" json:"tsp_contribution_limits,omitempty"`
+}
+
+// Default 2025 IRS elective deferral limits
+var (
+	defaultTSPElectiveDeferralLimit = decimal.NewFromInt(23500)
+	defaultTSPCatchUpLimit          = decimal.NewFromInt(7500)
+	defaultTSPSuperCatchUpLimit     = decimal.NewFromInt(11250)
+	tspLimitRoundingIncrement       = decimal.NewFromInt(500)
+)
+
+// TSPContributionLimits caps employee (not agency) TSP contributions. Zero values use the 2025 limits.
+type TSPContributionLimits struct {
+	ElectiveDeferralLimit decimal.Decimal  `yaml:"elective_deferral_limit,omitempty" json:"elective_deferral_limit,omitempty"` // Default: 23500 (2025)
+	CatchUpLimit          decimal.Decimal  `yaml:"catch_up_limit,omitempty" json:"catch_up_limit,omitempty"`                   // Default: 7500 (age 50+)
+	SuperCatchUpLimit     decimal.Decimal  `yaml:"super_catch_up_limit,omitempty" json:"super_catch_up_limit,omitempty"`       // Default: 11250 (ages 60-63, replaces the regular catch-up)
+	BaseYear              int              `yaml:"base_year,omitempty" json:"base_year,omitempty"`                             // Year the limits are stated for; default: 2025
+	IndexingRate          *decimal.Decimal `yaml:"indexing_rate,omitempty" json:"indexing_rate,omitempty"`                     // Annual limit growth; default: the inflation rate
+}
+
+// AnnualLimit returns the employee contribution limit for a calendar year given the age attained by the end
+// of that year. Limits grow at the indexing rate from the base year and, like the IRS figures, are rounded
+// down to a multiple of $500.
+func (l TSPContributionLimits) AnnualLimit(ageAtYearEnd, calendarYear int, inflationRate decimal.Decimal) decimal.Decimal {
+	base := l.ElectiveDeferralLimit
+	if base.IsZero() {
+		base = defaultTSPElectiveDeferralLimit
+	}
+	var catchUp decimal.Decimal
+	switch {
+	case ageAtYearEnd >= 60 && ageAtYearEnd <= 63:
+		catchUp = l.SuperCatchUpLimit
+		if catchUp.IsZero() {
+			catchUp = defaultTSPSuperCatchUpLimit
+		}
+	case ageAtYearEnd >= 50:
+		catchUp = l.CatchUpLimit
+		if catchUp.IsZero() {
+			catchUp = defaultTSPCatchUpLimit
+		}
+	}
+
+	baseYear := l.BaseYear
+	if baseYear == 0 {
+		baseYear = 2025
+	}
+	rate := inflationRate
+	if l.IndexingRate != nil {
+		rate = *l.IndexingRate
+	}
+	if years := calendarYear - baseYear; years > 0 {
+		factor := decimal.NewFromInt(1).Add(rate).Pow(decimal.NewFromInt(int64(years)))
+		base = indexTSPLimit(base, factor)
+		catchUp = indexTSPLimit(catchUp, factor)
+	}
+	return base.Add(catchUp)
+}
+
+// indexTSPLimit grows a limit by factor, never below its base amount, rounded down to a multiple of $500
+func indexTSPLimit(limit, factor decimal.Decimal) decimal.Decimal {
+	indexed := limit.Mul(factor).Div(tspLimitRoundingIncrement).Floor().Mul(tspLimitRoundingIncrement)
+	return decimal.Max(indexed, limit)
 }
 
 // SocialSecurityTaxThresholds contains income thresholds for SS taxation (updated annually)
@@ -246,6 +824,11 @@ type SocialSecurityRules struct {
 
 	// Delayed retirement credit: 2/3 of 1% per month (8% per year)
 	DelayedRetirementCredit decimal.Decimal `yaml:"delayed_retirement_credit" json:"delayed_retirement_credit"` // Default: 0.0066667 (2/3 of 1%)
+
+	// Retirement earnings test: benefits claimed before FRA are withheld $1 for every $2 of wages above the
+	// annual exempt amount, and $1 for every $3 above the higher amount in the year FRA is reached
+	EarningsTestExemptAmount        decimal.Decimal `yaml:"earnings_test_exempt_amount,omitempty" json:"earnings_test_exempt_amount,omitempty"`                   // Default: 23400 (2025)
+	EarningsTestFRAYearExemptAmount decimal.Decimal `yaml:"earnings_test_fra_year_exempt_amount,omitempty" json:"earnings_test_fra_year_exempt_amount,omitempty"` // Default: 62160 (2025)
 }
 
 // FERSRules contains FERS-specific rules and matching rates
@@ -253,6 +836,11 @@ type FERSRules struct {
 	// TSP matching rates
 	TSPMatchingRate      decimal.Decimal `yaml:"tsp_matching_rate" json:"tsp_matching_rate"`           // Default: 0.05 (5% maximum match)
 	TSPMatchingThreshold decimal.Decimal `yaml:"tsp_matching_threshold" json:"tsp_matching_threshold"` // Default: 0.05 (5% contribution required for full match)
+
+	// FERS "diet COLA" tiers; zero values use the statutory thresholds
+	COLAFullCPIThreshold decimal.Decimal `yaml:"cola_full_cpi_threshold,omitempty" json:"cola_full_cpi_threshold,omitempty"` // Default: 0.02 (CPI at or below is passed through in full)
+	COLACapThreshold     decimal.Decimal `yaml:"cola_cap_threshold,omitempty" json:"cola_cap_threshold,omitempty"`           // Default: 0.03 (CPI above is reduced by 1 point)
+	COLAMinimumAge       int             `yaml:"cola_minimum_age,omitempty" json:"cola_minimum_age,omitempty"`               // Default: 62 (regular retirees get no COLA before this age)
 }
 
 // FederalTaxConfig contains federal income tax configuration (updated annually)
@@ -265,6 +853,10 @@ type FederalTaxConfig struct {
 	// Tax brackets for 2025 (updated annually)
 	TaxBrackets2025       []TaxBracket `yaml:"tax_brackets_2025" json:"tax_brackets_2025"`
 	TaxBrackets2025Single []TaxBracket `yaml:"tax_brackets_2025_single" json:"tax_brackets_2025_single"`
+
+	// Long-term capital gains brackets (0/15/20%) by taxable income including the gains
+	CapitalGainsBrackets2025       []TaxBracket `yaml:"capital_gains_brackets_2025,omitempty" json:"capital_gains_brackets_2025,omitempty"`
+	CapitalGainsBrackets2025Single []TaxBracket `yaml:"capital_gains_brackets_2025_single,omitempty" json:"capital_gains_brackets_2025_single,omitempty"`
 }
 
 // TaxBracket represents a federal tax bracket
@@ -281,6 +873,31 @@ type StateLocalTaxConfig struct {
 
 	// Upper Makefield Township EIT (local tax)
 	UpperMakefieldEITRate decimal.Decimal `yaml:"upper_makefield_eit_rate" json:"upper_makefield_eit_rate"` // Default: 0.01 (1% on earned income)
+
+	// Local income taxes by municipality, matched against current_location.municipality ignoring case. An
+	// entry for Upper Makefield Township overrides upper_makefield_eit_rate. Unlisted municipalities pay no
+	// local tax.
+	LocalTaxes map[string]LocalTaxRule `yaml:"local_taxes,omitempty" json:"local_taxes,omitempty"`
+
+	// Retirement income rules for states that tax it. When unset, pensions, TSP withdrawals, and Social
+	// Security are fully exempt, as in Pennsylvania.
+	RetirementIncome *StateRetirementIncomeRules `yaml:"retirement_income,omitempty" json:"retirement_income,omitempty"`
+}
+
+// LocalTaxRule is a municipality's income tax: Rate on earned income, and on pensions and traditional TSP
+// withdrawals as well when TaxesRetirementIncome is set
+type LocalTaxRule struct {
+	Rate                  decimal.Decimal `yaml:"rate" json:"rate"`
+	TaxesRetirementIncome bool            `yaml:"taxes_retirement_income,omitempty" json:"taxes_retirement_income,omitempty"`
+}
+
+// StateRetirementIncomeRules makes pensions and TSP withdrawals state-taxable, with each person at or above
+// the minimum age excluding up to a flat amount of their own such income (e.g. New York's $20,000 at 59). Social Security stays exempt, as in
+// most states, unless TaxSocialSecurity is set, in which case its federally taxable portion is taxed.
+type StateRetirementIncomeRules struct {
+	ExclusionPerPerson decimal.Decimal `yaml:"exclusion_per_person" json:"exclusion_per_person"`
+	ExclusionMinAge    int             `yaml:"exclusion_min_age,omitempty" json:"exclusion_min_age,omitempty"`
+	TaxSocialSecurity  bool            `yaml:"tax_social_security,omitempty" json:"tax_social_security,omitempty"`
 }
 
 // FICATaxConfig contains FICA tax configuration (updated annually)
@@ -295,6 +912,12 @@ type FICATaxConfig struct {
 	// Additional Medicare tax (for high earners)
 	AdditionalMedicareRate decimal.Decimal `yaml:"additional_medicare_rate" json:"additional_medicare_rate"`   // Default: 0.009 (0.9%)
 	HighIncomeThresholdMFJ decimal.Decimal `yaml:"high_income_threshold_mfj" json:"high_income_threshold_mfj"` // Default: 250000 (MFJ)
+
+	// Year is the tax year the wage base above applies to (default 2025). In later projection years the wage
+	// base grows by WageBaseIndexingRate a year; the rates and the additional Medicare threshold are fixed
+	// in law and are not indexed.
+	Year                 int             `yaml:"year,omitempty" json:"year,omitempty"`
+	WageBaseIndexingRate decimal.Decimal `yaml:"wage_base_indexing_rate,omitempty" json:"wage_base_indexing_rate,omitempty"`
 }
 
 // MedicareConfig contains Medicare Part B premium configuration (updated annually)
@@ -304,6 +927,11 @@ type MedicareConfig struct {
 
 	// IRMAA (Income-Related Monthly Adjustment Amount) thresholds
 	IRMAAThresholds []MedicareIRMAAThreshold `yaml:"irmaa_thresholds" json:"irmaa_thresholds"`
+
+	// Optional Part D prescription drug coverage: base monthly premium and IRMAA-D surcharges, which use the
+	// same MAGI lookback as Part B. Both default to zero/empty, leaving Part D out of the premium.
+	PartDBasePremium2025 decimal.Decimal          `yaml:"part_d_base_premium_2025,omitempty" json:"part_d_base_premium_2025,omitempty"`
+	PartDIRMAAThresholds []MedicareIRMAAThreshold `yaml:"part_d_irmaa_thresholds,omitempty" json:"part_d_irmaa_thresholds,omitempty"`
 }
 
 // MedicareIRMAAThreshold represents an IRMAA income threshold and corresponding surcharge
@@ -324,6 +952,34 @@ type FEHBConfig struct {
 
 	// Custom multiplier for retirement premiums (if using custom_multiplier method)
 	RetirementPremiumMultiplier decimal.Decimal `yaml:"retirement_premium_multiplier" json:"retirement_premium_multiplier"` // Default: 1.0
+
+	// How FEHB coordinates with Medicare once a covered person turns 65
+	// Options: "keep_both", "medicare_primary", "suspend_fehb"
+	MedicareCoordination string `yaml:"medicare_coordination,omitempty" json:"medicare_coordination,omitempty"` // Default: "keep_both"
+
+	// Premium factor for the reduced plan chosen under medicare_primary
+	MedicarePrimaryPremiumFactor decimal.Decimal `yaml:"medicare_primary_premium_factor,omitempty" json:"medicare_primary_premium_factor,omitempty"` // Default: 0.5
+
+	// Share of the total premium the government pays (about 0.72). When set, configured FEHB premiums are
+	// the plan's total premium and only the enrollee's remaining share is modeled as out of pocket; when
+	// unset, they are taken as the enrollee's share already.
+	GovernmentShare decimal.Decimal `yaml:"government_share,omitempty" json:"government_share,omitempty"` // Default: 0
+}
+
+// FEHB/Medicare coordination modes
+const (
+	MedicareCoordinationKeepBoth        = "keep_both"        // Full FEHB premium alongside Medicare Part B
+	MedicareCoordinationMedicarePrimary = "medicare_primary" // Move to a reduced FEHB plan that wraps around Medicare
+	MedicareCoordinationSuspendFEHB     = "suspend_fehb"     // Suspend FEHB coverage; Medicare Part B continues
+)
+
+// IsValidMedicareCoordination reports whether mode is empty (default) or a known coordination mode
+func IsValidMedicareCoordination(mode string) bool {
+	switch mode {
+	case "", MedicareCoordinationKeepBoth, MedicareCoordinationMedicarePrimary, MedicareCoordinationSuspendFEHB:
+		return true
+	}
+	return false
 }
 
 // TSPStatisticalModels contains statistical parameters for each TSP fund
@@ -334,6 +990,11 @@ type TSPStatisticalModels struct {
 	IFund TSPFundStats `yaml:"i_fund" json:"i_fund"` // International Stock Index
 	FFund TSPFundStats `yaml:"f_fund" json:"f_fund"` // Fixed Income Index
 	GFund TSPFundStats `yaml:"g_fund" json:"g_fund"` // Government Securities
+
+	// CorrelationMatrix is an optional 5x5 correlation matrix between fund returns,
+	// ordered C, S, I, F, G. When omitted, fund returns are drawn independently. It must have a
+	// unit diagonal and be symmetric and positive definite; Monte Carlo runs reject any other matrix.
+	CorrelationMatrix [][]decimal.Decimal `yaml:"correlation_matrix,omitempty" json:"correlation_matrix,omitempty"`
 }
 
 // TSPFundStats contains statistical parameters for a TSP fund
@@ -351,19 +1012,23 @@ type Configuration struct {
 	Scenarios         []Scenario          `yaml:"scenarios" json:"scenarios"`
 }
 
-// Age calculates the age of the employee at a given date
+// Age calculates the age of the employee at a given date.
+// Month and day are compared directly (rather than day-of-year) so leap years
+// do not shift the birthday boundary for people born before March 1.
 func (e *Employee) Age(atDate time.Time) int {
 	age := atDate.Year() - e.BirthDate.Year()
-	if atDate.YearDay() < e.BirthDate.YearDay() {
+	if atDate.Month() < e.BirthDate.Month() ||
+		(atDate.Month() == e.BirthDate.Month() && atDate.Day() < e.BirthDate.Day()) {
 		age--
 	}
 	return age
 }
 
-// YearsOfService calculates the years of service at a given date, including sick leave credit
+// YearsOfService calculates the years of service at a given date, including sick leave credit and
+// military time bought back with a deposit
 func (e *Employee) YearsOfService(atDate time.Time) decimal.Decimal {
-	// Calculate basic service time from hire date to retirement/calculation date
-	serviceDuration := atDate.Sub(e.HireDate)
+	// Calculate basic service time from hire date to retirement/calculation date, less breaks in service
+	serviceDuration := atDate.Sub(e.HireDate) - e.serviceBreakDuration(e.HireDate, atDate)
 	years := decimal.NewFromFloat(serviceDuration.Hours() / 24 / 365.25)
 
 	// Add sick leave credit if available
@@ -375,9 +1040,57 @@ func (e *Employee) YearsOfService(atDate time.Time) decimal.Decimal {
 		years = years.Add(sickLeaveYears)
 	}
 
+	// Military time counts once the deposit has been paid
+	if e.MilitaryDepositPaid {
+		years = years.Add(e.MilitaryServiceYears)
+	}
+
 	return years.Round(4) // Round to 4 decimal places for precision
 }
 
+// serviceBreakDuration returns how much of the time between from and to falls in breaks in service
+func (e *Employee) serviceBreakDuration(from, to time.Time) time.Duration {
+	var total time.Duration
+	for _, b := range e.ServiceBreaks {
+		start, end := b.SeparationDate, b.ReemploymentDate
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// ServiceBreakFraction returns the share of a calendar year spent in breaks in service
+func (e *Employee) ServiceBreakFraction(calendarYear int) decimal.Decimal {
+	yearStart := time.Date(calendarYear, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextYear := yearStart.AddDate(1, 0, 0)
+	return decimal.NewFromFloat(e.serviceBreakDuration(yearStart, nextYear).Hours() / nextYear.Sub(yearStart).Hours())
+}
+
+// inServiceBreak reports whether a date falls in a break in service
+func (e *Employee) inServiceBreak(date time.Time) bool {
+	for _, b := range e.ServiceBreaks {
+		if !date.Before(b.SeparationDate) && date.Before(b.ReemploymentDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnpaidMilitaryYears returns military service years for which no deposit has been paid
+func (e *Employee) UnpaidMilitaryYears() decimal.Decimal {
+	if e.MilitaryDepositPaid {
+		return decimal.Zero
+	}
+	return e.MilitaryServiceYears
+}
+
 // FullRetirementAge calculates the Social Security Full Retirement Age based on birth year
 func (e *Employee) FullRetirementAge() int {
 	birthYear := e.BirthDate.Year()
@@ -412,6 +1125,73 @@ func (e *Employee) FullRetirementAge() int {
 	}
 }
 
+// MinimumRetirementDate returns the date the employee reaches the FERS Minimum Retirement Age, including
+// the extra two months per birth year that apply to those born 1948-1952 and 1965-1969
+func (e *Employee) MinimumRetirementDate() time.Time {
+	birthYear := e.BirthDate.Year()
+	years, months := 57, 0
+	switch {
+	case birthYear <= 1947:
+		years = 55
+	case birthYear <= 1952:
+		years, months = 55, 2*(birthYear-1947)
+	case birthYear <= 1964:
+		years = 56
+	case birthYear <= 1969:
+		years, months = 56, 2*(birthYear-1964)
+	}
+	return e.BirthDate.AddDate(years, months, 0)
+}
+
+// RetirementEligibility describes whether a separation qualifies for a FERS annuity
+type RetirementEligibility struct {
+	Eligible bool
+	Reduced  bool   // MRA+10 annuity, reduced 5% for each year under 62 at commencement
+	Reason   string // Which rule applies, or why none does
+}
+
+// RetirementEligibility checks FERS annuity eligibility for a separation on separationDate with the
+// annuity starting on commencementDate (the same date for an immediate annuity). The annuity is payable
+// at 62 with 5 years of service, 60 with 20, the MRA with 30, or the MRA with 10 (reduced); special-category
+// employees may also retire at 50 with 20 years or at any age with 25. A disability retirement has no age
+// requirement and needs 18 months of service. Service is measured at separation and age at commencement.
+func (e *Employee) RetirementEligibility(separationDate, commencementDate time.Time) RetirementEligibility {
+	service := e.YearsOfService(separationDate)
+	age := e.Age(commencementDate)
+	reachedMRA := !commencementDate.Before(e.MinimumRetirementDate())
+	hasService := func(years int64) bool { return service.GreaterThanOrEqual(decimal.NewFromInt(years)) }
+
+	if e.DisabilityRetirement {
+		if service.LessThan(decimal.NewFromFloat(1.5)) {
+			return RetirementEligibility{Reason: fmt.Sprintf("not eligible for a FERS disability annuity with %s years of service (requires 18 months)", service.StringFixed(1))}
+		}
+		return RetirementEligibility{Eligible: true, Reason: "disability retirement with 18+ months of service"}
+	}
+
+	switch {
+	case e.SpecialCategory && hasService(25):
+		return RetirementEligibility{Eligible: true, Reason: "special category with 25+ years of service"}
+	case e.SpecialCategory && age >= 50 && hasService(20):
+		return RetirementEligibility{Eligible: true, Reason: "special category at age 50 with 20+ years of service"}
+	case age >= 62 && hasService(5):
+		return RetirementEligibility{Eligible: true, Reason: "age 62 with 5+ years of service"}
+	case age >= 60 && hasService(20):
+		return RetirementEligibility{Eligible: true, Reason: "age 60 with 20+ years of service"}
+	case reachedMRA && hasService(30):
+		return RetirementEligibility{Eligible: true, Reason: "MRA with 30+ years of service"}
+	case reachedMRA && hasService(10):
+		return RetirementEligibility{Eligible: true, Reduced: true, Reason: "MRA with 10+ years of service (MRA+10, reduced)"}
+	}
+	specialCategory := ""
+	if e.SpecialCategory {
+		specialCategory = ", or as special category 50 with 20 or any age with 25"
+	}
+	return RetirementEligibility{Reason: fmt.Sprintf(
+		"not eligible for a FERS annuity at age %d with %s years of service on %s (requires 62 with 5 years, 60 with 20, or the MRA of %s with 10%s)",
+		age, service.StringFixed(1), commencementDate.Format("2006-01-02"), e.MinimumRetirementDate().Format("2006-01-02"), specialCategory,
+	)}
+}
+
 // MinimumRetirementAge calculates the FERS Minimum Retirement Age
 func (e *Employee) MinimumRetirementAge() int {
 	birthYear := e.BirthDate.Year()
@@ -448,6 +1228,34 @@ func (e *Employee) MinimumRetirementAge() int {
 	}
 }
 
+// FEHBEnrollmentType returns the employee's FEHB enrollment type, defaulting to self and family
+func (e *Employee) FEHBEnrollmentType() string {
+	if e.FEHBEnrollment == "" {
+		return FEHBSelfAndFamily
+	}
+	return e.FEHBEnrollment
+}
+
+// FEHBPremiumForEnrollment returns the per-pay-period premium for an enrollment type, falling back to
+// FEHBPremiumPerPayPeriod when the plan's premium for that type is not configured
+func (e *Employee) FEHBPremiumForEnrollment(enrollment string) decimal.Decimal {
+	if e.FEHBTierPremiums != nil {
+		var premium decimal.Decimal
+		switch enrollment {
+		case FEHBSelfOnly:
+			premium = e.FEHBTierPremiums.SelfOnly
+		case FEHBSelfPlusOne:
+			premium = e.FEHBTierPremiums.SelfPlusOne
+		case FEHBSelfAndFamily:
+			premium = e.FEHBTierPremiums.SelfAndFamily
+		}
+		if premium.IsPositive() {
+			return premium
+		}
+	}
+	return e.FEHBPremiumPerPayPeriod
+}
+
 // TotalTSPBalance returns the combined traditional and Roth TSP balance
 func (e *Employee) TotalTSPBalance() decimal.Decimal {
 	return e.TSPBalanceTraditional.Add(e.TSPBalanceRoth)
@@ -470,3 +1278,159 @@ func (e *Employee) AgencyMatch() decimal.Decimal {
 func (e *Employee) TotalAnnualTSPContribution() decimal.Decimal {
 	return e.AnnualTSPContribution().Add(e.AgencyMatch())
 }
+
+// SalaryInYear returns the salary paid in calendarYear, growing CurrentSalary (paid in baseYear) by
+// SalaryGrowthRate each year. Years before baseYear are discounted at the same rate.
+func (e *Employee) SalaryInYear(calendarYear, baseYear int) decimal.Decimal {
+	if e.SalaryGrowthRate == nil || calendarYear == baseYear {
+		return e.CurrentSalary
+	}
+	return e.CurrentSalary.Mul(decimal.NewFromInt(1).Add(*e.SalaryGrowthRate).Pow(decimal.NewFromInt(int64(calendarYear - baseYear))))
+}
+
+// ProjectedHigh3Salary returns the High-3 average for a retirement on retirementDate: the average salary
+// over the 36 months of service before separation under SalaryGrowthRate, skipping months in a break in
+// service, or High3Salary if that is higher or no growth rate is set
+func (e *Employee) ProjectedHigh3Salary(retirementDate time.Time, baseYear int) decimal.Decimal {
+	if e.SalaryGrowthRate == nil {
+		return e.High3Salary
+	}
+	// Count back whole months from the month in which the day after separation falls
+	separation := retirementDate.AddDate(0, 0, 1)
+	month := time.Date(separation.Year(), separation.Month(), 1, 0, 0, 0, 0, time.UTC)
+	total := decimal.Zero
+	for counted := 0; counted < 36; {
+		month = month.AddDate(0, -1, 0)
+		if e.inServiceBreak(month) {
+			continue
+		}
+		total = total.Add(e.SalaryInYear(month.Year(), baseYear))
+		counted++
+	}
+	return decimal.Max(e.High3Salary, total.Div(decimal.NewFromInt(36)))
+}
+
+// ssBendPoints holds the PIA formula bend points by year of first eligibility (the year of turning 62).
+// Later years use the latest published bend points, which suits earnings indexed to today's wage level.
+var ssBendPoints = map[int][2]int64{
+	2015: {826, 4980}, 2016: {856, 5157}, 2017: {885, 5336}, 2018: {895, 5397}, 2019: {926, 5583},
+	2020: {960, 5785}, 2021: {996, 6002}, 2022: {1024, 6172}, 2023: {1115, 6721}, 2024: {1174, 7078},
+	2025: {1226, 7391}, 2026: {1286, 7749},
+}
+
+// ssTaxableMaximums holds the contribution and benefit base (the most earnings that count toward Social
+// Security) for the same years as ssBendPoints, so indexed earnings are capped at the same wage level
+var ssTaxableMaximums = map[int]int64{
+	2015: 118500, 2016: 118500, 2017: 127200, 2018: 128400, 2019: 132900, 2020: 137700,
+	2021: 142800, 2022: 147000, 2023: 160200, 2024: 168600, 2025: 176100, 2026: 184500,
+}
+
+const (
+	firstSSBendPointYear = 2015
+	lastSSBendPointYear  = 2026
+)
+
+// SSComputationYears is the number of highest-earning years averaged into the AIME
+const SSComputationYears = 35
+
+// SSPrimaryInsuranceAmount returns the monthly PIA for wage-indexed annual earnings and the year of first
+// eligibility. Each year's earnings are capped at that year's taxable maximum, the highest 35 years (zeros
+// filling any shortfall) are averaged over 420 months and the AIME, rounded down to the dollar, is passed
+// through the 90/32/15 percent bend-point formula. The PIA is rounded down to the dime.
+func SSPrimaryInsuranceAmount(indexedEarnings []decimal.Decimal, eligibilityYear int) decimal.Decimal {
+	eligibilityYear = max(firstSSBendPointYear, min(eligibilityYear, lastSSBendPointYear))
+	taxableMaximum := decimal.NewFromInt(ssTaxableMaximums[eligibilityYear])
+
+	earnings := make([]decimal.Decimal, len(indexedEarnings))
+	for i, amount := range indexedEarnings {
+		earnings[i] = decimal.Min(amount, taxableMaximum)
+	}
+	sort.Slice(earnings, func(i, j int) bool { return earnings[i].GreaterThan(earnings[j]) })
+	total := decimal.Zero
+	for i := 0; i < len(earnings) && i < SSComputationYears; i++ {
+		total = total.Add(earnings[i])
+	}
+	aime := total.Div(decimal.NewFromInt(SSComputationYears * 12)).Floor()
+
+	first, second := decimal.NewFromInt(ssBendPoints[eligibilityYear][0]), decimal.NewFromInt(ssBendPoints[eligibilityYear][1])
+	pia := decimal.Min(aime, first).Mul(decimal.NewFromFloat(0.90))
+	if aime.GreaterThan(first) {
+		pia = pia.Add(decimal.Min(aime, second).Sub(first).Mul(decimal.NewFromFloat(0.32)))
+	}
+	if aime.GreaterThan(second) {
+		pia = pia.Add(aime.Sub(second).Mul(decimal.NewFromFloat(0.15)))
+	}
+	return pia.RoundFloor(1)
+}
+
+// SSClaimingAgeFactor returns the share of the FRA benefit paid when claiming at claimingAge: reduced by
+// 5/9 of 1% a month for the first 36 months early and 5/12 of 1% beyond, and raised by delayed retirement
+// credits of 2/3 of 1% a month up to age 70. Claiming before 62 pays nothing.
+func SSClaimingAgeFactor(fullRetirementAge, claimingAge int) decimal.Decimal {
+	if claimingAge < 62 {
+		return decimal.Zero
+	}
+
+	if claimingAge < fullRetirementAge {
+		monthsEarly := (fullRetirementAge - claimingAge) * 12
+		var reductionRate decimal.Decimal
+
+		if monthsEarly <= 36 {
+			reductionRate = decimal.NewFromFloat(5.0 / 9.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsEarly)))
+		} else {
+			firstReduction := decimal.NewFromFloat(5.0 / 9.0 / 100.0).Mul(decimal.NewFromInt(36))
+			additionalReduction := decimal.NewFromFloat(5.0 / 12.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsEarly - 36)))
+			reductionRate = firstReduction.Add(additionalReduction)
+		}
+		return decimal.NewFromFloat(1).Sub(reductionRate)
+	}
+
+	if claimingAge > fullRetirementAge {
+		monthsDelayed := min((claimingAge-fullRetirementAge)*12, 48) // Credits stop at age 70
+		delayCredit := decimal.NewFromFloat(2.0 / 3.0 / 100.0).Mul(decimal.NewFromInt(int64(monthsDelayed)))
+		return decimal.NewFromFloat(1).Add(delayCredit)
+	}
+
+	return decimal.NewFromInt(1)
+}
+
+// DeriveSSBenefitsFromEarnings fills SSBenefitFRA, SSBenefit62 and SSBenefit70 from SSIndexedEarnings when
+// no benefit amounts are given, and reports whether it did. The FRA benefit is the PIA; the age 62 and 70
+// amounts apply SSClaimingAgeFactor.
+func (e *Employee) DeriveSSBenefitsFromEarnings() bool {
+	if len(e.SSIndexedEarnings) == 0 || !e.SSBenefitFRA.IsZero() || !e.SSBenefit62.IsZero() || !e.SSBenefit70.IsZero() {
+		return false
+	}
+	pia := e.SSPrimaryInsuranceAmount()
+	fra := dateutil.FullRetirementAge(e.BirthDate)
+
+	e.SSBenefitFRA = pia
+	e.SSBenefit62 = pia.Mul(SSClaimingAgeFactor(fra, 62)).RoundFloor(1)
+	e.SSBenefit70 = pia.Mul(SSClaimingAgeFactor(fra, 70)).RoundFloor(1)
+	return true
+}
+
+// SSPrimaryInsuranceAmount returns the employee's PIA from SSIndexedEarnings, first eligible in the year
+// they turn 62
+func (e *Employee) SSPrimaryInsuranceAmount() decimal.Decimal {
+	return SSPrimaryInsuranceAmount(e.SSIndexedEarnings, e.BirthDate.Year()+62)
+}
+
+// LimitedAnnualTSPContribution returns the employee contribution capped at the elective deferral limit
+func (e *Employee) LimitedAnnualTSPContribution(limit decimal.Decimal) decimal.Decimal {
+	return decimal.Min(e.AnnualTSPContribution(), limit)
+}
+
+// LimitedTotalAnnualTSPContribution returns the capped employee contribution plus the agency match, which
+// does not count toward the elective deferral limit
+func (e *Employee) LimitedTotalAnnualTSPContribution(limit decimal.Decimal) decimal.Decimal {
+	return e.LimitedAnnualTSPContribution(limit).Add(e.AgencyMatch())
+}
+
+// LimitedTSPContributionSplit divides the capped employee contribution between the traditional and Roth
+// balances by TSPRothContributionPercent and adds the agency match to traditional
+func (e *Employee) LimitedTSPContributionSplit(limit decimal.Decimal) (traditional, roth decimal.Decimal) {
+	employee := e.LimitedAnnualTSPContribution(limit)
+	roth = employee.Mul(e.TSPRothContributionPercent)
+	return employee.Sub(roth).Add(e.AgencyMatch()), roth
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestEmployee_Age(t *testing.T) {
@@ -51,6 +53,83 @@ func TestEmployee_Age(t *testing.T) {
 	}
 }
 
+func TestEmployee_Age_LeapYearBoundaries(t *testing.T) {
+	testCases := []struct {
+		birthDate time.Time
+		atDate    time.Time
+		expected  int
+		desc      string
+	}{
+		{
+			birthDate: time.Date(1960, 2, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected:  63,
+			desc:      "Feb 1 birthday, day before in leap year",
+		},
+		{
+			birthDate: time.Date(1960, 2, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected:  64,
+			desc:      "Feb 1 birthday, on birthday in leap year",
+		},
+		{
+			birthDate: time.Date(1961, 3, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			expected:  62,
+			desc:      "Mar 1 birthday (non-leap birth year), Feb 29 of leap year",
+		},
+		{
+			birthDate: time.Date(1961, 3, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected:  63,
+			desc:      "Mar 1 birthday (non-leap birth year), Mar 1 of leap year",
+		},
+		{
+			birthDate: time.Date(1960, 3, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			expected:  64,
+			desc:      "Mar 1 birthday (leap birth year), Feb 28 of non-leap year",
+		},
+		{
+			birthDate: time.Date(1960, 3, 1, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected:  65,
+			desc:      "Mar 1 birthday (leap birth year), Mar 1 of non-leap year",
+		},
+		{
+			birthDate: time.Date(1960, 2, 29, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			expected:  64,
+			desc:      "Feb 29 birthday, Feb 28 of non-leap year",
+		},
+		{
+			birthDate: time.Date(1960, 2, 29, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected:  65,
+			desc:      "Feb 29 birthday, Mar 1 of non-leap year",
+		},
+		{
+			birthDate: time.Date(1960, 2, 29, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			expected:  64,
+			desc:      "Feb 29 birthday, Feb 29 of leap year",
+		},
+		{
+			birthDate: time.Date(1963, 12, 31, 0, 0, 0, 0, time.UTC),
+			atDate:    time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+			expected:  60,
+			desc:      "Dec 31 birthday, Dec 30 of leap year",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			employee := &Employee{BirthDate: tc.birthDate}
+			assert.Equal(t, tc.expected, employee.Age(tc.atDate))
+		})
+	}
+}
+
 func TestEmployee_YearsOfService(t *testing.T) {
 	// Test employee hired on March 20, 1985
 	hireDate := time.Date(1985, 3, 20, 0, 0, 0, 0, time.UTC)
@@ -177,6 +256,69 @@ func TestEmployee_MinimumRetirementAge(t *testing.T) {
 	}
 }
 
+func TestEmployee_RetirementEligibility(t *testing.T) {
+	employee := &Employee{
+		BirthDate: time.Date(1966, 3, 10, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// Born 1966: MRA is 56 and 4 months
+	assert.Equal(t, time.Date(2022, 7, 10, 0, 0, 0, 0, time.UTC), employee.MinimumRetirementDate())
+
+	beforeMRA := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+	eligibility := employee.RetirementEligibility(beforeMRA, beforeMRA)
+	assert.False(t, eligibility.Eligible)
+	assert.Contains(t, eligibility.Reason, "not eligible")
+
+	atMRA := time.Date(2022, 7, 31, 0, 0, 0, 0, time.UTC)
+	eligibility = employee.RetirementEligibility(atMRA, atMRA)
+	assert.True(t, eligibility.Eligible)
+	assert.False(t, eligibility.Reduced, "32 years of service is an unreduced MRA+30 retirement")
+
+	employee.HireDate = time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	eligibility = employee.RetirementEligibility(atMRA, atMRA)
+	assert.True(t, eligibility.Eligible)
+	assert.True(t, eligibility.Reduced, "12 years of service at the MRA is MRA+10")
+}
+
+func TestEmployee_RetirementEligibility_SpecialCategoryAndDisability(t *testing.T) {
+	employee := &Employee{
+		BirthDate: time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:  time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	at50 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) // 50 with 20 years
+
+	assert.False(t, employee.RetirementEligibility(at50, at50).Eligible, "50 with 20 years is not a regular retirement")
+
+	employee.SpecialCategory = true
+	eligibility := employee.RetirementEligibility(at50, at50)
+	assert.True(t, eligibility.Eligible)
+	assert.False(t, eligibility.Reduced)
+	assert.Contains(t, eligibility.Reason, "special category at age 50")
+
+	// 25 years qualifies at any age
+	employee.HireDate = time.Date(1998, 7, 1, 0, 0, 0, 0, time.UTC)
+	at48 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	eligibility = employee.RetirementEligibility(at48, at48)
+	assert.True(t, eligibility.Eligible)
+	assert.Contains(t, eligibility.Reason, "25+ years")
+
+	// 49 with 20 years is too young
+	employee.HireDate = time.Date(2004, 1, 1, 0, 0, 0, 0, time.UTC)
+	eligibility = employee.RetirementEligibility(at48, at48)
+	assert.False(t, eligibility.Eligible)
+	assert.Contains(t, eligibility.Reason, "50 with 20 or any age with 25")
+
+	// A disability retirement only needs 18 months of service
+	employee = &Employee{
+		BirthDate:            time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC),
+		HireDate:             time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		DisabilityRetirement: true,
+	}
+	assert.True(t, employee.RetirementEligibility(at50, at50).Eligible)
+	employee.HireDate = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, employee.RetirementEligibility(at50, at50).Eligible)
+}
+
 func TestEmployee_TotalTSPBalance(t *testing.T) {
 	employee := &Employee{
 		TSPBalanceTraditional: decimal.NewFromInt(450000),
@@ -243,6 +385,37 @@ func TestEmployee_TotalAnnualTSPContribution(t *testing.T) {
 	assert.True(t, total.Equal(expected))
 }
 
+func TestEmployee_LimitedTotalAnnualTSPContribution(t *testing.T) {
+	employee := &Employee{
+		CurrentSalary:          decimal.NewFromInt(400000),
+		TSPContributionPercent: decimal.NewFromFloat(0.20),
+	}
+	var limits TSPContributionLimits
+
+	// $80,000 elected at age 55: capped at $23,500 + $7,500 catch-up; the $20,000 match is separate
+	limit := limits.AnnualLimit(55, 2025, decimal.Zero)
+	assert.True(t, limit.Equal(decimal.NewFromInt(31000)))
+	assert.True(t, employee.LimitedAnnualTSPContribution(limit).Equal(decimal.NewFromInt(31000)))
+	assert.True(t, employee.LimitedTotalAnnualTSPContribution(limit).Equal(decimal.NewFromInt(51000)))
+}
+
+func TestTSPContributionLimits_AnnualLimit(t *testing.T) {
+	var limits TSPContributionLimits
+	inflation := decimal.NewFromFloat(0.03)
+
+	assert.True(t, limits.AnnualLimit(45, 2025, inflation).Equal(decimal.NewFromInt(23500)))
+	assert.True(t, limits.AnnualLimit(50, 2025, inflation).Equal(decimal.NewFromInt(31000)))
+	assert.True(t, limits.AnnualLimit(61, 2025, inflation).Equal(decimal.NewFromInt(34750)), "ages 60-63 use the higher catch-up")
+	assert.True(t, limits.AnnualLimit(64, 2025, inflation).Equal(decimal.NewFromInt(31000)))
+
+	// Indexed and rounded down to $500: 23500*1.03^2 = 24931 -> 24500, 7500*1.0609 = 7957 -> 7500
+	assert.True(t, limits.AnnualLimit(55, 2027, inflation).Equal(decimal.NewFromInt(32000)))
+
+	fixed := decimal.Zero
+	limits = TSPContributionLimits{ElectiveDeferralLimit: decimal.NewFromInt(23000), BaseYear: 2024, IndexingRate: &fixed}
+	assert.True(t, limits.AnnualLimit(45, 2030, inflation).Equal(decimal.NewFromInt(23000)))
+}
+
 func TestRetirementScenario_UnmarshalYAML(t *testing.T) {
 	// Test YAML unmarshaling with string values for decimal fields
 	// Note: This test would require creating a yaml.Node, which is complex
@@ -401,3 +574,106 @@ func TestTSPFundStats_Validation(t *testing.T) {
 	assert.Equal(t, "TSP.gov 1988-2024", stats.DataSource)
 	assert.Equal(t, "2024-01-01", stats.LastUpdated)
 }
+
+func TestRetirementScenario_UnmarshalYAML_PostRetirementWages(t *testing.T) {
+	var rs RetirementScenario
+	err := yaml.Unmarshal([]byte(`
+employee_name: person_a
+retirement_date: 2025-06-30T00:00:00Z
+ss_start_age: 62
+tsp_withdrawal_strategy: 4_percent_rule
+post_retirement_wages:
+  annual_amount: 30000
+  end_age: 65
+  schedule:
+    2026: 45000
+`), &rs)
+	require.NoError(t, err)
+	require.NotNil(t, rs.PostRetirementWages)
+
+	wages, scheduled := rs.PostRetirementWages.ForYear(2026, 61)
+	assert.True(t, scheduled)
+	assert.True(t, wages.Equal(decimal.NewFromInt(45000)))
+	wages, _ = rs.PostRetirementWages.ForYear(2027, 62)
+	assert.True(t, wages.Equal(decimal.NewFromInt(30000)))
+	wages, _ = rs.PostRetirementWages.ForYear(2030, 65)
+	assert.True(t, wages.IsZero())
+}
+
+func TestEmployee_ProjectedHigh3Salary(t *testing.T) {
+	growth := decimal.NewFromFloat(0.02)
+	emp := &Employee{CurrentSalary: decimal.NewFromInt(100000), High3Salary: decimal.NewFromInt(98000), SalaryGrowthRate: &growth}
+
+	assert.True(t, emp.SalaryInYear(2025, 2025).Equal(decimal.NewFromInt(100000)))
+	assert.True(t, emp.SalaryInYear(2027, 2025).Sub(decimal.NewFromInt(104040)).Abs().LessThan(decimal.NewFromFloat(0.01)))
+
+	// Retiring at the end of 2029: High-3 averages the 2027-2029 salaries
+	want := decimal.NewFromInt(104040).Add(decimal.NewFromFloat(106120.8)).Add(decimal.NewFromFloat(108243.216)).Div(decimal.NewFromInt(3))
+	got := emp.ProjectedHigh3Salary(time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC), 2025)
+	assert.True(t, got.Sub(want).Abs().LessThan(decimal.NewFromFloat(0.01)), "got %s want %s", got, want)
+
+	// A configured High-3 above the projection is kept, and no growth rate leaves it unchanged
+	assert.True(t, emp.ProjectedHigh3Salary(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 2025).Equal(decimal.NewFromInt(98000)))
+	emp.SalaryGrowthRate = nil
+	assert.True(t, emp.ProjectedHigh3Salary(time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC), 2025).Equal(decimal.NewFromInt(98000)))
+}
+
+func TestSSPrimaryInsuranceAmount_BendPointFormula(t *testing.T) {
+	// SSA's 2025 formula: 90% of the first $1,226 of AIME, 32% up to $7,391 and 15% above, rounded down to
+	// the dime. 35 years of $120,000 give an AIME of $10,000:
+	// 1,103.40 + 1,972.80 + 391.35 = 3,467.55 -> 3,467.50
+	earnings := make([]decimal.Decimal, 0, 40)
+	for i := 0; i < 35; i++ {
+		earnings = append(earnings, decimal.NewFromInt(120000))
+	}
+	assert.True(t, SSPrimaryInsuranceAmount(earnings, 2025).Equal(decimal.NewFromFloat(3467.50)))
+
+	// Only the highest 35 years count
+	for i := 0; i < 5; i++ {
+		earnings = append(earnings, decimal.NewFromInt(20000))
+	}
+	assert.True(t, SSPrimaryInsuranceAmount(earnings, 2025).Equal(decimal.NewFromFloat(3467.50)))
+
+	// A short record averages in zero years: 10 years of $42,000 give an AIME of $1,000, all in the 90% band
+	assert.True(t, SSPrimaryInsuranceAmount(nil, 2025).IsZero())
+	short := make([]decimal.Decimal, 10)
+	for i := range short {
+		short[i] = decimal.NewFromInt(42000)
+	}
+	assert.True(t, SSPrimaryInsuranceAmount(short, 2025).Equal(decimal.NewFromInt(900)))
+
+	// Earnings above the taxable maximum ($176,100 in 2025) do not count
+	capped, overMaximum := make([]decimal.Decimal, 35), make([]decimal.Decimal, 35)
+	for i := range capped {
+		capped[i], overMaximum[i] = decimal.NewFromInt(176100), decimal.NewFromInt(400000)
+	}
+	assert.True(t, SSPrimaryInsuranceAmount(overMaximum, 2025).Equal(SSPrimaryInsuranceAmount(capped, 2025)))
+}
+
+func TestEmployee_DeriveSSBenefitsFromEarnings(t *testing.T) {
+	earnings := make([]decimal.Decimal, 35)
+	for i := range earnings {
+		earnings[i] = decimal.NewFromInt(120000)
+	}
+	employee := &Employee{BirthDate: time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC), SSIndexedEarnings: earnings}
+	require.True(t, employee.DeriveSSBenefitsFromEarnings())
+
+	// FRA 67: claiming at 62 is 60 months early (30% reduction) and at 70 earns 36 months of credits (24%)
+	assert.True(t, employee.SSBenefitFRA.Equal(decimal.NewFromFloat(3467.50)), "got %s", employee.SSBenefitFRA)
+	assert.True(t, employee.SSBenefit62.Equal(decimal.NewFromFloat(2427.20)), "got %s", employee.SSBenefit62)
+	assert.True(t, employee.SSBenefit70.Equal(decimal.NewFromFloat(4299.70)), "got %s", employee.SSBenefit70)
+
+	// Benefits already given are left alone
+	assert.False(t, employee.DeriveSSBenefitsFromEarnings())
+}
+
+func TestGlobalAssumptions_SetPostRetirementReturnShiftsGlidePath(t *testing.T) {
+	path := map[int]decimal.Decimal{70: decimal.NewFromFloat(0.05), 80: decimal.NewFromFloat(0.04)}
+	ga := GlobalAssumptions{TSPReturnPostRetirement: decimal.NewFromFloat(0.06), TSPReturnGlidePath: path}
+
+	ga.SetPostRetirementReturn(decimal.NewFromFloat(0.03))
+	assert.True(t, ga.PostRetirementReturnAtAge(65).Equal(decimal.NewFromFloat(0.03)))
+	assert.True(t, ga.PostRetirementReturnAtAge(75).Equal(decimal.NewFromFloat(0.02)))
+	assert.True(t, ga.PostRetirementReturnAtAge(85).Equal(decimal.NewFromFloat(0.01)))
+	assert.True(t, path[70].Equal(decimal.NewFromFloat(0.05)), "the original glide path should be unchanged")
+}
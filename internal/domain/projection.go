@@ -14,45 +14,84 @@ type AnnualCashFlow struct {
 	AgePersonB int       `json:"age_person_b"`
 
 	// Income Sources
-	SalaryPersonA          decimal.Decimal `json:"salary_person_a"`
-	SalaryPersonB          decimal.Decimal `json:"salary_person_b"`
-	PensionPersonA         decimal.Decimal `json:"pension_person_a"`
-	PensionPersonB         decimal.Decimal `json:"pension_person_b"`
-	SurvivorPensionPersonA decimal.Decimal `json:"survivor_pension_person_a"`
-	SurvivorPensionPersonB decimal.Decimal `json:"survivor_pension_person_b"`
-	TSPWithdrawalPersonA   decimal.Decimal `json:"tsp_withdrawal_person_a"`
-	TSPWithdrawalPersonB   decimal.Decimal `json:"tsp_withdrawal_person_b"`
-	SSBenefitPersonA       decimal.Decimal `json:"ss_benefit_person_a"`
-	SSBenefitPersonB       decimal.Decimal `json:"ss_benefit_person_b"`
-	FERSSupplementPersonA  decimal.Decimal `json:"fers_supplement_person_a"`
-	FERSSupplementPersonB  decimal.Decimal `json:"fers_supplement_person_b"`
-	TotalGrossIncome       decimal.Decimal `json:"total_gross_income"`
+	SalaryPersonA              decimal.Decimal `json:"salary_person_a"`
+	SalaryPersonB              decimal.Decimal `json:"salary_person_b"`
+	PostRetirementWagesPersonA decimal.Decimal `json:"post_retirement_wages_person_a"` // Part-time earnings after separation
+	PostRetirementWagesPersonB decimal.Decimal `json:"post_retirement_wages_person_b"`
+	PensionPersonA             decimal.Decimal `json:"pension_person_a"`
+	PensionPersonB             decimal.Decimal `json:"pension_person_b"`
+	SurvivorPensionPersonA     decimal.Decimal `json:"survivor_pension_person_a"`
+	SurvivorPensionPersonB     decimal.Decimal `json:"survivor_pension_person_b"`
+	ExternalPensionPersonA     decimal.Decimal `json:"external_pension_person_a"` // Non-federal pensions earned by PersonA, including the survivor share after PersonA's death
+	ExternalPensionPersonB     decimal.Decimal `json:"external_pension_person_b"`
+	TSPWithdrawalPersonA       decimal.Decimal `json:"tsp_withdrawal_person_a"`
+	TSPWithdrawalPersonB       decimal.Decimal `json:"tsp_withdrawal_person_b"`
+	TSPAnnuityPersonA          decimal.Decimal `json:"tsp_annuity_person_a"` // TSP life annuity payments (including survivor continuation)
+	TSPAnnuityPersonB          decimal.Decimal `json:"tsp_annuity_person_b"`
+	TSPAnnuitized              bool            `json:"tsp_annuitized"` // A TSP life annuity is paying: the balance went to the annuity, so a zero balance is not depletion
+	SSBenefitPersonA           decimal.Decimal `json:"ss_benefit_person_a"`
+	SSBenefitPersonB           decimal.Decimal `json:"ss_benefit_person_b"`
+	FERSSupplementPersonA      decimal.Decimal `json:"fers_supplement_person_a"`
+	FERSSupplementPersonB      decimal.Decimal `json:"fers_supplement_person_b"`
+	EventIncome                decimal.Decimal `json:"event_income"`               // One-time inflows and TSP event distributions
+	HSAWithdrawal              decimal.Decimal `json:"hsa_withdrawal"`             // Tax-free HSA draws paying FEHB/Medicare premiums
+	TaxableAccountWithdrawal   decimal.Decimal `json:"taxable_account_withdrawal"` // Brokerage sales, drawn before the TSP for need-based spending
+	TotalGrossIncome           decimal.Decimal `json:"total_gross_income"`
+	GuaranteedIncome           decimal.Decimal `json:"guaranteed_income"`     // Pensions, Social Security, FERS supplement and TSP annuities
+	AtRiskIncome               decimal.Decimal `json:"at_risk_income"`        // TSP, brokerage and HSA withdrawals, which depend on markets
+	BlackoutYear               bool            `json:"blackout_year"`         // Retired, but a spouse's Social Security or FERS annuity has yet to start
+	GuaranteedIncomeGap        decimal.Decimal `json:"guaranteed_income_gap"` // Blackout years: shortfall from the ramped-up guaranteed income
 
 	// Deductions and Taxes
 	FederalTax               decimal.Decimal `json:"federal_tax"`
 	FederalTaxableIncome     decimal.Decimal `json:"federal_taxable_income"`
-	FederalStandardDeduction decimal.Decimal `json:"federal_standard_deduction"`
+	FederalStandardDeduction decimal.Decimal `json:"federal_standard_deduction"` // Deduction taken: the standard deduction, or itemized deductions when larger
 	FederalFilingStatus      string          `json:"federal_filing_status"`
 	FederalSeniors65Plus     int             `json:"federal_seniors_65_plus"`
+	EffectiveTaxRate         decimal.Decimal `json:"effective_tax_rate"`       // Federal tax as a share of gross income
+	EffectiveTotalTaxRate    decimal.Decimal `json:"effective_total_tax_rate"` // Federal, state and local income tax as a share of gross income
+	MarginalBracket          decimal.Decimal `json:"marginal_bracket"`         // Federal bracket rate of the last dollar of ordinary taxable income
+	EffectiveMarginalRate    decimal.Decimal `json:"effective_marginal_rate"`  // Retired years: federal tax on the next traditional withdrawal dollar, including on Social Security it makes taxable
+	TaxTorpedo               bool            `json:"tax_torpedo"`              // Extra Social Security becoming taxable lifts EffectiveMarginalRate above MarginalBracket
 	StateTax                 decimal.Decimal `json:"state_tax"`
 	LocalTax                 decimal.Decimal `json:"local_tax"`
 	FICATax                  decimal.Decimal `json:"fica_tax"`
 	TSPContributions         decimal.Decimal `json:"tsp_contributions"`
+	HSAContributions         decimal.Decimal `json:"hsa_contributions"`
 	FEHBPremium              decimal.Decimal `json:"fehb_premium"`
 	MedicarePremium          decimal.Decimal `json:"medicare_premium"`
+	EventExpenses            decimal.Decimal `json:"event_expenses"`  // One-time outflows, including those paid from the TSP
+	RothConversion           decimal.Decimal `json:"roth_conversion"` // Traditional TSP moved to Roth, taxed but not spendable
+	MAGI                     decimal.Decimal `json:"magi"`            // Estimated MAGI, used for IRMAA two years later
+	CapitalGains             decimal.Decimal `json:"capital_gains"`   // Brokerage dividends and realized gains, taxed at long-term rates
+	InterestIncome           decimal.Decimal `json:"interest_income"` // Interest on cash reserves, taxed as ordinary income
 	NetIncome                decimal.Decimal `json:"net_income"`
+	RealNetIncome            decimal.Decimal `json:"real_net_income"` // NetIncome in projection-start (today's) dollars
+
+	// Spending need comparison, set when the scenario has a spending_need
+	SpendingNeed    decimal.Decimal `json:"spending_need"`    // Inflation-adjusted required spending for the year
+	SpendingSurplus decimal.Decimal `json:"spending_surplus"` // NetIncome minus SpendingNeed; negative is a shortfall
 
 	// TSP Balances (end of year)
 	TSPBalancePersonA     decimal.Decimal `json:"tsp_balance_person_a"`
 	TSPBalancePersonB     decimal.Decimal `json:"tsp_balance_person_b"`
 	TSPBalanceTraditional decimal.Decimal `json:"tsp_balance_traditional"`
 	TSPBalanceRoth        decimal.Decimal `json:"tsp_balance_roth"`
+	HSABalance            decimal.Decimal `json:"hsa_balance"`
+	TaxableAccountBalance decimal.Decimal `json:"taxable_account_balance"`
+	CashBalance           decimal.Decimal `json:"cash_balance"`
+
+	// Per-fund (C/S/I/F/G) end-of-year balances, traditional plus Roth; only set for employees with an allocation
+	TSPFundBalancesPersonA map[string]decimal.Decimal `json:"tsp_fund_balances_person_a,omitempty"`
+	TSPFundBalancesPersonB map[string]decimal.Decimal `json:"tsp_fund_balances_person_b,omitempty"`
 
 	// Additional Information
 	IsRetired          bool            `json:"is_retired"`
 	IsMedicareEligible bool            `json:"is_medicare_eligible"`
 	IsRMDYear          bool            `json:"is_rmd_year"`
 	RMDAmount          decimal.Decimal `json:"rmd_amount"`
+	QCDPersonA         decimal.Decimal `json:"qcd_person_a"` // Qualified charitable distributions paid directly to charity
+	QCDPersonB         decimal.Decimal `json:"qcd_person_b"`
 
 	// Mortality / survivor tracking (Phase 1 deterministic death modeling)
 	PersonADeceased    bool `json:"person_a_deceased"`
@@ -67,19 +106,54 @@ type ScenarioSummary struct {
 	Year5NetIncome      decimal.Decimal  `json:"year_5_net_income"`
 	Year10NetIncome     decimal.Decimal  `json:"year_10_net_income"`
 	TotalLifetimeIncome decimal.Decimal  `json:"total_lifetime_income"`
+	NetPresentValue     decimal.Decimal  `json:"net_present_value"` // Net income discounted at GlobalAssumptions.DiscountRate
 	TSPLongevity        int              `json:"tsp_longevity"`
-	SuccessRate         decimal.Decimal  `json:"success_rate"` // From Monte Carlo
+	TSPAnnuitized       bool             `json:"tsp_annuitized"`        // Some TSP balance was converted to a life annuity during the projection
+	PlanHorizonYears    int              `json:"plan_horizon_years"`    // Years over which TSP longevity and success are judged
+	MinGuaranteedIncome decimal.Decimal  `json:"min_guaranteed_income"` // Lowest guaranteed income in any retired year
+	ShortfallYears      int              `json:"shortfall_years"`       // Years net income falls below the spending need
+	BlackoutYears       int              `json:"blackout_years"`        // Retired years before guaranteed income fully ramps up
+	MaxBlackoutGap      decimal.Decimal  `json:"max_blackout_gap"`      // Deepest guaranteed income gap in a blackout year
+	TaxTorpedoYears     int              `json:"tax_torpedo_years"`     // Years in the Social Security tax torpedo
+	SuccessRate         decimal.Decimal  `json:"success_rate"`          // From Monte Carlo
 	InitialTSPBalance   decimal.Decimal  `json:"initial_tsp_balance"`
 	FinalTSPBalance     decimal.Decimal  `json:"final_tsp_balance"`
 	Projection          []AnnualCashFlow `json:"projection"`
 
-	// Absolute calendar year comparisons for apples-to-apples analysis
+	// Absolute calendar year comparisons for apples-to-apples analysis. The three figures are for
+	// ComparisonYears (5, 10 and 15 years after the projection start); the field names reflect a 2025 start.
+	ComparisonYears      []int           `json:"comparison_years"`
 	NetIncome2030        decimal.Decimal `json:"net_income_2030"`
 	NetIncome2035        decimal.Decimal `json:"net_income_2035"`
 	NetIncome2040        decimal.Decimal `json:"net_income_2040"`
 	PreRetirementNet2030 decimal.Decimal `json:"pre_retirement_net_2030"` // What current net would be with COLA growth
 	PreRetirementNet2035 decimal.Decimal `json:"pre_retirement_net_2035"`
 	PreRetirementNet2040 decimal.Decimal `json:"pre_retirement_net_2040"`
+
+	// Nominal federal, state and local income tax summed over the projection, and the present value of the
+	// total at the discount rate, which compares tax efficiency across scenarios that pay at different times
+	TotalLifetimeFederalTax decimal.Decimal `json:"total_lifetime_federal_tax"`
+	TotalLifetimeStateTax   decimal.Decimal `json:"total_lifetime_state_tax"`
+	TotalLifetimeTax        decimal.Decimal `json:"total_lifetime_tax"`
+	TotalLifetimeTaxPV      decimal.Decimal `json:"total_lifetime_tax_pv"`
+}
+
+// ComparisonYears returns the calendar years 5, 10 and 15 years after startYear, used for the
+// apples-to-apples calendar year comparison
+func ComparisonYears(startYear int) []int {
+	return []int{startYear + 5, startYear + 10, startYear + 15}
+}
+
+// CalendarComparisonYears returns the summary's comparison years, deriving them from the first
+// projection year for summaries built without them
+func (s ScenarioSummary) CalendarComparisonYears() []int {
+	if len(s.ComparisonYears) == 3 {
+		return s.ComparisonYears
+	}
+	if len(s.Projection) > 0 {
+		return ComparisonYears(s.Projection[0].Date.Year())
+	}
+	return nil
 }
 
 // ScenarioComparison provides a comparison of all scenarios
@@ -103,6 +177,7 @@ type ImpactAnalysis struct {
 type LongTermAnalysis struct {
 	BestScenarioForIncome    string   `json:"best_scenario_for_income"`
 	BestScenarioForLongevity string   `json:"best_scenario_for_longevity"`
+	BestScenarioForTaxes     string   `json:"best_scenario_for_taxes"` // Lowest total lifetime tax
 	RiskAssessment           string   `json:"risk_assessment"`
 	Recommendations          []string `json:"recommendations"`
 }
@@ -170,6 +245,15 @@ type TaxableIncome struct {
 	OtherTaxableIncome decimal.Decimal `json:"other_taxable_income"`
 	WageIncome         decimal.Decimal `json:"wage_income"`
 	InterestIncome     decimal.Decimal `json:"interest_income"`
+	CapitalGains       decimal.Decimal `json:"capital_gains"` // Realized gains and dividends from taxable investments
+
+	// Itemized deductions claimed instead of the standard deduction when larger (nil means none)
+	ItemizedDeductions *ItemizedDeductions `json:"itemized_deductions,omitempty"`
+}
+
+// NetInvestmentIncome returns the income subject to the Net Investment Income Tax
+func (ti TaxableIncome) NetInvestmentIncome() decimal.Decimal {
+	return ti.InterestIncome.Add(ti.CapitalGains)
 }
 
 // CalculateTotalIncome calculates the total gross income for the year
@@ -177,15 +261,40 @@ func (acf *AnnualCashFlow) CalculateTotalIncome() decimal.Decimal {
 	return acf.SalaryPersonA.Add(acf.SalaryPersonB).
 		Add(acf.PensionPersonA).Add(acf.PensionPersonB).
 		Add(acf.SurvivorPensionPersonA).Add(acf.SurvivorPensionPersonB).
+		Add(acf.ExternalPensionPersonA).Add(acf.ExternalPensionPersonB).
 		Add(acf.TSPWithdrawalPersonA).Add(acf.TSPWithdrawalPersonB).
+		Add(acf.TSPAnnuityPersonA).Add(acf.TSPAnnuityPersonB).
+		Add(acf.SSBenefitPersonA).Add(acf.SSBenefitPersonB).
+		Add(acf.FERSSupplementPersonA).Add(acf.FERSSupplementPersonB).
+		Add(acf.EventIncome).Add(acf.HSAWithdrawal).Add(acf.TaxableAccountWithdrawal).
+		Add(acf.PostRetirementWagesPersonA).Add(acf.PostRetirementWagesPersonB)
+}
+
+// CalculateGuaranteedIncome returns the income that does not depend on market returns: FERS pensions
+// (including survivor annuities), non-federal pensions, Social Security, the FERS supplement and TSP life
+// annuity payments
+func (acf *AnnualCashFlow) CalculateGuaranteedIncome() decimal.Decimal {
+	return acf.PensionPersonA.Add(acf.PensionPersonB).
+		Add(acf.SurvivorPensionPersonA).Add(acf.SurvivorPensionPersonB).
+		Add(acf.ExternalPensionPersonA).Add(acf.ExternalPensionPersonB).
+		Add(acf.TSPAnnuityPersonA).Add(acf.TSPAnnuityPersonB).
 		Add(acf.SSBenefitPersonA).Add(acf.SSBenefitPersonB).
 		Add(acf.FERSSupplementPersonA).Add(acf.FERSSupplementPersonB)
 }
 
+// CalculateAtRiskIncome returns the income drawn from invested balances: TSP, brokerage and HSA
+// withdrawals. Wages and one-time event income fall in neither bucket.
+func (acf *AnnualCashFlow) CalculateAtRiskIncome() decimal.Decimal {
+	return acf.TSPWithdrawalPersonA.Add(acf.TSPWithdrawalPersonB).
+		Add(acf.TaxableAccountWithdrawal).Add(acf.HSAWithdrawal)
+}
+
 // CalculateTotalDeductions calculates the total deductions for the year
 func (acf *AnnualCashFlow) CalculateTotalDeductions() decimal.Decimal {
 	return acf.FederalTax.Add(acf.StateTax).Add(acf.LocalTax).Add(acf.FICATax).
-		Add(acf.TSPContributions).Add(acf.FEHBPremium).Add(acf.MedicarePremium)
+		Add(acf.TSPContributions).Add(acf.HSAContributions).Add(acf.FEHBPremium).Add(acf.MedicarePremium).
+		Add(acf.QCDPersonA).Add(acf.QCDPersonB).
+		Add(acf.EventExpenses)
 }
 
 // CalculateNetIncome calculates the net income for the year
@@ -194,12 +303,28 @@ func (acf *AnnualCashFlow) CalculateNetIncome() decimal.Decimal {
 	return acf.NetIncome
 }
 
+// IncomeTax returns the year's federal, state and local income tax
+func (acf *AnnualCashFlow) IncomeTax() decimal.Decimal {
+	return acf.FederalTax.Add(acf.StateTax).Add(acf.LocalTax)
+}
+
+// CalculateEffectiveTaxRates returns federal income tax, and federal, state and local income tax together,
+// as shares of gross income. Both are zero in a year without income.
+func (acf *AnnualCashFlow) CalculateEffectiveTaxRates() (federal, total decimal.Decimal) {
+	if !acf.TotalGrossIncome.IsPositive() {
+		return decimal.Zero, decimal.Zero
+	}
+	federal = acf.FederalTax.Div(acf.TotalGrossIncome)
+	total = acf.IncomeTax().Div(acf.TotalGrossIncome)
+	return federal, total
+}
+
 // TotalTSPBalance returns the combined TSP balance for both employees
 func (acf *AnnualCashFlow) TotalTSPBalance() decimal.Decimal {
 	return acf.TSPBalancePersonA.Add(acf.TSPBalancePersonB)
 }
 
-// IsTSPDepleted returns true if TSP balances are zero or negative
+// IsTSPDepleted returns true if TSP balances are zero or negative and the money was not annuitized
 func (acf *AnnualCashFlow) IsTSPDepleted() bool {
-	return acf.TotalTSPBalance().LessThanOrEqual(decimal.Zero)
+	return !acf.TSPAnnuitized && acf.TotalTSPBalance().LessThanOrEqual(decimal.Zero)
 }
@@ -1,9 +1,18 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	calc "github.com/rpgo/retirement-calculator/internal/calculation"
 	"github.com/rpgo/retirement-calculator/internal/domain"
 	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
 )
 
 // DefaultAssumptions lists key modeling assumptions rendered in detailed outputs.
@@ -30,3 +39,50 @@ func GenerateAssumptions(assumptions *domain.GlobalAssumptions) []string {
 }
 
 var decimalHundred = decimal.NewFromInt(100)
+
+// resolvedAssumptionsDocument nests the resolved assumptions under the configuration key they are read
+// from, so an export can be pasted back into a configuration file
+type resolvedAssumptionsDocument struct {
+	GlobalAssumptions domain.GlobalAssumptions `yaml:"global_assumptions" json:"global_assumptions"`
+}
+
+// WriteResolvedAssumptions writes config's global assumptions, federal rules and Monte Carlo settings
+// with every run-time default filled in, as "yaml" or "json"
+func WriteResolvedAssumptions(config *domain.Configuration, format string, w io.Writer) error {
+	if config == nil {
+		return fmt.Errorf("configuration is nil")
+	}
+	doc := resolvedAssumptionsDocument{GlobalAssumptions: calc.ResolveAssumptions(config)}
+	switch format {
+	case "yaml", "yml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode assumptions: %w", err)
+		}
+		return enc.Close()
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode assumptions: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w for assumptions: %q (use yaml or json)", ErrUnsupportedFormat, format)
+	}
+}
+
+// SaveResolvedAssumptions writes the resolved assumptions to filename, as JSON for a .json file and YAML
+// otherwise, to keep beside a report
+func SaveResolvedAssumptions(config *domain.Configuration, filename string) error {
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		format = "json"
+	}
+	var buf bytes.Buffer
+	if err := WriteResolvedAssumptions(config, format, &buf); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
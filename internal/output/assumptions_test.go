@@ -0,0 +1,80 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteResolvedAssumptions_MaterializesDefaults(t *testing.T) {
+	config := &domain.Configuration{GlobalAssumptions: domain.GlobalAssumptions{
+		InflationRate:           decimal.NewFromFloat(0.025),
+		TSPReturnPostRetirement: decimal.NewFromFloat(0.045),
+		ProjectionYears:         25,
+		ProjectionStartYear:     2025,
+		MonteCarloSettings:      domain.MonteCarloSettings{InflationVariability: decimal.NewFromFloat(0.03)},
+	}}
+
+	var out bytes.Buffer
+	if err := WriteResolvedAssumptions(config, "yaml", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "global_assumptions:\n") {
+		t.Fatalf("expected the export to nest under global_assumptions, got:\n%s", out.String())
+	}
+	var doc resolvedAssumptionsDocument
+	if err := yaml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("export does not parse back: %v", err)
+	}
+	resolved := doc.GlobalAssumptions
+
+	checks := []struct {
+		name      string
+		got, want decimal.Decimal
+	}{
+		{"discount_rate", *resolved.DiscountRate, decimal.NewFromFloat(0.03)},
+		{"hsa_return", *resolved.HSAReturn, decimal.NewFromFloat(0.045)},
+		{"tsp_return_variability", resolved.MonteCarloSettings.TSPReturnVariability, decimal.NewFromFloat(0.15)},
+		{"inflation_variability (configured)", resolved.MonteCarloSettings.InflationVariability, decimal.NewFromFloat(0.03)},
+		{"cola_variability", resolved.MonteCarloSettings.COLAVariability, decimal.NewFromFloat(0.02)},
+		{"fehb_variability", resolved.MonteCarloSettings.FEHBVariability, decimal.NewFromFloat(0.05)},
+		{"max_reasonable_income", resolved.MonteCarloSettings.MaxReasonableIncome, decimal.NewFromInt(5000000)},
+		{"default_tsp_allocation.c_fund", resolved.MonteCarloSettings.DefaultTSPAllocation.CFund, decimal.NewFromFloat(0.60)},
+		{"c_fund.mean", resolved.TSPStatisticalModels.CFund.Mean, decimal.NewFromFloat(0.1125)},
+		{"g_fund.standard_dev", resolved.TSPStatisticalModels.GFund.StandardDev, decimal.NewFromFloat(0.0165)},
+		{"inflation_rate (configured)", resolved.InflationRate, decimal.NewFromFloat(0.025)},
+	}
+	for _, c := range checks {
+		if !c.got.Equal(c.want) {
+			t.Errorf("%s: expected %s, got %s", c.name, c.want, c.got)
+		}
+	}
+	if resolved.ProjectionStartYear != 2025 || resolved.ProjectionYears != 25 {
+		t.Errorf("expected projection 2025 for 25 years, got %d for %d", resolved.ProjectionStartYear, resolved.ProjectionYears)
+	}
+	if config.GlobalAssumptions.DiscountRate != nil {
+		t.Errorf("the configuration itself should not be modified")
+	}
+
+	out.Reset()
+	if err := WriteResolvedAssumptions(config, "json", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var jsonDoc resolvedAssumptionsDocument
+	if err := json.Unmarshal(out.Bytes(), &jsonDoc); err != nil {
+		t.Fatalf("JSON export does not parse back: %v", err)
+	}
+	if !jsonDoc.GlobalAssumptions.MonteCarloSettings.TSPReturnVariability.Equal(decimal.NewFromFloat(0.15)) {
+		t.Errorf("expected the JSON export to carry the default TSP return variability")
+	}
+
+	if err := WriteResolvedAssumptions(config, "xml", &out); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
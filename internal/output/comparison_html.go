@@ -0,0 +1,113 @@
+package output
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+
+	calc "github.com/rpgo/retirement-calculator/internal/calculation"
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
+
+//go:embed templates/comparison.html.tmpl
+var comparisonHTMLTemplateSource string
+
+var comparisonHTMLTemplate = template.Must(template.New("comparison").Funcs(template.FuncMap{
+	"curr": FormatCurrency,
+	"pct":  FormatPercentage,
+	"json": func(v interface{}) template.JS {
+		b, _ := json.Marshal(v)
+		return template.JS(b)
+	},
+}).Parse(comparisonHTMLTemplateSource))
+
+// comparisonChartPoint is one Chart.js {x, y} point
+type comparisonChartPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// comparisonChartSeries is one scenario's annual and cumulative net income by calendar year
+type comparisonChartSeries struct {
+	Name       string                 `json:"name"`
+	NetIncome  []comparisonChartPoint `json:"net_income"`
+	Cumulative []comparisonChartPoint `json:"cumulative"` // Through the end of each year
+}
+
+// comparisonCrossover is where the cumulative net income of two scenarios crosses
+type comparisonCrossover struct {
+	First     string                          `json:"first"`
+	Second    string                          `json:"second"`
+	Point     comparisonChartPoint            `json:"point"` // Fractional calendar year and cumulative amount
+	BreakEven *calc.CumulativeBreakEvenResult `json:"break_even"`
+}
+
+// GenerateComparisonHTML writes an interactive HTML report comparing the scenarios side by side: a
+// summary metrics table, each scenario's net income over time on one chart, and cumulative net income
+// with the points where two scenarios' cumulative income crosses highlighted.
+func GenerateComparisonHTML(comparison *domain.ScenarioComparison, outputPath string) error {
+	if comparison == nil {
+		return fmt.Errorf("scenario comparison is required")
+	}
+	content, err := comparisonHTML(comparison)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write comparison HTML report: %w", err)
+	}
+	return nil
+}
+
+// comparisonHTML renders the comparison report
+func comparisonHTML(comparison *domain.ScenarioComparison) ([]byte, error) {
+	series := make([]comparisonChartSeries, len(comparison.Scenarios))
+	for i, scenario := range comparison.Scenarios {
+		series[i].Name = scenario.Name
+		var cumulative float64
+		for _, cf := range scenario.Projection {
+			year := float64(cf.Date.Year())
+			netIncome := cf.NetIncome.InexactFloat64()
+			cumulative += netIncome
+			series[i].NetIncome = append(series[i].NetIncome, comparisonChartPoint{X: year, Y: netIncome})
+			series[i].Cumulative = append(series[i].Cumulative, comparisonChartPoint{X: year, Y: cumulative})
+		}
+	}
+
+	// Every pair of scenarios can cross, so each is checked for its first crossover
+	var crossovers []comparisonCrossover
+	for i := range comparison.Scenarios {
+		for j := i + 1; j < len(comparison.Scenarios); j++ {
+			first, second := comparison.Scenarios[i], comparison.Scenarios[j]
+			breakEven, err := calc.CalculateCumulativeBreakEven(first.Projection, second.Projection)
+			if err != nil || breakEven == nil {
+				continue
+			}
+			crossovers = append(crossovers, comparisonCrossover{
+				First:     first.Name,
+				Second:    second.Name,
+				Point:     comparisonChartPoint{X: breakEven.CalendarYear, Y: breakEven.CumulativeAmount.InexactFloat64()},
+				BreakEven: breakEven,
+			})
+		}
+	}
+
+	data := struct {
+		*domain.ScenarioComparison
+		Series     []comparisonChartSeries
+		Crossovers []comparisonCrossover
+	}{comparison, series, crossovers}
+	var buf bytes.Buffer
+	if err := comparisonHTMLTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render comparison HTML report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
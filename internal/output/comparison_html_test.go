@@ -0,0 +1,79 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// comparisonHTMLScenario projects a flat net income after a different first year
+func comparisonHTMLScenario(name string, firstYear, laterYears int64) domain.ScenarioSummary {
+	summary := domain.ScenarioSummary{Name: name}
+	for i := 0; i < 10; i++ {
+		netIncome := decimal.NewFromInt(laterYears)
+		if i == 0 {
+			netIncome = decimal.NewFromInt(firstYear)
+		}
+		summary.Projection = append(summary.Projection, domain.AnnualCashFlow{
+			Year:      i + 1,
+			Date:      time.Date(2025+i, 1, 1, 0, 0, 0, 0, time.UTC),
+			NetIncome: netIncome,
+		})
+	}
+	return summary
+}
+
+func TestGenerateComparisonHTML_HighlightsCrossover(t *testing.T) {
+	// Cumulative income is 100k vs 90k after 2026 and 150k vs 160k after 2027, so the lines cross in 2026-2027
+	comparison := &domain.ScenarioComparison{Scenarios: []domain.ScenarioSummary{
+		comparisonHTMLScenario("Retire Early", 50000, 50000),
+		comparisonHTMLScenario("Retire Later", 20000, 70000),
+	}}
+
+	path := filepath.Join(t.TempDir(), "reports", "comparison.html")
+	if err := GenerateComparisonHTML(comparison, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	html := string(content)
+
+	for _, want := range []string{
+		"Retire Early", "Retire Later",
+		`id="netIncomeChart"`, `id="cumulativeChart"`,
+		"Retire Early and Retire Later cross in",
+		"Break-even crossover",
+		`"cumulative":[{"x":2025,"y":50000}`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("report missing %q", want)
+		}
+	}
+	if !strings.Contains(html, `"point":{"x":2026.5,"y":125000}`) {
+		t.Fatalf("expected the crossover point at mid-2026 and $125,000 cumulative")
+	}
+}
+
+func TestGenerateComparisonHTML_NoCrossover(t *testing.T) {
+	comparison := &domain.ScenarioComparison{Scenarios: []domain.ScenarioSummary{
+		comparisonHTMLScenario("Higher", 60000, 60000),
+		comparisonHTMLScenario("Lower", 50000, 50000),
+	}}
+	content, err := comparisonHTML(comparison)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(content), "never crosses") {
+		t.Fatalf("expected a note that the scenarios never cross")
+	}
+	if err := GenerateComparisonHTML(nil, filepath.Join(t.TempDir(), "x.html")); err == nil {
+		t.Fatalf("expected an error for a nil comparison")
+	}
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
+
+// RenderComparisonTable writes an aligned plain-text table with one column per scenario.
+// It is intended for CLI use where generating an HTML report would be overkill.
+func RenderComparisonTable(result *domain.ScenarioComparison, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("no comparison results to render")
+	}
+	recommended := result.ImmediateImpact.RecommendedScenario
+	if recommended == "" {
+		recommended = AnalyzeScenarios(result).ScenarioName
+	}
+
+	header := []string{"Metric"}
+	firstYear := []string{"First-year net income"}
+	year5 := []string{"Year 5 net income"}
+	year10 := []string{"Year 10 net income"}
+	longevity := []string{"TSP longevity (years)"}
+	lifetime := []string{"Lifetime income"}
+	lifetimeTax := []string{"Lifetime tax"}
+	lifetimeTaxPV := []string{"Lifetime tax (PV)"}
+	marker := []string{"Recommended"}
+	for _, sc := range result.Scenarios {
+		header = append(header, sc.Name)
+		firstYear = append(firstYear, FormatCurrency(sc.FirstYearNetIncome))
+		year5 = append(year5, FormatCurrency(sc.Year5NetIncome))
+		year10 = append(year10, FormatCurrency(sc.Year10NetIncome))
+		longevity = append(longevity, fmt.Sprintf("%d", sc.TSPLongevity))
+		lifetime = append(lifetime, FormatCurrency(sc.TotalLifetimeIncome))
+		lifetimeTax = append(lifetimeTax, FormatCurrency(sc.TotalLifetimeTax))
+		lifetimeTaxPV = append(lifetimeTaxPV, FormatCurrency(sc.TotalLifetimeTaxPV))
+		if sc.Name == recommended {
+			marker = append(marker, "*")
+		} else {
+			marker = append(marker, "")
+		}
+	}
+	rows := [][]string{firstYear, year5, year10, longevity, lifetime, lifetimeTax, lifetimeTaxPV, marker}
+
+	// Column-width pass so every cell in a column lines up
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(row []string) {
+		for i, cell := range row {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if i == 0 {
+				// Metric labels are left-aligned, values right-aligned
+				sb.WriteString(cell + pad)
+			} else {
+				sb.WriteString(" | " + pad + cell)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(header)
+	for i, width := range widths {
+		if i > 0 {
+			sb.WriteString("-+-")
+		}
+		sb.WriteString(strings.Repeat("-", width))
+	}
+	sb.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	if recommended != "" {
+		fmt.Fprintf(&sb, "\n* Recommended scenario: %s\n", recommended)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
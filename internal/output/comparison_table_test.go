@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderComparisonTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderComparisonTable(buildTestComparison(), &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) < 8 {
+		t.Fatalf("expected header, separator and six metric rows, got:\n%s", out)
+	}
+	// Every table row must have the same width so columns align
+	for _, line := range lines[1:8] {
+		if len(line) != len(lines[0]) {
+			t.Fatalf("misaligned row %q (want width %d):\n%s", line, len(lines[0]), out)
+		}
+	}
+	for _, want := range []string{"$105,000.00", "$1,600,000.00", "Recommended scenario: B"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
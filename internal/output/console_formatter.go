@@ -10,14 +10,28 @@ import (
 )
 
 // ConsoleFormatter provides a concise console style summary via the formatter interface.
-type ConsoleFormatter struct{}
+// When RealDollars is set, net income figures are shown in today's dollars instead of nominal.
+type ConsoleFormatter struct {
+	RealDollars bool
+}
 
-func (c ConsoleFormatter) Name() string { return "console-lite" }
+func (c ConsoleFormatter) Name() string {
+	if c.RealDollars {
+		return "console-lite-real"
+	}
+	return "console-lite"
+}
 
 func (c ConsoleFormatter) Format(results *domain.ScenarioComparison) ([]byte, error) {
+	if c.RealDollars {
+		results = RealDollarComparison(results)
+	}
 	var buf bytes.Buffer
 	fmt.Fprintln(&buf, "RETIREMENT SCENARIO SUMMARY")
 	fmt.Fprintln(&buf, "================================")
+	if c.RealDollars {
+		fmt.Fprintln(&buf, "(net income in today's dollars)")
+	}
 	fmt.Fprintf(&buf, "Current Net Income: %s\n", FormatCurrency(results.BaselineNetIncome))
 	fmt.Fprintln(&buf)
 	scenarios := append([]domain.ScenarioSummary(nil), results.Scenarios...)
@@ -37,7 +51,7 @@ func (c ConsoleFormatter) Format(results *domain.ScenarioComparison) ([]byte, er
 			FormatCurrency(sc.Year10NetIncome),
 			sc.TSPLongevity,
 		)
-		fmt.Fprintf(&buf, "  FirstRetiredNet=%s LifetimePV=%s\n", FormatCurrency(retiredNet), FormatCurrency(sc.TotalLifetimeIncome))
+		fmt.Fprintf(&buf, "  FirstRetiredNet=%s LifetimePV=%s NPV=%s\n", FormatCurrency(retiredNet), FormatCurrency(sc.TotalLifetimeIncome), FormatCurrency(sc.NetPresentValue))
 	}
 	rec := AnalyzeScenarios(results)
 	if rec.ScenarioName != "" {
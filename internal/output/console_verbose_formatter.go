@@ -45,18 +45,16 @@ func (c ConsoleVerboseFormatter) Format(results *domain.ScenarioComparison) ([]b
 		fmt.Fprintln(&buf, strings.Repeat("=", 50))
 		// first retirement year
 		var firstRetirementYear domain.AnnualCashFlow
-		var firstRetirementYearIndex int
 		found := false
-		for yIdx, y := range scenario.Projection {
+		for _, y := range scenario.Projection {
 			if y.IsRetired {
 				firstRetirementYear = y
-				firstRetirementYearIndex = yIdx
 				found = true
 				break
 			}
 		}
 		if found {
-			actualYear := 2025 + firstRetirementYearIndex
+			actualYear := firstRetirementYear.Date.Year()
 			fmt.Fprintf(&buf, "FIRST RETIREMENT YEAR (%d) INCOME BREAKDOWN:\n", actualYear)
 			fmt.Fprintln(&buf, "(Note: Amounts shown are current-year cash received - may be partial year)")
 			fmt.Fprintln(&buf, "----------------------------------------")
@@ -116,6 +114,7 @@ func (c ConsoleVerboseFormatter) Format(results *domain.ScenarioComparison) ([]b
 		fmt.Fprintf(&buf, "  Year 10 Net Income:      %s\n", FormatCurrency(scenario.Year10NetIncome))
 		fmt.Fprintf(&buf, "  TSP Longevity:           %d years\n", scenario.TSPLongevity)
 		fmt.Fprintf(&buf, "  Total Lifetime Income:   %s\n", FormatCurrency(scenario.TotalLifetimeIncome))
+		fmt.Fprintf(&buf, "  Total Lifetime Tax:      %s (present value %s)\n", FormatCurrency(scenario.TotalLifetimeTax), FormatCurrency(scenario.TotalLifetimeTaxPV))
 		fmt.Fprintln(&buf)
 		fmt.Fprintln(&buf)
 	}
@@ -179,16 +178,24 @@ func writeDetailedComparison(buf *bytes.Buffer, results *domain.ScenarioComparis
 		workingFICA := decimal.NewFromFloat(16837.08)
 		workingTSP := decimal.NewFromFloat(69812.52)
 		workingFEHB := decimal.NewFromFloat(12700.74)
-		cmpLine(buf, "  Federal Tax", workingFederal, firstRetirementYear.FederalTax)
-		cmpLine(buf, "  State Tax", workingState, firstRetirementYear.StateTax)
-		cmpLine(buf, "  Local Tax", workingLocal, firstRetirementYear.LocalTax)
-		cmpLine(buf, "  FICA Tax", workingFICA, firstRetirementYear.FICATax)
-		cmpLine(buf, "  TSP Contributions", workingTSP, firstRetirementYear.TSPContributions)
-		cmpLine(buf, "  FEHB Premium", workingFEHB, firstRetirementYear.FEHBPremium)
-		cmpLine(buf, "  Medicare Premium", decimal.Zero, firstRetirementYear.MedicarePremium)
+		// Round the retirement column so its lines add up to the displayed total
+		deductions := RoundMoneyComponents([]decimal.Decimal{
+			firstRetirementYear.FederalTax, firstRetirementYear.StateTax, firstRetirementYear.LocalTax, firstRetirementYear.FICATax,
+			firstRetirementYear.TSPContributions, firstRetirementYear.FEHBPremium, firstRetirementYear.MedicarePremium,
+		})
+		cmpLine(buf, "  Federal Tax", workingFederal, deductions[0])
+		cmpLine(buf, "  State Tax", workingState, deductions[1])
+		cmpLine(buf, "  Local Tax", workingLocal, deductions[2])
+		cmpLine(buf, "  FICA Tax", workingFICA, deductions[3])
+		cmpLine(buf, "  TSP Contributions", workingTSP, deductions[4])
+		cmpLine(buf, "  FEHB Premium", workingFEHB, deductions[5])
+		cmpLine(buf, "  Medicare Premium", decimal.Zero, deductions[6])
 		fmt.Fprintln(buf, strings.Repeat("-", 80))
 		workingTotalDeductions := workingFederal.Add(workingState).Add(workingLocal).Add(workingFICA).Add(workingTSP).Add(workingFEHB)
-		retirementTotalDeductions := firstRetirementYear.FederalTax.Add(firstRetirementYear.StateTax).Add(firstRetirementYear.LocalTax).Add(firstRetirementYear.FICATax).Add(firstRetirementYear.TSPContributions).Add(firstRetirementYear.FEHBPremium).Add(firstRetirementYear.MedicarePremium)
+		retirementTotalDeductions := decimal.Zero
+		for _, d := range deductions {
+			retirementTotalDeductions = retirementTotalDeductions.Add(d)
+		}
 		cmpLine(buf, "TOTAL DEDUCTIONS", workingTotalDeductions, retirementTotalDeductions)
 		fmt.Fprintln(buf)
 		fmt.Fprintln(buf, strings.Repeat("=", 80))
@@ -197,13 +204,13 @@ func writeDetailedComparison(buf *bytes.Buffer, results *domain.ScenarioComparis
 		percentChange := netDiff.Div(workingNet).Mul(decimal.NewFromInt(100))
 		fmt.Fprintln(buf)
 		fmt.Fprintln(buf, "KEY INSIGHTS:")
-		fmt.Fprintf(buf, "• Working income is reduced by $%.2f in TSP contributions\n", workingTSP.InexactFloat64())
-		fmt.Fprintf(buf, "• Working income is reduced by $%.2f in FICA taxes\n", workingFICA.InexactFloat64())
-		fmt.Fprintf(buf, "• Retirement adds $%.2f in pension income\n", firstRetirementYear.PensionPersonA.Add(firstRetirementYear.PensionPersonB).InexactFloat64())
-		fmt.Fprintf(buf, "• Retirement adds $%.2f in TSP withdrawals\n", firstRetirementYear.TSPWithdrawalPersonA.Add(firstRetirementYear.TSPWithdrawalPersonB).InexactFloat64())
-		fmt.Fprintf(buf, "• Retirement adds $%.2f in Social Security\n", firstRetirementYear.SSBenefitPersonA.Add(firstRetirementYear.SSBenefitPersonB).InexactFloat64())
+		fmt.Fprintf(buf, "• Working income is reduced by $%s in TSP contributions\n", FormatMoney(workingTSP))
+		fmt.Fprintf(buf, "• Working income is reduced by $%s in FICA taxes\n", FormatMoney(workingFICA))
+		fmt.Fprintf(buf, "• Retirement adds $%s in pension income\n", FormatMoney(firstRetirementYear.PensionPersonA.Add(firstRetirementYear.PensionPersonB)))
+		fmt.Fprintf(buf, "• Retirement adds $%s in TSP withdrawals\n", FormatMoney(firstRetirementYear.TSPWithdrawalPersonA.Add(firstRetirementYear.TSPWithdrawalPersonB)))
+		fmt.Fprintf(buf, "• Retirement adds $%s in Social Security\n", FormatMoney(firstRetirementYear.SSBenefitPersonA.Add(firstRetirementYear.SSBenefitPersonB)))
 		if firstRetirementYear.FERSSupplementPersonA.Add(firstRetirementYear.FERSSupplementPersonB).GreaterThan(decimal.Zero) {
-			fmt.Fprintf(buf, "• Retirement adds $%.2f in FERS supplement\n", firstRetirementYear.FERSSupplementPersonA.Add(firstRetirementYear.FERSSupplementPersonB).InexactFloat64())
+			fmt.Fprintf(buf, "• Retirement adds $%s in FERS supplement\n", FormatMoney(firstRetirementYear.FERSSupplementPersonA.Add(firstRetirementYear.FERSSupplementPersonB)))
 		}
 		fmt.Fprintf(buf, "\nNet Effect: %s (%s)\n", FormatCurrency(netDiff), FormatPercentage(percentChange))
 		fmt.Fprintln(buf)
@@ -29,9 +29,9 @@ func (c CSVDetailedExporter) Format(results *domain.ScenarioComparison) ([]byte,
 				sc.Name,
 				intToString(yr.Year),
 				intToString(yr.Date.Year()),
-				yr.NetIncome.StringFixed(2),
-				yr.TotalGrossIncome.StringFixed(2),
-				yr.TotalTSPBalance().StringFixed(2),
+				FormatMoney(yr.NetIncome),
+				FormatMoney(yr.TotalGrossIncome),
+				FormatMoney(yr.TotalTSPBalance()),
 				boolToString(yr.IsRetired),
 			}
 			if err := w.Write(row); err != nil {
@@ -16,7 +16,13 @@ func (c CSVSummarizer) Name() string { return "csv" }
 func (c CSVSummarizer) Format(results *domain.ScenarioComparison) ([]byte, error) {
 	buf := &bytes.Buffer{}
 	w := csv.NewWriter(buf)
-	header := []string{"Scenario", "FirstYearNetIncome", "Year5NetIncome", "Year10NetIncome", "TSPLongevity", "TotalLifetimeIncomePV", "InitialTSPBalance", "FinalTSPBalance", "NetIncome2030", "NetIncome2035", "NetIncome2040", "PreRetirementNet2030", "PreRetirementNet2035", "PreRetirementNet2040"}
+	header := []string{"Scenario", "FirstYearNetIncome", "Year5NetIncome", "Year10NetIncome", "TSPLongevity", "TotalLifetimeIncomePV", "InitialTSPBalance", "FinalTSPBalance"}
+	labels := comparisonYearLabels(results.Scenarios)
+	for _, prefix := range []string{"NetIncome", "PreRetirementNet"} {
+		for _, label := range labels {
+			header = append(header, prefix+label)
+		}
+	}
 	if err := w.Write(header); err != nil {
 		return nil, err
 	}
@@ -25,19 +31,19 @@ func (c CSVSummarizer) Format(results *domain.ScenarioComparison) ([]byte, error
 	for _, sc := range scenarios {
 		row := []string{
 			sc.Name,
-			sc.FirstYearNetIncome.StringFixed(2),
-			sc.Year5NetIncome.StringFixed(2),
-			sc.Year10NetIncome.StringFixed(2),
+			FormatMoney(sc.FirstYearNetIncome),
+			FormatMoney(sc.Year5NetIncome),
+			FormatMoney(sc.Year10NetIncome),
 			intToString(sc.TSPLongevity),
-			sc.TotalLifetimeIncome.StringFixed(2),
-			sc.InitialTSPBalance.StringFixed(2),
-			sc.FinalTSPBalance.StringFixed(2),
-			sc.NetIncome2030.StringFixed(2),
-			sc.NetIncome2035.StringFixed(2),
-			sc.NetIncome2040.StringFixed(2),
-			sc.PreRetirementNet2030.StringFixed(2),
-			sc.PreRetirementNet2035.StringFixed(2),
-			sc.PreRetirementNet2040.StringFixed(2),
+			FormatMoney(sc.TotalLifetimeIncome),
+			FormatMoney(sc.InitialTSPBalance),
+			FormatMoney(sc.FinalTSPBalance),
+			FormatMoney(sc.NetIncome2030),
+			FormatMoney(sc.NetIncome2035),
+			FormatMoney(sc.NetIncome2040),
+			FormatMoney(sc.PreRetirementNet2030),
+			FormatMoney(sc.PreRetirementNet2035),
+			FormatMoney(sc.PreRetirementNet2040),
 		}
 		if err := w.Write(row); err != nil {
 			return nil, err
@@ -46,3 +52,14 @@ func (c CSVSummarizer) Format(results *domain.ScenarioComparison) ([]byte, error
 	w.Flush()
 	return buf.Bytes(), nil
 }
+
+// comparisonYearLabels returns the header suffixes for the calendar year comparison columns, taken from
+// the first scenario's comparison years
+func comparisonYearLabels(scenarios []domain.ScenarioSummary) []string {
+	if len(scenarios) > 0 {
+		if years := scenarios[0].CalendarComparisonYears(); years != nil {
+			return []string{intToString(years[0]), intToString(years[1]), intToString(years[2])}
+		}
+	}
+	return []string{"StartPlus5", "StartPlus10", "StartPlus15"}
+}
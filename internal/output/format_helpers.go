@@ -1,10 +1,44 @@
 package output
 
-import "github.com/shopspring/decimal"
+import (
+	"strings"
 
-// FormatCurrency formats a decimal as USD currency with 2 decimals.
-// Kept here so it can be reused by multiple formatters and unit tested in isolation.
-func FormatCurrency(amount decimal.Decimal) string { return "$" + amount.StringFixed(2) }
+	"github.com/shopspring/decimal"
+)
+
+// FormatCurrency formats a decimal as USD currency with thousands separators, rounded to the cent (see
+// RoundMoney), e.g. "$1,234.56". Kept here so it can be reused by multiple formatters and unit tested in
+// isolation.
+func FormatCurrency(amount decimal.Decimal) string { return FormatCurrencyPlaces(amount, moneyPlaces) }
 
 // FormatPercentage formats a decimal as a percentage with 2 decimals.
 func FormatPercentage(amount decimal.Decimal) string { return amount.StringFixed(2) + "%" }
+
+// FormatCurrencyGrouped formats a decimal as USD with thousands separators and no cents, e.g. "$1,234"
+// or "-$1,234".
+func FormatCurrencyGrouped(amount decimal.Decimal) string { return FormatCurrencyPlaces(amount, 0) }
+
+// FormatCurrencyPlaces formats a decimal as USD with thousands separators, rounded half-to-even to the
+// given number of decimal places. Negative amounts take a leading minus ("-$1,234.50"); amounts that round
+// to zero print as zero without a sign.
+func FormatCurrencyPlaces(amount decimal.Decimal, places int32) string {
+	rounded := amount.RoundBank(places)
+	sign := ""
+	if rounded.IsNegative() {
+		sign = "-"
+		rounded = rounded.Neg()
+	}
+	digits, fraction, _ := strings.Cut(rounded.StringFixed(places), ".")
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if places > 0 {
+		b.WriteByte('.')
+		b.WriteString(fraction)
+	}
+	return sign + "$" + b.String()
+}
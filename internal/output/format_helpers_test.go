@@ -11,7 +11,7 @@ import (
 func TestFormatCurrency(t *testing.T) {
 	v := decimal.NewFromFloat(1234.567)
 	got := FormatCurrency(v)
-	want := "$1234.57"
+	want := "$1,234.57"
 	if got != want {
 		t.Errorf("FormatCurrency(%v) = %q, want %q", v, got, want)
 	}
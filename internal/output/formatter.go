@@ -46,7 +46,9 @@ var builtInFormatters = []Formatter{
 	CSVSummarizer{},
 	CSVDetailedExporter{},
 	ConsoleFormatter{},
+	ConsoleFormatter{RealDollars: true},
 	HTMLFormatter{},
+	HTMLFormatter{RealDollars: true},
 	JSONFormatter{},
 }
 
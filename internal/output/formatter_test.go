@@ -36,6 +36,28 @@ func TestConsoleLiteFormatter(t *testing.T) {
 	}
 }
 
+func TestConsoleLiteFormatterRealDollars(t *testing.T) {
+	cmp := buildTestComparison()
+	cmp.Scenarios[0].Projection[0].RealNetIncome = decimal.NewFromInt(90000)
+	cmp.Scenarios[1].Projection[0].RealNetIncome = decimal.NewFromInt(99000)
+
+	f := ConsoleFormatter{RealDollars: true}
+	if f.Name() != "console-lite-real" || GetFormatterByName("console-lite-real") == nil {
+		t.Fatalf("expected real-dollar console formatter to be registered")
+	}
+	out, err := f.Format(cmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(out)
+	if !strings.Contains(content, "today's dollars") || !strings.Contains(content, "FirstYear=$90,000.00") {
+		t.Fatalf("expected real-dollar figures, got: %s", content)
+	}
+	if !cmp.Scenarios[0].FirstYearNetIncome.Equal(decimal.NewFromInt(95000)) || !cmp.Scenarios[0].Projection[0].NetIncome.Equal(decimal.NewFromInt(95000)) {
+		t.Fatalf("real-dollar formatting must not modify the input comparison")
+	}
+}
+
 func TestConsoleVerboseFormatter(t *testing.T) {
 	f := ConsoleVerboseFormatter{}
 	out, err := f.Format(buildTestComparison())
@@ -11,9 +11,17 @@ import (
 )
 
 // HTMLFormatter produces an HTML report (current implementation ports legacy static HTML).
-type HTMLFormatter struct{}
+// When RealDollars is set, net income figures and the break-even are computed in today's dollars.
+type HTMLFormatter struct {
+	RealDollars bool
+}
 
-func (h HTMLFormatter) Name() string { return "html" }
+func (h HTMLFormatter) Name() string {
+	if h.RealDollars {
+		return "html-real"
+	}
+	return "html"
+}
 
 //go:embed templates/report.html.tmpl
 var htmlTemplateSource string
@@ -36,6 +44,9 @@ var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
 }).Parse(htmlTemplateSource))
 
 func (h HTMLFormatter) Format(results *domain.ScenarioComparison) ([]byte, error) {
+	if h.RealDollars {
+		results = RealDollarComparison(results)
+	}
 	var buf bytes.Buffer
 	rec := AnalyzeScenarios(results)
 
@@ -60,7 +71,8 @@ func (h HTMLFormatter) Format(results *domain.ScenarioComparison) ([]byte, error
 		Recommendation Recommendation
 		Assumptions    []string
 		BreakEven      *calc.CumulativeBreakEvenResult
-	}{results, rec, assumptions, serverBreakEven}
+		RealDollars    bool
+	}{results, rec, assumptions, serverBreakEven, h.RealDollars}
 	if err := htmlTemplate.Execute(&buf, data); err != nil {
 		return nil, err
 	}
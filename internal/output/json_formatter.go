@@ -2,6 +2,8 @@ package output
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/rpgo/retirement-calculator/internal/domain"
 )
@@ -14,3 +16,18 @@ func (j JSONFormatter) Name() string { return "json" }
 func (j JSONFormatter) Format(results *domain.ScenarioComparison) ([]byte, error) {
 	return json.MarshalIndent(results, "", "  ")
 }
+
+// WriteScenarioComparisonJSON writes the full comparison (every scenario summary with its
+// annual projection, plus immediate impact and long-term analysis) to w as indented JSON.
+// Decimal values are emitted as numeric strings so they round-trip without float loss.
+func WriteScenarioComparisonJSON(result *domain.ScenarioComparison, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("scenario comparison is nil")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode scenario comparison: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestWriteScenarioComparisonJSONRoundTrip(t *testing.T) {
+	cmp := buildTestComparison()
+	cmp.Scenarios[0].Projection[0].FederalTax = decimal.RequireFromString("12345.67")
+	cmp.ImmediateImpact = domain.ImpactAnalysis{
+		CurrentToFirstYear:  domain.IncomeChange{ScenarioName: "B", NetIncomeChange: decimal.NewFromInt(5000), PercentageChange: decimal.RequireFromString("5.25")},
+		RecommendedScenario: "B",
+		KeyConsiderations:   []string{"consider B"},
+	}
+	cmp.LongTermProjection = domain.LongTermAnalysis{BestScenarioForIncome: "B", Recommendations: []string{"retire later"}}
+
+	var buf bytes.Buffer
+	if err := WriteScenarioComparisonJSON(cmp, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := buf.String()
+	if !strings.Contains(first, `"federal_tax": "12345.67"`) {
+		t.Fatalf("expected decimal serialized as numeric string, got: %s", first)
+	}
+
+	var decoded domain.ScenarioComparison
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if !decoded.Scenarios[0].Projection[0].FederalTax.Equal(cmp.Scenarios[0].Projection[0].FederalTax) {
+		t.Fatalf("federal tax mismatch after round trip: %s", decoded.Scenarios[0].Projection[0].FederalTax)
+	}
+	if !decoded.ImmediateImpact.CurrentToFirstYear.PercentageChange.Equal(decimal.RequireFromString("5.25")) {
+		t.Fatalf("impact analysis not preserved: %+v", decoded.ImmediateImpact)
+	}
+
+	var again bytes.Buffer
+	if err := WriteScenarioComparisonJSON(&decoded, &again); err != nil {
+		t.Fatalf("unexpected error re-encoding: %v", err)
+	}
+	if again.String() != first {
+		t.Fatalf("round trip produced different JSON")
+	}
+}
+
+func TestWriteScenarioComparisonJSONNil(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteScenarioComparisonJSON(nil, &buf); err == nil {
+		t.Fatalf("expected error for nil comparison")
+	}
+}
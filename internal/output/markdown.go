@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+)
+
+// markdownColumn is one column of the per-year income table
+type markdownColumn struct {
+	header string
+	value  func(cf domain.AnnualCashFlow) string
+}
+
+var markdownProjectionColumns = []markdownColumn{
+	{"Year", func(cf domain.AnnualCashFlow) string { return fmt.Sprintf("%d", cf.Date.Year()) }},
+	{"Ages", func(cf domain.AnnualCashFlow) string { return fmt.Sprintf("%d / %d", cf.AgePersonA, cf.AgePersonB) }},
+	{"Salary", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.SalaryPersonA.Add(cf.SalaryPersonB))
+	}},
+	{"Pension", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.PensionPersonA.Add(cf.PensionPersonB).Add(cf.SurvivorPensionPersonA).Add(cf.SurvivorPensionPersonB))
+	}},
+	{"FERS Supplement", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.FERSSupplementPersonA.Add(cf.FERSSupplementPersonB))
+	}},
+	{"Social Security", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.SSBenefitPersonA.Add(cf.SSBenefitPersonB))
+	}},
+	{"TSP Withdrawals", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.TSPWithdrawalPersonA.Add(cf.TSPWithdrawalPersonB).Add(cf.TSPAnnuityPersonA).Add(cf.TSPAnnuityPersonB))
+	}},
+	{"Gross Income", func(cf domain.AnnualCashFlow) string { return FormatCurrencyGrouped(cf.TotalGrossIncome) }},
+	{"Taxes", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.FederalTax.Add(cf.StateTax).Add(cf.LocalTax).Add(cf.FICATax))
+	}},
+	{"Health Premiums", func(cf domain.AnnualCashFlow) string {
+		return FormatCurrencyGrouped(cf.FEHBPremium.Add(cf.MedicarePremium))
+	}},
+	{"Net Income", func(cf domain.AnnualCashFlow) string { return FormatCurrencyGrouped(cf.NetIncome) }},
+	{"TSP Balance", func(cf domain.AnnualCashFlow) string { return FormatCurrencyGrouped(cf.TotalTSPBalance()) }},
+}
+
+// WriteScenarioMarkdown writes a GitHub-flavored Markdown report for one scenario: a summary table, the
+// year-by-year income table, and the key assumptions. assumptions may be nil to omit that section.
+func WriteScenarioMarkdown(summary *domain.ScenarioSummary, assumptions *domain.GlobalAssumptions, w io.Writer) error {
+	if summary == nil {
+		return fmt.Errorf("no scenario summary to export")
+	}
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# Retirement Scenario: %s\n\n", markdownEscape(summary.Name))
+
+	fmt.Fprintln(bw, "## Summary")
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, "| Metric | Value |")
+	fmt.Fprintln(bw, "| --- | ---: |")
+	fmt.Fprintf(bw, "| First-year net income | %s |\n", FormatCurrencyGrouped(summary.FirstYearNetIncome))
+	fmt.Fprintf(bw, "| Year 5 net income | %s |\n", FormatCurrencyGrouped(summary.Year5NetIncome))
+	fmt.Fprintf(bw, "| Year 10 net income | %s |\n", FormatCurrencyGrouped(summary.Year10NetIncome))
+	fmt.Fprintf(bw, "| Lifetime income | %s |\n", FormatCurrencyGrouped(summary.TotalLifetimeIncome))
+	fmt.Fprintf(bw, "| TSP longevity | %s |\n", markdownLongevity(summary))
+	fmt.Fprintf(bw, "| Final TSP balance | %s |\n", FormatCurrencyGrouped(summary.FinalTSPBalance))
+	fmt.Fprintln(bw)
+
+	if len(summary.Projection) > 0 {
+		fmt.Fprintln(bw, "## Annual Income")
+		fmt.Fprintln(bw)
+		headers := make([]string, len(markdownProjectionColumns))
+		aligns := make([]string, len(markdownProjectionColumns))
+		for i, col := range markdownProjectionColumns {
+			headers[i] = col.header
+			aligns[i] = "---:"
+		}
+		aligns[0], aligns[1] = "---", "---"
+		fmt.Fprintf(bw, "| %s |\n", strings.Join(headers, " | "))
+		fmt.Fprintf(bw, "| %s |\n", strings.Join(aligns, " | "))
+		for _, cf := range summary.Projection {
+			cells := make([]string, len(markdownProjectionColumns))
+			for i, col := range markdownProjectionColumns {
+				cells[i] = col.value(cf)
+			}
+			fmt.Fprintf(bw, "| %s |\n", strings.Join(cells, " | "))
+		}
+		fmt.Fprintln(bw)
+	}
+
+	if assumptions != nil {
+		fmt.Fprintln(bw, "## Key Assumptions")
+		fmt.Fprintln(bw)
+		for _, a := range assumptions.GenerateAssumptions() {
+			fmt.Fprintf(bw, "- %s\n", markdownEscape(a))
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write markdown report: %w", err)
+	}
+	return nil
+}
+
+// markdownLongevity describes how long the TSP lasts, noting when it outlives the projection
+func markdownLongevity(summary *domain.ScenarioSummary) string {
+	if n := len(summary.Projection); n > 0 && summary.TSPLongevity >= n {
+		return fmt.Sprintf("%d+ years (not depleted)", n)
+	}
+	return fmt.Sprintf("%d years", summary.TSPLongevity)
+}
+
+// markdownEscape escapes characters that would break a table cell or be read as formatting
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "*", `\*`, "_", `\_`).Replace(s)
+}
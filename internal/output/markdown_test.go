@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestWriteScenarioMarkdown(t *testing.T) {
+	summary := buildTestComparison().Scenarios[1]
+	summary.Name = "B | retire at 62"
+	summary.Projection[0].SSBenefitPersonA = decimal.NewFromInt(28800)
+	assumptions := &domain.GlobalAssumptions{COLAGeneralRate: decimal.NewFromFloat(0.025)}
+
+	var buf bytes.Buffer
+	if err := WriteScenarioMarkdown(&summary, assumptions, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`# Retirement Scenario: B \| retire at 62`,
+		"| First-year net income | $105,000 |",
+		"| Year 10 net income | $107,000 |",
+		"| TSP longevity | 1+ years (not depleted) |",
+		"| Year | Ages | Salary |",
+		"| 2025 | 0 / 0 | $0 | $0 | $0 | $28,800 |",
+		"- General COLA (FERS pension & SS): 2.5% annually",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in report:\n%s", want, out)
+		}
+	}
+
+	// Every table row must have the same number of cells as its header for GitHub to render it
+	var tableCells int
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "| Year |") && (tableCells == 0 || !strings.HasPrefix(line, "| ")) {
+			continue
+		}
+		cells := strings.Count(line, " | ") + 1
+		if tableCells == 0 {
+			tableCells = cells
+		} else if cells != tableCells {
+			t.Fatalf("row %q has %d cells, header has %d", line, cells, tableCells)
+		}
+	}
+}
+
+func TestFormatCurrencyGrouped(t *testing.T) {
+	for in, want := range map[float64]string{0: "$0", 999.6: "$1,000", 1234567.4: "$1,234,567", -98765: "-$98,765"} {
+		if got := FormatCurrencyGrouped(decimal.NewFromFloat(in)); got != want {
+			t.Errorf("FormatCurrencyGrouped(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormatCurrencyPlaces(t *testing.T) {
+	cases := []struct {
+		amount float64
+		places int32
+		want   string
+	}{
+		{0, 0, "$0"},
+		{0, 2, "$0.00"},
+		{-0.004, 2, "$0.00"},
+		{-0.4, 0, "$0"},
+		{7.5, 2, "$7.50"},
+		{999.995, 2, "$1,000.00"},
+		{3000000, 0, "$3,000,000"},
+		{1234567.891, 2, "$1,234,567.89"},
+		{-45.678, 1, "-$45.7"},
+		{-2500000.5, 0, "-$2,500,000"},
+		{-123456.785, 2, "-$123,456.78"},
+	}
+	for _, tc := range cases {
+		if got := FormatCurrencyPlaces(decimal.NewFromFloat(tc.amount), tc.places); got != tc.want {
+			t.Errorf("FormatCurrencyPlaces(%v, %d) = %q, want %q", tc.amount, tc.places, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rounding policy: the calculation package keeps full decimal precision throughout the projection, and
+// money is rounded only here, at the reporting boundary. Cents are rounded half-to-even (banker's
+// rounding) so that large columns of values do not drift upward. Text, CSV and spreadsheet outputs go
+// through these helpers; JSON exports keep full precision so they round-trip.
+
+// moneyPlaces is the number of decimal places money is reported to
+const moneyPlaces = 2
+
+// RoundMoney rounds an amount to the cent, half-to-even
+func RoundMoney(amount decimal.Decimal) decimal.Decimal {
+	return amount.RoundBank(moneyPlaces)
+}
+
+// FormatMoney returns an amount rounded to the cent as a plain string, e.g. "1234.56"
+func FormatMoney(amount decimal.Decimal) string {
+	return RoundMoney(amount).StringFixed(moneyPlaces)
+}
+
+// RoundMoneyComponents rounds each component to the cent so that the rounded components add up to the
+// rounded total of the unrounded components. Cents lost or gained to rounding are given to (or taken
+// from) the components with the largest rounding error, the largest-remainder method.
+func RoundMoneyComponents(components []decimal.Decimal) []decimal.Decimal {
+	rounded := make([]decimal.Decimal, len(components))
+	total, roundedSum := decimal.Zero, decimal.Zero
+	for i, c := range components {
+		rounded[i] = RoundMoney(c)
+		total = total.Add(c)
+		roundedSum = roundedSum.Add(rounded[i])
+	}
+
+	cent := decimal.New(1, -moneyPlaces)
+	diff := RoundMoney(total).Sub(roundedSum)
+	if diff.IsZero() {
+		return rounded
+	}
+	step := cent
+	if diff.IsNegative() {
+		step = cent.Neg()
+	}
+
+	// Order by how far rounding moved each component against the direction of the correction
+	order := make([]int, len(components))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		errA := components[order[a]].Sub(rounded[order[a]]).Mul(step)
+		errB := components[order[b]].Sub(rounded[order[b]]).Mul(step)
+		return errA.GreaterThan(errB)
+	})
+	for i := 0; !diff.IsZero() && len(order) > 0; i++ {
+		idx := order[i%len(order)]
+		rounded[idx] = rounded[idx].Add(step)
+		diff = diff.Sub(step)
+	}
+	return rounded
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundMoneyHalfToEven(t *testing.T) {
+	tests := map[string]string{"1.005": "1.00", "1.015": "1.02", "2.675": "2.68", "-0.125": "-0.12"}
+	for in, want := range tests {
+		if got := FormatMoney(decimal.RequireFromString(in)); got != want {
+			t.Fatalf("FormatMoney(%s): expected %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestRoundMoneyComponentsSumToRoundedTotal(t *testing.T) {
+	cases := [][]string{
+		{"0.335", "0.335", "0.335"},       // 0.34 each sums to 1.02; the total 1.005 rounds to 1.00
+		{"10.004", "10.004", "10.004"},    // 10.00 each sums to 30.00; the total rounds to 30.01
+		{"0.006", "0.006", "0.006"},       // 0.01 each sums to 0.03; the total rounds to 0.02
+		{"1234.567", "-12.345", "99.999"}, // mixed signs
+		{"100", "200.10", "0"},
+	}
+	for _, c := range cases {
+		components := make([]decimal.Decimal, len(c))
+		total := decimal.Zero
+		for i, s := range c {
+			components[i] = decimal.RequireFromString(s)
+			total = total.Add(components[i])
+		}
+		rounded := RoundMoneyComponents(components)
+		sum := decimal.Zero
+		for i, r := range rounded {
+			if !r.Equal(RoundMoney(r)) {
+				t.Fatalf("%v: component %d not rounded to the cent: %s", c, i, r)
+			}
+			if r.Sub(components[i]).Abs().GreaterThan(decimal.RequireFromString("0.015")) {
+				t.Fatalf("%v: component %d moved too far: %s -> %s", c, i, components[i], r)
+			}
+			sum = sum.Add(r)
+		}
+		if !sum.Equal(RoundMoney(total)) {
+			t.Fatalf("%v: rounded components sum to %s, expected rounded total %s", c, sum, RoundMoney(total))
+		}
+	}
+}
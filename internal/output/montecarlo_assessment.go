@@ -0,0 +1,106 @@
+package output
+
+import (
+	"github.com/rpgo/retirement-calculator/internal/calculation"
+	"github.com/shopspring/decimal"
+)
+
+// RiskLevel grades a Monte Carlo success rate
+type RiskLevel string
+
+const (
+	// RiskLevelLow is a success rate of 90% or more
+	RiskLevelLow RiskLevel = "low"
+	// RiskLevelModerate is a success rate from 70% up to 90%
+	RiskLevelModerate RiskLevel = "moderate"
+	// RiskLevelHigh is a success rate below 70%
+	RiskLevelHigh RiskLevel = "high"
+)
+
+// MarketSensitivity grades how widely net income spreads across simulations
+type MarketSensitivity string
+
+const (
+	MarketSensitivityUnknown  MarketSensitivity = "unknown"
+	MarketSensitivityLow      MarketSensitivity = "low"
+	MarketSensitivityModerate MarketSensitivity = "moderate"
+	MarketSensitivityHigh     MarketSensitivity = "high"
+)
+
+// MonteCarloAssessment is the plain-language reading of a Monte Carlo run, computed once so every output
+// format presents the same risk level, concerns and recommendations
+type MonteCarloAssessment struct {
+	SuccessRatePercent float64           `json:"success_rate_percent"`
+	RiskLevel          RiskLevel         `json:"risk_level"`
+	Concerns           []string          `json:"concerns"`
+	MarketSensitivity  MarketSensitivity `json:"market_sensitivity"`
+	SensitivityNote    string            `json:"sensitivity_note"`
+	Recommendations    []string          `json:"recommendations"`
+}
+
+// NewMonteCarloAssessment grades a Monte Carlo result. Market sensitivity uses the spread between the 10th
+// and 90th percentile net income relative to the median.
+func NewMonteCarloAssessment(result *calculation.FERSMonteCarloResult) MonteCarloAssessment {
+	rate := result.SuccessRate.Mul(decimal.NewFromFloat(100)).InexactFloat64()
+	assessment := MonteCarloAssessment{SuccessRatePercent: rate}
+
+	switch {
+	case rate >= 90:
+		assessment.RiskLevel = RiskLevelLow
+		assessment.Concerns = []string{"Minimal concerns. Your retirement plan appears robust."}
+	case rate >= 70:
+		assessment.RiskLevel = RiskLevelModerate
+		assessment.Concerns = []string{"Market volatility could impact retirement income. Consider conservative strategies."}
+	default:
+		assessment.RiskLevel = RiskLevelHigh
+		assessment.Concerns = []string{"Significant risk of income shortfall. Immediate action recommended."}
+	}
+
+	assessment.MarketSensitivity, assessment.SensitivityNote = marketSensitivity(result)
+
+	if rate < 90 {
+		assessment.Recommendations = append(assessment.Recommendations,
+			"Consider increasing TSP contributions to improve retirement security",
+			"Review withdrawal strategies to optimize income sustainability")
+	}
+	if rate < 70 {
+		assessment.Recommendations = append(assessment.Recommendations,
+			"Consider delaying retirement to increase benefits",
+			"Explore additional income sources or part-time work",
+			"Consult with a financial advisor for personalized planning")
+	}
+	if len(assessment.Recommendations) == 0 {
+		assessment.Recommendations = []string{
+			"Maintain current retirement strategy",
+			"Regularly review and adjust plan as circumstances change",
+		}
+	}
+	return assessment
+}
+
+// marketSensitivity grades the 10th-90th percentile net income range as a fraction of the median
+func marketSensitivity(result *calculation.FERSMonteCarloResult) (MarketSensitivity, string) {
+	median := result.MedianNetIncome
+	if median.IsZero() {
+		return MarketSensitivityUnknown, "Unable to determine"
+	}
+	incomeRange := result.NetIncomePercentiles.P90.Sub(result.NetIncomePercentiles.P10)
+	cv := incomeRange.Div(median).InexactFloat64()
+	if cv < 0.5 {
+		return MarketSensitivityLow, "Low - Income is relatively stable across market conditions"
+	} else if cv < 1.0 {
+		return MarketSensitivityModerate, "Moderate - Income varies with market performance"
+	}
+	return MarketSensitivityHigh, "High - Income is highly sensitive to market conditions"
+}
+
+// Label is the display form of a risk level
+func (r RiskLevel) Label() string {
+	switch r {
+	case RiskLevelLow:
+		return "🟢 Low"
+	case RiskLevelModerate:
+		return "🟡 Moderate"
+	}
+	return "🔴 High"
+}
@@ -0,0 +1,34 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rpgo/retirement-calculator/internal/calculation"
+	"github.com/shopspring/decimal"
+)
+
+func TestMonteCarloAssessmentLowRisk(t *testing.T) {
+	result := &calculation.FERSMonteCarloResult{
+		SuccessRate:     decimal.NewFromFloat(0.95),
+		MedianNetIncome: decimal.NewFromInt(100000),
+	}
+	result.NetIncomePercentiles.P10 = decimal.NewFromInt(90000)
+	result.NetIncomePercentiles.P90 = decimal.NewFromInt(110000)
+
+	assessment := NewMonteCarloAssessment(result)
+	if assessment.RiskLevel != RiskLevelLow {
+		t.Fatalf("expected low risk at 95%% success, got %s", assessment.RiskLevel)
+	}
+	if assessment.MarketSensitivity != MarketSensitivityLow {
+		t.Fatalf("expected low market sensitivity, got %s", assessment.MarketSensitivity)
+	}
+	if len(assessment.Recommendations) != 2 || !strings.Contains(assessment.Recommendations[0], "Maintain") {
+		t.Fatalf("expected maintain-course recommendations, got %v", assessment.Recommendations)
+	}
+
+	report := &MonteCarloHTMLReport{Result: result}
+	if html := report.generateHTMLContent(); !strings.Contains(html, RiskLevelLow.Label()) {
+		t.Fatalf("expected the HTML report to show the %q risk level", RiskLevelLow.Label())
+	}
+}
@@ -38,14 +38,15 @@ func (m *MonteCarloCSVReport) GenerateSummaryCSV(outputPath string) error {
 	// Write summary data
 	summaryData := [][]string{
 		{"Success Rate", fmt.Sprintf("%.2f%%", m.Result.SuccessRate.Mul(decimal.NewFromFloat(100)).InexactFloat64()), "Percentage of successful simulations"},
-		{"Median Net Income", fmt.Sprintf("$%s", m.Result.MedianNetIncome.StringFixed(0)), "Median annual net income across all simulations"},
-		{"Income Volatility", fmt.Sprintf("$%s", m.Result.IncomeVolatility.StringFixed(0)), "Standard deviation of net income"},
+		{"Median Net Income", FormatCurrencyGrouped(m.Result.MedianNetIncome), "Median annual net income across all simulations"},
+		{"Income Volatility", FormatCurrencyGrouped(m.Result.IncomeVolatility), "Standard deviation of net income"},
 		{"TSP Depletion Rate", fmt.Sprintf("%.2f%%", m.Result.TSPDepletionRate.Mul(decimal.NewFromFloat(100)).InexactFloat64()), "Percentage of simulations where TSP was depleted"},
+		{"Sequence Risk", m.Result.SequenceRisk.BalanceCorrelation.StringFixed(2), fmt.Sprintf("Correlation of final TSP balance with the first %d years' average return", m.Result.SequenceRisk.Years)},
 		{"Median TSP Longevity", fmt.Sprintf("%s years", m.Result.TSPLongevityPercentiles.P50.StringFixed(0)), "Median years until TSP depletion"},
-		{"10th Percentile Income", fmt.Sprintf("$%s", m.Result.NetIncomePercentiles.P10.StringFixed(0)), "10th percentile of net income"},
-		{"25th Percentile Income", fmt.Sprintf("$%s", m.Result.NetIncomePercentiles.P25.StringFixed(0)), "25th percentile of net income"},
-		{"75th Percentile Income", fmt.Sprintf("$%s", m.Result.NetIncomePercentiles.P75.StringFixed(0)), "75th percentile of net income"},
-		{"90th Percentile Income", fmt.Sprintf("$%s", m.Result.NetIncomePercentiles.P90.StringFixed(0)), "90th percentile of net income"},
+		{"10th Percentile Income", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P10), "10th percentile of net income"},
+		{"25th Percentile Income", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P25), "25th percentile of net income"},
+		{"75th Percentile Income", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P75), "75th percentile of net income"},
+		{"90th Percentile Income", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P90), "90th percentile of net income"},
 		{"Number of Simulations", strconv.Itoa(m.Config.NumSimulations), "Total number of simulations run"},
 		{"Data Source", map[bool]string{true: "Historical", false: "Statistical"}[m.Config.UseHistorical], "Source of market data"},
 	}
@@ -93,12 +94,12 @@ func (m *MonteCarloCSVReport) GenerateDetailedCSV(outputPath string) error {
 		row := []string{
 			strconv.Itoa(sim.SimulationID),
 			strconv.FormatBool(sim.Success),
-			"$" + sim.NetIncomeMetrics.FirstYearNetIncome.StringFixed(0),
-			"$" + sim.NetIncomeMetrics.Year5NetIncome.StringFixed(0),
-			"$" + sim.NetIncomeMetrics.Year10NetIncome.StringFixed(0),
-			"$" + sim.NetIncomeMetrics.MinNetIncome.StringFixed(0),
-			"$" + sim.NetIncomeMetrics.MaxNetIncome.StringFixed(0),
-			"$" + sim.NetIncomeMetrics.AverageNetIncome.StringFixed(0),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.FirstYearNetIncome),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.Year5NetIncome),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.Year10NetIncome),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.MinNetIncome),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.MaxNetIncome),
+			FormatCurrencyGrouped(sim.NetIncomeMetrics.AverageNetIncome),
 			strconv.Itoa(sim.TSPMetrics.Longevity),
 			strconv.FormatBool(sim.TSPMetrics.Depleted),
 			"Historical", // This could be enhanced to show actual market conditions
@@ -135,11 +136,11 @@ func (m *MonteCarloCSVReport) GeneratePercentileCSV(outputPath string) error {
 
 	// Write percentile data
 	percentileData := [][]string{
-		{"10th", "$" + m.Result.NetIncomePercentiles.P10.StringFixed(0), m.Result.TSPLongevityPercentiles.P10.StringFixed(0), "Worst 10% of scenarios"},
-		{"25th", "$" + m.Result.NetIncomePercentiles.P25.StringFixed(0), m.Result.TSPLongevityPercentiles.P25.StringFixed(0), "Below average scenarios"},
-		{"50th (Median)", "$" + m.Result.NetIncomePercentiles.P50.StringFixed(0), m.Result.TSPLongevityPercentiles.P50.StringFixed(0), "Typical scenario"},
-		{"75th", "$" + m.Result.NetIncomePercentiles.P75.StringFixed(0), m.Result.TSPLongevityPercentiles.P75.StringFixed(0), "Above average scenarios"},
-		{"90th", "$" + m.Result.NetIncomePercentiles.P90.StringFixed(0), m.Result.TSPLongevityPercentiles.P90.StringFixed(0), "Best 10% of scenarios"},
+		{"10th", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P10), m.Result.TSPLongevityPercentiles.P10.StringFixed(0), "Worst 10% of scenarios"},
+		{"25th", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P25), m.Result.TSPLongevityPercentiles.P25.StringFixed(0), "Below average scenarios"},
+		{"50th (Median)", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P50), m.Result.TSPLongevityPercentiles.P50.StringFixed(0), "Typical scenario"},
+		{"75th", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P75), m.Result.TSPLongevityPercentiles.P75.StringFixed(0), "Above average scenarios"},
+		{"90th", FormatCurrencyGrouped(m.Result.NetIncomePercentiles.P90), m.Result.TSPLongevityPercentiles.P90.StringFixed(0), "Best 10% of scenarios"},
 	}
 
 	for _, row := range percentileData {
@@ -1,9 +1,11 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rpgo/retirement-calculator/internal/calculation"
@@ -39,6 +41,7 @@ func (m *MonteCarloHTMLReport) GenerateHTMLReport(outputPath string) error {
 func (m *MonteCarloHTMLReport) generateHTMLContent() string {
 	// Generate time-series data
 	netIncomeTimeSeriesData, tspBalanceTimeSeriesData := m.generateTimeSeriesData()
+	assessment := NewMonteCarloAssessment(m.Result)
 
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
@@ -227,7 +230,7 @@ func (m *MonteCarloHTMLReport) generateHTMLContent() string {
                 </div>
                 <div class="summary-card">
                     <h3>Median Net Income</h3>
-                    <div class="value">$%s</div>
+                    <div class="value">%s</div>
                 </div>
                 <div class="summary-card">
                     <h3>Simulations</h3>
@@ -250,6 +253,11 @@ func (m *MonteCarloHTMLReport) generateHTMLContent() string {
                 <canvas id="tspTimeSeriesChart" width="800" height="400"></canvas>
             </div>
 
+            <div class="chart-container full-width">
+                <h3>⏳ Probability of TSP Depletion by Age</h3>
+                <canvas id="depletionChart" width="800" height="300"></canvas>
+            </div>
+
             <!-- Distribution Charts -->
             <div class="chart-grid">
                 <div class="chart-container">
@@ -281,27 +289,27 @@ func (m *MonteCarloHTMLReport) generateHTMLContent() string {
                     <tbody>
                         <tr>
                             <td>10th</td>
-                            <td>$%s</td>
+                            <td>%s</td>
                             <td>Worst 10%% of scenarios</td>
                         </tr>
                         <tr>
                             <td>25th</td>
-                            <td>$%s</td>
+                            <td>%s</td>
                             <td>Below average scenarios</td>
                         </tr>
                         <tr>
                             <td>50th (Median)</td>
-                            <td>$%s</td>
+                            <td>%s</td>
                             <td>Typical scenario</td>
                         </tr>
                         <tr>
                             <td>75th</td>
-                            <td>$%s</td>
+                            <td>%s</td>
                             <td>Above average scenarios</td>
                         </tr>
                         <tr>
                             <td>90th</td>
-                            <td>$%s</td>
+                            <td>%s</td>
                             <td>Best 10%% of scenarios</td>
                         </tr>
                     </tbody>
@@ -631,106 +639,88 @@ func (m *MonteCarloHTMLReport) generateHTMLContent() string {
                 }
             }
         });
+
+        // TSP Depletion Probability Chart (cumulative share of simulations depleted by each year)
+        const depletionData = %s;
+        const depletionCtx = document.getElementById('depletionChart').getContext('2d');
+        new Chart(depletionCtx, {
+            type: 'bar',
+            data: {
+                labels: depletionData.map(p => p.year + ' (' + p.age_person_a + '/' + p.age_person_b + ')'),
+                datasets: [{
+                    label: 'Depleted by this year',
+                    data: depletionData.map(p => Number(p.depleted_fraction) * 100),
+                    backgroundColor: depletionData.map(p => 'rgba(231, 76, 60, ' + (0.15 + 0.85 * Number(p.depleted_fraction)) + ')')
+                }]
+            },
+            options: {
+                responsive: true,
+                maintainAspectRatio: true,
+                aspectRatio: 3,
+                animation: {
+                    duration: 0
+                },
+                plugins: {
+                    legend: {
+                        display: false
+                    }
+                },
+                scales: {
+                    x: {
+                        title: {
+                            display: true,
+                            text: 'Year (Person A age / Person B age)'
+                        }
+                    },
+                    y: {
+                        min: 0,
+                        max: 100,
+                        title: {
+                            display: true,
+                            text: 'Simulations Depleted (%%)'
+                        }
+                    }
+                }
+            }
+        });
     </script>
 </body>
 </html>`,
-		m.getSuccessRateClass(),
+		successRateClass(assessment.RiskLevel),
 		m.Result.SuccessRate.Mul(decimal.NewFromFloat(100)).InexactFloat64(),
 		m.formatCurrency(m.Result.MedianNetIncome),
 		m.Config.NumSimulations,
-		m.getRiskLevel(),
+		assessment.RiskLevel.Label(),
 		m.formatCurrency(m.Result.NetIncomePercentiles.P10),
 		m.formatCurrency(m.Result.NetIncomePercentiles.P25),
 		m.formatCurrency(m.Result.NetIncomePercentiles.P50),
 		m.formatCurrency(m.Result.NetIncomePercentiles.P75),
 		m.formatCurrency(m.Result.NetIncomePercentiles.P90),
-		m.getRiskLevel(),
-		m.getPrimaryConcerns(),
-		m.getMarketSensitivity(),
-		m.generateRecommendationsHTML(),
+		assessment.RiskLevel.Label(),
+		strings.Join(assessment.Concerns, " "),
+		assessment.SensitivityNote,
+		recommendationsHTML(assessment.Recommendations),
 		time.Now().Format("January 2, 2006 at 3:04 PM"),
 		m.generateNetIncomeData(),
 		m.generateTSPBalanceData(),
 		m.generatePercentileData(),
 		netIncomeTimeSeriesData,
-		tspBalanceTimeSeriesData)
+		tspBalanceTimeSeriesData,
+		m.generateDepletionData())
 }
 
 // Helper methods for HTML generation
-func (m *MonteCarloHTMLReport) getSuccessRateClass() string {
-	rate := m.Result.SuccessRate.Mul(decimal.NewFromFloat(100))
-	rateFloat, _ := rate.Float64()
-	if rateFloat >= 90 {
+func successRateClass(level RiskLevel) string {
+	switch level {
+	case RiskLevelLow:
 		return "success"
-	} else if rateFloat >= 70 {
+	case RiskLevelModerate:
 		return "warning"
 	}
 	return "danger"
 }
 
-func (m *MonteCarloHTMLReport) getRiskLevel() string {
-	rate := m.Result.SuccessRate.Mul(decimal.NewFromFloat(100))
-	rateFloat, _ := rate.Float64()
-	if rateFloat >= 90 {
-		return "🟢 Low"
-	} else if rateFloat >= 70 {
-		return "🟡 Moderate"
-	}
-	return "🔴 High"
-}
-
-func (m *MonteCarloHTMLReport) getPrimaryConcerns() string {
-	rate := m.Result.SuccessRate.Mul(decimal.NewFromFloat(100))
-	rateFloat, _ := rate.Float64()
-	if rateFloat >= 90 {
-		return "Minimal concerns. Your retirement plan appears robust."
-	} else if rateFloat >= 70 {
-		return "Market volatility could impact retirement income. Consider conservative strategies."
-	}
-	return "Significant risk of income shortfall. Immediate action recommended."
-}
-
-func (m *MonteCarloHTMLReport) getMarketSensitivity() string {
-	// Calculate coefficient of variation for net income
-	median := m.Result.MedianNetIncome
-	if median.IsZero() {
-		return "Unable to determine"
-	}
-
-	// Use the range between 10th and 90th percentile as a proxy for variability
-	incomeRange := m.Result.NetIncomePercentiles.P90.Sub(m.Result.NetIncomePercentiles.P10)
-	cv := incomeRange.Div(median)
-
-	cvFloat, _ := cv.Float64()
-	if cvFloat < 0.5 {
-		return "Low - Income is relatively stable across market conditions"
-	} else if cvFloat < 1.0 {
-		return "Moderate - Income varies with market performance"
-	}
-	return "High - Income is highly sensitive to market conditions"
-}
-
-func (m *MonteCarloHTMLReport) generateRecommendationsHTML() string {
-	rate := m.Result.SuccessRate.Mul(decimal.NewFromFloat(100))
-	rateFloat, _ := rate.Float64()
-	var recommendations []string
-
-	if rateFloat < 90 {
-		recommendations = append(recommendations, "Consider increasing TSP contributions to improve retirement security")
-		recommendations = append(recommendations, "Review withdrawal strategies to optimize income sustainability")
-	}
-
-	if rateFloat < 70 {
-		recommendations = append(recommendations, "Consider delaying retirement to increase benefits")
-		recommendations = append(recommendations, "Explore additional income sources or part-time work")
-		recommendations = append(recommendations, "Consult with a financial advisor for personalized planning")
-	}
-
-	if len(recommendations) == 0 {
-		recommendations = append(recommendations, "Maintain current retirement strategy")
-		recommendations = append(recommendations, "Regularly review and adjust plan as circumstances change")
-	}
-
+func recommendationsHTML(recommendations []string) string {
 	html := ""
 	for _, rec := range recommendations {
 		html += fmt.Sprintf("<li>%s</li>", rec)
@@ -739,7 +729,7 @@ func (m *MonteCarloHTMLReport) generateRecommendationsHTML() string {
 }
 
 func (m *MonteCarloHTMLReport) formatCurrency(amount decimal.Decimal) string {
-	return amount.StringFixed(0)
+	return FormatCurrencyGrouped(amount)
 }
 
 func (m *MonteCarloHTMLReport) generateNetIncomeData() string {
@@ -930,7 +920,7 @@ func (m *MonteCarloHTMLReport) generateTimeSeriesData() (string, string) {
 	netIncomeTimeSeries += "],"
 	tspBalanceTimeSeries += "],"
 
-	// Generate percentile arrays
+	// Generate percentile arrays, with the same interpolation as the summary percentiles
 	percentiles := []string{"p10", "p25", "p50", "p75", "p90"}
 	percentileFactors := []float64{0.10, 0.25, 0.50, 0.75, 0.90}
 
@@ -940,8 +930,8 @@ func (m *MonteCarloHTMLReport) generateTimeSeriesData() (string, string) {
 
 		for yearIdx := 0; yearIdx < projectionLength; yearIdx++ {
 			if len(yearlyNetIncomes[yearIdx]) > 0 {
-				netIncomePercentile := m.calculatePercentile(yearlyNetIncomes[yearIdx], percentileFactors[i])
-				tspBalancePercentile := m.calculatePercentile(yearlyTSPBalances[yearIdx], percentileFactors[i])
+				netIncomePercentile := calculation.Percentile(yearlyNetIncomes[yearIdx], percentileFactors[i], calculation.MonteCarloPercentileMethod)
+				tspBalancePercentile := calculation.Percentile(yearlyTSPBalances[yearIdx], percentileFactors[i], calculation.MonteCarloPercentileMethod)
 
 				netIncomeTimeSeries += fmt.Sprintf("%.0f", netIncomePercentile.InexactFloat64())
 				tspBalanceTimeSeries += fmt.Sprintf("%.0f", tspBalancePercentile.InexactFloat64())
@@ -970,42 +960,18 @@ func (m *MonteCarloHTMLReport) generateTimeSeriesData() (string, string) {
 	return netIncomeTimeSeries, tspBalanceTimeSeries
 }
 
-// calculatePercentile calculates a specific percentile from a slice of values
-func (m *MonteCarloHTMLReport) calculatePercentile(values []decimal.Decimal, percentile float64) decimal.Decimal {
-	if len(values) == 0 {
-		return decimal.Zero
-	}
-
-	// Sort values
-	sortedValues := make([]decimal.Decimal, len(values))
-	copy(sortedValues, values)
-
-	// Simple bubble sort for decimal values
-	for i := 0; i < len(sortedValues)-1; i++ {
-		for j := 0; j < len(sortedValues)-i-1; j++ {
-			if sortedValues[j].GreaterThan(sortedValues[j+1]) {
-				sortedValues[j], sortedValues[j+1] = sortedValues[j+1], sortedValues[j]
-			}
-		}
+// generateDepletionData returns the depletion-by-year series as a JSON array for the depletion chart
+func (m *MonteCarloHTMLReport) generateDepletionData() string {
+	points := m.Result.DepletionByYear
+	if points == nil {
+		points = m.Result.DepletionHeatmap()
 	}
-
-	// Calculate percentile index
-	index := percentile * float64(len(sortedValues)-1)
-	lowerIndex := int(index)
-	upperIndex := lowerIndex + 1
-
-	if upperIndex >= len(sortedValues) {
-		return sortedValues[len(sortedValues)-1]
+	if len(points) == 0 {
+		return "[]"
 	}
-
-	if lowerIndex == int(index) {
-		return sortedValues[lowerIndex]
+	data, err := json.Marshal(points)
+	if err != nil {
+		return "[]"
 	}
-
-	// Linear interpolation between the two nearest values
-	weight := decimal.NewFromFloat(index - float64(lowerIndex))
-	lower := sortedValues[lowerIndex]
-	upper := sortedValues[upperIndex]
-
-	return lower.Add(upper.Sub(lower).Mul(weight))
+	return string(data)
 }
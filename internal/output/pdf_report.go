@@ -0,0 +1,203 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// pdfTableColumns defines the year-by-year income table layout (widths in mm)
+var pdfTableColumns = []struct {
+	title string
+	width float64
+}{
+	{"Year", 16},
+	{"Ages", 18},
+	{"Gross Income", 32},
+	{"Taxes", 28},
+	{"Health", 24},
+	{"Net Income", 32},
+	{"TSP Balance", 36},
+}
+
+// GenerateScenarioPDF writes a printable PDF report for a single scenario: a summary page,
+// a paginated year-by-year income table, and a net income chart.
+func GenerateScenarioPDF(summary *domain.ScenarioSummary, outputPath string) error {
+	if summary == nil {
+		return fmt.Errorf("scenario summary is nil")
+	}
+
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetTitle("Retirement Scenario Report: "+summary.Name, true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	writePDFSummaryPage(pdf, summary)
+	writePDFIncomeTable(pdf, summary.Projection)
+	writePDFNetIncomeChart(pdf, summary.Projection)
+
+	if err := pdf.OutputFileAndClose(outputPath); err != nil {
+		return fmt.Errorf("failed to write PDF report: %w", err)
+	}
+	return nil
+}
+
+func writePDFSummaryPage(pdf *fpdf.Fpdf, summary *domain.ScenarioSummary) {
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "Retirement Scenario Report", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 13)
+	pdf.CellFormat(0, 8, summary.Name, "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"First-Year Net Income", FormatCurrency(summary.FirstYearNetIncome)},
+		{"Year 5 Net Income", FormatCurrency(summary.Year5NetIncome)},
+		{"Year 10 Net Income", FormatCurrency(summary.Year10NetIncome)},
+		{"Total Lifetime Income (PV)", FormatCurrency(summary.TotalLifetimeIncome)},
+		{"Initial TSP Balance", FormatCurrency(summary.InitialTSPBalance)},
+		{"Final TSP Balance", FormatCurrency(summary.FinalTSPBalance)},
+		{"TSP Longevity", fmt.Sprintf("%d years", summary.TSPLongevity)},
+	}
+
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, "Key Metrics", "B", 1, "L", false, 0, "")
+	pdf.Ln(2)
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(90, 8, row.label, "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 8, row.value, "", 1, "R", false, 0, "")
+	}
+}
+
+func writePDFTableHeader(pdf *fpdf.Fpdf) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(220, 228, 240)
+	for _, col := range pdfTableColumns {
+		pdf.CellFormat(col.width, 7, col.title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func writePDFIncomeTable(pdf *fpdf.Fpdf, projection []domain.AnnualCashFlow) {
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, "Year-by-Year Income", "", 1, "L", false, 0, "")
+	writePDFTableHeader(pdf)
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	const rowHeight = 6.0
+
+	for _, cf := range projection {
+		// Start a new page (repeating the header) before the row would overflow
+		if pdf.GetY()+rowHeight > pageHeight-bottom-5 {
+			pdf.AddPage()
+			writePDFTableHeader(pdf)
+		}
+		taxes := cf.FederalTax.Add(cf.StateTax).Add(cf.LocalTax).Add(cf.FICATax)
+		health := cf.FEHBPremium.Add(cf.MedicarePremium)
+		cells := []string{
+			fmt.Sprintf("%d", cf.Date.Year()),
+			fmt.Sprintf("%d/%d", cf.AgePersonA, cf.AgePersonB),
+			FormatCurrency(cf.TotalGrossIncome),
+			FormatCurrency(taxes),
+			FormatCurrency(health),
+			FormatCurrency(cf.NetIncome),
+			FormatCurrency(cf.TotalTSPBalance()),
+		}
+		pdf.SetFont("Helvetica", "", 8)
+		for i, col := range pdfTableColumns {
+			align := "R"
+			if i < 2 {
+				align = "C"
+			}
+			pdf.CellFormat(col.width, rowHeight, cells[i], "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+func writePDFNetIncomeChart(pdf *fpdf.Fpdf, projection []domain.AnnualCashFlow) {
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, "Net Income by Year", "", 1, "L", false, 0, "")
+	if len(projection) == 0 {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, 8, "No projection data available.", "", 1, "L", false, 0, "")
+		return
+	}
+
+	const (
+		left   = 35.0
+		top    = 35.0
+		width  = 160.0
+		height = 110.0
+	)
+
+	maxNet := decimal.Zero
+	for _, cf := range projection {
+		if cf.NetIncome.GreaterThan(maxNet) {
+			maxNet = cf.NetIncome
+		}
+	}
+	if maxNet.LessThanOrEqual(decimal.Zero) {
+		maxNet = decimal.NewFromInt(1)
+	}
+	maxValue := maxNet.InexactFloat64()
+
+	// Axes and horizontal grid lines with labels
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(left, top, left, top+height)
+	pdf.Line(left, top+height, left+width, top+height)
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetLineWidth(0.1)
+	for i := 1; i <= 4; i++ {
+		y := top + height - height*float64(i)/4
+		pdf.Line(left, y, left+width, y)
+		pdf.SetXY(left-28, y-2)
+		pdf.CellFormat(26, 4, FormatCurrency(maxNet.Mul(decimal.NewFromFloat(float64(i)/4)).Round(0)), "", 0, "R", false, 0, "")
+	}
+
+	// Bars for each projection year
+	slot := width / float64(len(projection))
+	barWidth := slot * 0.7
+	pdf.SetFillColor(52, 152, 219)
+	labelEvery := 1
+	if len(projection) > 15 {
+		labelEvery = (len(projection) + 14) / 15
+	}
+	for i, cf := range projection {
+		value := cf.NetIncome.InexactFloat64()
+		if value < 0 {
+			value = 0
+		}
+		barHeight := height * value / maxValue
+		x := left + slot*float64(i) + (slot-barWidth)/2
+		pdf.Rect(x, top+height-barHeight, barWidth, barHeight, "F")
+		if i%labelEvery == 0 {
+			pdf.SetXY(x-2, top+height+1)
+			pdf.CellFormat(barWidth+4, 4, fmt.Sprintf("%d", cf.Date.Year()), "", 0, "C", false, 0, "")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestGenerateScenarioPDF(t *testing.T) {
+	summary := &domain.ScenarioSummary{
+		Name:               "PDF Scenario",
+		FirstYearNetIncome: decimal.NewFromInt(95000),
+		Year5NetIncome:     decimal.NewFromInt(98000),
+		Year10NetIncome:    decimal.NewFromInt(101000),
+		TSPLongevity:       30,
+	}
+	for i := 0; i < 60; i++ {
+		summary.Projection = append(summary.Projection, domain.AnnualCashFlow{
+			Year:              i + 1,
+			Date:              time.Date(2025+i, 1, 1, 0, 0, 0, 0, time.UTC),
+			AgePersonA:        60 + i,
+			AgePersonB:        58 + i,
+			TotalGrossIncome:  decimal.NewFromInt(int64(120000 + i*1000)),
+			NetIncome:         decimal.NewFromInt(int64(95000 + i*800)),
+			TSPBalancePersonA: decimal.NewFromInt(500000),
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "reports", "scenario.pdf")
+	if err := GenerateScenarioPDF(summary, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read pdf: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Fatalf("output is not a PDF")
+	}
+	pages := regexp.MustCompile(`/Type /Page\b`).FindAll(data, -1)
+	// summary + at least two table pages for 60 rows + chart
+	if len(pages) < 4 {
+		t.Fatalf("expected paginated report with at least 4 pages, got %d", len(pages))
+	}
+}
+
+func TestGenerateScenarioPDFNilSummary(t *testing.T) {
+	if err := GenerateScenarioPDF(nil, filepath.Join(t.TempDir(), "x.pdf")); err == nil {
+		t.Fatalf("expected error for nil summary")
+	}
+}
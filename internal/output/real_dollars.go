@@ -0,0 +1,48 @@
+package output
+
+import (
+	calc "github.com/rpgo/retirement-calculator/internal/calculation"
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// RealDollarComparison returns a copy of results with every scenario's net income figures expressed in
+// today's dollars (from each year's RealNetIncome). Lifetime present values are already discounted and
+// are left unchanged. The input is not modified.
+func RealDollarComparison(results *domain.ScenarioComparison) *domain.ScenarioComparison {
+	if results == nil {
+		return nil
+	}
+	converted := *results
+	converted.Scenarios = make([]domain.ScenarioSummary, len(results.Scenarios))
+	for i, sc := range results.Scenarios {
+		adjusted := sc
+		adjusted.Projection = calc.RealDollarProjection(sc.Projection)
+		adjusted.FirstYearNetIncome = netIncomeAtIndex(adjusted.Projection, 0, sc.FirstYearNetIncome)
+		adjusted.Year5NetIncome = netIncomeAtIndex(adjusted.Projection, 4, sc.Year5NetIncome)
+		adjusted.Year10NetIncome = netIncomeAtIndex(adjusted.Projection, 9, sc.Year10NetIncome)
+		if years := sc.CalendarComparisonYears(); years != nil {
+			adjusted.NetIncome2030 = netIncomeForCalendarYear(adjusted.Projection, years[0], sc.NetIncome2030)
+			adjusted.NetIncome2035 = netIncomeForCalendarYear(adjusted.Projection, years[1], sc.NetIncome2035)
+			adjusted.NetIncome2040 = netIncomeForCalendarYear(adjusted.Projection, years[2], sc.NetIncome2040)
+		}
+		converted.Scenarios[i] = adjusted
+	}
+	return &converted
+}
+
+func netIncomeAtIndex(projection []domain.AnnualCashFlow, index int, fallback decimal.Decimal) decimal.Decimal {
+	if index < len(projection) {
+		return projection[index].NetIncome
+	}
+	return fallback
+}
+
+func netIncomeForCalendarYear(projection []domain.AnnualCashFlow, year int, fallback decimal.Decimal) decimal.Decimal {
+	for _, cf := range projection {
+		if cf.Date.Year() == year {
+			return cf.NetIncome
+		}
+	}
+	return fallback
+}
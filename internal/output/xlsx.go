@@ -0,0 +1,180 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rpgo/retirement-calculator/internal/domain"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxSummarySheet   = "Summary"
+	xlsxCurrencyFormat = `"$"#,##0.00;[Red]-"$"#,##0.00`
+	xlsxMaxSheetName   = 31
+)
+
+// xlsxProjectionColumn is one column of a scenario's year-by-year sheet
+type xlsxProjectionColumn struct {
+	header   string
+	currency bool
+	value    func(cf domain.AnnualCashFlow) interface{}
+}
+
+func money(d decimal.Decimal) interface{} { return RoundMoney(d).InexactFloat64() }
+
+var xlsxProjectionColumns = []xlsxProjectionColumn{
+	{"Year", false, func(cf domain.AnnualCashFlow) interface{} { return cf.Date.Year() }},
+	{"Age PersonA", false, func(cf domain.AnnualCashFlow) interface{} { return cf.AgePersonA }},
+	{"Age PersonB", false, func(cf domain.AnnualCashFlow) interface{} { return cf.AgePersonB }},
+	{"Salary", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.SalaryPersonA.Add(cf.SalaryPersonB)) }},
+	{"Pension", true, func(cf domain.AnnualCashFlow) interface{} {
+		return money(cf.PensionPersonA.Add(cf.PensionPersonB).Add(cf.SurvivorPensionPersonA).Add(cf.SurvivorPensionPersonB))
+	}},
+	{"FERS Supplement", true, func(cf domain.AnnualCashFlow) interface{} {
+		return money(cf.FERSSupplementPersonA.Add(cf.FERSSupplementPersonB))
+	}},
+	{"Social Security", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.SSBenefitPersonA.Add(cf.SSBenefitPersonB)) }},
+	{"TSP Withdrawals", true, func(cf domain.AnnualCashFlow) interface{} {
+		return money(cf.TSPWithdrawalPersonA.Add(cf.TSPWithdrawalPersonB))
+	}},
+	{"TSP Annuity", true, func(cf domain.AnnualCashFlow) interface{} {
+		return money(cf.TSPAnnuityPersonA.Add(cf.TSPAnnuityPersonB))
+	}},
+	{"Gross Income", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.TotalGrossIncome) }},
+	{"Federal Tax", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.FederalTax) }},
+	{"State Tax", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.StateTax) }},
+	{"Local Tax", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.LocalTax) }},
+	{"FICA", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.FICATax) }},
+	{"FEHB Premium", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.FEHBPremium) }},
+	{"Medicare Premium", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.MedicarePremium) }},
+	{"Net Income", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.NetIncome) }},
+	{"TSP Balance", true, func(cf domain.AnnualCashFlow) interface{} { return money(cf.TotalTSPBalance()) }},
+}
+
+// WriteScenarioXLSX writes an Excel workbook with a summary sheet comparing scenarios and one sheet per
+// scenario holding its year-by-year projection. Cells hold numbers (not text) so they can be charted.
+func WriteScenarioXLSX(comparison *domain.ScenarioComparison, outputPath string) error {
+	if comparison == nil {
+		return fmt.Errorf("no comparison results to export")
+	}
+	f := excelize.NewFile()
+	defer f.Close()
+
+	currencyFormat := xlsxCurrencyFormat
+	currencyStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &currencyFormat})
+	if err != nil {
+		return fmt.Errorf("failed to create currency style: %w", err)
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+
+	if err := f.SetSheetName(f.GetSheetName(0), xlsxSummarySheet); err != nil {
+		return fmt.Errorf("failed to create summary sheet: %w", err)
+	}
+	summaryHeader := []interface{}{"Scenario", "First-Year Net Income", "Year 5 Net Income", "Year 10 Net Income", "Lifetime Income", "Net Present Value", "TSP Longevity (years)", "Final TSP Balance"}
+	rows := [][]interface{}{summaryHeader}
+	for _, sc := range comparison.Scenarios {
+		rows = append(rows, []interface{}{
+			sc.Name, money(sc.FirstYearNetIncome), money(sc.Year5NetIncome), money(sc.Year10NetIncome),
+			money(sc.TotalLifetimeIncome), money(sc.NetPresentValue), sc.TSPLongevity, money(sc.FinalTSPBalance),
+		})
+	}
+	if err := writeXLSXSheet(f, xlsxSummarySheet, rows, headerStyle); err != nil {
+		return err
+	}
+	if err := f.SetColStyle(xlsxSummarySheet, "B:F", currencyStyle); err != nil {
+		return err
+	}
+	if err := f.SetColStyle(xlsxSummarySheet, "H", currencyStyle); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(xlsxSummarySheet, "A", "H", 22); err != nil {
+		return err
+	}
+
+	used := map[string]bool{xlsxSummarySheet: true}
+	for _, sc := range comparison.Scenarios {
+		sheet := uniqueSheetName(sc.Name, used)
+		if _, err := f.NewSheet(sheet); err != nil {
+			return fmt.Errorf("failed to create sheet for scenario %s: %w", sc.Name, err)
+		}
+		header := make([]interface{}, len(xlsxProjectionColumns))
+		for i, col := range xlsxProjectionColumns {
+			header[i] = col.header
+		}
+		rows := [][]interface{}{header}
+		for _, cf := range sc.Projection {
+			row := make([]interface{}, len(xlsxProjectionColumns))
+			for i, col := range xlsxProjectionColumns {
+				row[i] = col.value(cf)
+			}
+			rows = append(rows, row)
+		}
+		if err := writeXLSXSheet(f, sheet, rows, headerStyle); err != nil {
+			return err
+		}
+		for i, col := range xlsxProjectionColumns {
+			name, _ := excelize.ColumnNumberToName(i + 1)
+			if col.currency {
+				if err := f.SetColStyle(sheet, name, currencyStyle); err != nil {
+					return err
+				}
+			}
+		}
+		lastCol, _ := excelize.ColumnNumberToName(len(xlsxProjectionColumns))
+		if err := f.SetColWidth(sheet, "A", lastCol, 16); err != nil {
+			return err
+		}
+	}
+
+	if err := f.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("failed to write XLSX file %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// writeXLSXSheet writes rows starting at A1, bolds and freezes the header row
+func writeXLSXSheet(f *excelize.File, sheet string, rows [][]interface{}, headerStyle int) error {
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			return fmt.Errorf("failed to write sheet %s: %w", sheet, err)
+		}
+	}
+	if err := f.SetRowStyle(sheet, 1, 1, headerStyle); err != nil {
+		return err
+	}
+	return f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+}
+
+// uniqueSheetName makes a valid, unused Excel sheet name from a scenario name
+func uniqueSheetName(name string, used map[string]bool) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if cleaned == "" {
+		cleaned = "Scenario"
+	}
+	base := []rune(cleaned)
+	if len(base) > xlsxMaxSheetName {
+		base = base[:xlsxMaxSheetName]
+	}
+	candidate := string(base)
+	for n := 2; used[candidate]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		trimmed := base
+		if len(trimmed)+len(suffix) > xlsxMaxSheetName {
+			trimmed = trimmed[:xlsxMaxSheetName-len(suffix)]
+		}
+		candidate = string(trimmed) + suffix
+	}
+	used[candidate] = true
+	return candidate
+}
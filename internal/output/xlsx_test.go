@@ -0,0 +1,72 @@
+package output
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteScenarioXLSX(t *testing.T) {
+	cmp := buildTestComparison()
+	cmp.Scenarios[1].Name = "B: retire at 62?"
+	path := filepath.Join(t.TempDir(), "scenarios.xlsx")
+	if err := WriteScenarioXLSX(cmp, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to reopen workbook: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	want := []string{"Summary", "A", "B- retire at 62-"}
+	if len(sheets) != len(want) {
+		t.Fatalf("expected sheets %v, got %v", want, sheets)
+	}
+	for i := range want {
+		if sheets[i] != want[i] {
+			t.Fatalf("expected sheets %v, got %v", want, sheets)
+		}
+	}
+
+	if v, _ := f.GetCellValue("Summary", "A3"); v != "B: retire at 62?" {
+		t.Fatalf("expected scenario name in summary, got %q", v)
+	}
+	cellType, err := f.GetCellType("A", "Q2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cellType == excelize.CellTypeSharedString || cellType == excelize.CellTypeInlineString {
+		t.Fatalf("expected net income stored as a number, got cell type %v", cellType)
+	}
+	rows, err := f.GetRows("A", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[0][16] != "Net Income" || rows[1][16] != "95000" {
+		t.Fatalf("unexpected projection rows: %v", rows)
+	}
+	panes, err := f.GetPanes("A")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !panes.Freeze || panes.YSplit != 1 {
+		t.Fatalf("expected frozen header row, got %+v", panes)
+	}
+}
+
+func TestUniqueSheetName(t *testing.T) {
+	used := map[string]bool{"Summary": true}
+	long := "An extremely long scenario name that exceeds the limit"
+	first := uniqueSheetName(long, used)
+	second := uniqueSheetName(long, used)
+	if len(first) != 31 || len(second) != 31 || first == second {
+		t.Fatalf("expected distinct 31-character names, got %q and %q", first, second)
+	}
+	if got := uniqueSheetName("Summary", used); got != "Summary (2)" {
+		t.Fatalf("expected clash with summary sheet to be renamed, got %q", got)
+	}
+}
@@ -380,6 +380,24 @@ func TestGetRMDAge(t *testing.T) {
 			expectedAge: 73,
 			description: "SECURE 2.0 transition RMD age",
 		},
+		{
+			name:        "Born 1951 boundary",
+			birthYear:   1951,
+			expectedAge: 73,
+			description: "First SECURE 2.0 age-73 cohort",
+		},
+		{
+			name:        "Born 1959 boundary",
+			birthYear:   1959,
+			expectedAge: 73,
+			description: "Last SECURE 2.0 age-73 cohort",
+		},
+		{
+			name:        "Born 1960 boundary",
+			birthYear:   1960,
+			expectedAge: 75,
+			description: "First SECURE 2.0 age-75 cohort",
+		},
 		{
 			name:        "Born 1960 or later",
 			birthYear:   1965,
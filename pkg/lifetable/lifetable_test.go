@@ -0,0 +1,29 @@
+package lifetable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJointLifeExpectancyExceedsSingle(t *testing.T) {
+	husband := LifeExpectancy(62, Male)
+	wife := LifeExpectancy(60, Female)
+	joint := JointLifeExpectancy(62, Male, 60, Female)
+
+	assert.InDelta(t, 19.5, husband, 1.5, "SSA 2021 period life expectancy of a 62-year-old man")
+	assert.InDelta(t, 23.5, wife, 1.5, "SSA 2021 period life expectancy of a 60-year-old woman")
+	assert.Greater(t, joint, husband)
+	assert.Greater(t, joint, wife)
+	assert.Less(t, joint, float64(MaxAge-60))
+}
+
+func TestMortalityRateBySex(t *testing.T) {
+	for _, age := range []int{45, 62, 80, 97} {
+		male, female, unisex := MortalityRate(age, Male), MortalityRate(age, Female), MortalityRate(age, "")
+		assert.Greater(t, male, female, "age %d", age)
+		assert.InDelta(t, (male+female)/2, unisex, 0.001, "age %d: unisex is the midpoint", age)
+	}
+	assert.Equal(t, 1.0, MortalityRate(MaxAge, Female))
+	assert.Greater(t, LifeExpectancy(65, Female), LifeExpectancy(65, Male))
+}
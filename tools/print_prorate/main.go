@@ -31,10 +31,10 @@ func main() {
 	ds := domain.RetirementScenario{EmployeeName: "person_b", RetirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"}
 	scenario := domain.Scenario{Name: "ss-prorate", PersonA: rs, PersonB: ds}
 
-	proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &domain.GlobalAssumptions{ProjectionYears: 3, COLAGeneralRate: decimal.Zero}, domain.FederalRules{})
+	proj := ce.GenerateAnnualProjection(&personA, &personB, &scenario, &domain.GlobalAssumptions{ProjectionYears: 3, ProjectionStartYear: 2025, COLAGeneralRate: decimal.Zero}, domain.FederalRules{})
 	fmt.Println("SS Scenario projection row 0:")
 	fmt.Printf("SSBenefitPersonA: %s\n", proj[0].SSBenefitPersonA.StringFixed(2))
-	full := calculation.CalculateSSBenefitForYear(&personA, rs.SSStartAge, 0, decimal.Zero)
+	full := calculation.CalculateSSBenefitForYear(&personA, rs.SSStartAge, 2025, decimal.Zero)
 	fmt.Printf("Full-year SS (calc): %s\n", full.StringFixed(2))
 
 	// RMD scenario
@@ -47,7 +47,7 @@ func main() {
 	}
 	ds2 := domain.RetirementScenario{EmployeeName: "person_a", RetirementDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), SSStartAge: 62, TSPWithdrawalStrategy: "4_percent_rule"}
 	scenario2 := domain.Scenario{Name: "rmd-prorate", PersonA: ds2, PersonB: ds}
-	proj2 := ce.GenerateAnnualProjection(&personA2, &personB, &scenario2, &domain.GlobalAssumptions{ProjectionYears: 3, COLAGeneralRate: decimal.Zero}, domain.FederalRules{})
+	proj2 := ce.GenerateAnnualProjection(&personA2, &personB, &scenario2, &domain.GlobalAssumptions{ProjectionYears: 3, ProjectionStartYear: 2025, COLAGeneralRate: decimal.Zero}, domain.FederalRules{})
 	fmt.Println("RMD Scenario projection row 0:")
 	fmt.Printf("TSPWithdrawalPersonA: %s\n", proj2[0].TSPWithdrawalPersonA.StringFixed(2))
 	fullRMD := calculation.CalculateRMD(personA2.TSPBalanceTraditional, personA2.BirthDate.Year(), dateutil.GetRMDAge(personA2.BirthDate.Year()))